@@ -622,6 +622,7 @@ func Convert_v1alpha1_VirtualMachineSpec_To_v1alpha4_VirtualMachineSpec(
 
 	// Deprecated:
 	// in.Ports
+	out.Ports = nil
 
 	return nil
 }
@@ -670,6 +671,23 @@ func Convert_v1alpha4_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(
 
 	// Deprecated:
 	// out.Ports
+	out.Ports = nil
+
+	return nil
+}
+
+// Convert_v1alpha1_VirtualMachinePort_To_v1alpha4_VirtualMachinePortSpec does
+// not convert anything since the v1alpha1 ports are deprecated.
+func Convert_v1alpha1_VirtualMachinePort_To_v1alpha4_VirtualMachinePortSpec(
+	_ *VirtualMachinePort, _ *vmopv1.VirtualMachinePortSpec, _ apiconversion.Scope) error {
+
+	return nil
+}
+
+// Convert_v1alpha4_VirtualMachinePortSpec_To_v1alpha1_VirtualMachinePort does
+// not convert anything since the v1alpha1 ports are deprecated.
+func Convert_v1alpha4_VirtualMachinePortSpec_To_v1alpha1_VirtualMachinePort(
+	_ *vmopv1.VirtualMachinePortSpec, _ *VirtualMachinePort, _ apiconversion.Scope) error {
 
 	return nil
 }
@@ -954,6 +972,10 @@ func restore_v1alpha4_VirtualMachineReadinessProbeSpec(
 			dst.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
 		}
 		dst.Spec.ReadinessProbe.GuestInfo = src.Spec.ReadinessProbe.GuestInfo
+		dst.Spec.ReadinessProbe.HTTPGet = src.Spec.ReadinessProbe.HTTPGet
+		dst.Spec.ReadinessProbe.InitialDelaySeconds = src.Spec.ReadinessProbe.InitialDelaySeconds
+		dst.Spec.ReadinessProbe.SuccessThreshold = src.Spec.ReadinessProbe.SuccessThreshold
+		dst.Spec.ReadinessProbe.FailureThreshold = src.Spec.ReadinessProbe.FailureThreshold
 	}
 }
 
@@ -996,6 +1018,26 @@ func restore_v1alpha4_VirtualMachineCdrom(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.Cdrom = src.Spec.Cdrom
 }
 
+func restore_v1alpha4_VirtualMachineLivenessProbeSpec(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.LivenessProbe = src.Spec.LivenessProbe
+}
+
+func restore_v1alpha4_VirtualMachineTopologySpreadConstraints(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.TopologySpreadConstraints = src.Spec.TopologySpreadConstraints
+}
+
+func restore_v1alpha4_VirtualMachineCurrentSnapshot(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.CurrentSnapshot = src.Spec.CurrentSnapshot
+}
+
+func restore_v1alpha4_VirtualMachineClone(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Clone = src.Spec.Clone
+}
+
+func restore_v1alpha4_VirtualMachinePorts(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Ports = src.Spec.Ports
+}
+
 func convert_v1alpha1_PreReqsReadyCondition_to_v1alpha4_Conditions(
 	dst *vmopv1.VirtualMachine) []metav1.Condition {
 
@@ -1248,6 +1290,11 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha4_VirtualMachineGuestID(dst, restored)
 	restore_v1alpha4_VirtualMachineCdrom(dst, restored)
 	restore_v1alpha4_VirtualMachineCryptoSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineLivenessProbeSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineTopologySpreadConstraints(dst, restored)
+	restore_v1alpha4_VirtualMachineCurrentSnapshot(dst, restored)
+	restore_v1alpha4_VirtualMachineClone(dst, restored)
+	restore_v1alpha4_VirtualMachinePorts(dst, restored)
 
 	// END RESTORE
 
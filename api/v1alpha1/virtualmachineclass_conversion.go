@@ -5,21 +5,54 @@
 package v1alpha1
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 )
 
+func Convert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachineClassHardware(
+	in *vmopv1.VirtualMachineClassHardware, out *VirtualMachineClassHardware, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachineClassHardware(in, out, s)
+}
+
+func restore_v1alpha4_VirtualMachineClassHardwareStorageIO(dst, src *vmopv1.VirtualMachineClass) {
+	dst.Spec.Hardware.StorageIO = src.Spec.Hardware.StorageIO
+}
+
 // ConvertTo converts this VirtualMachineClass to the Hub version.
 func (src *VirtualMachineClass) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachineClass)
-	return Convert_v1alpha1_VirtualMachineClass_To_v1alpha4_VirtualMachineClass(src, dst, nil)
+	if err := Convert_v1alpha1_VirtualMachineClass_To_v1alpha4_VirtualMachineClass(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &vmopv1.VirtualMachineClass{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	// BEGIN RESTORE
+
+	restore_v1alpha4_VirtualMachineClassHardwareStorageIO(dst, restored)
+
+	// END RESTORE
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachineClass.
 func (dst *VirtualMachineClass) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	src := srcRaw.(*vmopv1.VirtualMachineClass)
-	return Convert_v1alpha4_VirtualMachineClass_To_v1alpha1_VirtualMachineClass(src, dst, nil)
+	if err := Convert_v1alpha4_VirtualMachineClass_To_v1alpha1_VirtualMachineClass(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachineClassList to the Hub version.
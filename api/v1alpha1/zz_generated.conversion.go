@@ -202,11 +202,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineClassHardware)(nil), (*VirtualMachineClassHardware)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachineClassHardware(a.(*v1alpha4.VirtualMachineClassHardware), b.(*VirtualMachineClassHardware), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineClassList)(nil), (*v1alpha4.VirtualMachineClassList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineClassList_To_v1alpha4_VirtualMachineClassList(a.(*VirtualMachineClassList), b.(*v1alpha4.VirtualMachineClassList), scope)
 	}); err != nil {
@@ -417,21 +412,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineServicePort)(nil), (*VirtualMachineServicePort)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(a.(*v1alpha4.VirtualMachineServicePort), b.(*VirtualMachineServicePort), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineServiceSpec)(nil), (*v1alpha4.VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineServiceSpec_To_v1alpha4_VirtualMachineServiceSpec(a.(*VirtualMachineServiceSpec), b.(*v1alpha4.VirtualMachineServiceSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineServiceSpec)(nil), (*VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(a.(*v1alpha4.VirtualMachineServiceSpec), b.(*VirtualMachineServiceSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineServiceStatus)(nil), (*v1alpha4.VirtualMachineServiceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineServiceStatus_To_v1alpha4_VirtualMachineServiceStatus(a.(*VirtualMachineServiceStatus), b.(*v1alpha4.VirtualMachineServiceStatus), scope)
 	}); err != nil {
@@ -507,6 +492,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*VirtualMachinePort)(nil), (*v1alpha4.VirtualMachinePortSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualMachinePort_To_v1alpha4_VirtualMachinePortSpec(a.(*VirtualMachinePort), b.(*v1alpha4.VirtualMachinePortSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*VirtualMachineSetResourcePolicySpec)(nil), (*v1alpha4.VirtualMachineSetResourcePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineSetResourcePolicySpec_To_v1alpha4_VirtualMachineSetResourcePolicySpec(a.(*VirtualMachineSetResourcePolicySpec), b.(*v1alpha4.VirtualMachineSetResourcePolicySpec), scope)
 	}); err != nil {
@@ -537,6 +527,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineClassHardware)(nil), (*VirtualMachineClassHardware)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachineClassHardware(a.(*v1alpha4.VirtualMachineClassHardware), b.(*VirtualMachineClassHardware), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageOSInfo)(nil), (*VirtualMachineImageOSInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineImageOSInfo_To_v1alpha1_VirtualMachineImageOSInfo(a.(*v1alpha4.VirtualMachineImageOSInfo), b.(*VirtualMachineImageOSInfo), scope)
 	}); err != nil {
@@ -552,11 +547,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachinePortSpec)(nil), (*VirtualMachinePort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachinePortSpec_To_v1alpha1_VirtualMachinePort(a.(*v1alpha4.VirtualMachinePortSpec), b.(*VirtualMachinePort), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineReadinessProbeSpec)(nil), (*Probe)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineReadinessProbeSpec_To_v1alpha1_Probe(a.(*v1alpha4.VirtualMachineReadinessProbeSpec), b.(*Probe), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineServicePort)(nil), (*VirtualMachineServicePort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(a.(*v1alpha4.VirtualMachineServicePort), b.(*VirtualMachineServicePort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineServiceSpec)(nil), (*VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(a.(*v1alpha4.VirtualMachineServiceSpec), b.(*VirtualMachineServiceSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineSetResourcePolicySpec)(nil), (*VirtualMachineSetResourcePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineSetResourcePolicySpec_To_v1alpha1_VirtualMachineSetResourcePolicySpec(a.(*v1alpha4.VirtualMachineSetResourcePolicySpec), b.(*VirtualMachineSetResourcePolicySpec), scope)
 	}); err != nil {
@@ -1098,14 +1108,10 @@ func autoConvert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachine
 	if err := Convert_v1alpha4_InstanceStorage_To_v1alpha1_InstanceStorage(&in.InstanceStorage, &out.InstanceStorage, s); err != nil {
 		return err
 	}
+	// WARNING: in.StorageIO requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachineClassHardware is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachineClassHardware(in *v1alpha4.VirtualMachineClassHardware, out *VirtualMachineClassHardware, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineClassHardware_To_v1alpha1_VirtualMachineClassHardware(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineClassList_To_v1alpha4_VirtualMachineClassList(in *VirtualMachineClassList, out *v1alpha4.VirtualMachineClassList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1805,7 +1811,17 @@ func Convert_v1alpha4_VirtualMachineService_To_v1alpha1_VirtualMachineService(in
 
 func autoConvert_v1alpha1_VirtualMachineServiceList_To_v1alpha4_VirtualMachineServiceList(in *VirtualMachineServiceList, out *v1alpha4.VirtualMachineServiceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha4.VirtualMachineService)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha4.VirtualMachineService, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_VirtualMachineService_To_v1alpha4_VirtualMachineService(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1816,7 +1832,17 @@ func Convert_v1alpha1_VirtualMachineServiceList_To_v1alpha4_VirtualMachineServic
 
 func autoConvert_v1alpha4_VirtualMachineServiceList_To_v1alpha1_VirtualMachineServiceList(in *v1alpha4.VirtualMachineServiceList, out *VirtualMachineServiceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachineService)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineService, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachineService_To_v1alpha1_VirtualMachineService(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1843,17 +1869,24 @@ func autoConvert_v1alpha4_VirtualMachineServicePort_To_v1alpha1_VirtualMachineSe
 	out.Protocol = in.Protocol
 	out.Port = in.Port
 	out.TargetPort = in.TargetPort
+	// WARNING: in.TargetPortName requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodePort requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(in *v1alpha4.VirtualMachineServicePort, out *VirtualMachineServicePort, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineServiceSpec_To_v1alpha4_VirtualMachineServiceSpec(in *VirtualMachineServiceSpec, out *v1alpha4.VirtualMachineServiceSpec, s conversion.Scope) error {
 	out.Type = v1alpha4.VirtualMachineServiceType(in.Type)
-	out.Ports = *(*[]v1alpha4.VirtualMachineServicePort)(unsafe.Pointer(&in.Ports))
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1alpha4.VirtualMachineServicePort, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_VirtualMachineServicePort_To_v1alpha4_VirtualMachineServicePort(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	out.Selector = *(*map[string]string)(unsafe.Pointer(&in.Selector))
 	out.LoadBalancerIP = in.LoadBalancerIP
 	out.LoadBalancerSourceRanges = *(*[]string)(unsafe.Pointer(&in.LoadBalancerSourceRanges))
@@ -1869,20 +1902,32 @@ func Convert_v1alpha1_VirtualMachineServiceSpec_To_v1alpha4_VirtualMachineServic
 
 func autoConvert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in *v1alpha4.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s conversion.Scope) error {
 	out.Type = VirtualMachineServiceType(in.Type)
-	out.Ports = *(*[]VirtualMachineServicePort)(unsafe.Pointer(&in.Ports))
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]VirtualMachineServicePort, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	out.Selector = *(*map[string]string)(unsafe.Pointer(&in.Selector))
 	out.LoadBalancerIP = in.LoadBalancerIP
 	out.LoadBalancerSourceRanges = *(*[]string)(unsafe.Pointer(&in.LoadBalancerSourceRanges))
+	// WARNING: in.LoadBalancerClass requires manual conversion: does not exist in peer-type
 	out.ClusterIP = in.ClusterIP
+	// WARNING: in.PublishNotReadyAddresses requires manual conversion: does not exist in peer-type
 	out.ExternalName = in.ExternalName
+	// WARNING: in.SessionAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.SessionAffinityConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.ExternalTrafficPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPFamilies requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPFamilyPolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in *v1alpha4.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineServiceStatus_To_v1alpha4_VirtualMachineServiceStatus(in *VirtualMachineServiceStatus, out *v1alpha4.VirtualMachineServiceStatus, s conversion.Scope) error {
 	if err := Convert_v1alpha1_LoadBalancerStatus_To_v1alpha4_LoadBalancerStatus(&in.LoadBalancer, &out.LoadBalancer, s); err != nil {
 		return err
@@ -2027,7 +2072,17 @@ func autoConvert_v1alpha1_VirtualMachineSpec_To_v1alpha4_VirtualMachineSpec(in *
 	out.SuspendMode = v1alpha4.VirtualMachinePowerOpMode(in.SuspendMode)
 	out.NextRestartTime = in.NextRestartTime
 	out.RestartMode = v1alpha4.VirtualMachinePowerOpMode(in.RestartMode)
-	// WARNING: in.Ports requires manual conversion: does not exist in peer-type
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1alpha4.VirtualMachinePortSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_VirtualMachinePort_To_v1alpha4_VirtualMachinePortSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	// WARNING: in.VmMetadata requires manual conversion: does not exist in peer-type
 	out.StorageClass = in.StorageClass
 	// WARNING: in.NetworkInterfaces requires manual conversion: does not exist in peer-type
@@ -2061,11 +2116,23 @@ func autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(in *
 	// WARNING: in.Cdrom requires manual conversion: does not exist in peer-type
 	// WARNING: in.Image requires manual conversion: does not exist in peer-type
 	out.ImageName = in.ImageName
+	// WARNING: in.Clone requires manual conversion: does not exist in peer-type
 	out.ClassName = in.ClassName
 	// WARNING: in.Crypto requires manual conversion: does not exist in peer-type
 	out.StorageClass = in.StorageClass
 	// WARNING: in.Bootstrap requires manual conversion: does not exist in peer-type
 	// WARNING: in.Network requires manual conversion: does not exist in peer-type
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]VirtualMachinePort, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachinePortSpec_To_v1alpha1_VirtualMachinePort(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	out.PowerState = VirtualMachinePowerState(in.PowerState)
 	out.PowerOffMode = VirtualMachinePowerOpMode(in.PowerOffMode)
 	out.SuspendMode = VirtualMachinePowerOpMode(in.SuspendMode)
@@ -2091,12 +2158,15 @@ func autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(in *
 	} else {
 		out.ReadinessProbe = nil
 	}
+	// WARNING: in.LivenessProbe requires manual conversion: does not exist in peer-type
+	// WARNING: in.TopologySpreadConstraints requires manual conversion: does not exist in peer-type
 	// WARNING: in.Advanced requires manual conversion: does not exist in peer-type
 	// WARNING: in.Reserved requires manual conversion: does not exist in peer-type
 	out.MinHardwareVersion = in.MinHardwareVersion
 	// WARNING: in.InstanceUUID requires manual conversion: does not exist in peer-type
 	// WARNING: in.BiosUUID requires manual conversion: does not exist in peer-type
 	// WARNING: in.GuestID requires manual conversion: does not exist in peer-type
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2172,8 +2242,11 @@ func autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha1_VirtualMachineStatus(
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
 	out.Zone = in.Zone
 	out.LastRestartTime = (*v1.Time)(unsafe.Pointer(in.LastRestartTime))
+	// WARNING: in.LivenessRestartCount requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
 	// WARNING: in.Storage requires manual conversion: does not exist in peer-type
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootSnapshots requires manual conversion: does not exist in peer-type
 	return nil
 }
 
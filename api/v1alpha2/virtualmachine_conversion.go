@@ -31,6 +31,18 @@ func Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNetwor
 	return autoConvert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNetworkSpec(in, out, s)
 }
 
+func Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(
+	in *vmopv1.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(
+	in *vmopv1.VirtualMachineReadinessProbeSpec, out *VirtualMachineReadinessProbeSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(in, out, s)
+}
+
 func Convert_v1alpha4_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(
 	in *vmopv1.VirtualMachineSpec, out *VirtualMachineSpec, s apiconversion.Scope) error {
 
@@ -91,6 +103,12 @@ func Convert_v1alpha2_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(
 	return autoConvert_v1alpha2_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(in, out, s)
 }
 
+func Convert_v1alpha4_VirtualMachineVolume_To_v1alpha2_VirtualMachineVolume(
+	in *vmopv1.VirtualMachineVolume, out *VirtualMachineVolume, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineVolume_To_v1alpha2_VirtualMachineVolume(in, out, s)
+}
+
 func Convert_v1alpha4_VirtualMachineVolumeStatus_To_v1alpha2_VirtualMachineVolumeStatus(
 	in *vmopv1.VirtualMachineVolumeStatus, out *VirtualMachineVolumeStatus, s apiconversion.Scope) error {
 
@@ -273,10 +291,99 @@ func restore_v1alpha4_VirtualMachineGuestID(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.GuestID = src.Spec.GuestID
 }
 
+func restore_v1alpha4_VirtualMachineReadinessProbeSpec(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.ReadinessProbe != nil {
+		if dst.Spec.ReadinessProbe == nil {
+			dst.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+		}
+		dst.Spec.ReadinessProbe.HTTPGet = src.Spec.ReadinessProbe.HTTPGet
+		dst.Spec.ReadinessProbe.InitialDelaySeconds = src.Spec.ReadinessProbe.InitialDelaySeconds
+		dst.Spec.ReadinessProbe.SuccessThreshold = src.Spec.ReadinessProbe.SuccessThreshold
+		dst.Spec.ReadinessProbe.FailureThreshold = src.Spec.ReadinessProbe.FailureThreshold
+	}
+}
+
 func restore_v1alpha4_VirtualMachineCdrom(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.Cdrom = src.Spec.Cdrom
 }
 
+func restore_v1alpha4_VirtualMachineLivenessProbeSpec(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.LivenessProbe = src.Spec.LivenessProbe
+}
+
+func restore_v1alpha4_VirtualMachineTopologySpreadConstraints(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.TopologySpreadConstraints = src.Spec.TopologySpreadConstraints
+}
+
+func restore_v1alpha4_VirtualMachineCurrentSnapshot(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.CurrentSnapshot = src.Spec.CurrentSnapshot
+}
+
+func restore_v1alpha4_VirtualMachineClone(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Clone = src.Spec.Clone
+}
+
+func restore_v1alpha4_VirtualMachinePorts(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Ports = src.Spec.Ports
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceSLAAC(dst, src *vmopv1.VirtualMachine) {
+	if dst.Spec.Network == nil || src.Spec.Network == nil {
+		return
+	}
+	for i := range dst.Spec.Network.Interfaces {
+		for j := range src.Spec.Network.Interfaces {
+			if dst.Spec.Network.Interfaces[i].Name == src.Spec.Network.Interfaces[j].Name {
+				dst.Spec.Network.Interfaces[i].SLAAC = src.Spec.Network.Interfaces[j].SLAAC
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkDevices(dst, src *vmopv1.VirtualMachine) {
+	if dst.Spec.Network == nil || src.Spec.Network == nil {
+		return
+	}
+	dst.Spec.Network.Bonds = src.Spec.Network.Bonds
+	dst.Spec.Network.VLANs = src.Spec.Network.VLANs
+	dst.Spec.Network.Bridges = src.Spec.Network.Bridges
+}
+
+func restore_v1alpha4_VirtualMachineVolumeDiskPlacement(dst, src *vmopv1.VirtualMachine) {
+	for i := range dst.Spec.Volumes {
+		for j := range src.Spec.Volumes {
+			if dst.Spec.Volumes[i].Name == src.Spec.Volumes[j].Name {
+				dst.Spec.Volumes[i].ControllerType = src.Spec.Volumes[j].ControllerType
+				dst.Spec.Volumes[i].ControllerBusNumber = src.Spec.Volumes[j].ControllerBusNumber
+				dst.Spec.Volumes[i].UnitNumber = src.Spec.Volumes[j].UnitNumber
+				dst.Spec.Volumes[i].SharingMode = src.Spec.Volumes[j].SharingMode
+				dst.Spec.Volumes[i].ControllerSharingMode = src.Spec.Volumes[j].ControllerSharingMode
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineVolumeStorageIO(dst, src *vmopv1.VirtualMachine) {
+	for i := range dst.Spec.Volumes {
+		for j := range src.Spec.Volumes {
+			if dst.Spec.Volumes[i].Name == src.Spec.Volumes[j].Name {
+				dst.Spec.Volumes[i].StorageIO = src.Spec.Volumes[j].StorageIO
+				break
+			}
+		}
+	}
+	for i := range dst.Status.Volumes {
+		for j := range src.Status.Volumes {
+			if dst.Status.Volumes[i].Name == src.Status.Volumes[j].Name {
+				dst.Status.Volumes[i].StorageIO = src.Status.Volumes[j].StorageIO
+				break
+			}
+		}
+	}
+}
+
 // ConvertTo converts this VirtualMachine to the Hub version.
 func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachine)
@@ -300,6 +407,16 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha4_VirtualMachineGuestID(dst, restored)
 	restore_v1alpha4_VirtualMachineCdrom(dst, restored)
 	restore_v1alpha4_VirtualMachineCryptoSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineReadinessProbeSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineLivenessProbeSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineTopologySpreadConstraints(dst, restored)
+	restore_v1alpha4_VirtualMachineCurrentSnapshot(dst, restored)
+	restore_v1alpha4_VirtualMachineClone(dst, restored)
+	restore_v1alpha4_VirtualMachinePorts(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceSLAAC(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkDevices(dst, restored)
+	restore_v1alpha4_VirtualMachineVolumeDiskPlacement(dst, restored)
+	restore_v1alpha4_VirtualMachineVolumeStorageIO(dst, restored)
 
 	// END RESTORE
 
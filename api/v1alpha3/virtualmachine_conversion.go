@@ -5,21 +5,207 @@
 package v1alpha3
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 )
 
+func Convert_v1alpha4_VirtualMachineReadinessProbeSpec_To_v1alpha3_VirtualMachineReadinessProbeSpec(
+	in *vmopv1.VirtualMachineReadinessProbeSpec, out *VirtualMachineReadinessProbeSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineReadinessProbeSpec_To_v1alpha3_VirtualMachineReadinessProbeSpec(in, out, s)
+}
+
+func restore_v1alpha4_VirtualMachineReadinessProbeSpec(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.ReadinessProbe != nil {
+		if dst.Spec.ReadinessProbe == nil {
+			dst.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+		}
+		dst.Spec.ReadinessProbe.HTTPGet = src.Spec.ReadinessProbe.HTTPGet
+		dst.Spec.ReadinessProbe.InitialDelaySeconds = src.Spec.ReadinessProbe.InitialDelaySeconds
+		dst.Spec.ReadinessProbe.SuccessThreshold = src.Spec.ReadinessProbe.SuccessThreshold
+		dst.Spec.ReadinessProbe.FailureThreshold = src.Spec.ReadinessProbe.FailureThreshold
+	}
+}
+
+func Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(
+	in *vmopv1.VirtualMachineNetworkSpec, out *VirtualMachineNetworkSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(
+	in *vmopv1.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(
+	in *vmopv1.VirtualMachineSpec, out *VirtualMachineSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineStatus_To_v1alpha3_VirtualMachineStatus(
+	in *vmopv1.VirtualMachineStatus, out *VirtualMachineStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha3_VirtualMachineStatus(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineVolume_To_v1alpha3_VirtualMachineVolume(
+	in *vmopv1.VirtualMachineVolume, out *VirtualMachineVolume, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineVolume_To_v1alpha3_VirtualMachineVolume(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineVolumeStatus_To_v1alpha3_VirtualMachineVolumeStatus(
+	in *vmopv1.VirtualMachineVolumeStatus, out *VirtualMachineVolumeStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineVolumeStatus_To_v1alpha3_VirtualMachineVolumeStatus(in, out, s)
+}
+
+func restore_v1alpha4_VirtualMachineLivenessProbeSpec(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.LivenessProbe = src.Spec.LivenessProbe
+}
+
+func restore_v1alpha4_VirtualMachineTopologySpreadConstraints(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.TopologySpreadConstraints = src.Spec.TopologySpreadConstraints
+}
+
+func restore_v1alpha4_VirtualMachineLivenessRestartCount(dst, src *vmopv1.VirtualMachine) {
+	dst.Status.LivenessRestartCount = src.Status.LivenessRestartCount
+}
+
+func restore_v1alpha4_VirtualMachineCurrentSnapshot(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.CurrentSnapshot = src.Spec.CurrentSnapshot
+}
+
+func restore_v1alpha4_VirtualMachineClone(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Clone = src.Spec.Clone
+}
+
+func restore_v1alpha4_VirtualMachinePorts(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Ports = src.Spec.Ports
+}
+
+func restore_v1alpha4_VirtualMachineSnapshotStatus(dst, src *vmopv1.VirtualMachine) {
+	dst.Status.CurrentSnapshot = src.Status.CurrentSnapshot
+	dst.Status.RootSnapshots = src.Status.RootSnapshots
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceSLAAC(dst, src *vmopv1.VirtualMachine) {
+	if dst.Spec.Network == nil || src.Spec.Network == nil {
+		return
+	}
+	for i := range dst.Spec.Network.Interfaces {
+		for j := range src.Spec.Network.Interfaces {
+			if dst.Spec.Network.Interfaces[i].Name == src.Spec.Network.Interfaces[j].Name {
+				dst.Spec.Network.Interfaces[i].SLAAC = src.Spec.Network.Interfaces[j].SLAAC
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkDevices(dst, src *vmopv1.VirtualMachine) {
+	if dst.Spec.Network == nil || src.Spec.Network == nil {
+		return
+	}
+	dst.Spec.Network.Bonds = src.Spec.Network.Bonds
+	dst.Spec.Network.VLANs = src.Spec.Network.VLANs
+	dst.Spec.Network.Bridges = src.Spec.Network.Bridges
+}
+
+func restore_v1alpha4_VirtualMachineVolumeResizeStatus(dst, src *vmopv1.VirtualMachine) {
+	for i := range dst.Status.Volumes {
+		for j := range src.Status.Volumes {
+			if dst.Status.Volumes[i].Name == src.Status.Volumes[j].Name {
+				dst.Status.Volumes[i].Resize = src.Status.Volumes[j].Resize
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineVolumeDiskPlacement(dst, src *vmopv1.VirtualMachine) {
+	for i := range dst.Spec.Volumes {
+		for j := range src.Spec.Volumes {
+			if dst.Spec.Volumes[i].Name == src.Spec.Volumes[j].Name {
+				dst.Spec.Volumes[i].ControllerType = src.Spec.Volumes[j].ControllerType
+				dst.Spec.Volumes[i].ControllerBusNumber = src.Spec.Volumes[j].ControllerBusNumber
+				dst.Spec.Volumes[i].UnitNumber = src.Spec.Volumes[j].UnitNumber
+				dst.Spec.Volumes[i].SharingMode = src.Spec.Volumes[j].SharingMode
+				dst.Spec.Volumes[i].ControllerSharingMode = src.Spec.Volumes[j].ControllerSharingMode
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineVolumeStorageIO(dst, src *vmopv1.VirtualMachine) {
+	for i := range dst.Spec.Volumes {
+		for j := range src.Spec.Volumes {
+			if dst.Spec.Volumes[i].Name == src.Spec.Volumes[j].Name {
+				dst.Spec.Volumes[i].StorageIO = src.Spec.Volumes[j].StorageIO
+				break
+			}
+		}
+	}
+	for i := range dst.Status.Volumes {
+		for j := range src.Status.Volumes {
+			if dst.Status.Volumes[i].Name == src.Status.Volumes[j].Name {
+				dst.Status.Volumes[i].StorageIO = src.Status.Volumes[j].StorageIO
+				break
+			}
+		}
+	}
+}
+
 // ConvertTo converts this VirtualMachine to the Hub version.
 func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachine)
-	return Convert_v1alpha3_VirtualMachine_To_v1alpha4_VirtualMachine(src, dst, nil)
+	if err := Convert_v1alpha3_VirtualMachine_To_v1alpha4_VirtualMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &vmopv1.VirtualMachine{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	// BEGIN RESTORE
+
+	restore_v1alpha4_VirtualMachineReadinessProbeSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineLivenessProbeSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineTopologySpreadConstraints(dst, restored)
+	restore_v1alpha4_VirtualMachineLivenessRestartCount(dst, restored)
+	restore_v1alpha4_VirtualMachineCurrentSnapshot(dst, restored)
+	restore_v1alpha4_VirtualMachineClone(dst, restored)
+	restore_v1alpha4_VirtualMachinePorts(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceSLAAC(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkDevices(dst, restored)
+	restore_v1alpha4_VirtualMachineSnapshotStatus(dst, restored)
+	restore_v1alpha4_VirtualMachineVolumeResizeStatus(dst, restored)
+	restore_v1alpha4_VirtualMachineVolumeDiskPlacement(dst, restored)
+	restore_v1alpha4_VirtualMachineVolumeStorageIO(dst, restored)
+
+	// END RESTORE
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachine.
 func (dst *VirtualMachine) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	src := srcRaw.(*vmopv1.VirtualMachine)
-	return Convert_v1alpha4_VirtualMachine_To_v1alpha3_VirtualMachine(src, dst, nil)
+	if err := Convert_v1alpha4_VirtualMachine_To_v1alpha3_VirtualMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachineList to the Hub version.
@@ -0,0 +1,23 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+func Convert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(
+	in *vmopv1.VirtualMachineReplicaSetSpec, out *VirtualMachineReplicaSetSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineReplicaSetStatus_To_v1alpha3_VirtualMachineReplicaSetStatus(
+	in *vmopv1.VirtualMachineReplicaSetStatus, out *VirtualMachineReplicaSetStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineReplicaSetStatus_To_v1alpha3_VirtualMachineReplicaSetStatus(in, out, s)
+}
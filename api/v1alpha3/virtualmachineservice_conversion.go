@@ -5,21 +5,75 @@
 package v1alpha3
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 )
 
+func Convert_v1alpha4_VirtualMachineServicePort_To_v1alpha3_VirtualMachineServicePort(
+	in *vmopv1.VirtualMachineServicePort, out *VirtualMachineServicePort, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineServicePort_To_v1alpha3_VirtualMachineServicePort(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServiceSpec(
+	in *vmopv1.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServiceSpec(in, out, s)
+}
+
+func restore_v1alpha4_VirtualMachineServicePorts(dst, src *vmopv1.VirtualMachineService) {
+	for i := range dst.Spec.Ports {
+		for j := range src.Spec.Ports {
+			if dst.Spec.Ports[i].Name == src.Spec.Ports[j].Name {
+				dst.Spec.Ports[i].TargetPortName = src.Spec.Ports[j].TargetPortName
+				dst.Spec.Ports[i].NodePort = src.Spec.Ports[j].NodePort
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineServiceSpec(dst, src *vmopv1.VirtualMachineService) {
+	dst.Spec.LoadBalancerClass = src.Spec.LoadBalancerClass
+	dst.Spec.PublishNotReadyAddresses = src.Spec.PublishNotReadyAddresses
+	dst.Spec.SessionAffinity = src.Spec.SessionAffinity
+	dst.Spec.SessionAffinityConfig = src.Spec.SessionAffinityConfig
+	dst.Spec.ExternalTrafficPolicy = src.Spec.ExternalTrafficPolicy
+	dst.Spec.IPFamilies = src.Spec.IPFamilies
+	dst.Spec.IPFamilyPolicy = src.Spec.IPFamilyPolicy
+}
+
 // ConvertTo converts this VirtualMachineService to the Hub version.
 func (src *VirtualMachineService) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachineService)
-	return Convert_v1alpha3_VirtualMachineService_To_v1alpha4_VirtualMachineService(src, dst, nil)
+	if err := Convert_v1alpha3_VirtualMachineService_To_v1alpha4_VirtualMachineService(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &vmopv1.VirtualMachineService{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	restore_v1alpha4_VirtualMachineServicePorts(dst, restored)
+	restore_v1alpha4_VirtualMachineServiceSpec(dst, restored)
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachineService.
 func (dst *VirtualMachineService) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	src := srcRaw.(*vmopv1.VirtualMachineService)
-	return Convert_v1alpha4_VirtualMachineService_To_v1alpha3_VirtualMachineService(src, dst, nil)
+	if err := Convert_v1alpha4_VirtualMachineService_To_v1alpha3_VirtualMachineService(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachineServiceList to the Hub version.
@@ -0,0 +1,153 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IPPoolNameLabel is the label put on an IPAddressClaim that identifies
+	// the IPPool from which the address was allocated.
+	IPPoolNameLabel = GroupName + "/ip-pool"
+
+	// IPAddressClaimInterfaceNameLabel is the label put on an IPAddressClaim
+	// that identifies the name of the network interface of the VM to which
+	// the address is assigned.
+	IPAddressClaimInterfaceNameLabel = GroupName + "/interface-name"
+)
+
+// IPPoolSpec defines the desired state of IPPool.
+type IPPoolSpec struct {
+	// NetworkName is the name of the network from which the addresses in this
+	// pool are allocated. A VM network interface on this network that does
+	// not specify its addresses and does not use DHCP is allocated an address
+	// from this pool.
+	NetworkName string `json:"networkName"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Addresses is the list of addresses in this pool. Each item may be a
+	// single IP address, such as 192.168.1.10, a range of IP addresses, such
+	// as 192.168.1.10-192.168.1.100, or a CIDR, such as 192.168.1.0/26. All of
+	// the addresses must be of the same IP family.
+	Addresses []string `json:"addresses"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+
+	// Prefix is the network prefix length of the addresses allocated from this
+	// pool, for example 24 for the subnet mask 255.255.255.0.
+	Prefix int32 `json:"prefix"`
+
+	// +optional
+
+	// Gateway is the IP address of the gateway of the addresses allocated from
+	// this pool. The gateway is never allocated from this pool.
+	Gateway string `json:"gateway,omitempty"`
+
+	// +optional
+
+	// Nameservers is the list of IP addresses of the DNS servers of the
+	// network interfaces allocated an address from this pool. The
+	// nameservers of a network interface's spec take precedence over these.
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// +optional
+
+	// SearchDomains is the list of search domains of the network interfaces
+	// allocated an address from this pool. The search domains of a network
+	// interface's spec take precedence over these.
+	SearchDomains []string `json:"searchDomains,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=ippool
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Network",type="string",JSONPath=".spec.networkName"
+// +kubebuilder:printcolumn:name="Prefix",type="integer",JSONPath=".spec.prefix"
+// +kubebuilder:printcolumn:name="Gateway",type="string",JSONPath=".spec.gateway"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// IPPool is the schema for the ippools API and represents a pool of static IP
+// addresses that are allocated to the network interfaces of VMs on a network.
+type IPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IPPoolSpec `json:"spec,omitempty"`
+}
+
+func (p *IPPool) NamespacedName() string {
+	return p.Namespace + "/" + p.Name
+}
+
+// +kubebuilder:object:root=true
+
+// IPPoolList contains a list of IPPool.
+type IPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPPool `json:"items"`
+}
+
+// IPAddressClaimSpec defines the desired state of IPAddressClaim.
+type IPAddressClaimSpec struct {
+	// PoolName is the name of the IPPool from which the address was
+	// allocated.
+	PoolName string `json:"poolName"`
+
+	// Address is the allocated IP address.
+	Address string `json:"address"`
+
+	// +optional
+
+	// VMName is the name of the VM to which the address is assigned.
+	VMName string `json:"vmName,omitempty"`
+
+	// +optional
+
+	// InterfaceName is the name of the network interface of the VM to which
+	// the address is assigned.
+	InterfaceName string `json:"interfaceName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=ipclaim
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Pool",type="string",JSONPath=".spec.poolName"
+// +kubebuilder:printcolumn:name="Address",type="string",JSONPath=".spec.address"
+// +kubebuilder:printcolumn:name="VM",type="string",JSONPath=".spec.vmName"
+// +kubebuilder:printcolumn:name="Interface",type="string",JSONPath=".spec.interfaceName"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// IPAddressClaim is the schema for the ipaddressclaims API and records the
+// allocation of an address from an IPPool. A claim is owned by the VM to which
+// the address is assigned so the address is released when the VM is deleted.
+type IPAddressClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IPAddressClaimSpec `json:"spec,omitempty"`
+}
+
+func (c *IPAddressClaim) NamespacedName() string {
+	return c.Namespace + "/" + c.Name
+}
+
+// +kubebuilder:object:root=true
+
+// IPAddressClaimList contains a list of IPAddressClaim.
+type IPAddressClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPAddressClaim `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&IPPool{}, &IPPoolList{},
+		&IPAddressClaim{}, &IPAddressClaimList{})
+}
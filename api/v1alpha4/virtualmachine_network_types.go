@@ -22,6 +22,33 @@ type VirtualMachineNetworkRouteSpec struct {
 	Metric int32 `json:"metric"`
 }
 
+// VirtualMachinePortSpec describes a named port on which a VM's guest
+// listens.
+type VirtualMachinePortSpec struct {
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+
+	// Name describes the name of this port, which must be unique across the
+	// VM's ports.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// Port describes the port number on which the guest listens.
+	Port int32 `json:"port"`
+
+	// +optional
+	// +kubebuilder:default=TCP
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+
+	// Protocol describes the Layer 4 transport protocol for this port.
+	// Supports "TCP", "UDP", and "SCTP".
+	//
+	// Defaults to "TCP".
+	Protocol string `json:"protocol,omitempty"`
+}
+
 // VirtualMachineNetworkInterfaceSpec describes the desired state of a VM's
 // network interface.
 type VirtualMachineNetworkInterfaceSpec struct {
@@ -98,6 +125,24 @@ type VirtualMachineNetworkInterfaceSpec struct {
 
 	// +optional
 
+	// SLAAC indicates whether or not this interface uses stateless address
+	// autoconfiguration (SLAAC) for IP6 networking, i.e. whether the guest
+	// accepts IP6 router advertisements to configure an IP6 address and the
+	// default IP6 route.
+	//
+	// Please note this field is only supported if the network connection
+	// has an IP6 router that sends router advertisements.
+	//
+	// Please note this field may be used with DHCP6, ex. when the router
+	// advertisements indicate that DHCPv6 provides other configuration, such
+	// as the nameservers, and with IP6 addresses in the Addresses field.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit, LinuxPrep, and Sysprep.
+	SLAAC bool `json:"slaac,omitempty"`
+
+	// +optional
+
 	// Gateway4 is the default, IP4 gateway for this interface.
 	//
 	// Please note this field is only supported if the network connection
@@ -176,6 +221,213 @@ type VirtualMachineNetworkInterfaceSpec struct {
 	SearchDomains []string `json:"searchDomains,omitempty"`
 }
 
+// VirtualMachineNetworkDeviceIPSpec describes the IP configuration of a bond,
+// VLAN, or bridge device configured inside of the guest.
+type VirtualMachineNetworkDeviceIPSpec struct {
+	// +optional
+
+	// Addresses is an optional list of IP4 or IP6 addresses to assign to this
+	// device.
+	//
+	// Please note IP4 and IP6 addresses must include the network prefix length,
+	// ex. 192.168.0.10/24 or 2001:db8:101::a/64.
+	//
+	// Please note this field may not contain IP4 addresses if DHCP4 is set
+	// to true or IP6 addresses if DHCP6 is set to true.
+	//
+	// Please note if no addresses are specified and both DHCP4 and DHCP6 are
+	// false, the device is still brought up, but it is not addressable from
+	// the network.
+	Addresses []string `json:"addresses,omitempty"`
+
+	// +optional
+
+	// DHCP4 indicates whether or not this device uses DHCP for IP4 networking.
+	//
+	// Please note this field is mutually exclusive with IP4 addresses in the
+	// Addresses field and the Gateway4 field.
+	DHCP4 bool `json:"dhcp4,omitempty"`
+
+	// +optional
+
+	// DHCP6 indicates whether or not this device uses DHCP for IP6 networking.
+	//
+	// Please note this field is mutually exclusive with IP6 addresses in the
+	// Addresses field and the Gateway6 field.
+	DHCP6 bool `json:"dhcp6,omitempty"`
+
+	// +optional
+
+	// Gateway4 is the default, IP4 gateway for this device.
+	//
+	// If the Addresses field includes at least one IP4 address, then this
+	// field is required.
+	//
+	// Please note this field is mutually exclusive with DHCP4.
+	Gateway4 string `json:"gateway4,omitempty"`
+
+	// +optional
+
+	// Gateway6 is the primary IP6 gateway for this device.
+	//
+	// If the Addresses field includes at least one IP6 address, then this
+	// field is required.
+	//
+	// Please note this field is mutually exclusive with DHCP6.
+	Gateway6 string `json:"gateway6,omitempty"`
+
+	// +optional
+
+	// MTU is the Maximum Transmission Unit size in bytes.
+	MTU *int64 `json:"mtu,omitempty"`
+
+	// +optional
+
+	// Nameservers is a list of IP4 and/or IP6 addresses used as DNS
+	// nameservers.
+	//
+	// When UseGlobalNameserversAsDefault is either unset or true, and this
+	// device has an IP configuration, if nameservers is not provided, the
+	// global nameservers will be used instead.
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// +optional
+
+	// Routes is a list of optional, static routes.
+	Routes []VirtualMachineNetworkRouteSpec `json:"routes,omitempty"`
+
+	// +optional
+
+	// SearchDomains is a list of search domains used when resolving IP
+	// addresses with DNS.
+	//
+	// When UseGlobalSearchDomainsAsDefault is either unset or true, and this
+	// device has an IP configuration, if search domains is not provided, the
+	// global search domains will be used instead.
+	SearchDomains []string `json:"searchDomains,omitempty"`
+}
+
+// VirtualMachineNetworkBondSpec describes a bond device that aggregates one
+// or more of the VM's network interfaces inside of the guest.
+type VirtualMachineNetworkBondSpec struct {
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+
+	// Name describes the name of the bond device inside of the guest, ex.
+	// bond0. The name must be unique across the VM's interfaces, bonds, VLANs,
+	// and bridges.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Interfaces is the list of the names of the network interfaces from
+	// spec.network.interfaces that are members of this bond.
+	//
+	// Please note an interface that is a member of a bond is not configured
+	// with any IP addresses of its own, and may not be a member of any other
+	// bond or bridge.
+	Interfaces []string `json:"interfaces"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=balance-rr;active-backup;balance-xor;broadcast;"802.3ad";balance-tlb;balance-alb
+
+	// Mode describes the bonding mode.
+	//
+	// If omitted, the guest's default mode, typically balance-rr, is used.
+	Mode string `json:"mode,omitempty"`
+
+	// +optional
+
+	// Primary is the name of the interface from the Interfaces field that is
+	// preferred as the active member of the bond.
+	//
+	// Please note this field is only valid when Mode is active-backup.
+	Primary string `json:"primary,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// MIIMonitorInterval is the interval, in milliseconds, at which the links
+	// of the bond's members are checked for failure. A value of zero disables
+	// link monitoring.
+	MIIMonitorInterval *int32 `json:"miiMonitorInterval,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=slow;fast
+
+	// LACPRate is the rate at which LACPDUs are transmitted.
+	//
+	// Please note this field is only valid when Mode is 802.3ad.
+	LACPRate string `json:"lacpRate,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=layer2;"layer2+3";"layer3+4";"encap2+3";"encap3+4"
+
+	// TransmitHashPolicy is the policy used to select a member of the bond
+	// when transmitting.
+	//
+	// Please note this field is only valid when Mode is balance-xor or 802.3ad.
+	TransmitHashPolicy string `json:"transmitHashPolicy,omitempty"`
+
+	VirtualMachineNetworkDeviceIPSpec `json:",inline"`
+}
+
+// VirtualMachineNetworkVLANSpec describes a VLAN sub-interface configured
+// inside of the guest on top of one of the VM's network interfaces or bonds.
+type VirtualMachineNetworkVLANSpec struct {
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+
+	// Name describes the name of the VLAN device inside of the guest, ex.
+	// vlan100 or eth0.100. The name must be unique across the VM's interfaces,
+	// bonds, VLANs, and bridges.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4094
+
+	// ID is the VLAN ID.
+	ID int32 `json:"id"`
+
+	// Link is the name of the network interface from spec.network.interfaces
+	// or the name of the bond from spec.network.bonds on which this VLAN is
+	// created.
+	Link string `json:"link"`
+
+	VirtualMachineNetworkDeviceIPSpec `json:",inline"`
+}
+
+// VirtualMachineNetworkBridgeSpec describes a bridge device that connects one
+// or more of the VM's network interfaces, bonds, or VLANs inside of the guest.
+type VirtualMachineNetworkBridgeSpec struct {
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+
+	// Name describes the name of the bridge device inside of the guest, ex.
+	// br0. The name must be unique across the VM's interfaces, bonds, VLANs,
+	// and bridges.
+	Name string `json:"name"`
+
+	// +optional
+
+	// Interfaces is the list of the names of the network interfaces, bonds,
+	// and VLANs that are ports of this bridge.
+	//
+	// Please note a device that is a port of a bridge is not configured with
+	// any IP addresses of its own, and may not be a member of any other bond
+	// or bridge.
+	Interfaces []string `json:"interfaces,omitempty"`
+
+	// +optional
+
+	// STP indicates whether or not the bridge uses the Spanning Tree Protocol.
+	//
+	// If omitted, the guest's default, typically true, is used.
+	STP *bool `json:"stp,omitempty"`
+
+	VirtualMachineNetworkDeviceIPSpec `json:",inline"`
+}
+
 // VirtualMachineNetworkSpec defines a VM's desired network configuration.
 type VirtualMachineNetworkSpec struct {
 	// +optional
@@ -287,6 +539,39 @@ type VirtualMachineNetworkSpec struct {
 	// The maximum number of network interface allowed is 10 because a vSphere
 	// virtual machine may not have more than 10 virtual ethernet card devices.
 	Interfaces []VirtualMachineNetworkInterfaceSpec `json:"interfaces,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Bonds is the list of bond devices configured inside of the guest on top
+	// of the VM's network interfaces.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	Bonds []VirtualMachineNetworkBondSpec `json:"bonds,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// VLANs is the list of VLAN sub-interfaces configured inside of the guest
+	// on top of the VM's network interfaces or bonds.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	VLANs []VirtualMachineNetworkVLANSpec `json:"vlans,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Bridges is the list of bridge devices configured inside of the guest on
+	// top of the VM's network interfaces, bonds, or VLANs.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	Bridges []VirtualMachineNetworkBridgeSpec `json:"bridges,omitempty"`
 }
 
 // VirtualMachineNetworkDNSStatus describes the observed state of the guest's
@@ -696,6 +981,10 @@ type VirtualMachineNetworkStatus struct {
 	// If the bootstrap provider is anything else then this field is set to the
 	// value of the infrastructure VM's "guest.ipAddress" field. Please see
 	// https://bit.ly/3Au0jM4 for more information.
+	//
+	// If the above value is not an IP4 address, ex. for a dual-stack VM, then
+	// this field is set to the first, non-local IP4 address reported by the
+	// VM's network interfaces.
 	PrimaryIP4 string `json:"primaryIP4,omitempty"`
 
 	// +optional
@@ -710,6 +999,10 @@ type VirtualMachineNetworkStatus struct {
 	// If the bootstrap provider is anything else then this field is set to the
 	// value of the infrastructure VM's "guest.ipAddress" field. Please see
 	// https://bit.ly/3Au0jM4 for more information.
+	//
+	// If the above value is not an IP6 address, ex. for a dual-stack VM, then
+	// this field is set to the first, non-local IP6 address reported by the
+	// VM's network interfaces.
 	PrimaryIP6 string `json:"primaryIP6,omitempty"`
 }
 
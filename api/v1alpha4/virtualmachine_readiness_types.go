@@ -52,6 +52,15 @@ type VirtualMachineReadinessProbeSpec struct {
 	// VM resource will be marked as ready.
 	GuestInfo []GuestInfoAction `json:"guestInfo,omitempty"`
 
+	// +optional
+
+	// HTTPGet specifies an action involving an HTTP GET request to the VM.
+	//
+	// Unlike the TCPSocket action, which only verifies a port is open, this
+	// action verifies the application listening on the port responds with
+	// an expected HTTP status code.
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+
 	// +optional
 	// +kubebuilder:validation:Minimum:=1
 	// +kubebuilder:validation:Maximum:=60
@@ -66,6 +75,96 @@ type VirtualMachineReadinessProbeSpec struct {
 	// PeriodSeconds specifics how often (in seconds) to perform the probe.
 	// Defaults to 10 seconds. Minimum value is 1.
 	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+
+	// InitialDelaySeconds specifies the number of seconds after the VM is
+	// observed as powered on before the probe is initiated.
+	// Defaults to 0 seconds.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// SuccessThreshold specifies the minimum number of consecutive successes
+	// for the probe to be considered successful after having failed.
+	// Defaults to 1. Minimum value is 1.
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// FailureThreshold specifies the minimum number of consecutive failures
+	// for the probe to be considered failed after having succeeded.
+	// Defaults to 1. Minimum value is 1.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// VirtualMachineLivenessProbeSpec describes a probe used to determine if the
+// guest of a VM is alive. When the probe fails FailureThreshold consecutive
+// times, the VM is restarted in accordance with spec.restartMode. All probe
+// actions are mutually exclusive.
+type VirtualMachineLivenessProbeSpec struct {
+	// +optional
+
+	// TCPSocket specifies an action involving a TCP port.
+	TCPSocket *TCPSocketAction `json:"tcpSocket,omitempty"`
+
+	// +optional
+
+	// HTTPGet specifies an action involving an HTTP GET request to the VM.
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+
+	// +optional
+
+	// GuestHeartbeat specifies an action involving the guest heartbeat status.
+	GuestHeartbeat *GuestHeartbeatAction `json:"guestHeartbeat,omitempty"`
+
+	// +optional
+
+	// GuestInfo specifies an action involving key/value pairs from GuestInfo.
+	//
+	// The elements are evaluated with the logical AND operator, meaning
+	// all expressions must evaluate as true for the probe to succeed.
+	//
+	// Please refer to VirtualMachineReadinessProbeSpec.GuestInfo for more
+	// information.
+	GuestInfo []GuestInfoAction `json:"guestInfo,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=60
+
+	// TimeoutSeconds specifies a number of seconds after which the probe times out.
+	// Defaults to 10 seconds. Minimum value is 1.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// PeriodSeconds specifics how often (in seconds) to perform the probe.
+	// Defaults to 10 seconds. Minimum value is 1.
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+
+	// InitialDelaySeconds specifies the number of seconds after the VM is
+	// observed as powered on, or was restarted, before the probe is
+	// initiated. Please note this value should exceed the time it takes for
+	// the guest to boot, otherwise the VM may be restarted before the guest
+	// is able to respond to the probe.
+	// Defaults to 0 seconds.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// FailureThreshold specifies the number of consecutive failures after
+	// which the VM is restarted.
+	// Defaults to 3. Minimum value is 1.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
 // TCPSocketAction describes an action based on opening a socket.
@@ -81,6 +180,91 @@ type TCPSocketAction struct {
 	Host string `json:"host,omitempty"`
 }
 
+// URIScheme identifies the scheme used for connection to a host for Get
+// actions.
+//
+// +kubebuilder:validation:Enum=HTTP;HTTPS
+type URIScheme string
+
+const (
+	// URISchemeHTTP means that the scheme used will be http://.
+	URISchemeHTTP URIScheme = "HTTP"
+	// URISchemeHTTPS means that the scheme used will be https://.
+	URISchemeHTTPS URIScheme = "HTTPS"
+)
+
+// HTTPHeader describes a custom header to be used in HTTP probes.
+type HTTPHeader struct {
+	// Name is the header field name.
+	// This will be canonicalized upon output, so case-variant names will be
+	// understood as the same header.
+	Name string `json:"name"`
+
+	// Value is the header field value.
+	Value string `json:"value"`
+}
+
+// HTTPStatusCodeRange describes an inclusive range of HTTP status codes.
+type HTTPStatusCodeRange struct {
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+
+	// Min is the lowest status code considered successful.
+	Min int32 `json:"min"`
+
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+
+	// Max is the highest status code considered successful.
+	Max int32 `json:"max"`
+}
+
+// HTTPGetAction describes an action based on HTTP Get requests.
+type HTTPGetAction struct {
+	// +optional
+
+	// Path is the path to access on the HTTP server. Defaults to "/".
+	Path string `json:"path,omitempty"`
+
+	// Port specifies a number or name of the port to access on the VM.
+	// If the format of port is a number, it must be in the range 1 to 65535.
+	// If the format of name is a string, it must be an IANA_SVC_NAME.
+	Port intstr.IntOrString `json:"port"`
+
+	// +optional
+
+	// Host is an optional host name to connect to. Host defaults to the VM
+	// IP.
+	Host string `json:"host,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=HTTP
+
+	// Scheme to use for connecting to the host. Defaults to HTTP.
+	Scheme URIScheme `json:"scheme,omitempty"`
+
+	// +optional
+	// +listType=atomic
+
+	// HTTPHeaders are custom headers to set in the request. HTTP allows
+	// repeated headers.
+	HTTPHeaders []HTTPHeader `json:"httpHeaders,omitempty"`
+
+	// +optional
+
+	// SuccessStatusCodes is the range of HTTP status codes that indicate
+	// success. Defaults to 200-399.
+	SuccessStatusCodes *HTTPStatusCodeRange `json:"successStatusCodes,omitempty"`
+
+	// +optional
+
+	// InsecureSkipTLSVerify indicates the server's certificate is not
+	// verified when the Scheme is HTTPS. Please note this is not
+	// recommended and should only be used for VMs with self-signed
+	// certificates.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
 // GuestHeartbeatStatus is the guest heartbeat status.
 type GuestHeartbeatStatus string
 
@@ -32,8 +32,202 @@ type VirtualMachineVolume struct {
 	// VirtualMachineVolumeSource represents the location and type of a volume
 	// to mount.
 	VirtualMachineVolumeSource `json:",inline"`
+
+	// +optional
+
+	// ControllerType describes the type of the controller to which the
+	// volume's disk is attached.
+	//
+	// When omitted, the controller is selected automatically.
+	ControllerType VirtualControllerType `json:"controllerType,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3
+
+	// ControllerBusNumber describes the bus number of the controller to which
+	// the volume's disk is attached. If no controller of ControllerType exists
+	// with this bus number, one is added to the VM.
+	//
+	// This field may only be specified if ControllerType is also specified.
+	ControllerBusNumber *int32 `json:"controllerBusNumber,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// UnitNumber describes the unit number of the volume's disk on its
+	// controller. The following ranges are valid for each controller type:
+	//
+	//   - SCSI -- 0-15, excluding 7, which is reserved for the controller
+	//   - SATA -- 0-29
+	//   - NVME -- 0-14
+	//
+	// This field may only be specified if ControllerType and
+	// ControllerBusNumber are also specified.
+	UnitNumber *int32 `json:"unitNumber,omitempty"`
+
+	// +optional
+
+	// SharingMode describes the sharing mode of the volume's disk.
+	//
+	// When omitted, the disk is not shared.
+	//
+	// Please note, the MultiWriter sharing mode is not supported for disks
+	// attached to a SATA controller or to a controller whose bus is shared.
+	SharingMode VolumeSharingMode `json:"sharingMode,omitempty"`
+
+	// +optional
+
+	// ControllerSharingMode describes the bus sharing mode of the controller
+	// to which the volume's disk is attached. Disks shared by the VMs of a
+	// Windows Server Failover Cluster require a controller with the Physical
+	// bus sharing mode. Such disks do not use the MultiWriter sharing mode.
+	//
+	// When omitted, the controller's bus is not shared.
+	//
+	// This field may only be specified if ControllerType is SCSI and
+	// ControllerBusNumber is also specified.
+	ControllerSharingMode VirtualControllerSharingMode `json:"controllerSharingMode,omitempty"`
+
+	// +optional
+
+	// StorageIO describes the storage I/O resources allocated to the volume's
+	// disk.
+	//
+	// When omitted, the storage I/O allocation from the VM's class is used, if
+	// any.
+	StorageIO *VirtualMachineStorageIOAllocation `json:"storageIO,omitempty"`
+}
+
+// VirtualMachineStorageIOAllocation describes the storage I/O resources
+// allocated to a virtual disk.
+type VirtualMachineStorageIOAllocation struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// Limit describes the maximum number of I/O operations per second (IOPS)
+	// for the disk.
+	//
+	// When omitted, the disk's IOPS are not limited.
+	Limit *int64 `json:"limit,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// Reservation describes the number of I/O operations per second (IOPS)
+	// guaranteed to the disk.
+	//
+	// Please note, the reservation may not exceed the limit.
+	Reservation *int32 `json:"reservation,omitempty"`
+
+	// +optional
+
+	// Shares describes the disk's relative priority when there is contention
+	// for storage I/O.
+	Shares *VirtualMachineStorageIOShares `json:"shares,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Low;Normal;High;Custom
+
+// VirtualMachineStorageIOSharesLevel describes a pre-defined number of storage
+// I/O shares.
+type VirtualMachineStorageIOSharesLevel string
+
+const (
+	// VirtualMachineStorageIOSharesLevelLow describes 500 shares.
+	VirtualMachineStorageIOSharesLevelLow VirtualMachineStorageIOSharesLevel = "Low"
+
+	// VirtualMachineStorageIOSharesLevelNormal describes 1000 shares.
+	VirtualMachineStorageIOSharesLevelNormal VirtualMachineStorageIOSharesLevel = "Normal"
+
+	// VirtualMachineStorageIOSharesLevelHigh describes 2000 shares.
+	VirtualMachineStorageIOSharesLevelHigh VirtualMachineStorageIOSharesLevel = "High"
+
+	// VirtualMachineStorageIOSharesLevelCustom describes the number of shares
+	// specified by VirtualMachineStorageIOShares.Shares.
+	VirtualMachineStorageIOSharesLevelCustom VirtualMachineStorageIOSharesLevel = "Custom"
+)
+
+// VirtualMachineStorageIOShares describes the storage I/O shares of a virtual
+// disk.
+type VirtualMachineStorageIOShares struct {
+	// Level describes the number of shares as a pre-defined level, or Custom
+	// to specify the number of shares with Shares.
+	Level VirtualMachineStorageIOSharesLevel `json:"level"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// Shares describes the number of shares.
+	//
+	// This field may only be specified if Level is Custom.
+	Shares int32 `json:"shares,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=SCSI;SATA;NVME
+
+// VirtualControllerType describes the type of a virtual disk controller.
+type VirtualControllerType string
+
+const (
+	// VirtualControllerTypeSCSI describes a paravirtual SCSI controller.
+	VirtualControllerTypeSCSI VirtualControllerType = "SCSI"
+
+	// VirtualControllerTypeSATA describes an AHCI SATA controller.
+	VirtualControllerTypeSATA VirtualControllerType = "SATA"
+
+	// VirtualControllerTypeNVME describes an NVMe controller.
+	VirtualControllerTypeNVME VirtualControllerType = "NVME"
+)
+
+// MaxUnitNumber returns the number of unit numbers available on a
+// controller of this type.
+func (t VirtualControllerType) MaxUnitNumber() int32 {
+	switch t {
+	case VirtualControllerTypeSCSI:
+		return 16
+	case VirtualControllerTypeSATA:
+		return 30
+	case VirtualControllerTypeNVME:
+		return 15
+	}
+	return 0
 }
 
+// +kubebuilder:validation:Enum=None;Physical;Virtual
+
+// VirtualControllerSharingMode describes the bus sharing mode of a virtual
+// disk controller.
+type VirtualControllerSharingMode string
+
+const (
+	// VirtualControllerSharingModeNone indicates the controller's bus is not
+	// shared.
+	VirtualControllerSharingModeNone VirtualControllerSharingMode = "None"
+
+	// VirtualControllerSharingModePhysical indicates the controller's bus may
+	// be shared with VMs on any host.
+	VirtualControllerSharingModePhysical VirtualControllerSharingMode = "Physical"
+
+	// VirtualControllerSharingModeVirtual indicates the controller's bus may
+	// be shared with VMs on the same host.
+	VirtualControllerSharingModeVirtual VirtualControllerSharingMode = "Virtual"
+)
+
+// +kubebuilder:validation:Enum=None;MultiWriter
+
+// VolumeSharingMode describes the sharing mode of a volume's disk.
+type VolumeSharingMode string
+
+const (
+	// VolumeSharingModeNone indicates the disk is not shared.
+	VolumeSharingModeNone VolumeSharingMode = "None"
+
+	// VolumeSharingModeMultiWriter indicates the disk may be opened for
+	// writing by multiple VMs at the same time.
+	VolumeSharingModeMultiWriter VolumeSharingMode = "MultiWriter"
+)
+
 // VirtualMachineVolumeSource represents the source location of a volume to
 // mount. Only one of its members may be specified.
 type VirtualMachineVolumeSource struct {
@@ -119,7 +313,9 @@ type VirtualMachineVolumeStatus struct {
 
 	// +optional
 
-	// Limit describes the storage limit for the volume.
+	// Limit describes the storage limit for the volume. Until the storage
+	// provider has expanded the volume, this is the capacity prior to the
+	// expansion.
 	Limit *resource.Quantity `json:"limit,omitempty"`
 
 	// +optional
@@ -146,6 +342,57 @@ type VirtualMachineVolumeStatus struct {
 	// Error represents the last error seen when attaching or detaching a
 	// volume.  Error will be empty if attachment succeeds.
 	Error string `json:"error,omitempty"`
+
+	// +optional
+
+	// Resize describes the progress of expanding the volume. This field is
+	// only set while the volume's PersistentVolumeClaim requests more storage
+	// than is currently available to the VM.
+	Resize *VirtualMachineVolumeResizeStatus `json:"resize,omitempty"`
+
+	// +optional
+
+	// StorageIO describes the observed storage I/O resources allocated to the
+	// volume's disk. This field is only set while the volume specifies its own
+	// allocation.
+	StorageIO *VirtualMachineStorageIOAllocation `json:"storageIO,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=InProgress;FileSystemResizePending;Failed
+
+// VirtualMachineVolumeResizeState describes the state of a volume expansion.
+type VirtualMachineVolumeResizeState string
+
+const (
+	// VirtualMachineVolumeResizeStateInProgress indicates the volume's
+	// backing storage is being expanded by the storage provider.
+	VirtualMachineVolumeResizeStateInProgress VirtualMachineVolumeResizeState = "InProgress"
+
+	// VirtualMachineVolumeResizeStateFileSystemResizePending indicates the
+	// volume's backing storage has been expanded, and the file system on the
+	// volume must be grown in the guest.
+	VirtualMachineVolumeResizeStateFileSystemResizePending VirtualMachineVolumeResizeState = "FileSystemResizePending"
+
+	// VirtualMachineVolumeResizeStateFailed indicates the volume could not be
+	// expanded.
+	VirtualMachineVolumeResizeStateFailed VirtualMachineVolumeResizeState = "Failed"
+)
+
+// VirtualMachineVolumeResizeStatus describes the progress of expanding a
+// volume.
+type VirtualMachineVolumeResizeStatus struct {
+	// State describes the state of the expansion.
+	State VirtualMachineVolumeResizeState `json:"state"`
+
+	// +optional
+
+	// Requested describes the capacity to which the volume is being expanded.
+	Requested *resource.Quantity `json:"requested,omitempty"`
+
+	// +optional
+
+	// Message describes why the volume could not be expanded, if applicable.
+	Message string `json:"message,omitempty"`
 }
 
 // SortVirtualMachineVolumeStatuses sorts the provided list of
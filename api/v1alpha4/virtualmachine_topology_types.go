@@ -0,0 +1,71 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UnsatisfiableConstraintAction describes how to place a VM when a topology
+// spread constraint cannot be satisfied.
+//
+// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+type UnsatisfiableConstraintAction string
+
+const (
+	// DoNotSchedule instructs placement to fail rather than place the VM in a
+	// topology domain that violates the constraint.
+	DoNotSchedule UnsatisfiableConstraintAction = "DoNotSchedule"
+
+	// ScheduleAnyway instructs placement to place the VM in the topology
+	// domain that minimizes the skew, even if the constraint is violated.
+	ScheduleAnyway UnsatisfiableConstraintAction = "ScheduleAnyway"
+)
+
+// VirtualMachineTopologySpreadConstraint describes how a group of VMs ought
+// to be spread across topology domains, ex. the zones of a Supervisor.
+type VirtualMachineTopologySpreadConstraint struct {
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxSkew describes the degree to which the VMs selected by LabelSelector
+	// may be unevenly distributed. It is the maximum permitted difference
+	// between the number of matching VMs in a topology domain and the minimum
+	// number of matching VMs in any eligible topology domain.
+	//
+	// For example, with three zones and MaxSkew=1, if the matching VMs are
+	// placed 2/1/1 across the zones, then the next VM may be placed in the
+	// second or third zone, but not the first.
+	MaxSkew int32 `json:"maxSkew"`
+
+	// +optional
+	// +kubebuilder:default="topology.kubernetes.io/zone"
+	// +kubebuilder:validation:Enum="topology.kubernetes.io/zone"
+
+	// TopologyKey is the key of the label that describes the topology domain
+	// of a VM. Only "topology.kubernetes.io/zone" is supported.
+	//
+	// Defaults to "topology.kubernetes.io/zone".
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=DoNotSchedule
+
+	// WhenUnsatisfiable describes how to place the VM if it does not satisfy
+	// the spread constraint:
+	//
+	// - DoNotSchedule -- The VM is not placed until the constraint can be
+	//                    satisfied.
+	// - ScheduleAnyway -- The VM is placed in the topology domain that
+	//                     minimizes the skew.
+	//
+	// Defaults to DoNotSchedule.
+	WhenUnsatisfiable UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+
+	// LabelSelector is used to find the VMs in the same namespace as this VM
+	// that are counted when determining the number of VMs in their topology
+	// domain. Typically, this selects the peers of the VM, ex. the replicas of
+	// a VirtualMachineReplicaSet.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+}
@@ -49,9 +49,30 @@ const (
 	// VirtualMachineConditionCreated indicates that the VM has been created.
 	VirtualMachineConditionCreated = "VirtualMachineCreated"
 
+	// VirtualMachineConditionPoweredOn indicates that the VM is powered on.
+	// The condition's last transition time is the time at which the VM was
+	// last powered on, or powered off or suspended. The reason of a False
+	// condition is the VM's power state.
+	VirtualMachineConditionPoweredOn = "VirtualMachinePoweredOn"
+
+	// VirtualMachineTooManyCreatesReason documents that the VM has not yet
+	// been created because the number of concurrent create operations has
+	// reached the allowed limit.
+	VirtualMachineTooManyCreatesReason = "TooManyCreates"
+
 	// VirtualMachineClassConfigurationSynced indicates that the VM's current configuration is synced to the
 	// current version of its VirtualMachineClass.
 	VirtualMachineClassConfigurationSynced = "VirtualMachineClassConfigurationSynced"
+
+	// VirtualMachineDiskPlacementSynced indicates that the disks of the VM's
+	// attached volumes are attached to the controller and unit number, and
+	// have the sharing mode, requested by their volume.
+	VirtualMachineDiskPlacementSynced = "VirtualMachineDiskPlacementSynced"
+
+	// VirtualMachineDiskPlacementPendingPowerOnReason documents that the
+	// requested placement of one or more disks is applied the next time the
+	// VM is powered on.
+	VirtualMachineDiskPlacementPendingPowerOnReason = "PendingPowerOn"
 )
 
 const (
@@ -149,6 +170,12 @@ const (
 	// VirtualMachineSameVMClassResizeAnnotation is an annotation that indicates the VM
 	// should be resized as the class it points to changes.
 	VirtualMachineSameVMClassResizeAnnotation = GroupName + "/same-vm-class-resize"
+
+	// GuestVolumeResizeHintAnnotation is an annotation that indicates the
+	// capacities of the VM's volumes should be published to the guest via
+	// GuestInfo so that an agent in the guest may grow the file systems on
+	// the volumes after they are expanded.
+	GuestVolumeResizeHintAnnotation = GroupName + "/guest-volume-resize-hint"
 )
 
 const (
@@ -286,13 +313,48 @@ const (
 type VirtualMachineImageRef struct {
 	// Kind describes the type of image, either a namespace-scoped
 	// VirtualMachineImage or cluster-scoped ClusterVirtualMachineImage.
+	//
+	// When used as a VM's spec.image, Kind may also be VirtualMachine, in
+	// which case the VM is deployed by cloning another VirtualMachine.
 	Kind string `json:"kind"`
 
 	// Name refers to the name of a VirtualMachineImage resource in the same
 	// namespace as this VM or a cluster-scoped ClusterVirtualMachineImage.
+	//
+	// If Kind is VirtualMachine, Name refers to the name of a VirtualMachine
+	// resource in the same namespace as this VM.
 	Name string `json:"name"`
 }
 
+// +kubebuilder:validation:Enum=Full;Linked
+
+// VirtualMachineCloneMode describes how the disks of a source VM are cloned.
+type VirtualMachineCloneMode string
+
+const (
+	// VirtualMachineCloneModeFull indicates the disks of the source VM are
+	// fully copied to the new VM.
+	VirtualMachineCloneModeFull VirtualMachineCloneMode = "Full"
+
+	// VirtualMachineCloneModeLinked indicates the new VM's disks are backed
+	// by child disks of the source VM's current snapshot. Linked clones are
+	// created much faster than full clones and consume less storage, but
+	// require the source VM to have a snapshot.
+	VirtualMachineCloneModeLinked VirtualMachineCloneMode = "Linked"
+)
+
+// VirtualMachineCloneSpec describes how a VM is cloned from the
+// VirtualMachine referenced by spec.image.
+type VirtualMachineCloneSpec struct {
+	// +optional
+	// +kubebuilder:default=Full
+
+	// Mode describes how the disks of the source VM are cloned.
+	//
+	// Defaults to Full.
+	Mode VirtualMachineCloneMode `json:"mode,omitempty"`
+}
+
 // VirtualMachineCdromSpec describes the desired state of a CD-ROM device.
 type VirtualMachineCdromSpec struct {
 	// +kubebuilder:validation:Pattern="^[a-z0-9]{2,}$"
@@ -431,6 +493,10 @@ type VirtualMachineSpec struct {
 	// Image describes the reference to the VirtualMachineImage or
 	// ClusterVirtualMachineImage resource used to deploy this VM.
 	//
+	// Image may also refer to another VirtualMachine in the same namespace as
+	// this VM, in which case this VM is deployed by cloning that VM. Please
+	// refer to spec.clone for more information.
+	//
 	// Please note, unlike the field spec.imageName, the value of
 	// spec.image.name MUST be a Kubernetes object name.
 	//
@@ -477,6 +543,21 @@ type VirtualMachineSpec struct {
 
 	// +optional
 
+	// Clone describes how this VM is cloned from the VirtualMachine referenced
+	// by spec.image. This field may only be set when spec.image.kind is
+	// VirtualMachine.
+	//
+	// When a VM is cloned, the source VM's classic disks are copied, the new
+	// VM is assigned its own BIOS and instance UUIDs, and the source VM's
+	// network interfaces are replaced with those in spec.network. The data of
+	// the source VM's PersistentVolumeClaims is copied to new claims via CSI
+	// volume cloning.
+	//
+	// This field is immutable.
+	Clone *VirtualMachineCloneSpec `json:"clone,omitempty"`
+
+	// +optional
+
 	// ClassName describes the name of the VirtualMachineClass resource used to
 	// deploy this VM.
 	//
@@ -518,6 +599,17 @@ type VirtualMachineSpec struct {
 	// Namespace's default network.
 	Network *VirtualMachineNetworkSpec `json:"network,omitempty"`
 
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Ports describes the named ports on which the VM's guest listens.
+	//
+	// A VirtualMachineService may refer to one of these ports by name with
+	// spec.ports[].targetPortName, which allows VMs listening on different
+	// port numbers to be selected by the same VirtualMachineService.
+	Ports []VirtualMachinePortSpec `json:"ports,omitempty"`
+
 	// +optional
 
 	// PowerState describes the desired power state of a VirtualMachine.
@@ -610,6 +702,25 @@ type VirtualMachineSpec struct {
 
 	// +optional
 
+	// LivenessProbe describes a probe used to determine whether the VM's guest
+	// is alive. A VM whose liveness probe fails is restarted in accordance
+	// with RestartMode.
+	//
+	// Please note the probe is only run against VMs that are powered on.
+	LivenessProbe *VirtualMachineLivenessProbeSpec `json:"livenessProbe,omitempty"`
+
+	// +optional
+
+	// TopologySpreadConstraints describes how this VM and its peers ought to
+	// be spread across zones, ex. to ensure the replicas of a
+	// VirtualMachineReplicaSet are not all placed in the same zone.
+	//
+	// The constraints are only considered when the VM's zone is selected
+	// during placement. All of the constraints are ANDed.
+	TopologySpreadConstraints []VirtualMachineTopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +optional
+
 	// Advanced describes a set of optional, advanced VM configuration options.
 	Advanced *VirtualMachineAdvancedSpec `json:"advanced,omitempty"`
 
@@ -705,6 +816,18 @@ type VirtualMachineSpec struct {
 	//
 	// This field is required when the VM has any CD-ROM devices attached.
 	GuestID string `json:"guestID,omitempty"`
+
+	// +optional
+
+	// CurrentSnapshot describes the name of a VirtualMachineSnapshot, in the
+	// same namespace as the VM, to which the VM should be reverted.
+	//
+	// Once the VM has been reverted to the snapshot this field is cleared, and
+	// VirtualMachineStatus.CurrentSnapshot reflects the snapshot.
+	//
+	// Please note the VM is powered on after the revert only if the snapshot
+	// includes the VM's memory and the VM's desired power state is PoweredOn.
+	CurrentSnapshot string `json:"currentSnapshot,omitempty"`
 }
 
 // VirtualMachineReservedSpec describes a set of VM configuration options
@@ -727,8 +850,12 @@ type VirtualMachineAdvancedSpec struct {
 	// BootDiskCapacity is the capacity of the VM's boot disk -- the first disk
 	// from the VirtualMachineImage from which the VM was deployed.
 	//
-	// Please note it is not advised to change this value while the VM is
-	// running. Also, resizing the VM's boot disk may require actions inside of
+	// Increasing this value resizes the boot disk of a powered off VM the next
+	// time the VM is powered on, and, if supported, the boot disk of a powered
+	// on VM while it is running. This value may not be decreased, and the boot
+	// disk of a linked clone or a VM with snapshots may not be resized.
+	//
+	// Please note resizing the VM's boot disk may require actions inside of
 	// the guest to take advantage of the additional capacity. Finally, changing
 	// the size of the VM's boot disk, even increasing it, could adversely
 	// affect the VM.
@@ -872,6 +999,12 @@ type VirtualMachineStatus struct {
 
 	// +optional
 
+	// LivenessRestartCount describes the number of times the VM was restarted
+	// because its liveness probe failed.
+	LivenessRestartCount int32 `json:"livenessRestartCount,omitempty"`
+
+	// +optional
+
 	// HardwareVersion describes the VirtualMachine resource's observed
 	// hardware version.
 	//
@@ -883,6 +1016,20 @@ type VirtualMachineStatus struct {
 
 	// Storage describes the observed state of the VirtualMachine's storage.
 	Storage *VirtualMachineStorageStatus `json:"storage,omitempty"`
+
+	// +optional
+
+	// CurrentSnapshot describes the name of the snapshot from which the VM's
+	// current state is derived, i.e. the snapshot that was most recently
+	// taken or reverted to.
+	CurrentSnapshot string `json:"currentSnapshot,omitempty"`
+
+	// +optional
+
+	// RootSnapshots describes the names of the snapshots at the root of the
+	// VM's snapshot tree. The children of each snapshot are reported by the
+	// status of the corresponding VirtualMachineSnapshot resource.
+	RootSnapshots []string `json:"rootSnapshots,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -75,6 +75,12 @@ type VirtualMachineClassHardware struct {
 
 	// +optional
 	InstanceStorage InstanceStorage `json:"instanceStorage,omitempty"`
+
+	// +optional
+
+	// StorageIO describes the storage I/O resources allocated to each of the
+	// VM's disks, unless overridden by the disk's volume.
+	StorageIO *VirtualMachineStorageIOAllocation `json:"storageIO,omitempty"`
 }
 
 // VirtualMachineResourceSpec describes a virtual hardware policy specification.
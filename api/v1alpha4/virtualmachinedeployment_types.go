@@ -0,0 +1,278 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// VirtualMachineDeploymentNameLabel is the key of the label applied to the
+	// VirtualMachineReplicaSets owned by a VirtualMachineDeployment, and
+	// propagated to their VirtualMachines. The value of this label is the name
+	// of the VirtualMachineDeployment.
+	VirtualMachineDeploymentNameLabel = "vmoperator.vmware.com/deployment-name"
+
+	// VirtualMachineTemplateHashLabel is the key of the label applied to the
+	// VirtualMachineReplicaSets owned by a VirtualMachineDeployment, and to
+	// their VirtualMachines. The value of this label is a hash of the
+	// VirtualMachineDeployment's template and is used to distinguish the
+	// replica sets of different revisions.
+	VirtualMachineTemplateHashLabel = "vmoperator.vmware.com/vm-template-hash"
+
+	// VirtualMachineDeploymentRevisionAnnotation is the key of the annotation
+	// applied to a VirtualMachineReplicaSet owned by a VirtualMachineDeployment
+	// and to the VirtualMachineDeployment itself. The value of this annotation
+	// is the revision number of the replica set, and on the deployment, the
+	// revision number of its current replica set.
+	VirtualMachineDeploymentRevisionAnnotation = "vmoperator.vmware.com/deployment-revision"
+)
+
+const (
+	// VirtualMachineDeploymentAvailableCondition documents that the
+	// VirtualMachineDeployment has at least the minimum number of ready
+	// replicas required by its strategy.
+	VirtualMachineDeploymentAvailableCondition = "Available"
+
+	// VirtualMachineDeploymentRolledOutCondition documents that all of the
+	// replicas of the VirtualMachineDeployment run the current revision of its
+	// template and are ready. When this condition is false, the deployment is
+	// rolling out a new revision, scaling, or is paused.
+	VirtualMachineDeploymentRolledOutCondition = "RolledOut"
+
+	// MinimumReplicasUnavailableReason documents a VirtualMachineDeployment
+	// that does not have the minimum number of ready replicas.
+	MinimumReplicasUnavailableReason = "MinimumReplicasUnavailable"
+
+	// RolloutInProgressReason documents a VirtualMachineDeployment that is
+	// replacing the replicas of a previous revision or waiting for the
+	// replicas of the current revision to become ready.
+	RolloutInProgressReason = "RolloutInProgress"
+
+	// DeploymentPausedReason documents a VirtualMachineDeployment that is
+	// paused.
+	DeploymentPausedReason = "DeploymentPaused"
+)
+
+// VirtualMachineDeploymentStrategyType describes how existing replicas are
+// replaced by new ones.
+//
+// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+type VirtualMachineDeploymentStrategyType string
+
+const (
+	// VirtualMachineDeploymentStrategyTypeRollingUpdate replaces the old
+	// VirtualMachineReplicaSet by the new one gradually, i.e. it scales down
+	// the old replica set and scales up the new one while honoring the
+	// deployment's maxSurge and maxUnavailable settings.
+	VirtualMachineDeploymentStrategyTypeRollingUpdate VirtualMachineDeploymentStrategyType = "RollingUpdate"
+
+	// VirtualMachineDeploymentStrategyTypeRecreate deletes all of the existing
+	// VirtualMachines before creating new ones.
+	VirtualMachineDeploymentStrategyTypeRecreate VirtualMachineDeploymentStrategyType = "Recreate"
+)
+
+// VirtualMachineDeploymentStrategy describes how to replace existing
+// VirtualMachines with new ones.
+type VirtualMachineDeploymentStrategy struct {
+	// +optional
+	// +kubebuilder:default=RollingUpdate
+	//
+	// Type of deployment. Can be "Recreate" or "RollingUpdate". Defaults to
+	// RollingUpdate.
+	Type VirtualMachineDeploymentStrategyType `json:"type,omitempty"`
+
+	// +optional
+	//
+	// RollingUpdate describes the rolling update config params. Present only
+	// if Type is RollingUpdate.
+	RollingUpdate *VirtualMachineDeploymentRollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// VirtualMachineDeploymentRollingUpdate describes the parameters used to
+// control the rolling update of a VirtualMachineDeployment.
+type VirtualMachineDeploymentRollingUpdate struct {
+	// +optional
+	//
+	// MaxUnavailable is the maximum number of VirtualMachines that can be
+	// unavailable during the update. Value can be an absolute number (ex: 5)
+	// or a percentage of desired replicas (ex: 10%). The absolute number is
+	// calculated from percentage by rounding down. This can not be 0 if
+	// MaxSurge is 0. Defaults to 25%.
+	//
+	// A VirtualMachine is considered available when its Ready condition is
+	// true.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// +optional
+	//
+	// MaxSurge is the maximum number of VirtualMachines that can be scheduled
+	// above the desired number of replicas. Value can be an absolute number
+	// (ex: 5) or a percentage of desired replicas (ex: 10%). This can not be 0
+	// if MaxUnavailable is 0. The absolute number is calculated from
+	// percentage by rounding up. Defaults to 25%.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// VirtualMachineDeploymentRollback describes the revision to which a
+// VirtualMachineDeployment should be rolled back.
+type VirtualMachineDeploymentRollback struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	//
+	// Revision is the revision to which the deployment should be rolled back.
+	// If set to 0, the deployment is rolled back to the last revision prior
+	// to the current one.
+	Revision int64 `json:"revision,omitempty"`
+}
+
+// VirtualMachineDeploymentSpec is the specification of a
+// VirtualMachineDeployment.
+type VirtualMachineDeploymentSpec struct {
+	// +optional
+	// +kubebuilder:default=1
+	//
+	// Replicas is the number of desired replicas.
+	// This is a pointer to distinguish between explicit zero and unspecified.
+	// Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Selector is a label query over virtual machines that should match the
+	// replica count. A virtual machine's label keys and values must match in
+	// order to be controlled by this VirtualMachineDeployment.
+	//
+	// It must match the VirtualMachine template's labels.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// +optional
+	//
+	// Template is the object that describes the virtual machines that will be
+	// created by the VirtualMachineReplicaSets owned by this deployment. Any
+	// change to the template results in a new revision.
+	Template VirtualMachineTemplateSpec `json:"template,omitempty"`
+
+	// +optional
+	//
+	// Strategy describes how to replace existing VirtualMachines with new
+	// ones.
+	Strategy VirtualMachineDeploymentStrategy `json:"strategy,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	//
+	// RevisionHistoryLimit is the number of old VirtualMachineReplicaSets to
+	// retain in order to allow rollback. Defaults to 10.
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// +optional
+	//
+	// Paused indicates that the deployment is paused. Changes to the template
+	// of a paused deployment do not trigger a new rollout.
+	Paused bool `json:"paused,omitempty"`
+
+	// +optional
+	//
+	// RollbackTo is the config this deployment is rolling back to. It is
+	// cleared by the controller once the rollback has been performed.
+	RollbackTo *VirtualMachineDeploymentRollback `json:"rollbackTo,omitempty"`
+}
+
+// VirtualMachineDeploymentStatus represents the observed state of a
+// VirtualMachineDeployment resource.
+type VirtualMachineDeploymentStatus struct {
+	// +optional
+	//
+	// ObservedGeneration reflects the generation of the most recently observed
+	// VirtualMachineDeployment.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	//
+	// Replicas is the total number of non-terminated virtual machines
+	// targeted by this deployment.
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// +optional
+	//
+	// UpdatedReplicas is the total number of non-terminated virtual machines
+	// targeted by this deployment that have the desired template.
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// +optional
+	//
+	// ReadyReplicas is the number of virtual machines targeted by this
+	// deployment whose Ready condition is true.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// +optional
+	//
+	// UnavailableReplicas is the total number of virtual machines targeted by
+	// this deployment that are not yet ready. This is the number of virtual
+	// machines that are still required for the deployment to have 100%
+	// available capacity.
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
+
+	// +optional
+	//
+	// Revision is the revision of the deployment's current
+	// VirtualMachineReplicaSet.
+	Revision int64 `json:"revision,omitempty"`
+
+	// +optional
+	//
+	// Conditions represents the latest available observations of a
+	// VirtualMachineDeployment's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (d *VirtualMachineDeployment) GetConditions() []metav1.Condition {
+	return d.Status.Conditions
+}
+
+func (d *VirtualMachineDeployment) SetConditions(conditions []metav1.Condition) {
+	d.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmdeploy;vmdeployment
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Total number of non-terminated virtual machines targeted by this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Updated",type="integer",JSONPath=".status.updatedReplicas",description="Total number of virtual machines targeted by this VirtualMachineDeployment that have the desired template"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="Total number of ready virtual machines targeted by this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VirtualMachineDeployment"
+
+// VirtualMachineDeployment is the schema for the virtualmachinedeployments
+// API. A VirtualMachineDeployment owns revisioned VirtualMachineReplicaSets
+// and rolls out changes to its template by replacing the VirtualMachines of
+// the previous revision.
+type VirtualMachineDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineDeploymentSpec   `json:"spec,omitempty"`
+	Status VirtualMachineDeploymentStatus `json:"status,omitempty"`
+}
+
+func (d *VirtualMachineDeployment) NamespacedName() string {
+	return d.Namespace + "/" + d.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineDeploymentList contains a list of VirtualMachineDeployment.
+type VirtualMachineDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineDeployment `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineDeployment{}, &VirtualMachineDeploymentList{})
+}
@@ -0,0 +1,224 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// VirtualMachineNetworkPolicyReadyCondition exposes the status of the
+	// security policies that enforce a VirtualMachineNetworkPolicy.
+	VirtualMachineNetworkPolicyReadyCondition = "Ready"
+
+	// VirtualMachineNetworkPolicyNotSupportedReason is the reason of the
+	// ready condition when the network provider does not support enforcing
+	// VirtualMachineNetworkPolicies.
+	VirtualMachineNetworkPolicyNotSupportedReason = "NotSupported"
+
+	// VirtualMachineNetworkPolicySecurityPolicyNotReadyReason is the reason
+	// of the ready condition when a security policy that enforces the
+	// VirtualMachineNetworkPolicy is not yet ready.
+	VirtualMachineNetworkPolicySecurityPolicyNotReadyReason = "SecurityPolicyNotReady"
+)
+
+// VirtualMachineNetworkPolicyType describes the direction of the traffic to
+// which a VirtualMachineNetworkPolicy applies.
+//
+// +kubebuilder:validation:Enum=Ingress;Egress
+type VirtualMachineNetworkPolicyType string
+
+const (
+	// VirtualMachineNetworkPolicyTypeIngress describes the traffic to the
+	// selected VMs.
+	VirtualMachineNetworkPolicyTypeIngress VirtualMachineNetworkPolicyType = "Ingress"
+
+	// VirtualMachineNetworkPolicyTypeEgress describes the traffic from the
+	// selected VMs.
+	VirtualMachineNetworkPolicyTypeEgress VirtualMachineNetworkPolicyType = "Egress"
+)
+
+// VirtualMachineNetworkPolicyIPBlock describes a CIDR to or from which
+// traffic is allowed.
+type VirtualMachineNetworkPolicyIPBlock struct {
+	// CIDR is an IP4 or IP6 CIDR, ex. 192.168.1.0/24 or 2001:db8::/64.
+	CIDR string `json:"cidr"`
+}
+
+// VirtualMachineNetworkPolicyPeer describes the endpoints to or from which
+// traffic is allowed.
+//
+// If both VMSelector and NamespaceSelector are specified, the peer selects
+// the VMs that match VMSelector in the namespaces that match
+// NamespaceSelector. If only VMSelector is specified, the peer selects the
+// VMs in the policy's namespace that match VMSelector. If IPBlock is
+// specified, then VMSelector and NamespaceSelector must not be specified.
+type VirtualMachineNetworkPolicyPeer struct {
+	// +optional
+
+	// VMSelector selects VMs by their labels.
+	VMSelector *metav1.LabelSelector `json:"vmSelector,omitempty"`
+
+	// +optional
+
+	// NamespaceSelector selects namespaces by their labels.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// +optional
+
+	// IPBlock selects a CIDR.
+	IPBlock *VirtualMachineNetworkPolicyIPBlock `json:"ipBlock,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyPort describes a port or a range of ports on
+// which traffic is allowed.
+type VirtualMachineNetworkPolicyPort struct {
+	// +optional
+	// +kubebuilder:default=TCP
+	// +kubebuilder:validation:Enum=TCP;UDP
+
+	// Protocol describes the Layer 4 transport protocol of the traffic.
+	// Supports "TCP" and "UDP".
+	//
+	// Defaults to "TCP".
+	Protocol string `json:"protocol,omitempty"`
+
+	// +optional
+
+	// Port is the number or the name of the port. If omitted, all ports are
+	// matched.
+	Port *intstr.IntOrString `json:"port,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// EndPort is the end of the range of ports that starts at Port.
+	//
+	// Please note this field may be specified only if Port is a number, and
+	// must be greater than or equal to Port.
+	EndPort *int32 `json:"endPort,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyIngressRule describes the traffic allowed to the
+// selected VMs.
+type VirtualMachineNetworkPolicyIngressRule struct {
+	// +optional
+
+	// From is the list of the sources of the allowed traffic. If empty, the
+	// traffic from all sources is allowed.
+	From []VirtualMachineNetworkPolicyPeer `json:"from,omitempty"`
+
+	// +optional
+
+	// Ports is the list of the ports on which traffic is allowed. If empty,
+	// the traffic on all ports is allowed.
+	Ports []VirtualMachineNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyEgressRule describes the traffic allowed from the
+// selected VMs.
+type VirtualMachineNetworkPolicyEgressRule struct {
+	// +optional
+
+	// To is the list of the destinations of the allowed traffic. If empty,
+	// the traffic to all destinations is allowed.
+	To []VirtualMachineNetworkPolicyPeer `json:"to,omitempty"`
+
+	// +optional
+
+	// Ports is the list of the ports on which traffic is allowed. If empty,
+	// the traffic on all ports is allowed.
+	Ports []VirtualMachineNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// VirtualMachineNetworkPolicySpec defines the desired state of
+// VirtualMachineNetworkPolicy.
+type VirtualMachineNetworkPolicySpec struct {
+	// VMSelector selects the VMs in the policy's namespace to which the policy
+	// applies. An empty selector selects all of the VMs in the namespace.
+	VMSelector metav1.LabelSelector `json:"vmSelector"`
+
+	// +optional
+
+	// PolicyTypes is the list of the directions of the traffic to which the
+	// policy applies. A selected VM is isolated for each direction in this
+	// list, i.e. only the traffic that is allowed by the rules of the
+	// policies that select the VM is permitted in that direction.
+	//
+	// If omitted, the policy applies to the Ingress direction, and to the
+	// Egress direction if Egress is not empty.
+	PolicyTypes []VirtualMachineNetworkPolicyType `json:"policyTypes,omitempty"`
+
+	// +optional
+
+	// Ingress is the list of the rules that allow traffic to the selected
+	// VMs.
+	Ingress []VirtualMachineNetworkPolicyIngressRule `json:"ingress,omitempty"`
+
+	// +optional
+
+	// Egress is the list of the rules that allow traffic from the selected
+	// VMs.
+	Egress []VirtualMachineNetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// VirtualMachineNetworkPolicyStatus defines the observed state of
+// VirtualMachineNetworkPolicy.
+type VirtualMachineNetworkPolicyStatus struct {
+	// +optional
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineNetworkPolicy.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (p *VirtualMachineNetworkPolicy) GetConditions() []metav1.Condition {
+	return p.Status.Conditions
+}
+
+func (p *VirtualMachineNetworkPolicy) SetConditions(conditions []metav1.Condition) {
+	p.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmnetpol
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineNetworkPolicy is the schema for the
+// virtualmachinenetworkpolicies API and describes the traffic that is allowed
+// to and from the VMs in a namespace, similar to a Kubernetes NetworkPolicy.
+// A VirtualMachineNetworkPolicy is enforced only when the VMs are connected to
+// NSX-T or VPC networks.
+type VirtualMachineNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineNetworkPolicySpec   `json:"spec,omitempty"`
+	Status VirtualMachineNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+func (p *VirtualMachineNetworkPolicy) NamespacedName() string {
+	return p.Namespace + "/" + p.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineNetworkPolicyList contains a list of
+// VirtualMachineNetworkPolicy.
+type VirtualMachineNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineNetworkPolicy `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineNetworkPolicy{}, &VirtualMachineNetworkPolicyList{})
+}
@@ -16,6 +16,15 @@ const (
 	// security policy, host selection, or deleted due to the host being down or
 	// finalizers are failing.
 	VirtualMachineReplicaSetReplicaFailure = "ReplicaFailure"
+
+	// VirtualMachineReplicaSetFailedCreateReason documents a
+	// VirtualMachineReplicaSet failing to create one of its VMs, or one of its
+	// VMs failing to be created on the underlying infrastructure.
+	VirtualMachineReplicaSetFailedCreateReason = "FailedCreate"
+
+	// VirtualMachineReplicaSetFailedDeleteReason documents a
+	// VirtualMachineReplicaSet failing to delete one of its VMs.
+	VirtualMachineReplicaSetFailedDeleteReason = "FailedDelete"
 )
 
 const (
@@ -47,6 +56,35 @@ const (
 	// replicas VirtualMachine objects that it owns.  The value of this label is the
 	// name of the VirtualMachineReplicaSet.
 	VirtualMachineReplicaSetNameLabel = "vmoperator.vmware.com/replicaset-name"
+
+	// VirtualMachineReplicaSetDeleteVMAnnotation may be applied to a replica
+	// VirtualMachine to indicate it should be deleted before the other replicas
+	// when the VirtualMachineReplicaSet is scaled down, regardless of the
+	// delete policy. The value of the annotation is ignored.
+	VirtualMachineReplicaSetDeleteVMAnnotation = "vmoperator.vmware.com/delete-vm"
+)
+
+const (
+	// RandomVirtualMachineReplicaSetDeletePolicy prioritizes both replicas
+	// that have the delete annotation and replicas that are already being
+	// deleted, and then deletes the remaining replicas in an arbitrary order.
+	RandomVirtualMachineReplicaSetDeletePolicy = "Random"
+
+	// NewestVirtualMachineReplicaSetDeletePolicy prioritizes both replicas
+	// that have the delete annotation and replicas that are already being
+	// deleted, and then deletes the most recently created replicas first.
+	NewestVirtualMachineReplicaSetDeletePolicy = "Newest"
+
+	// OldestVirtualMachineReplicaSetDeletePolicy prioritizes both replicas
+	// that have the delete annotation and replicas that are already being
+	// deleted, and then deletes the least recently created replicas first.
+	OldestVirtualMachineReplicaSetDeletePolicy = "Oldest"
+
+	// NotReadyFirstVirtualMachineReplicaSetDeletePolicy prioritizes both
+	// replicas that have the delete annotation and replicas that are already
+	// being deleted, and then deletes the replicas that are not ready before
+	// the ones that are.
+	NotReadyFirstVirtualMachineReplicaSetDeletePolicy = "NotReadyFirst"
 )
 
 // VirtualMachineTemplateSpec describes the data needed to create a VirtualMachine
@@ -75,12 +113,27 @@ type VirtualMachineReplicaSetSpec struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 
 	// +optional
-	// +kubebuilder:validation:Enum=Random
+	// +kubebuilder:validation:Enum=Random;Newest;Oldest;NotReadyFirst
 	//
 	// DeletePolicy defines the policy used to identify nodes to delete when downscaling.
-	// Only supported deletion policy is "Random".
+	// Supported deletion policies are "Random", "Newest", "Oldest", and
+	// "NotReadyFirst". Defaults to "Random".
+	//
+	// Regardless of the policy, replicas with the
+	// vmoperator.vmware.com/delete-vm annotation are deleted before the
+	// other replicas.
 	DeletePolicy string `json:"deletePolicy,omitempty"`
 
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	//
+	// MinReadySeconds is the minimum number of seconds for which a newly
+	// created virtual machine should be ready for it to be considered
+	// available.
+	// Defaults to 0 (virtual machine will be considered available as soon as
+	// it is ready).
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
 	// +optional
 	//
 	// Selector is a label to query over virtual machines that should match the
@@ -119,6 +172,23 @@ type VirtualMachineReplicaSetStatus struct {
 	// true.
 	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 
+	// +optional
+	//
+	// AvailableReplicas is the number of available replicas for this
+	// VirtualMachineReplicaSet. A virtual machine is considered available when
+	// it has been ready for at least MinReadySeconds, as measured from the
+	// last transition of its "Ready" condition, or of its
+	// "VirtualMachinePoweredOn" condition if it has no readiness probe.
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// +optional
+	//
+	// Selector is the label selector, in string form, that is used to query
+	// the replicas of this VirtualMachineReplicaSet. It is exposed via the
+	// scale subresource so tools such as the HorizontalPodAutoscaler are able
+	// to find the replicas.
+	Selector string `json:"selector,omitempty"`
+
 	// +optional
 	//
 	// ObservedGeneration reflects the generation of the most recently observed
@@ -144,9 +214,10 @@ func (rs *VirtualMachineReplicaSet) SetConditions(conditions []metav1.Condition)
 // +kubebuilder:resource:scope=Namespaced,shortName=vmrs;vmreplicaset
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
-// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 // +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Total number of non-terminated virtual machines targeted by this VirtualMachineReplicaSet"
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="Total number of ready virtual machines targeted by this VirtualMachineReplicaSet"
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableReplicas",description="Total number of available virtual machines targeted by this VirtualMachineReplicaSet"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VirtualMachineReplicaSet"
 
 // VirtualMachineReplicaSet is the schema for the virtualmachinereplicasets API.
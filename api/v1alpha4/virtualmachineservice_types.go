@@ -17,6 +17,10 @@ const (
 	// accessible inside the cluster, via the cluster IP.
 	VirtualMachineServiceTypeClusterIP VirtualMachineServiceType = "ClusterIP"
 
+	// VirtualMachineServiceTypeNodePort means a service will be exposed on one
+	// port of every node, in addition to 'ClusterIP' type.
+	VirtualMachineServiceTypeNodePort VirtualMachineServiceType = "NodePort"
+
 	// VirtualMachineServiceTypeLoadBalancer means a service will be exposed via
 	// an external load balancer (if the cloud provider supports it), in
 	// addition to 'NodePort' type.
@@ -29,6 +33,89 @@ const (
 	VirtualMachineServiceTypeExternalName VirtualMachineServiceType = "ExternalName"
 )
 
+// VirtualMachineServiceAffinity is the session affinity of a service.
+type VirtualMachineServiceAffinity string
+
+const (
+	// VirtualMachineServiceAffinityClientIP means connections from the same
+	// client IP are passed to the same VirtualMachine.
+	VirtualMachineServiceAffinityClientIP VirtualMachineServiceAffinity = "ClientIP"
+
+	// VirtualMachineServiceAffinityNone means there is no session affinity.
+	VirtualMachineServiceAffinityNone VirtualMachineServiceAffinity = "None"
+)
+
+// VirtualMachineServiceSessionAffinityConfig represents the configurations of
+// session affinity.
+type VirtualMachineServiceSessionAffinityConfig struct {
+	// +optional
+
+	// ClientIP contains the configurations of ClientIP based session affinity.
+	ClientIP *VirtualMachineServiceClientIPConfig `json:"clientIP,omitempty"`
+}
+
+// VirtualMachineServiceClientIPConfig represents the configurations of ClientIP
+// based session affinity.
+type VirtualMachineServiceClientIPConfig struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+
+	// TimeoutSeconds specifies the seconds of ClientIP type session sticky
+	// time. The value must be > 0 && <= 86400 (for 1 day) if
+	// SessionAffinity == "ClientIP". Defaults to 10800 (3 hours).
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// VirtualMachineServiceExternalTrafficPolicy describes how nodes distribute
+// service traffic they receive on one of the service's externally-facing
+// addresses.
+type VirtualMachineServiceExternalTrafficPolicy string
+
+const (
+	// VirtualMachineServiceExternalTrafficPolicyCluster routes traffic to all
+	// endpoints.
+	VirtualMachineServiceExternalTrafficPolicyCluster VirtualMachineServiceExternalTrafficPolicy = "Cluster"
+
+	// VirtualMachineServiceExternalTrafficPolicyLocal preserves the source IP
+	// of the traffic by routing only to endpoints on the same node as the
+	// traffic was received on, dropping the traffic if there are no local
+	// endpoints.
+	VirtualMachineServiceExternalTrafficPolicyLocal VirtualMachineServiceExternalTrafficPolicy = "Local"
+)
+
+// VirtualMachineServiceIPFamily represents the IP Family (IPv4 or IPv6) of a
+// service.
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type VirtualMachineServiceIPFamily string
+
+const (
+	// VirtualMachineServiceIPv4Protocol indicates that this IP is IPv4.
+	VirtualMachineServiceIPv4Protocol VirtualMachineServiceIPFamily = "IPv4"
+
+	// VirtualMachineServiceIPv6Protocol indicates that this IP is IPv6.
+	VirtualMachineServiceIPv6Protocol VirtualMachineServiceIPFamily = "IPv6"
+)
+
+// VirtualMachineServiceIPFamilyPolicy represents the dual-stack-ness requested
+// or required by a service.
+type VirtualMachineServiceIPFamilyPolicy string
+
+const (
+	// VirtualMachineServiceIPFamilyPolicySingleStack indicates that the
+	// service is required to have a single IPFamily.
+	VirtualMachineServiceIPFamilyPolicySingleStack VirtualMachineServiceIPFamilyPolicy = "SingleStack"
+
+	// VirtualMachineServiceIPFamilyPolicyPreferDualStack indicates that the
+	// service prefers dual-stack when the cluster is configured for
+	// dual-stack, and single-stack otherwise.
+	VirtualMachineServiceIPFamilyPolicyPreferDualStack VirtualMachineServiceIPFamilyPolicy = "PreferDualStack"
+
+	// VirtualMachineServiceIPFamilyPolicyRequireDualStack indicates that the
+	// service requires dual-stack.
+	VirtualMachineServiceIPFamilyPolicyRequireDualStack VirtualMachineServiceIPFamilyPolicy = "RequireDualStack"
+)
+
 // VirtualMachineServicePort describes the specification of a service port to
 // be exposed by a VirtualMachineService. This VirtualMachineServicePort
 // specification includes attributes that define the external and internal
@@ -45,9 +132,35 @@ type VirtualMachineServicePort struct {
 	// Port describes the external port that will be exposed by the service.
 	Port int32 `json:"port"`
 
+	// +optional
+
 	// TargetPort describes the internal port open on a VirtualMachine that
 	// should be mapped to the external Port.
-	TargetPort int32 `json:"targetPort"`
+	//
+	// When TargetPortName is also specified, this port is used for the
+	// VirtualMachines that do not have a port with that name.
+	TargetPort int32 `json:"targetPort,omitempty"`
+
+	// +optional
+
+	// TargetPortName describes the name of a port in the spec.ports of the
+	// VirtualMachines selected by this service. The port is resolved for each
+	// VirtualMachine, which allows the VirtualMachines to listen on different
+	// port numbers.
+	//
+	// A VirtualMachine that does not have a port with this name and protocol
+	// is mapped to TargetPort, or, if TargetPort is not specified, is not an
+	// endpoint for this port.
+	//
+	// At least one of TargetPort and TargetPortName must be specified.
+	TargetPortName string `json:"targetPortName,omitempty"`
+
+	// +optional
+
+	// NodePort describes the port on each node on which this service is
+	// exposed when Type is NodePort or LoadBalancer. If not specified, a port
+	// is allocated automatically.
+	NodePort int32 `json:"nodePort,omitempty"`
 }
 
 // LoadBalancerStatus represents the status of a load balancer.
@@ -81,8 +194,8 @@ type LoadBalancerIngress struct {
 // VirtualMachineServiceSpec defines the desired state of VirtualMachineService.
 type VirtualMachineServiceSpec struct {
 	// Type specifies a desired VirtualMachineServiceType for this
-	// VirtualMachineService. Supported types are ClusterIP, LoadBalancer,
-	// ExternalName.
+	// VirtualMachineService. Supported types are ClusterIP, NodePort,
+	// LoadBalancer, ExternalName.
 	Type VirtualMachineServiceType `json:"type"`
 
 	// Ports specifies a list of VirtualMachineServicePort to expose with this
@@ -120,24 +233,89 @@ type VirtualMachineServiceSpec struct {
 
 	// +optional
 
+	// LoadBalancerClass is the class of the load balancer implementation this
+	// service belongs to. If specified, the value is copied to the Service so
+	// the load balancer implementation with this class, such as MetalLB or
+	// kube-vip, provisions the load balancer. Only applies to
+	// VirtualMachineService Type: LoadBalancer. This field can not be changed
+	// through updates.
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// +optional
+
 	// ClusterIP is the IP address of the service and is usually assigned
 	// randomly by the master. If an address is specified manually and is not in
 	// use by others, it will be allocated to the service; otherwise, creation
 	// of the service will fail. This field can not be changed through updates.
 	// Valid values are "None", empty string (""), or a valid IP address. "None"
 	// can be specified for headless services when proxying is not required.
-	// Only applies to types ClusterIP and LoadBalancer.
+	// The VirtualMachines selected by a headless service have DNS records with
+	// the host name from their spec.network.hostName, or, if not specified,
+	// their name.
+	// Only applies to types ClusterIP, NodePort and LoadBalancer.
 	// Ignored if type is ExternalName.
 	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies
 	ClusterIP string `json:"clusterIp,omitempty"`
 
 	// +optional
 
+	// PublishNotReadyAddresses indicates that the endpoints of the
+	// VirtualMachines selected by this service are published regardless of
+	// their readiness. This is primarily used by headless services so the
+	// VirtualMachines can discover each other by DNS name before they are
+	// ready, for example to bootstrap a clustered database.
+	PublishNotReadyAddresses bool `json:"publishNotReadyAddresses,omitempty"`
+
+	// +optional
+
 	// ExternalName is the external reference that kubedns or equivalent will
 	// return as a CNAME record for this service. No proxying will be involved.
 	// Must be a valid RFC-1123 hostname (https://tools.ietf.org/html/rfc1123)
 	// and requires Type to be ExternalName.
 	ExternalName string `json:"externalName,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=ClientIP;None
+
+	// SessionAffinity may be used to maintain session affinity.
+	// Supports "ClientIP" and "None". Defaults to "None".
+	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies
+	SessionAffinity VirtualMachineServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// +optional
+
+	// SessionAffinityConfig contains the configurations of session affinity.
+	// May only be set when SessionAffinity is ClientIP.
+	SessionAffinityConfig *VirtualMachineServiceSessionAffinityConfig `json:"sessionAffinityConfig,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+
+	// ExternalTrafficPolicy describes how nodes distribute service traffic
+	// they receive on one of the service's externally-facing addresses.
+	// Supports "Cluster" and "Local". Only applies to types NodePort and
+	// LoadBalancer. Defaults to "Cluster".
+	ExternalTrafficPolicy VirtualMachineServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=2
+
+	// IPFamilies is a list of IP families (e.g. IPv4, IPv6) assigned to this
+	// service. When not specified, the families are assigned based on the
+	// IPFamilyPolicy and the cluster configuration. The first family may not
+	// be changed after the service is created. Does not apply to type
+	// ExternalName.
+	IPFamilies []VirtualMachineServiceIPFamily `json:"ipFamilies,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+
+	// IPFamilyPolicy represents the dual-stack-ness requested or required by
+	// this service. Supports "SingleStack", "PreferDualStack" and
+	// "RequireDualStack". When not specified, defaults to "SingleStack" unless
+	// two IPFamilies are specified. Does not apply to type ExternalName.
+	IPFamilyPolicy *VirtualMachineServiceIPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
 }
 
 // VirtualMachineServiceStatus defines the observed state of
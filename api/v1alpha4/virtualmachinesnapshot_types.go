@@ -0,0 +1,161 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineSnapshot.
+const (
+	// VirtualMachineSnapshotVMNotFoundReason documents that the VM of the
+	// VirtualMachineSnapshot does not exist.
+	VirtualMachineSnapshotVMNotFoundReason = "VirtualMachineNotFound"
+
+	// VirtualMachineSnapshotVMNotCreatedReason documents that the VM of the
+	// VirtualMachineSnapshot has not yet been created on the underlying
+	// infrastructure.
+	VirtualMachineSnapshotVMNotCreatedReason = "VirtualMachineNotCreated"
+
+	// VirtualMachineSnapshotCreateFailedReason documents that the snapshot
+	// could not be created.
+	VirtualMachineSnapshotCreateFailedReason = "CreateFailed"
+)
+
+// QuiesceSpec describes how the guest file system of a VM is quiesced before
+// a snapshot is taken.
+type QuiesceSpec struct {
+	// +optional
+
+	// Timeout describes the maximum amount of time the guest is given to
+	// quiesce its file system. The value is rounded to the nearest minute, and
+	// must be between 5 and 240 minutes.
+	//
+	// When omitted, the default timeout of the underlying infrastructure is
+	// used.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// VirtualMachineSnapshotSpec defines the desired state of a
+// VirtualMachineSnapshot.
+//
+// Please note the spec is immutable since it describes how the snapshot is
+// taken.
+type VirtualMachineSnapshotSpec struct {
+	// VMName is the name of the VM in the same namespace as this snapshot of
+	// which the snapshot is taken.
+	VMName string `json:"vmName"`
+
+	// +optional
+
+	// Description is a description of the snapshot.
+	Description string `json:"description,omitempty"`
+
+	// +optional
+
+	// Memory describes whether the memory of the VM is included in the
+	// snapshot. This is only supported when the VM is powered on, and allows
+	// the VM to be reverted to its powered on state.
+	//
+	// Defaults to false.
+	Memory bool `json:"memory,omitempty"`
+
+	// +optional
+
+	// Quiesce describes whether the guest file system of the VM is quiesced
+	// before the snapshot is taken. This requires the VM to be powered on and
+	// running VMware Tools. Quiescing is ignored if Memory is true.
+	Quiesce *QuiesceSpec `json:"quiesce,omitempty"`
+}
+
+// VirtualMachineSnapshotStatus defines the observed state of a
+// VirtualMachineSnapshot.
+type VirtualMachineSnapshotStatus struct {
+	// +optional
+
+	// UniqueID describes the unique identifier of the snapshot on the
+	// underlying infrastructure, such as vSphere.
+	UniqueID string `json:"uniqueID,omitempty"`
+
+	// +optional
+
+	// PowerState describes the power state of the VM when the snapshot was
+	// taken.
+	PowerState VirtualMachinePowerState `json:"powerState,omitempty"`
+
+	// +optional
+
+	// Quiesced describes whether the guest file system of the VM was quiesced
+	// when the snapshot was taken.
+	Quiesced bool `json:"quiesced,omitempty"`
+
+	// +optional
+
+	// Parent describes the name of the snapshot that is the parent of this
+	// snapshot in the VM's snapshot tree.
+	Parent string `json:"parent,omitempty"`
+
+	// +optional
+
+	// Children describes the names of the snapshots that are the children of
+	// this snapshot in the VM's snapshot tree.
+	Children []string `json:"children,omitempty"`
+
+	// +optional
+
+	// Size describes the amount of storage consumed by the snapshot.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// +optional
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineSnapshot.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (s *VirtualMachineSnapshot) GetConditions() []metav1.Condition {
+	return s.Status.Conditions
+}
+
+func (s *VirtualMachineSnapshot) SetConditions(conditions []metav1.Condition) {
+	s.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmsnapshot
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VM",type="string",JSONPath=".spec.vmName"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Size",type="string",priority=1,JSONPath=".status.size"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineSnapshot is the schema for the virtualmachinesnapshots API and
+// represents a point-in-time snapshot of a VirtualMachine.
+type VirtualMachineSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineSnapshotSpec   `json:"spec,omitempty"`
+	Status VirtualMachineSnapshotStatus `json:"status,omitempty"`
+}
+
+func (s *VirtualMachineSnapshot) NamespacedName() string {
+	return s.Namespace + "/" + s.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineSnapshotList contains a list of VirtualMachineSnapshot.
+type VirtualMachineSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineSnapshot `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineSnapshot{}, &VirtualMachineSnapshotList{})
+}
@@ -26,6 +26,7 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	spqutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube/spq"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 // AddToManager adds this package's controller to the provided manager.
@@ -246,6 +247,16 @@ func reportReserved(
 			return nil
 		}
 		imgStatus = img.Status
+	case "VirtualMachine":
+		imgKey.Namespace = vm.Namespace
+		var srcVM vmopv1.VirtualMachine
+		if err := k8sClient.Get(ctx, imgKey, &srcVM); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get %s %s: %w", imgKind, imgKey, err)
+			}
+			return nil
+		}
+		imgStatus.Disks = vmopv1util.ClassicDiskInfo(srcVM)
 	}
 
 	for i := range imgStatus.Disks {
@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -75,7 +77,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
 	)
 
-	proberManager, err := prober.AddToManager(mgr, ctx.VMProvider)
+	proberManager, err := prober.AddToManager(ctx, mgr, ctx.VMProvider)
 	if err != nil {
 		return err
 	}
@@ -269,11 +271,17 @@ type Reconciler struct {
 	VMProvider providers.VirtualMachineProviderInterface
 	Prober     prober.Manager
 	vmMetrics  *metrics.VMMetrics
+
+	// vmUtilizationUpdated records when the utilization metrics of a VM were
+	// last refreshed from vSphere.
+	vmUtilizationUpdated sync.Map
 }
 
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=ippools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=vmware.com,resources=virtualnetworkinterfaces;virtualnetworkinterfaces/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=netoperator.vmware.com,resources=networkinterfaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
@@ -413,7 +421,7 @@ func requeueDelay(
 
 	// Do not requeue for the IP address if async signal is enabled.
 	if pkgcfg.FromContext(ctx).AsyncSignalEnabled {
-		return 0
+		return vmMetricsRequeueDelay(ctx)
 	}
 
 	if ctx.VM.Status.PowerState == vmopv1.VirtualMachinePowerStateOn {
@@ -426,7 +434,90 @@ func requeueDelay(
 		}
 	}
 
-	return 0
+	return vmMetricsRequeueDelay(ctx)
+}
+
+// vmMetricsRequeueDelay returns the requeue delay used to refresh the
+// utilization metrics of a powered on VM that is controlled by a
+// VirtualMachineReplicaSet, otherwise zero.
+func vmMetricsRequeueDelay(ctx *pkgctx.VirtualMachineContext) time.Duration {
+	if ctx.VM.Status.PowerState != vmopv1.VirtualMachinePowerStateOn ||
+		!isControlledByReplicaSet(ctx.VM) {
+
+		return 0
+	}
+	return pkgcfg.FromContext(ctx).VMMetricsRequeueDelay
+}
+
+// isControlledByReplicaSet returns true if the VM is controlled by a
+// VirtualMachineReplicaSet, including the replica sets of a
+// VirtualMachineDeployment.
+func isControlledByReplicaSet(vm *vmopv1.VirtualMachine) bool {
+	ref := metav1.GetControllerOfNoCopy(vm)
+	return ref != nil && ref.Kind == "VirtualMachineReplicaSet"
+}
+
+// vmUtilizationPropertyPaths are the properties of the vSphere VM used to
+// determine the VM's utilization.
+var vmUtilizationPropertyPaths = []string{
+	"summary.quickStats",
+	"summary.runtime.maxCpuUsage",
+	"summary.config.memorySizeMB",
+}
+
+// registerVMUtilizationMetrics publishes the CPU and memory utilization of the
+// VM from the quick statistics of the vSphere VM. The utilization is only
+// published for VMs controlled by a VirtualMachineReplicaSet, since it is used
+// to autoscale the replica set, and is refreshed from vSphere at most once per
+// VMMetricsRequeueDelay to limit the load on vCenter.
+func (r *Reconciler) registerVMUtilizationMetrics(ctx *pkgctx.VirtualMachineContext) {
+	if ctx.VM.Status.UniqueID == "" ||
+		ctx.VM.Status.PowerState != vmopv1.VirtualMachinePowerStateOn ||
+		!isControlledByReplicaSet(ctx.VM) {
+
+		r.deleteVMUtilizationMetrics(ctx)
+		return
+	}
+
+	key := ctx.VM.NamespacedName()
+	now := time.Now()
+	if v, ok := r.vmUtilizationUpdated.Load(key); ok &&
+		now.Sub(v.(time.Time)) < pkgcfg.FromContext(ctx).VMMetricsRequeueDelay {
+
+		return
+	}
+	r.vmUtilizationUpdated.Store(key, now)
+
+	props, err := r.VMProvider.GetVirtualMachineProperties(
+		ctx, ctx.VM, vmUtilizationPropertyPaths)
+	if err != nil {
+		ctx.Logger.V(4).Info("Failed to get properties for VM utilization metrics",
+			"err", err)
+		return
+	}
+
+	quickStats, ok := props["summary.quickStats"].(vimtypes.VirtualMachineQuickStats)
+	if !ok {
+		return
+	}
+
+	utilization := metrics.VMUtilization{
+		CPUUsageMHz:   quickStats.OverallCpuUsage,
+		MemoryUsageMB: quickStats.GuestMemoryUsage,
+	}
+	if v, ok := props["summary.runtime.maxCpuUsage"].(int32); ok {
+		utilization.MaxCPUUsageMHz = v
+	}
+	if v, ok := props["summary.config.memorySizeMB"].(int32); ok {
+		utilization.MemorySizeMB = v
+	}
+
+	r.vmMetrics.RegisterVMUtilizationMetrics(ctx, utilization)
+}
+
+func (r *Reconciler) deleteVMUtilizationMetrics(ctx *pkgctx.VirtualMachineContext) {
+	r.vmUtilizationUpdated.Delete(ctx.VM.NamespacedName())
+	r.vmMetrics.DeleteVMUtilizationMetrics(ctx)
 }
 
 func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineContext) (reterr error) {
@@ -457,6 +548,7 @@ func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineContext) (reterr
 
 	// BMV: Shouldn't these be in the ContainsFinalizer block?
 	r.vmMetrics.DeleteMetrics(ctx)
+	r.vmUtilizationUpdated.Delete(ctx.VM.NamespacedName())
 	r.Prober.RemoveFromProberManager(ctx.VM)
 
 	ctx.Logger.Info("Finished Reconciling VirtualMachine Deletion")
@@ -506,6 +598,7 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 
 	defer func() {
 		r.vmMetrics.RegisterVMCreateOrUpdateMetrics(ctx)
+		r.registerVMUtilizationMetrics(ctx)
 	}()
 
 	// Upgrade schema fields where needed
@@ -537,6 +630,16 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 	}
 
 	switch {
+	case errors.Is(err, providers.ErrTooManyCreates):
+
+		// Surface why the VM has not yet been created so the owner of the VM,
+		// ex. a VirtualMachineReplicaSet, is able to report it.
+		conditions.MarkFalse(
+			ctx.VM,
+			vmopv1.VirtualMachineConditionCreated,
+			vmopv1.VirtualMachineTooManyCreatesReason,
+			"The number of concurrent create operations has reached the allowed limit")
+
 	case ctxop.IsCreate(ctx) && !ignoredCreateErr(err):
 
 		if chanErr == nil {
@@ -579,19 +682,10 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 		r.Recorder.EmitEvent(ctx.VM, "ReconcileNormal", err, true)
 	}
 
-	if !pkgcfg.FromContext(ctx).AsyncSignalEnabled {
-
-		// Add the VM to the probe manager. This is idempotent.
-		r.Prober.AddToProberManager(ctx.VM)
-
-	} else if p := ctx.VM.Spec.ReadinessProbe; p != nil && p.TCPSocket != nil {
-		// TCP probes still use the probe manager.
-		r.Prober.AddToProberManager(ctx.VM)
-	} else {
-		// Remove the probe in case it *was* a TCP probe but switched to one
-		// of the other types.
-		r.Prober.RemoveFromProberManager(ctx.VM)
-	}
+	// Add the VM to the probe manager. This is idempotent. Please note, when
+	// async signal is enabled, the probe manager does not run the readiness
+	// probes that are evaluated when the VM's status is updated.
+	r.Prober.AddToProberManager(ctx.VM)
 
 	return err
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -18,13 +19,16 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	ctxop "github.com/vmware-tanzu/vm-operator/pkg/context/operation"
 	proberfake "github.com/vmware-tanzu/vm-operator/pkg/prober/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -270,6 +274,95 @@ func unitTestsReconcile() {
 			})
 		})
 
+		It("Should mark the VM as not created if there are too many concurrent creates", func() {
+			providerfake.SetCreateOrUpdateFunction(
+				vmCtx,
+				fakeVMProvider,
+				func(ctx context.Context, vm *vmopv1.VirtualMachine) error {
+					ctxop.MarkCreate(ctx)
+					return providers.ErrTooManyCreates
+				},
+			)
+
+			Expect(reconciler.ReconcileNormal(vmCtx)).To(MatchError(providers.ErrTooManyCreates))
+			c := conditions.Get(vmCtx.VM, vmopv1.VirtualMachineConditionCreated)
+			Expect(c).ToNot(BeNil())
+			Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			Expect(c.Reason).To(Equal(vmopv1.VirtualMachineTooManyCreatesReason))
+			expectEvents(ctx)
+		})
+
+		Context("Utilization metrics", func() {
+			var (
+				propertyPaths []string
+				getCount      int
+			)
+
+			BeforeEach(func() {
+				propertyPaths = nil
+				getCount = 0
+				vm.Status.UniqueID = "vm-1"
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+				vm.OwnerReferences = []metav1.OwnerReference{
+					{
+						APIVersion: vmopv1.GroupVersion.String(),
+						Kind:       "VirtualMachineReplicaSet",
+						Name:       "my-rs",
+						UID:        "my-rs-uid",
+						Controller: ptr.To(true),
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.GetVirtualMachinePropertiesFn = func(
+					ctx context.Context,
+					vm *vmopv1.VirtualMachine,
+					paths []string) (map[string]any, error) {
+
+					propertyPaths = paths
+					getCount++
+					return nil, nil
+				}
+			})
+
+			It("Should get the quick stats of a powered on VM", func() {
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+				Expect(propertyPaths).To(ContainElement("summary.quickStats"))
+			})
+
+			It("Should not get the quick stats again until the requeue delay has passed", func() {
+				pkgcfg.SetContext(vmCtx, func(config *pkgcfg.Config) {
+					config.VMMetricsRequeueDelay = time.Minute
+				})
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+				Expect(getCount).To(Equal(1))
+			})
+
+			When("the VM is not controlled by a replica set", func() {
+				BeforeEach(func() {
+					vm.OwnerReferences = nil
+				})
+
+				It("Should not get the quick stats", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(propertyPaths).To(BeNil())
+				})
+			})
+
+			When("the VM is powered off", func() {
+				BeforeEach(func() {
+					vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				})
+
+				It("Should not get the quick stats", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(propertyPaths).To(BeNil())
+				})
+			})
+		})
+
 		It("Should emit UpdateSuccess event if ReconcileNormal causes a successful VM update", func() {
 			providerfake.SetCreateOrUpdateFunction(
 				vmCtx,
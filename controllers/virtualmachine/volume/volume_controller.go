@@ -110,6 +110,20 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		return err
 	}
 
+	if pkgcfg.FromContext(ctx).Features.VMVolumeExpansion {
+		// Watch for changes to PersistentVolumeClaim, and enqueue the VMs
+		// that refer to the PersistentVolumeClaim so the expansion of their
+		// volumes may be reconciled.
+		if err := c.Watch(source.Kind(
+			mgr.GetCache(),
+			&corev1.PersistentVolumeClaim{},
+			handler.TypedEnqueueRequestsFromMapFunc(pvcToVMMapperFn(ctx, r.Client)),
+		)); err != nil {
+
+			return err
+		}
+	}
+
 	if pkgcfg.FromContext(ctx).Features.InstanceStorage {
 		// Instance storage isn't enabled in all envs and is not that commonly used. Avoid the
 		// memory and CPU cost of watching PVCs until we encounter a VM with instance storage.
@@ -174,6 +188,33 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	return nil
 }
 
+// pvcToVMMapperFn returns a mapper function that may be used to enqueue
+// reconcile requests for the VMs that refer to a PersistentVolumeClaim.
+func pvcToVMMapperFn(
+	ctx context.Context,
+	c client.Client) handler.TypedMapFunc[*corev1.PersistentVolumeClaim, reconcile.Request] {
+
+	return func(_ context.Context, pvc *corev1.PersistentVolumeClaim) []reconcile.Request {
+		vmList := &vmopv1.VirtualMachineList{}
+		if err := c.List(
+			ctx,
+			vmList,
+			client.InNamespace(pvc.Namespace),
+			client.MatchingFields{vmopv1util.PVCClaimNameIndex: pvc.Name}); err != nil {
+
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(vmList.Items))
+		for i := range vmList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&vmList.Items[i]),
+			})
+		}
+		return requests
+	}
+}
+
 func NewReconciler(
 	ctx context.Context,
 	client client.Client,
@@ -207,6 +248,7 @@ type Reconciler struct {
 
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cns.vmware.com,resources=cnsnodevmattachments,verbs=create;delete;get;list;watch;patch;update
 // +kubebuilder:rbac:groups=cns.vmware.com,resources=cnsnodevmattachments/status,verbs=get;list
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=create;delete;get;list;watch;patch;update
@@ -301,6 +343,12 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VolumeContext) error {
 		}
 	}
 
+	if pkgcfg.FromContext(ctx).Features.VMClone && vmopv1util.IsClonedVM(*ctx.VM) {
+		if err := r.reconcileClonePVCs(ctx); err != nil {
+			return err
+		}
+	}
+
 	if ctx.VM.Status.BiosUUID == "" {
 		// CSI requires the BiosUUID to match up the attachment request with the VM. Defer here
 		// until it is set by the VirtualMachine controller.
@@ -543,6 +591,114 @@ func (r *Reconciler) createInstanceStoragePVC(
 	return nil
 }
 
+// reconcileClonePVCs creates the missing claims of a VM that is cloned from
+// another VM. Each missing claim is created by cloning the claim of the source
+// VM's volume that has the same name.
+func (r *Reconciler) reconcileClonePVCs(ctx *pkgctx.VolumeContext) error {
+	srcVM := &vmopv1.VirtualMachine{}
+	srcVMKey := client.ObjectKey{Namespace: ctx.VM.Namespace, Name: ctx.VM.Spec.Image.Name}
+	if err := r.Client.Get(ctx, srcVMKey, srcVM); err != nil {
+		// The source VM may have been deleted after the VM was cloned.
+		return client.IgnoreNotFound(err)
+	}
+
+	srcClaimNames := map[string]string{}
+	for _, vol := range srcVM.Spec.Volumes {
+		if pvc := vol.PersistentVolumeClaim; pvc != nil && pvc.InstanceVolumeClaim == nil {
+			srcClaimNames[vol.Name] = pvc.ClaimName
+		}
+	}
+
+	var createErrs []error
+
+	for _, vol := range ctx.VM.Spec.Volumes {
+		pvc := vol.PersistentVolumeClaim
+		if pvc == nil || pvc.InstanceVolumeClaim != nil {
+			continue
+		}
+
+		srcClaimName, ok := srcClaimNames[vol.Name]
+		if !ok || srcClaimName == pvc.ClaimName {
+			continue
+		}
+
+		claimKey := client.ObjectKey{Namespace: ctx.VM.Namespace, Name: pvc.ClaimName}
+		if err := r.Client.Get(ctx, claimKey, &corev1.PersistentVolumeClaim{}); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			createErrs = append(createErrs, err)
+			continue
+		}
+
+		createErrs = append(createErrs, r.createClonePVC(ctx, pvc.ClaimName, srcClaimName))
+	}
+
+	return apierrorsutil.NewAggregate(createErrs)
+}
+
+func (r *Reconciler) createClonePVC(
+	ctx *pkgctx.VolumeContext,
+	claimName, srcClaimName string) error {
+
+	srcPVC := &corev1.PersistentVolumeClaim{}
+	srcPVCKey := client.ObjectKey{Namespace: ctx.VM.Namespace, Name: srcClaimName}
+	if err := r.Client.Get(ctx, srcPVCKey, srcPVC); err != nil {
+		return fmt.Errorf("failed to get source PersistentVolumeClaim %s: %w", srcClaimName, err)
+	}
+
+	// A cloned volume must be at least as large as its source, which may have
+	// been expanded beyond its original request.
+	resources := *srcPVC.Spec.Resources.DeepCopy()
+	if capacity, ok := srcPVC.Status.Capacity[corev1.ResourceStorage]; ok {
+		if request := resources.Requests[corev1.ResourceStorage]; capacity.Cmp(request) > 0 {
+			if resources.Requests == nil {
+				resources.Requests = corev1.ResourceList{}
+			}
+			resources.Requests[corev1.ResourceStorage] = capacity
+		}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: ctx.VM.Namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: srcPVC.Spec.StorageClassName,
+			AccessModes:      srcPVC.Spec.AccessModes,
+			VolumeMode:       srcPVC.Spec.VolumeMode,
+			Resources:        resources,
+			DataSource: &corev1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: srcPVC.Name,
+			},
+		},
+	}
+
+	if v, ok := srcPVC.Annotations[constants.EncryptionClassNameAnnotation]; ok {
+		// Assign the cloned PVC the same EncryptionClass as its source.
+		pvc.Annotations = map[string]string{constants.EncryptionClassNameAnnotation: v}
+	}
+
+	// The VM owns the cloned PVC so it is garbage collected when the VM is
+	// deleted.
+	if err := controllerutil.SetOwnerReference(ctx.VM, pvc, r.Client.Scheme()); err != nil {
+		return fmt.Errorf("cannot set owner reference on PersistentVolumeClaim: %w", err)
+	}
+
+	ctx.Logger.Info("Creating cloned PersistentVolumeClaim",
+		"claimName", claimName, "sourceClaimName", srcClaimName)
+
+	if err := r.Create(ctx, pvc); err != nil {
+		if vmopv1util.IsInsufficientQuota(err) {
+			r.recorder.EmitEvent(ctx.VM, "Create", err, true)
+		}
+		return client.IgnoreAlreadyExists(err)
+	}
+
+	return nil
+}
+
 func (r *Reconciler) getInstanceStoragePVCs(
 	ctx *pkgctx.VolumeContext,
 	pvcReader client.Reader,
@@ -635,6 +791,7 @@ func (r *Reconciler) processAttachments(
 
 	var volumeStatuses []vmopv1.VirtualMachineVolumeStatus
 	var createErrs []error
+	var storageIOErrs []error
 	var hasPendingAttachment bool
 
 	// When creating a VM, try to attach the volumes in the VM Spec.Volumes order since that is a reasonable
@@ -644,6 +801,11 @@ func (r *Reconciler) processAttachments(
 	// the first place.
 	onlyAllowOnePendingAttachment := ctx.VM.Status.PowerState == "" || ctx.VM.Status.PowerState == vmopv1.VirtualMachinePowerStateOff
 
+	// A disk that is being detached may still occupy the controller and unit
+	// number requested by another volume, so the disk of a volume that
+	// requests a controller is not attached until all detaches are complete.
+	hasDetachingAttachment := len(orphanedAttachments) > 0
+
 	for _, volume := range ctx.VM.Spec.Volumes {
 		if volume.PersistentVolumeClaim == nil {
 			// Don't process VsphereVolumes here. Note that we don't have Volume status
@@ -663,8 +825,15 @@ func (r *Reconciler) processAttachments(
 				volumeStatus := attachmentToVolumeStatus(volume.Name, attachment)
 				volumeStatus.Used = existingManagedVols[volume.Name].Used
 				volumeStatus.Crypto = existingManagedVols[volume.Name].Crypto
-				if err := updateVolumeStatusWithLimit(ctx, r.Client, *volume.PersistentVolumeClaim, &volumeStatus); err != nil {
+				volumeStatus.StorageIO = existingManagedVols[volume.Name].StorageIO
+				pvc, err := updateVolumeStatusWithLimit(ctx, r.Client, *volume.PersistentVolumeClaim, &volumeStatus)
+				if err != nil {
 					ctx.Logger.Error(err, "failed to get volume status limit")
+				} else if pvc != nil && pkgcfg.FromContext(ctx).Features.VMVolumeExpansion {
+					reconcileVolumeExpansion(pvc, &volumeStatus)
+				}
+				if err := r.reconcileVolumeStorageIO(ctx, volume, &volumeStatus); err != nil {
+					storageIOErrs = append(storageIOErrs, err)
 				}
 				volumeStatuses = append(volumeStatuses, volumeStatus)
 				hasPendingAttachment = hasPendingAttachment || !attachment.Status.Attached
@@ -693,6 +862,12 @@ func (r *Reconciler) processAttachments(
 			continue
 		}
 
+		if hasDetachingAttachment && volume.ControllerType != "" {
+			// Do not create the CnsNodeVmAttachment until the detaching volumes are
+			// detached. The deletion of the CnsNodeVmAttachments requeues the VM.
+			continue
+		}
+
 		// If VM hardware version doesn't meet minimal requirement, don't create CNS attachment.
 		// We only fetch the hardware version when this is the first time to create the CNS attachment.
 		//
@@ -721,6 +896,11 @@ func (r *Reconciler) processAttachments(
 		// to keep the volumes attached in order.
 		hasPendingAttachment = true
 
+		if err := r.checkSharedPVC(ctx, volume); err != nil {
+			createErrs = append(createErrs, err)
+			continue
+		}
+
 		if err := r.handlePVCWithWFFC(ctx, volume); err != nil {
 			createErrs = append(createErrs, err)
 			continue
@@ -757,7 +937,7 @@ func (r *Reconciler) processAttachments(
 	// more sense.
 	vmopv1.SortVirtualMachineVolumeStatuses(ctx.VM.Status.Volumes)
 
-	return apierrorsutil.NewAggregate(createErrs)
+	return apierrorsutil.NewAggregate(slices.Concat(createErrs, storageIOErrs))
 }
 
 func (r *Reconciler) createCNSAttachment(
@@ -791,6 +971,40 @@ func (r *Reconciler) createCNSAttachment(
 	return nil
 }
 
+// checkSharedPVC returns an error if the volume is shared, i.e. it has the
+// MultiWriter sharing mode or a controller whose bus is shared, and its PVC
+// cannot be attached to multiple VMs at the same time, i.e. it is not a
+// ReadWriteMany, block PVC. The PVC of a volume that is not shared is not
+// checked.
+func (r *Reconciler) checkSharedPVC(
+	ctx *pkgctx.VolumeContext,
+	volume vmopv1.VirtualMachineVolume) error {
+
+	if !vmopv1util.IsSharedVolume(volume) {
+		return nil
+	}
+
+	pvc := corev1.PersistentVolumeClaim{}
+	pvcKey := client.ObjectKey{
+		Namespace: ctx.VM.Namespace,
+		Name:      volume.PersistentVolumeClaim.ClaimName,
+	}
+
+	if err := r.Get(ctx, pvcKey, &pvc); err != nil {
+		return fmt.Errorf("cannot get PVC: %w", err)
+	}
+
+	if !slices.Contains(pvc.Spec.AccessModes, corev1.ReadWriteMany) {
+		return fmt.Errorf("shared PVC %s does not have ReadWriteMany access mode", pvc.Name)
+	}
+
+	if mode := pvc.Spec.VolumeMode; mode == nil || *mode != corev1.PersistentVolumeBlock {
+		return fmt.Errorf("shared PVC %s does not have Block volume mode", pvc.Name)
+	}
+
+	return nil
+}
+
 // handlePVCWithWFFC sets the selected-node annotation on an unbound PVC if it has
 // a WaitForFirstConsumer StorageClass.
 func (r *Reconciler) handlePVCWithWFFC(
@@ -1055,7 +1269,7 @@ func updateVolumeStatusWithLimit(
 	ctx *pkgctx.VolumeContext,
 	c client.Reader,
 	pvcSpec vmopv1.PersistentVolumeClaimVolumeSource,
-	status *vmopv1.VirtualMachineVolumeStatus) error {
+	status *vmopv1.VirtualMachineVolumeStatus) (*corev1.PersistentVolumeClaim, error) {
 
 	// See if the volume is an instance storage volume.
 	if pvcSpec.InstanceVolumeClaim != nil {
@@ -1063,7 +1277,7 @@ func updateVolumeStatusWithLimit(
 		// volumes already have the requested size and the PVC does
 		// not need to be fetched.
 		status.Limit = &pvcSpec.InstanceVolumeClaim.Size
-		return nil
+		return nil, nil
 	}
 
 	var (
@@ -1075,7 +1289,7 @@ func updateVolumeStatusWithLimit(
 	)
 
 	if err := c.Get(ctx, pvcKey, &pvc); err != nil {
-		return err
+		return nil, err
 	}
 
 	if v, ok := pvc.Spec.Resources.Limits[corev1.ResourceStorage]; ok {
@@ -1086,5 +1300,128 @@ func updateVolumeStatusWithLimit(
 		status.Limit = &v
 	}
 
+	return &pvc, nil
+}
+
+// reconcileVolumeExpansion updates the status of a volume whose PVC is being
+// expanded. The volume is expanded by the storage provider, i.e. CSI and CNS,
+// so this only reports the progress of the expansion and the new capacity of
+// the volume. Growing the file system is left to the guest.
+func reconcileVolumeExpansion(
+	pvc *corev1.PersistentVolumeClaim,
+	status *vmopv1.VirtualMachineVolumeStatus) {
+
+	status.Resize = nil
+
+	requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return
+	}
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok || requested.Cmp(capacity) <= 0 {
+		return
+	}
+
+	// Until the storage provider has expanded the volume, the limit is the
+	// capacity prior to the expansion.
+	status.Limit = &capacity
+	status.Resize = &vmopv1.VirtualMachineVolumeResizeStatus{
+		State:     vmopv1.VirtualMachineVolumeResizeStateInProgress,
+		Requested: &requested,
+	}
+
+	for _, c := range pvc.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case corev1.PersistentVolumeClaimControllerResizeError:
+			status.Resize.State = vmopv1.VirtualMachineVolumeResizeStateFailed
+			status.Resize.Message = c.Message
+			return
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			// The volume has been expanded, but there is no kubelet to grow
+			// the file system and complete the expansion of the PVC.
+			status.Limit = &requested
+			status.Resize.State = vmopv1.VirtualMachineVolumeResizeStateFileSystemResizePending
+		}
+	}
+}
+
+// reconcileVolumeStorageIO updates the storage I/O allocation of an attached
+// volume's disk when it differs from the allocation requested by the volume.
+// When the volume's allocation is cleared, the disk is reset to the allocation
+// from the VM's class, or the default allocation if there is none. Unlike the
+// disk's placement, the allocation may be updated while the VM is powered on.
+func (r *Reconciler) reconcileVolumeStorageIO(
+	ctx *pkgctx.VolumeContext,
+	volume vmopv1.VirtualMachineVolume,
+	status *vmopv1.VirtualMachineVolumeStatus) error {
+
+	if !status.Attached || status.DiskUUID == "" {
+		return nil
+	}
+
+	if volume.StorageIO == nil {
+		// The allocation of the disk is only reset once it has been observed,
+		// i.e. after the volume's allocation was cleared, to the allocation
+		// from the VM's class, or the default allocation if the class does
+		// not specify one. The observed allocation is then cleared so the
+		// disk is not reset again on later reconciles.
+		if status.StorageIO == nil {
+			return nil
+		}
+
+		classStorageIO, err := r.getVMClassStorageIO(ctx)
+		if err != nil {
+			return err
+		}
+		storageIO := vmopv1util.GetVolumeStorageIOAllocation(volume, classStorageIO)
+		if storageIO == nil {
+			storageIO = &vmopv1.VirtualMachineStorageIOAllocation{}
+		}
+
+		if !vmopv1util.StorageIOAllocationsEqual(storageIO, status.StorageIO) {
+			if err := r.VMProvider.UpdateVirtualMachineDiskStorageIOAllocation(
+				ctx, ctx.VM, status.DiskUUID, *storageIO); err != nil {
+
+				return fmt.Errorf("failed to update storage I/O allocation of disk for volume %s: %w", status.Name, err)
+			}
+		}
+
+		status.StorageIO = nil
+
+		return nil
+	}
+
+	if vmopv1util.StorageIOAllocationsEqual(volume.StorageIO, status.StorageIO) {
+		return nil
+	}
+
+	if err := r.VMProvider.UpdateVirtualMachineDiskStorageIOAllocation(
+		ctx, ctx.VM, status.DiskUUID, *volume.StorageIO); err != nil {
+
+		return fmt.Errorf("failed to update storage I/O allocation of disk for volume %s: %w", status.Name, err)
+	}
+
+	status.StorageIO = volume.StorageIO.DeepCopy()
+
 	return nil
 }
+
+// getVMClassStorageIO returns the storage I/O allocation from the VM's class.
+// Nil is returned for a VM without a class.
+func (r *Reconciler) getVMClassStorageIO(
+	ctx *pkgctx.VolumeContext) (*vmopv1.VirtualMachineStorageIOAllocation, error) {
+
+	if ctx.VM.Spec.ClassName == "" {
+		return nil, nil
+	}
+
+	var vmClass vmopv1.VirtualMachineClass
+	if err := r.Get(ctx, client.ObjectKey{Namespace: ctx.VM.Namespace, Name: ctx.VM.Spec.ClassName}, &vmClass); err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachineClass %s: %w", ctx.VM.Spec.ClassName, err)
+	}
+
+	return vmClass.Spec.Hardware.StorageIO, nil
+}
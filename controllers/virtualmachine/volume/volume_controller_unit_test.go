@@ -371,6 +371,83 @@ func unitTestsReconcile() {
 			})
 		})
 
+		When("VM is cloned from another VM", func() {
+			var srcVM *vmopv1.VirtualMachine
+
+			BeforeEach(func() {
+				srcVM = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-src-vm",
+						Namespace: vm.Namespace,
+					},
+					Spec: vmopv1.VirtualMachineSpec{
+						Volumes: []vmopv1.VirtualMachineVolume{*vmVolumeWithPVC1},
+					},
+				}
+				boundPVC1.Spec.StorageClassName = ptr.To("dummy-storage-class")
+				boundPVC1.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+				boundPVC1.Spec.Resources.Requests = corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("10Gi"),
+				}
+				boundPVC1.Status.Capacity = corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("20Gi"),
+				}
+				initObjects = append(initObjects, srcVM, boundPVC1)
+
+				vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachine",
+					Name: srcVM.Name,
+				}
+				vmVol = *vmVolumeWithPVC1.DeepCopy()
+				vmVol.PersistentVolumeClaim.ClaimName = vm.Name + "-" + vmVol.Name
+				vm.Spec.Volumes = append(vm.Spec.Volumes, vmVol)
+				vm.Status.BiosUUID = ""
+			})
+
+			JustBeforeEach(func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.Features.VMClone = true
+				})
+			})
+
+			It("creates the PVC by cloning the source VM's PVC", func() {
+				Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+				pvc := &corev1.PersistentVolumeClaim{}
+				pvcKey := client.ObjectKey{Namespace: vm.Namespace, Name: vmVol.PersistentVolumeClaim.ClaimName}
+				Expect(ctx.Client.Get(ctx, pvcKey, pvc)).To(Succeed())
+
+				Expect(pvc.Spec.DataSource).ToNot(BeNil())
+				Expect(pvc.Spec.DataSource.Kind).To(Equal("PersistentVolumeClaim"))
+				Expect(pvc.Spec.DataSource.Name).To(Equal(boundPVC1.Name))
+				Expect(pvc.Spec.StorageClassName).To(HaveValue(Equal("dummy-storage-class")))
+				Expect(pvc.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+				Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("20Gi"))
+				Expect(pvc.OwnerReferences).To(HaveLen(1))
+				Expect(pvc.OwnerReferences[0].Name).To(Equal(vm.Name))
+			})
+
+			When("the PVC already exists", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      vmVol.PersistentVolumeClaim.ClaimName,
+							Namespace: vm.Namespace,
+						},
+					})
+				})
+
+				It("does not clone the source VM's PVC", func() {
+					Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+					pvc := &corev1.PersistentVolumeClaim{}
+					pvcKey := client.ObjectKey{Namespace: vm.Namespace, Name: vmVol.PersistentVolumeClaim.ClaimName}
+					Expect(ctx.Client.Get(ctx, pvcKey, pvc)).To(Succeed())
+					Expect(pvc.Spec.DataSource).To(BeNil())
+				})
+			})
+		})
+
 		When("VM Spec.Volumes is empty", func() {
 			It("returns success", func() {
 				err := reconciler.ReconcileNormal(volCtx)
@@ -666,6 +743,99 @@ func unitTestsReconcile() {
 			})
 		})
 
+		When("VM Spec.Volumes has CNS volume with MultiWriter sharing mode", func() {
+			var sharedPVC *corev1.PersistentVolumeClaim
+
+			BeforeEach(func() {
+				sharedPVC = boundPVC1.DeepCopy()
+				sharedPVC.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+				sharedPVC.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+				initObjects = append(initObjects, sharedPVC)
+
+				vmVol = *vmVolumeWithPVC1
+				vmVol.SharingMode = vmopv1.VolumeSharingModeMultiWriter
+				vm.Spec.Volumes = append(vm.Spec.Volumes, vmVol)
+			})
+
+			AfterEach(func() {
+				sharedPVC = nil
+			})
+
+			It("returns success", func() {
+				err := reconciler.ReconcileNormal(volCtx)
+				Expect(err).ToNot(HaveOccurred())
+
+				attachment := getCNSAttachmentForVolumeName(vm, vmVol.Name)
+				Expect(attachment).ToNot(BeNil())
+				assertAttachmentSpecFromVMVol(vm, vmVol, attachment)
+			})
+
+			When("PVC does not have ReadWriteMany access mode", func() {
+				BeforeEach(func() {
+					sharedPVC.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+				})
+
+				It("returns error", func() {
+					err := reconciler.ReconcileNormal(volCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("does not have ReadWriteMany access mode"))
+
+					By("Did not create CnsNodeVmAttachment", func() {
+						Expect(getCNSAttachmentForVolumeName(vm, vmVol.Name)).To(BeNil())
+					})
+				})
+			})
+
+			When("PVC does not have Block volume mode", func() {
+				BeforeEach(func() {
+					sharedPVC.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem)
+				})
+
+				It("returns error", func() {
+					err := reconciler.ReconcileNormal(volCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("does not have Block volume mode"))
+
+					By("Did not create CnsNodeVmAttachment", func() {
+						Expect(getCNSAttachmentForVolumeName(vm, vmVol.Name)).To(BeNil())
+					})
+				})
+			})
+		})
+
+		When("VM Spec.Volumes has CNS volume with Physical controller sharing mode", func() {
+			var sharedPVC *corev1.PersistentVolumeClaim
+
+			BeforeEach(func() {
+				sharedPVC = boundPVC1.DeepCopy()
+				sharedPVC.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+				sharedPVC.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem)
+				initObjects = append(initObjects, sharedPVC)
+
+				vmVol = *vmVolumeWithPVC1
+				vmVol.ControllerType = vmopv1.VirtualControllerTypeSCSI
+				vmVol.ControllerBusNumber = ptr.To(int32(1))
+				vmVol.ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+				vm.Spec.Volumes = append(vm.Spec.Volumes, vmVol)
+			})
+
+			AfterEach(func() {
+				sharedPVC = nil
+			})
+
+			When("PVC does not have Block volume mode", func() {
+				It("returns error", func() {
+					err := reconciler.ReconcileNormal(volCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("does not have Block volume mode"))
+
+					By("Did not create CnsNodeVmAttachment", func() {
+						Expect(getCNSAttachmentForVolumeName(vm, vmVol.Name)).To(BeNil())
+					})
+				})
+			})
+		})
+
 		When("VM Spec.Volumes has CNS volume that references WFFC StorageClass", func() {
 			const zoneName = "my-zone"
 
@@ -1058,6 +1228,47 @@ FaultMessage: ([]vimtypes.LocalizableMessage) \u003cnil\u003e\\n }\\n },\\n Type
 			})
 		})
 
+		When("VM has a detaching CNS volume and a volume that requests a controller", func() {
+			var (
+				vmVol1, vmVol2 vmopv1.VirtualMachineVolume
+				attachment     *cnsv1alpha1.CnsNodeVmAttachment
+			)
+
+			BeforeEach(func() {
+				initObjects = append(initObjects, boundPVC1, boundPVC2)
+
+				vmVol1 = *vmVolumeWithPVC1
+				vmVol2 = *vmVolumeWithPVC2
+				vmVol2.ControllerType = vmopv1.VirtualControllerTypeNVME
+				vm.Spec.Volumes = append(vm.Spec.Volumes, vmVol2)
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+
+				attachment = cnsAttachmentForVMVolume(vm, vmVol1)
+				attachment.Finalizers = append(attachment.Finalizers, "simulate-detaching-volume")
+				attachment.Status.Attached = true
+				attachment.Status.AttachmentMetadata = map[string]string{
+					volume.AttributeFirstClassDiskUUID: dummyDiskUUID,
+				}
+				initObjects = append(initObjects, attachment)
+			})
+
+			It("attaches the volume after the detach is complete", func() {
+				Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+				Expect(getCNSAttachmentForVolumeName(vm, vmVol2.Name)).To(BeNil())
+
+				attachment1 := getCNSAttachmentForVolumeName(vm, vmVol1.Name)
+				Expect(attachment1).ToNot(BeNil())
+				Expect(attachment1.DeletionTimestamp.IsZero()).To(BeFalse())
+				attachment1.Finalizers = nil
+				Expect(ctx.Client.Update(ctx, attachment1)).To(Succeed())
+
+				Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+				attachment2 := getCNSAttachmentForVolumeName(vm, vmVol2.Name)
+				Expect(attachment2).ToNot(BeNil())
+				assertAttachmentSpecFromVMVol(vm, vmVol2, attachment2)
+			})
+		})
+
 		When("VM Status.Volumes is sorted as expected", func() {
 			var vmVol1 vmopv1.VirtualMachineVolume
 			var vmVol2 vmopv1.VirtualMachineVolume
@@ -1324,6 +1535,275 @@ FaultMessage: ([]vimtypes.LocalizableMessage) \u003cnil\u003e\\n }\\n },\\n Type
 						})
 					})
 				})
+
+				When("A PVC is being expanded", func() {
+
+					BeforeEach(func() {
+						boundPVC1.Spec.Resources.Requests = corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("20Gi"),
+						}
+						boundPVC1.Status.Capacity = corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("10Gi"),
+						}
+					})
+
+					JustBeforeEach(func() {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMVolumeExpansion = true
+						})
+					})
+
+					getPVC1 := func() *corev1.PersistentVolumeClaim {
+						pvc := &corev1.PersistentVolumeClaim{}
+						ExpectWithOffset(1, ctx.Client.Get(ctx, client.ObjectKeyFromObject(boundPVC1), pvc)).To(Succeed())
+						return pvc
+					}
+
+					It("reports the expansion is in progress", func() {
+						Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+						Expect(vm.Status.Volumes).To(HaveLen(2))
+						volStatus := vm.Status.Volumes[1]
+						Expect(volStatus.Name).To(Equal(vmVol1.Name))
+						Expect(volStatus.Limit).To(Equal(ptr.To(resource.MustParse("10Gi"))))
+						Expect(volStatus.Resize).To(Equal(&vmopv1.VirtualMachineVolumeResizeStatus{
+							State:     vmopv1.VirtualMachineVolumeResizeStateInProgress,
+							Requested: ptr.To(resource.MustParse("20Gi")),
+						}))
+					})
+
+					When("Volume expansion is disabled", func() {
+						JustBeforeEach(func() {
+							pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+								config.Features.VMVolumeExpansion = false
+							})
+						})
+
+						It("does not report the expansion", func() {
+							Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+							Expect(vm.Status.Volumes).To(HaveLen(2))
+							Expect(vm.Status.Volumes[1].Limit).To(Equal(ptr.To(resource.MustParse("20Gi"))))
+							Expect(vm.Status.Volumes[1].Resize).To(BeNil())
+						})
+					})
+
+					When("The storage provider failed to expand the volume", func() {
+						BeforeEach(func() {
+							boundPVC1.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+								{
+									Type:    corev1.PersistentVolumeClaimControllerResizeError,
+									Status:  corev1.ConditionTrue,
+									Message: "insufficient space",
+								},
+							}
+						})
+
+						It("reports the expansion failed", func() {
+							Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+							Expect(vm.Status.Volumes).To(HaveLen(2))
+							volStatus := vm.Status.Volumes[1]
+							Expect(volStatus.Resize).ToNot(BeNil())
+							Expect(volStatus.Resize.State).To(Equal(vmopv1.VirtualMachineVolumeResizeStateFailed))
+							Expect(volStatus.Resize.Message).To(Equal("insufficient space"))
+						})
+					})
+
+					When("The PVC is waiting for the file system to be resized", func() {
+						BeforeEach(func() {
+							boundPVC1.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+								{
+									Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+									Status: corev1.ConditionTrue,
+								},
+							}
+						})
+
+						It("reports the expanded capacity and does not modify the PVC", func() {
+							Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+							Expect(vm.Status.Volumes).To(HaveLen(2))
+							volStatus := vm.Status.Volumes[1]
+							Expect(volStatus.Limit).To(Equal(ptr.To(resource.MustParse("20Gi"))))
+							Expect(volStatus.Resize).To(Equal(&vmopv1.VirtualMachineVolumeResizeStatus{
+								State:     vmopv1.VirtualMachineVolumeResizeStateFileSystemResizePending,
+								Requested: ptr.To(resource.MustParse("20Gi")),
+							}))
+
+							pvc := getPVC1()
+							Expect(pvc.Status.Capacity).To(HaveKeyWithValue(
+								corev1.ResourceStorage, resource.MustParse("10Gi")))
+							Expect(pvc.Status.Conditions).To(HaveLen(1))
+						})
+					})
+				})
+
+				When("A volume specifies a storage I/O allocation", func() {
+					var (
+						updatedDiskUUID  string
+						updatedStorageIO *vmopv1.VirtualMachineStorageIOAllocation
+					)
+
+					newStorageIO := func() *vmopv1.VirtualMachineStorageIOAllocation {
+						return &vmopv1.VirtualMachineStorageIOAllocation{
+							Limit: ptr.To(int64(1000)),
+							Shares: &vmopv1.VirtualMachineStorageIOShares{
+								Level: vmopv1.VirtualMachineStorageIOSharesLevelHigh,
+							},
+						}
+					}
+
+					BeforeEach(func() {
+						updatedDiskUUID = ""
+						updatedStorageIO = nil
+
+						vm.Spec.Volumes[0].StorageIO = newStorageIO()
+					})
+
+					JustBeforeEach(func() {
+						fakeVMProvider.UpdateVirtualMachineDiskStorageIOAllocationFn = func(
+							_ context.Context,
+							_ *vmopv1.VirtualMachine,
+							diskUUID string,
+							storageIO vmopv1.VirtualMachineStorageIOAllocation) error {
+
+							updatedDiskUUID = diskUUID
+							updatedStorageIO = &storageIO
+							return nil
+						}
+					})
+
+					It("updates the disk's allocation", func() {
+						Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+						Expect(updatedDiskUUID).To(Equal(dummyDiskUUID1))
+						Expect(updatedStorageIO).To(Equal(newStorageIO()))
+
+						Expect(vm.Status.Volumes).To(HaveLen(2))
+						Expect(vm.Status.Volumes[1].Name).To(Equal(vmVol1.Name))
+						Expect(vm.Status.Volumes[1].StorageIO).To(Equal(newStorageIO()))
+						Expect(vm.Status.Volumes[0].StorageIO).To(BeNil())
+					})
+
+					When("The disk already has the allocation", func() {
+						BeforeEach(func() {
+							vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+								{
+									Name:     vmVol1.Name,
+									Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+									DiskUUID: dummyDiskUUID1,
+									StorageIO: &vmopv1.VirtualMachineStorageIOAllocation{
+										Limit:       ptr.To(int64(1000)),
+										Reservation: ptr.To(int32(0)),
+										Shares: &vmopv1.VirtualMachineStorageIOShares{
+											Level: vmopv1.VirtualMachineStorageIOSharesLevelHigh,
+										},
+									},
+								},
+							}
+						})
+
+						It("does not update the disk and preserves the observed allocation", func() {
+							Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+							Expect(updatedStorageIO).To(BeNil())
+
+							Expect(vm.Status.Volumes).To(HaveLen(2))
+							Expect(vm.Status.Volumes[1].StorageIO).ToNot(BeNil())
+							Expect(vm.Status.Volumes[1].StorageIO.Reservation).To(HaveValue(BeZero()))
+						})
+					})
+
+					When("Updating the disk's allocation fails", func() {
+						JustBeforeEach(func() {
+							fakeVMProvider.UpdateVirtualMachineDiskStorageIOAllocationFn = func(
+								_ context.Context,
+								_ *vmopv1.VirtualMachine,
+								_ string,
+								_ vmopv1.VirtualMachineStorageIOAllocation) error {
+
+								return errors.New("fake reconfigure error")
+							}
+						})
+
+						It("returns an error", func() {
+							err := reconciler.ReconcileNormal(volCtx)
+							Expect(err).To(HaveOccurred())
+							Expect(err.Error()).To(ContainSubstring("fake reconfigure error"))
+
+							Expect(vm.Status.Volumes).To(HaveLen(2))
+							Expect(vm.Status.Volumes[1].StorageIO).To(BeNil())
+						})
+					})
+
+					When("The volume's allocation is cleared", func() {
+						BeforeEach(func() {
+							vm.Spec.Volumes[0].StorageIO = nil
+							vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+								{
+									Name:      vmVol1.Name,
+									Type:      vmopv1.VirtualMachineStorageDiskTypeManaged,
+									DiskUUID:  dummyDiskUUID1,
+									StorageIO: newStorageIO(),
+								},
+							}
+						})
+
+						It("resets the disk to the default allocation", func() {
+							Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+							Expect(updatedDiskUUID).To(Equal(dummyDiskUUID1))
+							Expect(updatedStorageIO).To(Equal(&vmopv1.VirtualMachineStorageIOAllocation{}))
+
+							Expect(vm.Status.Volumes).To(HaveLen(2))
+							Expect(vm.Status.Volumes[1].StorageIO).To(BeNil())
+
+							By("not resetting the disk again", func() {
+								updatedStorageIO = nil
+								Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+								Expect(updatedStorageIO).To(BeNil())
+								Expect(vm.Status.Volumes[1].StorageIO).To(BeNil())
+							})
+						})
+
+						When("The VM's class specifies an allocation", func() {
+							classStorageIO := &vmopv1.VirtualMachineStorageIOAllocation{
+								Limit: ptr.To(int64(500)),
+							}
+
+							BeforeEach(func() {
+								vmClass := builder.DummyVirtualMachineClass("my-class")
+								vmClass.Namespace = vm.Namespace
+								vmClass.Spec.Hardware.StorageIO = classStorageIO
+								initObjects = append(initObjects, vmClass)
+								vm.Spec.ClassName = vmClass.Name
+							})
+
+							It("resets the disk to the class's allocation", func() {
+								Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+								Expect(updatedDiskUUID).To(Equal(dummyDiskUUID1))
+								Expect(updatedStorageIO).To(Equal(classStorageIO))
+
+								Expect(vm.Status.Volumes).To(HaveLen(2))
+								Expect(vm.Status.Volumes[1].StorageIO).To(BeNil())
+							})
+						})
+					})
+
+					When("The volume's allocation has not been observed", func() {
+						BeforeEach(func() {
+							vm.Spec.Volumes[0].StorageIO = nil
+						})
+
+						It("does not update the disk", func() {
+							Expect(reconciler.ReconcileNormal(volCtx)).To(Succeed())
+
+							Expect(updatedStorageIO).To(BeNil())
+						})
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,205 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	finalizerName = "virtualmachinedeployment.vmoperator.vmware.com"
+)
+
+var (
+	// deploymentKind contains the schema.GroupVersionKind for the
+	// VirtualMachineDeployment type.
+	deploymentKind = vmopv1.GroupVersion.WithKind("VirtualMachineDeployment")
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineDeployment{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Owns(&vmopv1.VirtualMachineReplicaSet{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineDeployment object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedeployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedeployments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=create;get;list;watch;update;patch;delete
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	d := &vmopv1.VirtualMachineDeployment{}
+	if err := r.Get(ctx, req.NamespacedName, d); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	dCtx := &pkgctx.VirtualMachineDeploymentContext{
+		Context:    ctx,
+		Logger:     ctrl.Log.WithName("VirtualMachineDeployment").WithValues("namespace", d.Namespace, "name", d.Name),
+		Deployment: d,
+	}
+
+	patchHelper, err := patch.NewHelper(d, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", dCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, d); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			dCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !d.DeletionTimestamp.IsZero() {
+		err := r.ReconcileDelete(dCtx)
+		if err != nil {
+			dCtx.Logger.Error(err, "Failed to reconcile VirtualMachineDeployment")
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ReconcileNormal(dCtx); err != nil {
+		dCtx.Logger.Error(err, "Failed to reconcile VirtualMachineDeployment")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ReconcileDelete removes the finalizer from the VirtualMachineDeployment. The
+// owned VirtualMachineReplicaSets, and in turn their VirtualMachines, are
+// removed by the garbage collector.
+func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineDeploymentContext) error {
+	ctx.Logger.Info("Reconciling VirtualMachineDeployment Deletion")
+
+	if controllerutil.ContainsFinalizer(ctx.Deployment, finalizerName) {
+		defer func() {
+			r.Recorder.EmitEvent(ctx.Deployment, "Delete", nil, false)
+		}()
+
+		controllerutil.RemoveFinalizer(ctx.Deployment, finalizerName)
+	}
+
+	return nil
+}
+
+// ReconcileNormal rolls out the VirtualMachineDeployment's current template
+// by reconciling its revisioned VirtualMachineReplicaSets.
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineDeploymentContext) error {
+	if !controllerutil.ContainsFinalizer(ctx.Deployment, finalizerName) {
+		// Set the finalizer and return so the object is patched immediately.
+		controllerutil.AddFinalizer(ctx.Deployment, finalizerName)
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineDeployment")
+
+	d := ctx.Deployment
+
+	if d.Spec.Replicas == nil {
+		return fmt.Errorf("the Replicas field in Spec for VirtualMachineDeployment %v is nil, this should not be allowed", d.Name)
+	}
+
+	rsList, err := r.getReplicaSetsForDeployment(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A rollback only updates the deployment's template. The new template is
+	// rolled out when the deployment is reconciled again.
+	if d.Spec.RollbackTo != nil {
+		r.rollback(ctx, rsList)
+		return nil
+	}
+
+	newRS, oldRSs, err := r.getAllReplicaSetsAndSyncRevision(ctx, rsList, !d.Spec.Paused)
+	if err != nil {
+		return err
+	}
+
+	var syncErr error
+	if !d.Spec.Paused {
+		switch d.Spec.Strategy.Type {
+		case vmopv1.VirtualMachineDeploymentStrategyTypeRecreate:
+			syncErr = r.rolloutRecreate(ctx, newRS, oldRSs)
+		default:
+			syncErr = r.rolloutRolling(ctx, newRS, oldRSs)
+		}
+	}
+
+	if syncErr == nil {
+		syncErr = r.cleanupDeployment(ctx, oldRSs)
+	}
+
+	// Update the status of the VirtualMachineDeployment even in case of error
+	// since syncing might have resulted in replica sets being scaled.
+	r.updateStatus(ctx, newRS, oldRSs)
+
+	if syncErr != nil {
+		return fmt.Errorf("failed to sync VirtualMachineDeployment replica sets: %w", syncErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+
+	var (
+		ctx *builder.IntegrationTestContext
+
+		d    *vmopv1.VirtualMachineDeployment
+		dKey types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		d = &vmopv1.VirtualMachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-deployment",
+				Namespace: ctx.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineDeploymentSpec{
+				Replicas: ptr.To[int32](2),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"appname": "db",
+					},
+				},
+				Template: vmopv1.VirtualMachineTemplateSpec{
+					ObjectMeta: vmopv1common.ObjectMeta{
+						Labels: map[string]string{
+							"appname": "db",
+						},
+					},
+					Spec: vmopv1.VirtualMachineSpec{
+						ImageName:  "dummy-image",
+						ClassName:  "dummy-class",
+						PowerState: vmopv1.VirtualMachinePowerStateOn,
+					},
+				},
+			},
+		}
+		dKey = types.NamespacedName{Name: d.Name, Namespace: d.Namespace}
+
+		providerfake.SetCreateOrUpdateFunction(
+			ctx,
+			intgFakeVMProvider,
+			func(_ context.Context, vm *vmopv1.VirtualMachine) error {
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+				return nil
+			})
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	getDeployment := func() *vmopv1.VirtualMachineDeployment {
+		obj := &vmopv1.VirtualMachineDeployment{}
+		if err := ctx.Client.Get(ctx, dKey, obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	listReplicaSets := func(g Gomega) []vmopv1.VirtualMachineReplicaSet {
+		rsList := &vmopv1.VirtualMachineReplicaSetList{}
+		g.Expect(ctx.Client.List(ctx, rsList, client.InNamespace(ctx.Namespace),
+			client.MatchingLabels{vmopv1.VirtualMachineDeploymentNameLabel: d.Name})).To(Succeed())
+		return rsList.Items
+	}
+
+	It("Reconciles after VirtualMachineDeployment creation", func() {
+		Expect(ctx.Client.Create(ctx, d)).To(Succeed())
+
+		By("VirtualMachineDeployment should have finalizer added", func() {
+			Eventually(func() []string {
+				if obj := getDeployment(); obj != nil {
+					return obj.GetFinalizers()
+				}
+				return nil
+			}).Should(ContainElement(finalizerName))
+		})
+
+		By("The replica set of the first revision should be created", func() {
+			Eventually(func(g Gomega) {
+				rss := listReplicaSets(g)
+				g.Expect(rss).To(HaveLen(1))
+				g.Expect(rss[0].Spec.Replicas).To(HaveValue(BeEquivalentTo(2)))
+				g.Expect(rss[0].Annotations).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentRevisionAnnotation, "1"))
+			}, 10*time.Second, 1*time.Second).Should(Succeed())
+		})
+
+		By("Updating the template should create a new revision", func() {
+			_, err := controllerutil.CreateOrPatch(ctx, ctx.Client, d, func() error {
+				d.Spec.Template.Spec.ImageName = "new-dummy-image"
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func(g Gomega) {
+				rss := listReplicaSets(g)
+				g.Expect(rss).To(HaveLen(2))
+				obj := getDeployment()
+				g.Expect(obj).ToNot(BeNil())
+				g.Expect(obj.Status.Revision).To(BeEquivalentTo(2))
+			}, 10*time.Second, 1*time.Second).Should(Succeed())
+		})
+	})
+}
@@ -0,0 +1,309 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinedeployment"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const (
+	finalizerName = "virtualmachinedeployment.vmoperator.vmware.com"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+		reconciler  *virtualmachinedeployment.Reconciler
+		dCtx        *pkgctx.VirtualMachineDeploymentContext
+		d           *vmopv1.VirtualMachineDeployment
+	)
+
+	listReplicaSets := func() []vmopv1.VirtualMachineReplicaSet {
+		rsList := &vmopv1.VirtualMachineReplicaSetList{}
+		ExpectWithOffset(1, ctx.Client.List(ctx, rsList, client.InNamespace(d.Namespace))).To(Succeed())
+		return rsList.Items
+	}
+
+	getReplicaSetByRevision := func(rev string) *vmopv1.VirtualMachineReplicaSet {
+		for _, rs := range listReplicaSets() {
+			if rs.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation] == rev {
+				return &rs
+			}
+		}
+		return nil
+	}
+
+	// markReplicaSetReady updates the status of the replica set of the
+	// provided revision as if all of its replicas were created and ready.
+	markReplicaSetReady := func(rev string) {
+		rs := getReplicaSetByRevision(rev)
+		ExpectWithOffset(1, rs).ToNot(BeNil())
+		rs.Status.Replicas = *rs.Spec.Replicas
+		rs.Status.ReadyReplicas = *rs.Spec.Replicas
+		ExpectWithOffset(1, ctx.Client.Status().Update(ctx, rs)).To(Succeed())
+	}
+
+	reconcileNormal := func() {
+		ExpectWithOffset(1, reconciler.ReconcileNormal(dCtx)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		d = builder.DummyVirtualMachineDeployment()
+		d.Name = "dummy-deployment"
+		d.Namespace = "dummy-ns"
+		d.UID = "dummy-uid"
+		d.Spec.Replicas = ptr.To[int32](4)
+		d.Spec.Selector.MatchLabels = map[string]string{"app": "db"}
+		d.Spec.Template.Labels = map[string]string{"app": "db"}
+		d.Finalizers = []string{finalizerName}
+		initObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+
+		reconciler = virtualmachinedeployment.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+
+		dCtx = &pkgctx.VirtualMachineDeploymentContext{
+			Context:    ctx,
+			Logger:     ctx.Logger.WithName(d.Name),
+			Deployment: d,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		dCtx = nil
+	})
+
+	Context("ReconcileNormal", func() {
+		When("the finalizer is not set", func() {
+			BeforeEach(func() {
+				d.Finalizers = nil
+			})
+
+			It("will have finalizer set after reconciliation", func() {
+				reconcileNormal()
+				Expect(d.GetFinalizers()).To(ContainElement(finalizerName))
+				Expect(listReplicaSets()).To(BeEmpty())
+			})
+		})
+
+		It("creates the replica set of the first revision", func() {
+			reconcileNormal()
+
+			rss := listReplicaSets()
+			Expect(rss).To(HaveLen(1))
+			rs := rss[0]
+			Expect(metav1.IsControlledBy(&rs, d)).To(BeTrue())
+			Expect(rs.Annotations).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentRevisionAnnotation, "1"))
+			Expect(rs.Labels).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentNameLabel, d.Name))
+			Expect(rs.Spec.Template.Labels).To(HaveKey(vmopv1.VirtualMachineTemplateHashLabel))
+			Expect(rs.Spec.Selector.MatchLabels).To(HaveKey(vmopv1.VirtualMachineTemplateHashLabel))
+			Expect(rs.Spec.Replicas).To(HaveValue(BeEquivalentTo(4)))
+
+			Expect(d.Annotations).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentRevisionAnnotation, "1"))
+			Expect(d.Status.Revision).To(BeEquivalentTo(1))
+			Expect(conditions.IsFalse(d, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+		})
+
+		It("marks the deployment rolled out once the replicas are ready", func() {
+			reconcileNormal()
+			markReplicaSetReady("1")
+			reconcileNormal()
+
+			Expect(d.Status.Replicas).To(BeEquivalentTo(4))
+			Expect(d.Status.ReadyReplicas).To(BeEquivalentTo(4))
+			Expect(d.Status.UpdatedReplicas).To(BeEquivalentTo(4))
+			Expect(d.Status.UnavailableReplicas).To(BeZero())
+			Expect(conditions.IsTrue(d, vmopv1.VirtualMachineDeploymentAvailableCondition)).To(BeTrue())
+			Expect(conditions.IsTrue(d, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+		})
+
+		When("the template is updated with the RollingUpdate strategy", func() {
+			JustBeforeEach(func() {
+				reconcileNormal()
+				markReplicaSetReady("1")
+				reconcileNormal()
+
+				d.Spec.Template.Spec.ImageName = "new-image"
+				d.Spec.Strategy.RollingUpdate = &vmopv1.VirtualMachineDeploymentRollingUpdate{
+					MaxSurge:       ptr.To(intstr.FromInt32(1)),
+					MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+				}
+			})
+
+			It("surges the new replica set without scaling down the old one", func() {
+				reconcileNormal()
+
+				oldRS, newRS := getReplicaSetByRevision("1"), getReplicaSetByRevision("2")
+				Expect(newRS).ToNot(BeNil())
+				Expect(newRS.Spec.Template.Spec.ImageName).To(Equal("new-image"))
+				Expect(newRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(1)))
+				Expect(oldRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(4)))
+			})
+
+			It("scales down the old replica set once new replicas are ready", func() {
+				reconcileNormal()
+				markReplicaSetReady("2")
+				reconcileNormal()
+
+				oldRS, newRS := getReplicaSetByRevision("1"), getReplicaSetByRevision("2")
+				Expect(newRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(1)))
+				Expect(oldRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(3)))
+			})
+
+			It("completes the rollout", func() {
+				for range 20 {
+					reconcileNormal()
+					for _, rev := range []string{"1", "2"} {
+						markReplicaSetReady(rev)
+					}
+				}
+				reconcileNormal()
+
+				oldRS, newRS := getReplicaSetByRevision("1"), getReplicaSetByRevision("2")
+				Expect(newRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(4)))
+				Expect(oldRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(0)))
+				Expect(d.Status.Revision).To(BeEquivalentTo(2))
+				Expect(conditions.IsTrue(d, vmopv1.VirtualMachineDeploymentRolledOutCondition)).To(BeTrue())
+			})
+		})
+
+		When("the template is updated with the Recreate strategy", func() {
+			JustBeforeEach(func() {
+				d.Spec.Strategy.Type = vmopv1.VirtualMachineDeploymentStrategyTypeRecreate
+				reconcileNormal()
+				markReplicaSetReady("1")
+				reconcileNormal()
+
+				d.Spec.Template.Spec.ImageName = "new-image"
+			})
+
+			It("scales up the new replica set only after the old replicas are gone", func() {
+				reconcileNormal()
+
+				oldRS, newRS := getReplicaSetByRevision("1"), getReplicaSetByRevision("2")
+				Expect(oldRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(0)))
+				Expect(newRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(0)))
+
+				markReplicaSetReady("1")
+				reconcileNormal()
+
+				newRS = getReplicaSetByRevision("2")
+				Expect(newRS.Spec.Replicas).To(HaveValue(BeEquivalentTo(4)))
+			})
+		})
+
+		When("the deployment is paused", func() {
+			JustBeforeEach(func() {
+				reconcileNormal()
+				d.Spec.Paused = true
+				d.Spec.Template.Spec.ImageName = "new-image"
+			})
+
+			It("does not roll out a new revision", func() {
+				reconcileNormal()
+
+				Expect(listReplicaSets()).To(HaveLen(1))
+				Expect(conditions.GetReason(d, vmopv1.VirtualMachineDeploymentRolledOutCondition)).
+					To(Equal(vmopv1.DeploymentPausedReason))
+			})
+		})
+
+		When("a rollback is requested", func() {
+			JustBeforeEach(func() {
+				d.Spec.Strategy.Type = vmopv1.VirtualMachineDeploymentStrategyTypeRecreate
+				reconcileNormal()
+				d.Spec.Template.Spec.ImageName = "new-image"
+				reconcileNormal()
+				Expect(getReplicaSetByRevision("2")).ToNot(BeNil())
+			})
+
+			It("restores the template of the previous revision", func() {
+				d.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollback{}
+				reconcileNormal()
+
+				Expect(d.Spec.RollbackTo).To(BeNil())
+				Expect(d.Spec.Template.Spec.ImageName).ToNot(Equal("new-image"))
+				Expect(d.Spec.Template.Labels).ToNot(HaveKey(vmopv1.VirtualMachineTemplateHashLabel))
+
+				reconcileNormal()
+				Expect(listReplicaSets()).To(HaveLen(2))
+				Expect(getReplicaSetByRevision("3")).ToNot(BeNil())
+				Expect(d.Status.Revision).To(BeEquivalentTo(3))
+			})
+
+			It("ignores an unknown revision", func() {
+				d.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollback{Revision: 42}
+				reconcileNormal()
+
+				Expect(d.Spec.RollbackTo).To(BeNil())
+				Expect(d.Spec.Template.Spec.ImageName).To(Equal("new-image"))
+			})
+		})
+
+		When("the revision history limit is exceeded", func() {
+			BeforeEach(func() {
+				d.Spec.RevisionHistoryLimit = ptr.To[int32](1)
+				d.Spec.Strategy.Type = vmopv1.VirtualMachineDeploymentStrategyTypeRecreate
+			})
+
+			It("deletes the oldest replica sets", func() {
+				for _, image := range []string{"image-a", "image-b", "image-c"} {
+					d.Spec.Template.Spec.ImageName = image
+					reconcileNormal()
+				}
+				reconcileNormal()
+
+				rss := listReplicaSets()
+				Expect(rss).To(HaveLen(2))
+				Expect(getReplicaSetByRevision("1")).To(BeNil())
+				Expect(getReplicaSetByRevision("2")).ToNot(BeNil())
+				Expect(getReplicaSetByRevision("3")).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("ReconcileDelete", func() {
+		It("removes the finalizer", func() {
+			Expect(reconciler.ReconcileDelete(dCtx)).To(Succeed())
+			Expect(d.GetFinalizers()).ToNot(ContainElement(finalizerName))
+		})
+	})
+}
@@ -0,0 +1,186 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// rolloutRecreate implements the logic for recreating a replica set. All of
+// the VirtualMachines of the previous revisions are deleted before the replica
+// set of the current revision is scaled up.
+func (r *Reconciler) rolloutRecreate(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	// Scale down the old replica sets.
+	for _, rs := range oldRSs {
+		if err := r.scaleReplicaSet(ctx, rs, 0); err != nil {
+			return err
+		}
+	}
+
+	// Do not scale up the new replica set until all of the old VMs are gone.
+	// The controller is requeued when the status of the old replica sets is
+	// updated.
+	if n := statusReplicas(oldRSs...); n > 0 {
+		ctx.Logger.Info("Waiting for old VirtualMachines to be deleted", "remaining", n)
+		return nil
+	}
+
+	return r.scaleReplicaSet(ctx, newRS, *ctx.Deployment.Spec.Replicas)
+}
+
+// rolloutRolling implements the logic for rolling a new replica set. The new
+// replica set is scaled up and the old replica sets are scaled down while the
+// deployment's maxSurge and maxUnavailable bounds are honored.
+func (r *Reconciler) rolloutRolling(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	maxSurge, maxUnavailable, err := resolveFenceposts(ctx.Deployment)
+	if err != nil {
+		return err
+	}
+
+	scaledUp, err := r.reconcileNewReplicaSet(ctx, newRS, oldRSs, maxSurge)
+	if err != nil || scaledUp {
+		// Scaling up and down in the same reconcile could violate the
+		// maxUnavailable bound since the new replicas are not yet ready.
+		return err
+	}
+
+	return r.reconcileOldReplicaSets(ctx, newRS, oldRSs, maxUnavailable)
+}
+
+// reconcileNewReplicaSet scales the new replica set towards the desired
+// number of replicas, bounded by maxSurge. It returns true if the new replica
+// set was scaled up.
+func (r *Reconciler) reconcileNewReplicaSet(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet,
+	maxSurge int32) (bool, error) {
+
+	desired := *ctx.Deployment.Spec.Replicas
+	current := replicasOf(newRS)
+
+	switch {
+	case current == desired:
+		return false, nil
+	case current > desired:
+		return false, r.scaleReplicaSet(ctx, newRS, desired)
+	}
+
+	// The total number of replicas may not exceed the desired number of
+	// replicas plus maxSurge.
+	allReplicas := specReplicas(oldRSs...) + current
+	maxTotal := desired + maxSurge
+	if allReplicas >= maxTotal {
+		return false, nil
+	}
+
+	scaleUpCount := min(maxTotal-allReplicas, desired-current)
+	if err := r.scaleReplicaSet(ctx, newRS, current+scaleUpCount); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// reconcileOldReplicaSets scales down the old replica sets while keeping at
+// least desired - maxUnavailable replicas ready.
+func (r *Reconciler) reconcileOldReplicaSets(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet,
+	maxUnavailable int32) error {
+
+	if specReplicas(oldRSs...) == 0 {
+		// Nothing left to scale down.
+		return nil
+	}
+
+	desired := *ctx.Deployment.Spec.Replicas
+	minAvailable := desired - maxUnavailable
+
+	// The replicas of the new replica set that are not ready yet count
+	// against maxUnavailable, so they limit how many old replicas may be
+	// removed.
+	newRSUnavailable := max(replicasOf(newRS)-newRS.Status.ReadyReplicas, 0)
+	allReplicas := specReplicas(oldRSs...) + replicasOf(newRS)
+	maxScaledDown := allReplicas - minAvailable - newRSUnavailable
+	if maxScaledDown <= 0 {
+		return nil
+	}
+
+	// Clean up the unhealthy replicas of the old replica sets first since
+	// removing them does not decrease the number of ready replicas.
+	cleanedUp, err := r.cleanupUnhealthyReplicas(ctx, oldRSs, maxScaledDown)
+	if err != nil {
+		return err
+	}
+
+	// Scale down the old replica sets, oldest first, by as many ready
+	// replicas as may be removed without violating minAvailable. Replicas
+	// that are ready but already being scaled down are not counted.
+	allReady := min(newRS.Status.ReadyReplicas, replicasOf(newRS))
+	for _, rs := range oldRSs {
+		allReady += min(rs.Status.ReadyReplicas, replicasOf(rs))
+	}
+	totalScaleDown := min(allReady-minAvailable, maxScaledDown-cleanedUp)
+
+	for _, rs := range oldRSs {
+		if totalScaleDown <= 0 {
+			break
+		}
+
+		current := replicasOf(rs)
+		if current == 0 {
+			continue
+		}
+
+		scaleDown := min(current, totalScaleDown)
+		if err := r.scaleReplicaSet(ctx, rs, current-scaleDown); err != nil {
+			return err
+		}
+		totalScaleDown -= scaleDown
+	}
+
+	return nil
+}
+
+// cleanupUnhealthyReplicas scales down the old replica sets by the number of
+// their replicas that are not ready, up to maxCleanupCount. It returns the
+// number of replicas that were removed.
+func (r *Reconciler) cleanupUnhealthyReplicas(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet,
+	maxCleanupCount int32) (int32, error) {
+
+	var total int32
+	for _, rs := range oldRSs {
+		if total >= maxCleanupCount {
+			break
+		}
+
+		current := replicasOf(rs)
+		unhealthy := current - rs.Status.ReadyReplicas
+		if current == 0 || unhealthy <= 0 {
+			continue
+		}
+
+		scaleDown := min(unhealthy, maxCleanupCount-total)
+		if err := r.scaleReplicaSet(ctx, rs, current-scaleDown); err != nil {
+			return total, err
+		}
+		total += scaleDown
+	}
+
+	return total, nil
+}
@@ -0,0 +1,350 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	"fmt"
+	"maps"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+// getReplicaSetsForDeployment returns the VirtualMachineReplicaSets that are
+// controlled by the deployment.
+func (r *Reconciler) getReplicaSetsForDeployment(
+	ctx *pkgctx.VirtualMachineDeploymentContext) ([]*vmopv1.VirtualMachineReplicaSet, error) {
+
+	d := ctx.Deployment
+
+	rsList := &vmopv1.VirtualMachineReplicaSetList{}
+	if err := r.Client.List(ctx,
+		rsList,
+		client.InNamespace(d.Namespace),
+		client.MatchingLabels{vmopv1.VirtualMachineDeploymentNameLabel: util.MustFormatValue(d.Name)},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list VirtualMachineReplicaSets: %w", err)
+	}
+
+	rss := make([]*vmopv1.VirtualMachineReplicaSet, 0, len(rsList.Items))
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if metav1.IsControlledBy(rs, d) {
+			rss = append(rss, rs)
+		}
+	}
+
+	return rss, nil
+}
+
+// getAllReplicaSetsAndSyncRevision returns the replica set of the current
+// revision and the replica sets of the previous revisions. If the replica set
+// of the current revision does not exist and createIfNotExisted is true, it
+// is created.
+func (r *Reconciler) getAllReplicaSetsAndSyncRevision(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rsList []*vmopv1.VirtualMachineReplicaSet,
+	createIfNotExisted bool) (*vmopv1.VirtualMachineReplicaSet, []*vmopv1.VirtualMachineReplicaSet, error) {
+
+	d := ctx.Deployment
+	hash := computeTemplateHash(&d.Spec.Template)
+
+	var (
+		newRS  *vmopv1.VirtualMachineReplicaSet
+		oldRSs []*vmopv1.VirtualMachineReplicaSet
+	)
+
+	for _, rs := range rsList {
+		if newRS == nil && rs.Labels[vmopv1.VirtualMachineTemplateHashLabel] == hash {
+			newRS = rs
+			continue
+		}
+		oldRSs = append(oldRSs, rs)
+	}
+	sortByRevision(oldRSs)
+
+	newRevision := maxRevision(oldRSs) + 1
+
+	switch {
+	case newRS != nil:
+		// The template was reverted to the one of a previous revision, so
+		// the existing replica set becomes the current revision.
+		if revision(newRS) < newRevision {
+			patch := client.MergeFrom(newRS.DeepCopy())
+			newRS.Annotations = setRevision(newRS.Annotations, newRevision)
+			if err := r.Client.Patch(ctx, newRS, patch); err != nil {
+				return nil, nil, fmt.Errorf("failed to update revision of VirtualMachineReplicaSet %q: %w",
+					newRS.Name, err)
+			}
+		}
+	case createIfNotExisted:
+		rs, err := r.createReplicaSet(ctx, hash, newRevision)
+		if err != nil {
+			return nil, nil, err
+		}
+		newRS = rs
+	default:
+		return nil, oldRSs, nil
+	}
+
+	d.Annotations = setRevision(d.Annotations, revision(newRS))
+
+	return newRS, oldRSs, nil
+}
+
+// createReplicaSet creates the VirtualMachineReplicaSet for the deployment's
+// current template. The replica set is created with zero replicas and scaled
+// up by the rollout strategy.
+func (r *Reconciler) createReplicaSet(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	hash string,
+	rev int64) (*vmopv1.VirtualMachineReplicaSet, error) {
+
+	d := ctx.Deployment
+	deploymentLabel := util.MustFormatValue(d.Name)
+
+	template := *d.Spec.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.Labels[vmopv1.VirtualMachineTemplateHashLabel] = hash
+	template.Labels[vmopv1.VirtualMachineDeploymentNameLabel] = deploymentLabel
+
+	selector := d.Spec.Selector.DeepCopy()
+	if selector.MatchLabels == nil {
+		selector.MatchLabels = map[string]string{}
+	}
+	selector.MatchLabels[vmopv1.VirtualMachineTemplateHashLabel] = hash
+
+	rsLabels := maps.Clone(template.Labels)
+
+	rs := &vmopv1.VirtualMachineReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-%s", d.Name, hash),
+			Namespace:       d.Namespace,
+			Labels:          rsLabels,
+			Annotations:     setRevision(nil, rev),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, deploymentKind)},
+		},
+		Spec: vmopv1.VirtualMachineReplicaSetSpec{
+			Replicas: ptr.To[int32](0),
+			Selector: selector,
+			Template: template,
+		},
+	}
+
+	if err := r.Client.Create(ctx, rs); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			r.Recorder.Warnf(d, "FailedCreate", "Failed to create VirtualMachineReplicaSet %q: %v", rs.Name, err)
+			return nil, fmt.Errorf("failed to create VirtualMachineReplicaSet %q: %w", rs.Name, err)
+		}
+
+		// The replica set may have been created by a previous reconcile that
+		// is not yet reflected in the cache.
+		existing := &vmopv1.VirtualMachineReplicaSet{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(rs), existing); err != nil {
+			return nil, fmt.Errorf("failed to get VirtualMachineReplicaSet %q: %w", rs.Name, err)
+		}
+		if !metav1.IsControlledBy(existing, d) ||
+			!apiequality.Semantic.DeepEqual(existing.Spec.Template, rs.Spec.Template) {
+
+			return nil, fmt.Errorf("VirtualMachineReplicaSet %q already exists and is not the "+
+				"current revision of VirtualMachineDeployment %q", rs.Name, d.Name)
+		}
+		return existing, nil
+	}
+
+	ctx.Logger.Info("Created VirtualMachineReplicaSet", "replicaSet", rs.Name, "revision", rev)
+	r.Recorder.Eventf(d, "SuccessfulCreate", "Created VirtualMachineReplicaSet %q with revision %d", rs.Name, rev)
+
+	return rs, nil
+}
+
+// scaleReplicaSet updates the desired number of replicas of the provided
+// replica set.
+func (r *Reconciler) scaleReplicaSet(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rs *vmopv1.VirtualMachineReplicaSet,
+	replicas int32) error {
+
+	oldReplicas := replicasOf(rs)
+	if rs.Spec.Replicas != nil && oldReplicas == replicas {
+		return nil
+	}
+
+	patch := client.MergeFrom(rs.DeepCopy())
+	rs.Spec.Replicas = ptr.To(replicas)
+	if err := r.Client.Patch(ctx, rs, patch); err != nil {
+		r.Recorder.Warnf(ctx.Deployment, "FailedScale",
+			"Failed to scale VirtualMachineReplicaSet %q to %d: %v", rs.Name, replicas, err)
+		return fmt.Errorf("failed to scale VirtualMachineReplicaSet %q to %d: %w", rs.Name, replicas, err)
+	}
+
+	scalingOperation := "down"
+	if oldReplicas < replicas {
+		scalingOperation = "up"
+	}
+
+	ctx.Logger.Info("Scaled VirtualMachineReplicaSet",
+		"replicaSet", rs.Name, "oldReplicas", oldReplicas, "newReplicas", replicas)
+	r.Recorder.Eventf(ctx.Deployment, "ScalingReplicaSet",
+		"Scaled %s VirtualMachineReplicaSet %q from %d to %d", scalingOperation, rs.Name, oldReplicas, replicas)
+
+	return nil
+}
+
+// rollback updates the deployment's template to the one of the revision
+// specified by spec.rollbackTo and clears spec.rollbackTo.
+func (r *Reconciler) rollback(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rsList []*vmopv1.VirtualMachineReplicaSet) {
+
+	d := ctx.Deployment
+	toRevision := d.Spec.RollbackTo.Revision
+
+	// Always clear the rollback request so it is not retried forever.
+	d.Spec.RollbackTo = nil
+
+	rss := append([]*vmopv1.VirtualMachineReplicaSet{}, rsList...)
+	sortByRevision(rss)
+
+	if toRevision == 0 {
+		// Roll back to the revision prior to the current one.
+		if len(rss) < 2 {
+			r.Recorder.Warnf(d, "RollbackRevisionNotFound", "Unable to find last revision")
+			return
+		}
+		toRevision = revision(rss[len(rss)-2])
+	}
+
+	for _, rs := range rss {
+		if revision(rs) != toRevision {
+			continue
+		}
+
+		ctx.Logger.Info("Rolling back VirtualMachineDeployment", "revision", toRevision, "replicaSet", rs.Name)
+		d.Spec.Template = templateWithoutDeploymentLabels(rs.Spec.Template)
+		r.Recorder.Eventf(d, "DeploymentRollback", "Rolled back VirtualMachineDeployment to revision %d", toRevision)
+		return
+	}
+
+	r.Recorder.Warnf(d, "RollbackRevisionNotFound", "Unable to find revision %d", toRevision)
+}
+
+// cleanupDeployment deletes the oldest replica sets that are scaled down to
+// zero replicas and exceed the deployment's revision history limit.
+func (r *Reconciler) cleanupDeployment(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	d := ctx.Deployment
+
+	limit := int32(defaultRevisionHistoryLimit)
+	if d.Spec.RevisionHistoryLimit != nil {
+		limit = *d.Spec.RevisionHistoryLimit
+	}
+
+	var cleanable []*vmopv1.VirtualMachineReplicaSet
+	for _, rs := range oldRSs {
+		if rs.DeletionTimestamp.IsZero() && replicasOf(rs) == 0 && rs.Status.Replicas == 0 {
+			cleanable = append(cleanable, rs)
+		}
+	}
+
+	diff := len(cleanable) - int(limit)
+	if diff <= 0 {
+		return nil
+	}
+
+	sortByRevision(cleanable)
+	for _, rs := range cleanable[:diff] {
+		ctx.Logger.Info("Deleting old VirtualMachineReplicaSet", "replicaSet", rs.Name, "revision", revision(rs))
+		if err := r.Client.Delete(ctx, rs); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete VirtualMachineReplicaSet %q: %w", rs.Name, err)
+		}
+		r.Recorder.Eventf(d, "SuccessfulDelete", "Deleted VirtualMachineReplicaSet %q", rs.Name)
+	}
+
+	return nil
+}
+
+// updateStatus updates the Status field of the VirtualMachineDeployment.
+func (r *Reconciler) updateStatus(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) {
+
+	d := ctx.Deployment
+	desired := *d.Spec.Replicas
+	allRSs := append([]*vmopv1.VirtualMachineReplicaSet{newRS}, oldRSs...)
+
+	d.Status.ObservedGeneration = d.Generation
+	d.Status.Replicas = statusReplicas(allRSs...)
+	d.Status.ReadyReplicas = readyReplicas(allRSs...)
+	d.Status.UnavailableReplicas = max(desired-d.Status.ReadyReplicas, 0)
+	d.Status.UpdatedReplicas = 0
+	d.Status.Revision = 0
+	if newRS != nil {
+		d.Status.UpdatedReplicas = newRS.Status.Replicas
+		d.Status.Revision = revision(newRS)
+	}
+
+	_, maxUnavailable, err := resolveFenceposts(d)
+	if err != nil {
+		ctx.Logger.Error(err, "Failed to resolve rolling update parameters")
+	}
+	minAvailable := desired - maxUnavailable
+
+	if d.Status.ReadyReplicas >= minAvailable {
+		conditions.MarkTrue(d, vmopv1.VirtualMachineDeploymentAvailableCondition)
+	} else {
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentAvailableCondition,
+			vmopv1.MinimumReplicasUnavailableReason,
+			"%d of %d replicas are ready, at least %d are required",
+			d.Status.ReadyReplicas,
+			desired,
+			minAvailable)
+	}
+
+	switch {
+	case d.Spec.Paused:
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentRolledOutCondition,
+			vmopv1.DeploymentPausedReason,
+			"VirtualMachineDeployment is paused")
+	case newRS != nil &&
+		replicasOf(newRS) == desired &&
+		d.Status.UpdatedReplicas == desired &&
+		d.Status.Replicas == desired &&
+		newRS.Status.ReadyReplicas == desired:
+
+		if conditions.IsFalse(d, vmopv1.VirtualMachineDeploymentRolledOutCondition) {
+			ctx.Logger.Info("VirtualMachineDeployment rolled out", "revision", d.Status.Revision)
+		}
+		conditions.MarkTrue(d, vmopv1.VirtualMachineDeploymentRolledOutCondition)
+	default:
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentRolledOutCondition,
+			vmopv1.RolloutInProgressReason,
+			"Rolling out revision %d: %d of %d replicas updated, %d ready, %d total",
+			d.Status.Revision,
+			d.Status.UpdatedReplicas,
+			desired,
+			d.Status.ReadyReplicas,
+			d.Status.Replicas)
+	}
+}
@@ -0,0 +1,193 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+var (
+	// defaultMaxSurge is the default value of the maxSurge field of the
+	// RollingUpdate strategy.
+	defaultMaxSurge = intstr.FromString("25%")
+
+	// defaultMaxUnavailable is the default value of the maxUnavailable field
+	// of the RollingUpdate strategy.
+	defaultMaxUnavailable = intstr.FromString("25%")
+)
+
+const (
+	// defaultRevisionHistoryLimit is the number of old replica sets retained
+	// when spec.revisionHistoryLimit is not set.
+	defaultRevisionHistoryLimit = 10
+)
+
+// computeTemplateHash returns a hash value calculated from the provided
+// VirtualMachineTemplateSpec. The value is safe to use as a label value and as
+// part of an object name.
+//
+// The hash is calculated from the JSON encoding of the template so that a
+// template read back from the API server, where empty maps and slices may have
+// been dropped, hashes to the same value as the one that was written.
+func computeTemplateHash(template *vmopv1.VirtualMachineTemplateSpec) string {
+	hasher := fnv.New32a()
+	data, _ := json.Marshal(template)
+	_, _ = hasher.Write(data)
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+}
+
+// templateWithoutDeploymentLabels returns a copy of the provided template
+// without the labels the controller adds to the templates of the replica sets
+// it owns.
+func templateWithoutDeploymentLabels(
+	template vmopv1.VirtualMachineTemplateSpec) vmopv1.VirtualMachineTemplateSpec {
+
+	out := *template.DeepCopy()
+	if out.Labels != nil {
+		out.Labels = maps.Clone(out.Labels)
+		delete(out.Labels, vmopv1.VirtualMachineTemplateHashLabel)
+		delete(out.Labels, vmopv1.VirtualMachineDeploymentNameLabel)
+		if len(out.Labels) == 0 {
+			out.Labels = nil
+		}
+	}
+	return out
+}
+
+// revision returns the revision number of the provided replica set, or zero
+// if the replica set has no valid revision annotation.
+func revision(rs *vmopv1.VirtualMachineReplicaSet) int64 {
+	v, ok := rs.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation]
+	if !ok {
+		return 0
+	}
+	r, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return r
+}
+
+// setRevision sets the revision annotation on the provided object meta
+// annotations.
+func setRevision(annotations map[string]string, rev int64) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation] = strconv.FormatInt(rev, 10)
+	return annotations
+}
+
+// maxRevision returns the largest revision of the provided replica sets.
+func maxRevision(rss []*vmopv1.VirtualMachineReplicaSet) int64 {
+	var m int64
+	for _, rs := range rss {
+		if v := revision(rs); v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// replicasOf returns the desired number of replicas of the provided replica
+// set.
+func replicasOf(rs *vmopv1.VirtualMachineReplicaSet) int32 {
+	if rs == nil || rs.Spec.Replicas == nil {
+		return 0
+	}
+	return *rs.Spec.Replicas
+}
+
+// specReplicas returns the sum of the desired replicas of the provided
+// replica sets.
+func specReplicas(rss ...*vmopv1.VirtualMachineReplicaSet) int32 {
+	var n int32
+	for _, rs := range rss {
+		n += replicasOf(rs)
+	}
+	return n
+}
+
+// statusReplicas returns the sum of the observed replicas of the provided
+// replica sets.
+func statusReplicas(rss ...*vmopv1.VirtualMachineReplicaSet) int32 {
+	var n int32
+	for _, rs := range rss {
+		if rs != nil {
+			n += rs.Status.Replicas
+		}
+	}
+	return n
+}
+
+// readyReplicas returns the sum of the ready replicas of the provided replica
+// sets.
+func readyReplicas(rss ...*vmopv1.VirtualMachineReplicaSet) int32 {
+	var n int32
+	for _, rs := range rss {
+		if rs != nil {
+			n += rs.Status.ReadyReplicas
+		}
+	}
+	return n
+}
+
+// sortByRevision sorts the provided replica sets from the oldest to the
+// newest revision.
+func sortByRevision(rss []*vmopv1.VirtualMachineReplicaSet) {
+	sort.SliceStable(rss, func(i, j int) bool {
+		ri, rj := revision(rss[i]), revision(rss[j])
+		if ri == rj {
+			return rss[i].Name < rss[j].Name
+		}
+		return ri < rj
+	})
+}
+
+// resolveFenceposts returns the absolute values of maxSurge and
+// maxUnavailable for the provided deployment. If both values resolve to zero,
+// maxUnavailable is set to one so the rollout is able to make progress.
+func resolveFenceposts(d *vmopv1.VirtualMachineDeployment) (int32, int32, error) {
+	desired := int(*d.Spec.Replicas)
+
+	if d.Spec.Strategy.Type == vmopv1.VirtualMachineDeploymentStrategyTypeRecreate {
+		return 0, 0, nil
+	}
+
+	maxSurge, maxUnavailable := &defaultMaxSurge, &defaultMaxUnavailable
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		if ru.MaxSurge != nil {
+			maxSurge = ru.MaxSurge
+		}
+		if ru.MaxUnavailable != nil {
+			maxUnavailable = ru.MaxUnavailable
+		}
+	}
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(maxSurge, desired, true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxSurge: %w", err)
+	}
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, desired, false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxUnavailable: %w", err)
+	}
+
+	if surge == 0 && unavailable == 0 {
+		unavailable = 1
+	}
+
+	return int32(surge), int32(unavailable), nil //nolint:gosec // disable G115
+}
@@ -0,0 +1,171 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinenetworkpolicy
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// AllowPriority is the priority of the security policies that allow the
+	// traffic described by the rules of VirtualMachineNetworkPolicies.
+	AllowPriority = 10
+
+	// IsolationPriority is the priority of the security policies that drop
+	// the traffic to and from the selected VMs that is not allowed by any
+	// VirtualMachineNetworkPolicy. The lower precedence of this priority
+	// ensures the allowed traffic of all the policies that select a VM is
+	// evaluated before the traffic is dropped, i.e. policies are additive.
+	IsolationPriority = 20
+
+	allowSecurityPolicySuffix     = "-allow"
+	isolationSecurityPolicySuffix = "-isolation"
+)
+
+// AllowSecurityPolicyName returns the name of the security policy that allows
+// the traffic described by the rules of a VirtualMachineNetworkPolicy.
+func AllowSecurityPolicyName(name string) string {
+	return name + allowSecurityPolicySuffix
+}
+
+// IsolationSecurityPolicyName returns the name of the security policy that
+// isolates the VMs selected by a VirtualMachineNetworkPolicy.
+func IsolationSecurityPolicyName(name string) string {
+	return name + isolationSecurityPolicySuffix
+}
+
+// PolicyTypes returns whether the policy applies to the ingress and egress
+// traffic of the selected VMs.
+func PolicyTypes(spec vmopv1.VirtualMachineNetworkPolicySpec) (ingress, egress bool) {
+	if len(spec.PolicyTypes) == 0 {
+		return true, len(spec.Egress) > 0
+	}
+	for _, t := range spec.PolicyTypes {
+		switch t {
+		case vmopv1.VirtualMachineNetworkPolicyTypeIngress:
+			ingress = true
+		case vmopv1.VirtualMachineNetworkPolicyTypeEgress:
+			egress = true
+		}
+	}
+	return ingress, egress
+}
+
+// SecurityPolicySpecs translates a VirtualMachineNetworkPolicy into the specs
+// of two security policies: one that allows the traffic described by the
+// policy's rules, and one that drops all other traffic to and from the
+// selected VMs for the directions to which the policy applies.
+func SecurityPolicySpecs(
+	np *vmopv1.VirtualMachineNetworkPolicy) (allow, isolation vpcv1alpha1.SecurityPolicySpec) {
+
+	appliedTo := []vpcv1alpha1.SecurityPolicyTarget{
+		{
+			VMSelector: np.Spec.VMSelector.DeepCopy(),
+		},
+	}
+
+	allow.Priority = AllowPriority
+	allow.AppliedTo = appliedTo
+	isolation.Priority = IsolationPriority
+	isolation.AppliedTo = appliedTo
+
+	ingress, egress := PolicyTypes(np.Spec)
+
+	if ingress {
+		for i, r := range np.Spec.Ingress {
+			allow.Rules = append(allow.Rules, vpcv1alpha1.SecurityPolicyRule{
+				Name:      fmt.Sprintf("ingress-%d", i),
+				Action:    ptr.To(vpcv1alpha1.RuleActionAllow),
+				Direction: ptr.To(vpcv1alpha1.RuleDirectionIn),
+				Sources:   toSecurityPolicyPeers(r.From),
+				Ports:     toSecurityPolicyPorts(r.Ports),
+			})
+		}
+		isolation.Rules = append(isolation.Rules, vpcv1alpha1.SecurityPolicyRule{
+			Name:      "ingress-isolation",
+			Action:    ptr.To(vpcv1alpha1.RuleActionDrop),
+			Direction: ptr.To(vpcv1alpha1.RuleDirectionIn),
+		})
+	}
+
+	if egress {
+		for i, r := range np.Spec.Egress {
+			allow.Rules = append(allow.Rules, vpcv1alpha1.SecurityPolicyRule{
+				Name:         fmt.Sprintf("egress-%d", i),
+				Action:       ptr.To(vpcv1alpha1.RuleActionAllow),
+				Direction:    ptr.To(vpcv1alpha1.RuleDirectionOut),
+				Destinations: toSecurityPolicyPeers(r.To),
+				Ports:        toSecurityPolicyPorts(r.Ports),
+			})
+		}
+		isolation.Rules = append(isolation.Rules, vpcv1alpha1.SecurityPolicyRule{
+			Name:      "egress-isolation",
+			Action:    ptr.To(vpcv1alpha1.RuleActionDrop),
+			Direction: ptr.To(vpcv1alpha1.RuleDirectionOut),
+		})
+	}
+
+	return allow, isolation
+}
+
+func toSecurityPolicyPeers(
+	peers []vmopv1.VirtualMachineNetworkPolicyPeer) []vpcv1alpha1.SecurityPolicyPeer {
+
+	if len(peers) == 0 {
+		return nil
+	}
+
+	out := make([]vpcv1alpha1.SecurityPolicyPeer, 0, len(peers))
+	for _, p := range peers {
+		var sp vpcv1alpha1.SecurityPolicyPeer
+		if p.IPBlock != nil {
+			sp.IPBlocks = []vpcv1alpha1.IPBlock{{CIDR: p.IPBlock.CIDR}}
+		} else {
+			sp.VMSelector = p.VMSelector.DeepCopy()
+			sp.NamespaceSelector = p.NamespaceSelector.DeepCopy()
+			if sp.VMSelector == nil && sp.NamespaceSelector != nil {
+				// Like a NetworkPolicy, a peer with only a namespace selector
+				// selects all of the VMs in the selected namespaces.
+				sp.VMSelector = &metav1.LabelSelector{}
+			}
+		}
+		out = append(out, sp)
+	}
+	return out
+}
+
+func toSecurityPolicyPorts(
+	ports []vmopv1.VirtualMachineNetworkPolicyPort) []vpcv1alpha1.SecurityPolicyPort {
+
+	if len(ports) == 0 {
+		return nil
+	}
+
+	out := make([]vpcv1alpha1.SecurityPolicyPort, 0, len(ports))
+	for _, p := range ports {
+		sp := vpcv1alpha1.SecurityPolicyPort{
+			Protocol: corev1.ProtocolTCP,
+		}
+		if p.Protocol != "" {
+			sp.Protocol = corev1.Protocol(p.Protocol)
+		}
+		if p.Port != nil {
+			sp.Port = *p.Port
+		}
+		if p.EndPort != nil {
+			sp.EndPort = int(*p.EndPort)
+		}
+		out = append(out, sp)
+	}
+	return out
+}
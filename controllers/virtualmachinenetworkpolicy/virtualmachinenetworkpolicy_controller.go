@@ -0,0 +1,258 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinenetworkpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineNetworkPolicy{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+	)
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles})
+
+	switch pkgcfg.FromContext(ctx).NetworkProviderType {
+	case pkgcfg.NetworkProviderTypeVPC:
+		builder = builder.Owns(&vpcv1alpha1.SecurityPolicy{})
+	case pkgcfg.NetworkProviderTypeNSXT:
+		builder = builder.Owns(&nsxv1alpha1.SecurityPolicy{})
+	}
+
+	return builder.Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger) *Reconciler {
+	return &Reconciler{
+		Context: ctx,
+		Client:  client,
+		Logger:  logger,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineNetworkPolicy object.
+type Reconciler struct {
+	Context context.Context
+	client.Client
+	Logger logr.Logger
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinenetworkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinenetworkpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=crd.nsx.vmware.com,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=crd.nsx.vmware.com,resources=securitypolicies/status,verbs=get
+// +kubebuilder:rbac:groups=nsx.vmware.com,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nsx.vmware.com,resources=securitypolicies/status,verbs=get
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	np := &vmopv1.VirtualMachineNetworkPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, np); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	npCtx := &pkgctx.VirtualMachineNetworkPolicyContext{
+		Context:       ctx,
+		Logger:        r.Logger.WithName("VirtualMachineNetworkPolicy").WithValues("name", np.NamespacedName()),
+		NetworkPolicy: np,
+	}
+
+	// The security policies are owned by the VirtualMachineNetworkPolicy and
+	// are garbage collected when it is deleted.
+	if !np.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(np, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", npCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, np); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			npCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	return ctrl.Result{}, r.ReconcileNormal(npCtx)
+}
+
+// ReconcileNormal reconciles a VirtualMachineNetworkPolicy.
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineNetworkPolicyContext) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineNetworkPolicy")
+	defer ctx.Logger.V(4).Info("Finished Reconciling VirtualMachineNetworkPolicy")
+
+	var reconcileFn func(*pkgctx.VirtualMachineNetworkPolicyContext, string, vpcv1alpha1.SecurityPolicySpec) (bool, string, error)
+
+	networkProviderType := pkgcfg.FromContext(ctx).NetworkProviderType
+	switch networkProviderType {
+	case pkgcfg.NetworkProviderTypeVPC:
+		reconcileFn = r.reconcileVPCSecurityPolicy
+	case pkgcfg.NetworkProviderTypeNSXT:
+		reconcileFn = r.reconcileNSXTSecurityPolicy
+	default:
+		conditions.MarkFalse(
+			ctx.NetworkPolicy,
+			vmopv1.VirtualMachineNetworkPolicyReadyCondition,
+			vmopv1.VirtualMachineNetworkPolicyNotSupportedReason,
+			"VirtualMachineNetworkPolicy is not supported with network provider %q",
+			networkProviderType)
+		return nil
+	}
+
+	allowSpec, isolationSpec := SecurityPolicySpecs(ctx.NetworkPolicy)
+
+	var notReady []string
+	for _, sp := range []struct {
+		name string
+		spec vpcv1alpha1.SecurityPolicySpec
+	}{
+		{name: AllowSecurityPolicyName(ctx.NetworkPolicy.Name), spec: allowSpec},
+		{name: IsolationSecurityPolicyName(ctx.NetworkPolicy.Name), spec: isolationSpec},
+	} {
+		ready, message, err := reconcileFn(ctx, sp.name, sp.spec)
+		if err != nil {
+			conditions.MarkFalse(
+				ctx.NetworkPolicy,
+				vmopv1.VirtualMachineNetworkPolicyReadyCondition,
+				vmopv1.VirtualMachineNetworkPolicySecurityPolicyNotReadyReason,
+				"Failed to reconcile SecurityPolicy %s: %v", sp.name, err)
+			return err
+		}
+		if !ready {
+			if message == "" {
+				message = "not ready"
+			}
+			notReady = append(notReady, fmt.Sprintf("SecurityPolicy %s: %s", sp.name, message))
+		}
+	}
+
+	if len(notReady) > 0 {
+		conditions.MarkFalse(
+			ctx.NetworkPolicy,
+			vmopv1.VirtualMachineNetworkPolicyReadyCondition,
+			vmopv1.VirtualMachineNetworkPolicySecurityPolicyNotReadyReason,
+			"%s", strings.Join(notReady, "; "))
+		return nil
+	}
+
+	conditions.MarkTrue(ctx.NetworkPolicy, vmopv1.VirtualMachineNetworkPolicyReadyCondition)
+	return nil
+}
+
+// reconcileVPCSecurityPolicy creates or patches a VPC SecurityPolicy and
+// returns whether it is ready.
+func (r *Reconciler) reconcileVPCSecurityPolicy(
+	ctx *pkgctx.VirtualMachineNetworkPolicyContext,
+	name string,
+	spec vpcv1alpha1.SecurityPolicySpec) (bool, string, error) {
+
+	sp := &vpcv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ctx.NetworkPolicy.Namespace,
+		},
+	}
+
+	if _, err := controllerutil.CreateOrPatch(ctx, r.Client, sp, func() error {
+		if err := controllerutil.SetControllerReference(ctx.NetworkPolicy, sp, r.Client.Scheme()); err != nil {
+			return err
+		}
+		sp.Spec = spec
+		return nil
+	}); err != nil {
+		return false, "", err
+	}
+
+	for _, c := range sp.Status.Conditions {
+		if c.Type == vpcv1alpha1.Ready {
+			return c.Status == corev1.ConditionTrue, c.Message, nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// reconcileNSXTSecurityPolicy creates or patches an NSX-T SecurityPolicy and
+// returns whether it is ready.
+func (r *Reconciler) reconcileNSXTSecurityPolicy(
+	ctx *pkgctx.VirtualMachineNetworkPolicyContext,
+	name string,
+	spec vpcv1alpha1.SecurityPolicySpec) (bool, string, error) {
+
+	// The NSX-T and VPC SecurityPolicy APIs share the same schema.
+	var nsxSpec nsxv1alpha1.SecurityPolicySpec
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return false, "", err
+	}
+	if err := json.Unmarshal(data, &nsxSpec); err != nil {
+		return false, "", err
+	}
+
+	sp := &nsxv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ctx.NetworkPolicy.Namespace,
+		},
+	}
+
+	if _, err := controllerutil.CreateOrPatch(ctx, r.Client, sp, func() error {
+		if err := controllerutil.SetControllerReference(ctx.NetworkPolicy, sp, r.Client.Scheme()); err != nil {
+			return err
+		}
+		sp.Spec = nsxSpec
+		return nil
+	}); err != nil {
+		return false, "", err
+	}
+
+	for _, c := range sp.Status.Conditions {
+		if c.Type == nsxv1alpha1.Ready {
+			return c.Status == corev1.ConditionTrue, c.Message, nil
+		}
+	}
+
+	return false, "", nil
+}
@@ -0,0 +1,65 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinenetworkpolicy_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+
+		np    *vmopv1.VirtualMachineNetworkPolicy
+		npKey client.ObjectKey
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		np = builder.DummyVirtualMachineNetworkPolicy(ctx.Namespace, "dummy-np")
+		npKey = client.ObjectKeyFromObject(np)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	When("the network provider does not support network policies", func() {
+		It("marks the policy as not supported", func() {
+			Expect(ctx.Client.Create(ctx, np)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				obj := &vmopv1.VirtualMachineNetworkPolicy{}
+				g.Expect(ctx.Client.Get(ctx, npKey, obj)).To(Succeed())
+				g.Expect(conditions.GetReason(obj, vmopv1.VirtualMachineNetworkPolicyReadyCondition)).To(
+					Equal(vmopv1.VirtualMachineNetworkPolicyNotSupportedReason))
+			}).Should(Succeed())
+
+			Expect(ctx.Client.Delete(ctx, np)).To(Succeed())
+		})
+	})
+}
@@ -0,0 +1,300 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinenetworkpolicy_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
+	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinenetworkpolicy"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		),
+		unitTestsReconcile,
+	)
+	Describe(
+		"SecurityPolicySpecs",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		),
+		unitTestsSecurityPolicySpecs,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+		reconciler  *virtualmachinenetworkpolicy.Reconciler
+
+		npCtx *pkgctx.VirtualMachineNetworkPolicyContext
+		np    *vmopv1.VirtualMachineNetworkPolicy
+
+		networkProviderType pkgcfg.NetworkProviderType
+	)
+
+	BeforeEach(func() {
+		np = builder.DummyVirtualMachineNetworkPolicy("dummy-ns", "dummy-np")
+		initObjects = append(initObjects, np)
+		networkProviderType = pkgcfg.NetworkProviderTypeVPC
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.NetworkProviderType = networkProviderType
+		})
+
+		reconciler = virtualmachinenetworkpolicy.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+		)
+
+		npCtx = &pkgctx.VirtualMachineNetworkPolicyContext{
+			Context:       ctx,
+			Logger:        ctx.Logger.WithName(np.Namespace).WithName(np.Name),
+			NetworkPolicy: np,
+		}
+	})
+
+	AfterEach(func() {
+		ctx = nil
+		initObjects = nil
+		npCtx = nil
+		reconciler = nil
+	})
+
+	When("the network provider is VPC", func() {
+		getSecurityPolicy := func(name string) *vpcv1alpha1.SecurityPolicy {
+			sp := &vpcv1alpha1.SecurityPolicy{}
+			Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: np.Namespace, Name: name}, sp)).To(Succeed())
+			return sp
+		}
+
+		It("creates the security policies", func() {
+			Expect(reconciler.ReconcileNormal(npCtx)).To(Succeed())
+
+			allow := getSecurityPolicy(np.Name + "-allow")
+			Expect(allow.OwnerReferences).To(HaveLen(1))
+			Expect(allow.OwnerReferences[0].Name).To(Equal(np.Name))
+			Expect(allow.Spec.Priority).To(Equal(virtualmachinenetworkpolicy.AllowPriority))
+			Expect(allow.Spec.Rules).To(HaveLen(1))
+
+			isolation := getSecurityPolicy(np.Name + "-isolation")
+			Expect(isolation.OwnerReferences).To(HaveLen(1))
+			Expect(isolation.Spec.Priority).To(Equal(virtualmachinenetworkpolicy.IsolationPriority))
+			Expect(isolation.Spec.Rules).To(HaveLen(1))
+
+			By("the policy is not ready until the security policies are", func() {
+				c := conditions.Get(np, vmopv1.VirtualMachineNetworkPolicyReadyCondition)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineNetworkPolicySecurityPolicyNotReadyReason))
+			})
+		})
+
+		When("the security policies are ready", func() {
+			JustBeforeEach(func() {
+				Expect(reconciler.ReconcileNormal(npCtx)).To(Succeed())
+
+				for _, name := range []string{np.Name + "-allow", np.Name + "-isolation"} {
+					sp := getSecurityPolicy(name)
+					sp.Status.Conditions = []vpcv1alpha1.Condition{
+						{
+							Type:   vpcv1alpha1.Ready,
+							Status: corev1.ConditionTrue,
+						},
+					}
+					Expect(ctx.Client.Status().Update(ctx, sp)).To(Succeed())
+				}
+			})
+
+			It("marks the policy as ready", func() {
+				Expect(reconciler.ReconcileNormal(npCtx)).To(Succeed())
+				Expect(conditions.IsTrue(np, vmopv1.VirtualMachineNetworkPolicyReadyCondition)).To(BeTrue())
+			})
+		})
+
+		When("the rules are updated", func() {
+			JustBeforeEach(func() {
+				Expect(reconciler.ReconcileNormal(npCtx)).To(Succeed())
+			})
+
+			It("updates the security policies", func() {
+				np.Spec.Ingress = append(np.Spec.Ingress, vmopv1.VirtualMachineNetworkPolicyIngressRule{})
+				Expect(reconciler.ReconcileNormal(npCtx)).To(Succeed())
+				Expect(getSecurityPolicy(np.Name + "-allow").Spec.Rules).To(HaveLen(2))
+			})
+		})
+	})
+
+	When("the network provider is NSX-T", func() {
+		BeforeEach(func() {
+			networkProviderType = pkgcfg.NetworkProviderTypeNSXT
+		})
+
+		It("creates the security policies", func() {
+			Expect(reconciler.ReconcileNormal(npCtx)).To(Succeed())
+
+			for _, name := range []string{np.Name + "-allow", np.Name + "-isolation"} {
+				sp := &nsxv1alpha1.SecurityPolicy{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: np.Namespace, Name: name}, sp)).To(Succeed())
+				Expect(sp.OwnerReferences).To(HaveLen(1))
+				Expect(sp.Spec.AppliedTo).To(HaveLen(1))
+				Expect(sp.Spec.AppliedTo[0].VMSelector).To(Equal(&np.Spec.VMSelector))
+			}
+		})
+	})
+
+	When("the network provider is VDS", func() {
+		BeforeEach(func() {
+			networkProviderType = pkgcfg.NetworkProviderTypeVDS
+		})
+
+		It("marks the policy as not supported", func() {
+			Expect(reconciler.ReconcileNormal(npCtx)).To(Succeed())
+
+			c := conditions.Get(np, vmopv1.VirtualMachineNetworkPolicyReadyCondition)
+			Expect(c).ToNot(BeNil())
+			Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			Expect(c.Reason).To(Equal(vmopv1.VirtualMachineNetworkPolicyNotSupportedReason))
+
+			list := &vpcv1alpha1.SecurityPolicyList{}
+			Expect(ctx.Client.List(ctx, list)).To(Succeed())
+			Expect(list.Items).To(BeEmpty())
+		})
+	})
+}
+
+func unitTestsSecurityPolicySpecs() {
+	var (
+		np *vmopv1.VirtualMachineNetworkPolicy
+	)
+
+	BeforeEach(func() {
+		np = builder.DummyVirtualMachineNetworkPolicy("dummy-ns", "dummy-np")
+	})
+
+	It("translates the ingress rules", func() {
+		allow, isolation := virtualmachinenetworkpolicy.SecurityPolicySpecs(np)
+
+		Expect(allow.AppliedTo).To(Equal([]vpcv1alpha1.SecurityPolicyTarget{{VMSelector: &np.Spec.VMSelector}}))
+		Expect(allow.Rules).To(Equal([]vpcv1alpha1.SecurityPolicyRule{
+			{
+				Name:      "ingress-0",
+				Action:    ptr.To(vpcv1alpha1.RuleActionAllow),
+				Direction: ptr.To(vpcv1alpha1.RuleDirectionIn),
+				Sources: []vpcv1alpha1.SecurityPolicyPeer{
+					{
+						VMSelector: np.Spec.Ingress[0].From[0].VMSelector,
+					},
+				},
+				Ports: []vpcv1alpha1.SecurityPolicyPort{
+					{
+						Protocol: corev1.ProtocolTCP,
+						Port:     intstr.FromInt32(5432),
+					},
+				},
+			},
+		}))
+
+		Expect(isolation.AppliedTo).To(Equal(allow.AppliedTo))
+		Expect(isolation.Rules).To(Equal([]vpcv1alpha1.SecurityPolicyRule{
+			{
+				Name:      "ingress-isolation",
+				Action:    ptr.To(vpcv1alpha1.RuleActionDrop),
+				Direction: ptr.To(vpcv1alpha1.RuleDirectionIn),
+			},
+		}))
+	})
+
+	It("applies to egress when there are egress rules", func() {
+		np.Spec.Egress = []vmopv1.VirtualMachineNetworkPolicyEgressRule{
+			{
+				To: []vmopv1.VirtualMachineNetworkPolicyPeer{
+					{
+						IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{
+							CIDR: "10.0.0.0/8",
+						},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{},
+					},
+				},
+				Ports: []vmopv1.VirtualMachineNetworkPolicyPort{
+					{
+						Protocol: "UDP",
+						Port:     ptr.To(intstr.FromInt32(5000)),
+						EndPort:  ptr.To[int32](5010),
+					},
+				},
+			},
+		}
+
+		allow, isolation := virtualmachinenetworkpolicy.SecurityPolicySpecs(np)
+
+		Expect(allow.Rules).To(HaveLen(2))
+		Expect(allow.Rules[1]).To(Equal(vpcv1alpha1.SecurityPolicyRule{
+			Name:      "egress-0",
+			Action:    ptr.To(vpcv1alpha1.RuleActionAllow),
+			Direction: ptr.To(vpcv1alpha1.RuleDirectionOut),
+			Destinations: []vpcv1alpha1.SecurityPolicyPeer{
+				{
+					IPBlocks: []vpcv1alpha1.IPBlock{{CIDR: "10.0.0.0/8"}},
+				},
+				{
+					VMSelector:        &metav1.LabelSelector{},
+					NamespaceSelector: &metav1.LabelSelector{},
+				},
+			},
+			Ports: []vpcv1alpha1.SecurityPolicyPort{
+				{
+					Protocol: corev1.ProtocolUDP,
+					Port:     intstr.FromInt32(5000),
+					EndPort:  5010,
+				},
+			},
+		}))
+
+		Expect(isolation.Rules).To(HaveLen(2))
+		Expect(isolation.Rules[1].Name).To(Equal("egress-isolation"))
+		Expect(isolation.Rules[1].Direction).To(Equal(ptr.To(vpcv1alpha1.RuleDirectionOut)))
+	})
+
+	It("isolates only the egress traffic when the policy type is Egress", func() {
+		np.Spec.PolicyTypes = []vmopv1.VirtualMachineNetworkPolicyType{
+			vmopv1.VirtualMachineNetworkPolicyTypeEgress,
+		}
+
+		allow, isolation := virtualmachinenetworkpolicy.SecurityPolicySpecs(np)
+
+		Expect(allow.Rules).To(BeEmpty())
+		Expect(isolation.Rules).To(HaveLen(1))
+		Expect(isolation.Rules[0].Name).To(Equal("egress-isolation"))
+	})
+}
@@ -50,6 +50,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/prober"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 var (
@@ -194,7 +195,7 @@ type Reconciler struct {
 	Prober prober.Manager
 }
 
-// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=create;get;list;watch;update;patch;
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=create;get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets/status,verbs=get;update;patch
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -370,9 +371,6 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineReplicaSetContext
 			"vm", vm,
 			"key", vmopv1.VirtualMachineReplicaSetNameLabel,
 			"value", util.MustFormatValue(ctx.ReplicaSet.Name))
-
-		// TODO: Propagate the Deployment label from Deployment to replica set to VirtualMachine if
-		// it is set on the replica set.
 	}
 
 	// TODO: Handle in place propagation of fields to machines before syncing replicas.
@@ -381,7 +379,7 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineReplicaSetContext
 
 	// Update the status of the VirtualMachineReplicaSet even in case of error
 	// since syncing might have resulted in replicas being added or removed.
-	r.updateStatus(ctx, ctx.ReplicaSet, filteredVMs)
+	r.updateStatus(ctx, ctx.ReplicaSet, filteredVMs, syncErr)
 
 	if syncErr != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to sync VirtualMachineReplicaSet replicas: %w", syncErr)
@@ -392,11 +390,20 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineReplicaSetContext
 		replicas = *ctx.ReplicaSet.Spec.Replicas
 	}
 
-	// Queue faster reconciles until all replicas are available.
+	// Queue faster reconciles until all replicas are ready.
 	if ctx.ReplicaSet.Status.ReadyReplicas != replicas {
 		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
 	}
 
+	// Requeue once the ready replicas are expected to have been ready for
+	// MinReadySeconds, since nothing else triggers a reconcile when a replica
+	// becomes available.
+	if minReadySeconds := ctx.ReplicaSet.Spec.MinReadySeconds; minReadySeconds > 0 &&
+		ctx.ReplicaSet.Status.AvailableReplicas != replicas {
+
+		return ctrl.Result{RequeueAfter: time.Duration(minReadySeconds) * time.Second}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -428,8 +435,6 @@ func (r *Reconciler) getNewVirtualMachine(rs *vmopv1.VirtualMachineReplicaSet) *
 
 	vm.Annotations = getAnnotationsFromReplicaSet(rs)
 
-	// TODO: Propagate the VirtualMachineDeploymentNameLabel from VirtualMachineReplicaSet to VirtualMachines if it exists.
-
 	return vm
 }
 
@@ -442,6 +447,12 @@ func getLabelsFromVMReplicaSet(rs *vmopv1.VirtualMachineReplicaSet) map[string]s
 	// Ensure that the ReplicaSetLabel is always present.
 	labels[vmopv1.VirtualMachineReplicaSetNameLabel] = util.MustFormatValue(rs.Name)
 
+	// Propagate the DeploymentLabel if the replica set is owned by a
+	// VirtualMachineDeployment.
+	if v, ok := rs.Labels[vmopv1.VirtualMachineDeploymentNameLabel]; ok {
+		labels[vmopv1.VirtualMachineDeploymentNameLabel] = v
+	}
+
 	return labels
 }
 
@@ -511,7 +522,7 @@ func (r *Reconciler) syncReplicas(
 			"currentReplicas", len(vms),
 			"desiredReplicas", *(rs.Spec.Replicas),
 			"vmsToBeCreated", diff,
-			"deletePolicy", "oldestFirst",
+			"deletePolicy", rs.Spec.DeletePolicy,
 		)
 
 		deletePriorityFunc, err := getDeletePriorityFunc(rs)
@@ -591,11 +602,13 @@ func (r *Reconciler) waitForVMCreation(ctx *pkgctx.VirtualMachineReplicaSetConte
 	return nil
 }
 
-// updateStatus updates the Status field of the VirtualMachineReplicaSet.
+// updateStatus updates the Status field of the VirtualMachineReplicaSet. The
+// syncErr is the error, if any, that occurred while syncing the replicas.
 func (r *Reconciler) updateStatus(
 	ctx *pkgctx.VirtualMachineReplicaSetContext,
 	rs *vmopv1.VirtualMachineReplicaSet,
-	filteredVMs []*vmopv1.VirtualMachine) {
+	filteredVMs []*vmopv1.VirtualMachine,
+	syncErr error) {
 
 	newStatus := rs.Status.DeepCopy()
 
@@ -604,7 +617,9 @@ func (r *Reconciler) updateStatus(
 	// in the template.
 	fullyLabeledReplicasCount := 0
 	readyReplicasCount := 0
+	availableReplicasCount := 0
 	desiredReplicas := *rs.Spec.Replicas
+	now := time.Now()
 	// Create a selector from labels since that is significantly faster at scale
 	// and initializing a selector directly.
 	templateLabel := labels.Set(rs.Spec.Template.Labels).AsSelectorPreValidated()
@@ -614,21 +629,31 @@ func (r *Reconciler) updateStatus(
 			fullyLabeledReplicasCount++
 		}
 
-		// TODO: Figure out an equivalent of Ready condition on the VirtualMachine
-		// resource so we can populate the ready and available replicas in the Status.
-		// For now, we count all replicas as ready and available.
-		readyReplicasCount++
+		if vmopv1util.IsReady(*vm) {
+			readyReplicasCount++
 
+			if vmopv1util.IsAvailable(*vm, rs.Spec.MinReadySeconds, now) {
+				availableReplicasCount++
+			}
+		}
 	}
 
 	newStatus.Replicas = int32(len(filteredVMs))                      //nolint:gosec // disable G115
 	newStatus.FullyLabeledReplicas = int32(fullyLabeledReplicasCount) //nolint:gosec // disable G115
 	newStatus.ReadyReplicas = int32(readyReplicasCount)               //nolint:gosec // disable G115
+	newStatus.AvailableReplicas = int32(availableReplicasCount)       //nolint:gosec // disable G115
+
+	// The selector is exposed via the scale subresource in its string form.
+	if selector, err := metav1.LabelSelectorAsSelector(rs.Spec.Selector); err == nil {
+		newStatus.Selector = selector.String()
+	}
 
 	// Copy the newly calculated status into the VirtualMachineReplicaSet.
 	if rs.Status.Replicas != newStatus.Replicas ||
 		rs.Status.FullyLabeledReplicas != newStatus.FullyLabeledReplicas ||
 		rs.Status.ReadyReplicas != newStatus.ReadyReplicas ||
+		rs.Status.AvailableReplicas != newStatus.AvailableReplicas ||
+		rs.Status.Selector != newStatus.Selector ||
 		rs.Generation != rs.Status.ObservedGeneration {
 
 		ctx.Logger.Info("Updating status",
@@ -639,6 +664,10 @@ func (r *Reconciler) updateStatus(
 			"fullyLabeledReplicaCountNew", newStatus.FullyLabeledReplicas,
 			"readyReplicasOld", rs.Status.ReadyReplicas,
 			"readyReplicasNew", newStatus.ReadyReplicas,
+			"availableReplicasOld", rs.Status.AvailableReplicas,
+			"availableReplicasNew", newStatus.AvailableReplicas,
+			"selectorOld", rs.Status.Selector,
+			"selectorNew", newStatus.Selector,
 			"observedGenerationOld", rs.Status.ObservedGeneration,
 			"observedGenerationNew", newStatus.ObservedGeneration)
 
@@ -684,5 +713,56 @@ func (r *Reconciler) updateStatus(
 		// This means that we have sufficient number of VirtualMachine objects.
 		conditions.MarkTrue(rs, vmopv1.VirtualMachinesCreatedCondition)
 	}
+
+	updateReplicaFailureCondition(rs, filteredVMs, syncErr)
+
 	// TODO: Set aggregate condition based on the condition of the individual Virtual Machines
 }
+
+// updateReplicaFailureCondition sets the ReplicaFailure condition if the
+// replicas could not be synced or one of the replicas failed to be created on
+// the underlying infrastructure, and removes the condition otherwise.
+func updateReplicaFailureCondition(
+	rs *vmopv1.VirtualMachineReplicaSet,
+	filteredVMs []*vmopv1.VirtualMachine,
+	syncErr error) {
+
+	if syncErr != nil {
+		reason := vmopv1.VirtualMachineReplicaSetFailedCreateReason
+		if rs.Spec.Replicas != nil && len(filteredVMs) > int(*rs.Spec.Replicas) {
+			reason = vmopv1.VirtualMachineReplicaSetFailedDeleteReason
+		}
+		conditions.Set(rs, &metav1.Condition{
+			Type:    vmopv1.VirtualMachineReplicaSetReplicaFailure,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: syncErr.Error(),
+		})
+		return
+	}
+
+	for _, vm := range filteredVMs {
+		if !vm.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// A VM that failed to be placed, or to be created, is not able to
+		// become ready until the failure is addressed.
+		for _, t := range []string{
+			vmopv1.VirtualMachineConditionPlacementReady,
+			vmopv1.VirtualMachineConditionCreated,
+		} {
+			if c := conditions.Get(vm, t); c != nil && c.Status == metav1.ConditionFalse {
+				conditions.Set(rs, &metav1.Condition{
+					Type:    vmopv1.VirtualMachineReplicaSetReplicaFailure,
+					Status:  metav1.ConditionTrue,
+					Reason:  vmopv1.VirtualMachineReplicaSetFailedCreateReason,
+					Message: fmt.Sprintf("VirtualMachine %q: %s: %s", vm.Name, c.Reason, c.Message),
+				})
+				return
+			}
+		}
+	}
+
+	conditions.Delete(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure)
+}
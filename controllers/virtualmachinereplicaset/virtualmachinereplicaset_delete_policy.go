@@ -21,9 +21,14 @@ limitations under the License.
 package virtualmachinereplicaset
 
 import (
+	"fmt"
+	"math"
 	"sort"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 type (
@@ -33,18 +38,65 @@ type (
 
 const (
 	mustDelete    deletePriority = 100.0
-	couldDelete   deletePriority = 50.0
+	shouldDelete  deletePriority = 75.0
+	betterDelete  deletePriority = 50.0
+	couldDelete   deletePriority = 20.0
 	mustNotDelete deletePriority = 0.0
+
+	secondsPerTenDays float64 = 864000
 )
 
-func randomDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+// requiredDeletePriority returns the priority of VMs that are deleted first
+// regardless of the delete policy, i.e. VMs that are already being deleted
+// and VMs that have the delete annotation. The second return value is false
+// if the VM is not one of them.
+func requiredDeletePriority(vm *vmopv1.VirtualMachine) (deletePriority, bool) {
 	if !vm.DeletionTimestamp.IsZero() {
-		return mustDelete
+		return mustDelete, true
 	}
+	if _, ok := vm.Annotations[vmopv1.VirtualMachineReplicaSetDeleteVMAnnotation]; ok {
+		return shouldDelete, true
+	}
+	return mustNotDelete, false
+}
 
-	// TODO: when we support a Ready/healthy condition, that should have a
-	// higher priority for deletion.  For now, all VMs that are not marked
-	// for deletion get the same priority.
+// oldestDeletePriority maps the creation timestamp onto the 0-50 priority
+// range.
+func oldestDeletePriority(vm *vmopv1.VirtualMachine) deletePriority {
+	if p, ok := requiredDeletePriority(vm); ok {
+		return p
+	}
+	if vm.CreationTimestamp.Time.IsZero() {
+		return mustNotDelete
+	}
+	d := metav1.Now().Sub(vm.CreationTimestamp.Time)
+	if d.Seconds() < 0 {
+		return mustNotDelete
+	}
+	return deletePriority(float64(betterDelete) * (1.0 - math.Exp(-d.Seconds()/secondsPerTenDays)))
+}
+
+func newestDeletePriority(vm *vmopv1.VirtualMachine) deletePriority {
+	if p, ok := requiredDeletePriority(vm); ok {
+		return p
+	}
+	return betterDelete - oldestDeletePriority(vm)
+}
+
+func notReadyFirstDeletePriority(vm *vmopv1.VirtualMachine) deletePriority {
+	if p, ok := requiredDeletePriority(vm); ok {
+		return p
+	}
+	if !vmopv1util.IsReady(*vm) {
+		return betterDelete
+	}
+	return couldDelete
+}
+
+func randomDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+	if p, ok := requiredDeletePriority(vm); ok {
+		return p
+	}
 	return couldDelete
 }
 
@@ -82,8 +134,23 @@ func getMachinesToDeletePrioritized(filteredMachines []*vmopv1.VirtualMachine, d
 	return sortable.machines[:diff]
 }
 
-// TODO(muchhals): Needed when we add delete priority field.
-func getDeletePriorityFunc(_ *vmopv1.VirtualMachineReplicaSet) (deletePriorityFunc, error) {
-	// TODO: Expand when we start supporting other delete policies.
-	return randomDeletePolicy, nil
+func getDeletePriorityFunc(rs *vmopv1.VirtualMachineReplicaSet) (deletePriorityFunc, error) {
+	// Map the Spec.DeletePolicy value to the appropriate delete priority function.
+	switch dp := rs.Spec.DeletePolicy; dp {
+	case vmopv1.RandomVirtualMachineReplicaSetDeletePolicy, "":
+		return randomDeletePolicy, nil
+	case vmopv1.NewestVirtualMachineReplicaSetDeletePolicy:
+		return newestDeletePriority, nil
+	case vmopv1.OldestVirtualMachineReplicaSetDeletePolicy:
+		return oldestDeletePriority, nil
+	case vmopv1.NotReadyFirstVirtualMachineReplicaSetDeletePolicy:
+		return notReadyFirstDeletePriority, nil
+	default:
+		return nil, fmt.Errorf("unsupported delete policy %q, must be one of %q, %q, %q, or %q",
+			dp,
+			vmopv1.RandomVirtualMachineReplicaSetDeletePolicy,
+			vmopv1.NewestVirtualMachineReplicaSetDeletePolicy,
+			vmopv1.OldestVirtualMachineReplicaSetDeletePolicy,
+			vmopv1.NotReadyFirstVirtualMachineReplicaSetDeletePolicy)
+	}
 }
@@ -0,0 +1,167 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+)
+
+var _ = Describe(
+	"Delete policy",
+	Label(testlabels.Controller, testlabels.API),
+	func() {
+		var (
+			now time.Time
+
+			deletingVM   *vmopv1.VirtualMachine
+			annotatedVM  *vmopv1.VirtualMachine
+			oldReadyVM   *vmopv1.VirtualMachine
+			newReadyVM   *vmopv1.VirtualMachine
+			notReadyVM   *vmopv1.VirtualMachine
+			allVMs       []*vmopv1.VirtualMachine
+			rs           *vmopv1.VirtualMachineReplicaSet
+			vmsToDelete  []*vmopv1.VirtualMachine
+			deletePolicy string
+		)
+
+		newVM := func(name string, age time.Duration, ready bool) *vmopv1.VirtualMachine {
+			vm := &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				},
+				Spec: vmopv1.VirtualMachineSpec{
+					ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{},
+				},
+				Status: vmopv1.VirtualMachineStatus{
+					PowerState: vmopv1.VirtualMachinePowerStateOn,
+				},
+			}
+			if ready {
+				conditions.MarkTrue(vm, vmopv1.ReadyConditionType)
+			} else {
+				conditions.MarkFalse(vm, vmopv1.ReadyConditionType, "NotReady", "")
+			}
+			return vm
+		}
+
+		BeforeEach(func() {
+			now = time.Now()
+
+			deletingVM = newVM("deleting", time.Hour, true)
+			deletingVM.DeletionTimestamp = &metav1.Time{Time: now}
+			annotatedVM = newVM("annotated", 2*time.Hour, true)
+			annotatedVM.Annotations = map[string]string{
+				vmopv1.VirtualMachineReplicaSetDeleteVMAnnotation: "",
+			}
+			oldReadyVM = newVM("old-ready", 72*time.Hour, true)
+			newReadyVM = newVM("new-ready", time.Minute, true)
+			notReadyVM = newVM("not-ready", 24*time.Hour, false)
+
+			// Use an order that matches none of the expected results.
+			allVMs = []*vmopv1.VirtualMachine{
+				newReadyVM,
+				oldReadyVM,
+				notReadyVM,
+				annotatedVM,
+				deletingVM,
+			}
+
+			rs = &vmopv1.VirtualMachineReplicaSet{}
+			deletePolicy = ""
+		})
+
+		JustBeforeEach(func() {
+			rs.Spec.DeletePolicy = deletePolicy
+			fn, err := getDeletePriorityFunc(rs)
+			Expect(err).ToNot(HaveOccurred())
+			vmsToDelete = getMachinesToDeletePrioritized(allVMs, 3, fn)
+		})
+
+		When("the policy is not specified", func() {
+			It("should prioritize the deleting and annotated VMs", func() {
+				Expect(vmsToDelete).To(HaveLen(3))
+				Expect(vmsToDelete[0]).To(Equal(deletingVM))
+				Expect(vmsToDelete[1]).To(Equal(annotatedVM))
+			})
+		})
+
+		When("the policy is Random", func() {
+			BeforeEach(func() {
+				deletePolicy = vmopv1.RandomVirtualMachineReplicaSetDeletePolicy
+			})
+			It("should prioritize the deleting and annotated VMs", func() {
+				Expect(vmsToDelete).To(HaveLen(3))
+				Expect(vmsToDelete[0]).To(Equal(deletingVM))
+				Expect(vmsToDelete[1]).To(Equal(annotatedVM))
+			})
+		})
+
+		When("the policy is Oldest", func() {
+			BeforeEach(func() {
+				deletePolicy = vmopv1.OldestVirtualMachineReplicaSetDeletePolicy
+			})
+			It("should delete the oldest VM", func() {
+				Expect(vmsToDelete).To(Equal([]*vmopv1.VirtualMachine{deletingVM, annotatedVM, oldReadyVM}))
+			})
+		})
+
+		When("the policy is Newest", func() {
+			BeforeEach(func() {
+				deletePolicy = vmopv1.NewestVirtualMachineReplicaSetDeletePolicy
+			})
+			It("should delete the newest VM", func() {
+				Expect(vmsToDelete).To(Equal([]*vmopv1.VirtualMachine{deletingVM, annotatedVM, newReadyVM}))
+			})
+		})
+
+		When("the policy is NotReadyFirst", func() {
+			BeforeEach(func() {
+				deletePolicy = vmopv1.NotReadyFirstVirtualMachineReplicaSetDeletePolicy
+			})
+			It("should delete the VM that is not ready", func() {
+				Expect(vmsToDelete).To(Equal([]*vmopv1.VirtualMachine{deletingVM, annotatedVM, notReadyVM}))
+			})
+
+			When("a VM does not have a Ready condition", func() {
+				BeforeEach(func() {
+					notReadyVM.Status.Conditions = nil
+				})
+				It("should delete the VM that is not ready", func() {
+					Expect(vmsToDelete).To(Equal([]*vmopv1.VirtualMachine{deletingVM, annotatedVM, notReadyVM}))
+				})
+			})
+
+			When("the VMs do not have a readiness probe", func() {
+				BeforeEach(func() {
+					for _, vm := range allVMs {
+						vm.Spec.ReadinessProbe = nil
+						vm.Status.Conditions = nil
+					}
+					newReadyVM.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				})
+				It("should delete the VM that is not powered on", func() {
+					Expect(vmsToDelete).To(Equal([]*vmopv1.VirtualMachine{deletingVM, annotatedVM, newReadyVM}))
+				})
+			})
+		})
+
+		When("the policy is not supported", func() {
+			It("should return an error", func() {
+				rs.Spec.DeletePolicy = "Unsupported"
+				_, err := getDeletePriorityFunc(rs)
+				Expect(err).To(MatchError(ContainSubstring(`unsupported delete policy "Unsupported"`)))
+			})
+		})
+	})
@@ -0,0 +1,142 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+var _ = Describe(
+	"ReplicaFailure condition",
+	Label(testlabels.Controller, testlabels.API),
+	func() {
+		var (
+			rs      *vmopv1.VirtualMachineReplicaSet
+			vms     []*vmopv1.VirtualMachine
+			syncErr error
+		)
+
+		newVM := func(name string) *vmopv1.VirtualMachine {
+			return &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			rs = &vmopv1.VirtualMachineReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "rs",
+					Namespace: "default",
+				},
+				Spec: vmopv1.VirtualMachineReplicaSetSpec{
+					Replicas: ptr.To[int32](2),
+				},
+			}
+			vms = []*vmopv1.VirtualMachine{newVM("vm-1"), newVM("vm-2")}
+			syncErr = nil
+		})
+
+		JustBeforeEach(func() {
+			updateReplicaFailureCondition(rs, vms, syncErr)
+		})
+
+		When("the replicas are synced and created", func() {
+			BeforeEach(func() {
+				conditions.MarkFalse(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure, "Stale", "")
+			})
+
+			It("removes the condition", func() {
+				Expect(conditions.Get(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure)).To(BeNil())
+			})
+		})
+
+		When("creating a replica fails", func() {
+			BeforeEach(func() {
+				vms = vms[:1]
+				syncErr = errors.New("quota exceeded")
+			})
+
+			It("sets the condition with the FailedCreate reason", func() {
+				c := conditions.Get(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineReplicaSetFailedCreateReason))
+				Expect(c.Message).To(Equal("quota exceeded"))
+			})
+		})
+
+		When("deleting a replica fails", func() {
+			BeforeEach(func() {
+				vms = append(vms, newVM("vm-3"))
+				syncErr = errors.New("forbidden")
+			})
+
+			It("sets the condition with the FailedDelete reason", func() {
+				c := conditions.Get(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineReplicaSetFailedDeleteReason))
+			})
+		})
+
+		When("a replica failed placement", func() {
+			BeforeEach(func() {
+				conditions.MarkFalse(
+					vms[1],
+					vmopv1.VirtualMachineConditionPlacementReady,
+					"NotReady",
+					"no placement candidates available")
+			})
+
+			It("sets the condition with the FailedCreate reason", func() {
+				c := conditions.Get(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineReplicaSetFailedCreateReason))
+				Expect(c.Message).To(Equal(`VirtualMachine "vm-2": NotReady: no placement candidates available`))
+			})
+
+			When("the replica is being deleted", func() {
+				BeforeEach(func() {
+					vms[1].DeletionTimestamp = ptr.To(metav1.NewTime(time.Now()))
+				})
+
+				It("removes the condition", func() {
+					Expect(conditions.Get(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure)).To(BeNil())
+				})
+			})
+		})
+
+		When("a replica could not be created due to too many creates", func() {
+			BeforeEach(func() {
+				conditions.MarkFalse(
+					vms[0],
+					vmopv1.VirtualMachineConditionCreated,
+					vmopv1.VirtualMachineTooManyCreatesReason,
+					"too many creates")
+			})
+
+			It("sets the condition with the FailedCreate reason", func() {
+				c := conditions.Get(rs, vmopv1.VirtualMachineReplicaSetReplicaFailure)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineReplicaSetFailedCreateReason))
+				Expect(c.Message).To(ContainSubstring(vmopv1.VirtualMachineTooManyCreatesReason))
+			})
+		})
+	})
@@ -8,7 +8,6 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 
@@ -65,13 +64,6 @@ type LoadbalancerProvider interface {
 	GetToBeRemovedServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error)
 }
 
-func GetLoadbalancerProviderByType(mgr manager.Manager, providerType string) (LoadbalancerProvider, error) {
-	if providerType == NSXTLoadBalancer {
-		return NsxtLoadBalancerProvider(), nil
-	}
-	return NoopLoadbalancerProvider{}, nil
-}
-
 type NoopLoadbalancerProvider struct{}
 
 func (NoopLoadbalancerProvider) EnsureLoadBalancer(context.Context, *vmopv1.VirtualMachineService) error {
@@ -113,7 +105,7 @@ func (nl *NsxtLoadbalancerProvider) GetServiceLabels(ctx context.Context, vmServ
 
 	// When externalTrafficPolicy is set to Local, skip kube-proxy for the
 	// target Service
-	if etp := vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; corev1.ServiceExternalTrafficPolicyType(etp) == corev1.ServiceExternalTrafficPolicyTypeLocal {
+	if isExternalTrafficPolicyLocal(vmService) {
 		res[LabelServiceProxyName] = NSXTServiceProxy
 	}
 
@@ -127,13 +119,24 @@ func (nl *NsxtLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Cont
 
 	// When there is no externalTrafficPolicy configured or it's not Local,
 	// remove the service-proxy label
-	if etp := vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; corev1.ServiceExternalTrafficPolicyType(etp) != corev1.ServiceExternalTrafficPolicyTypeLocal {
+	if !isExternalTrafficPolicyLocal(vmService) {
 		res[LabelServiceProxyName] = NSXTServiceProxy
 	}
 
 	return res, nil
 }
 
+// isExternalTrafficPolicyLocal returns true if the effective
+// externalTrafficPolicy of the VirtualMachineService is Local. The policy in
+// the spec takes precedence over the annotation.
+func isExternalTrafficPolicyLocal(vmService *vmopv1.VirtualMachineService) bool {
+	if etp := vmService.Spec.ExternalTrafficPolicy; etp != "" {
+		return etp == vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+	}
+	etp := vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]
+	return corev1.ServiceExternalTrafficPolicyType(etp) == corev1.ServiceExternalTrafficPolicyTypeLocal
+}
+
 // GetServiceAnnotations provides the intended NSX-T specific annotations on
 // Service. The responsibility is left to the caller to actually set them.
 func (nl *NsxtLoadbalancerProvider) GetServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
@@ -0,0 +1,81 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package providers
+
+import (
+	"context"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+const (
+	GenericLoadBalancer = "generic-lb"
+
+	// MetalLBLoadBalancerIPsAnnotationKey is the annotation MetalLB uses to
+	// request specific load balancer IPs for a Service.
+	MetalLBLoadBalancerIPsAnnotationKey = "metallb.universe.tf/loadBalancerIPs"
+	// KubeVIPLoadBalancerIPsAnnotationKey is the annotation kube-vip uses to
+	// request specific load balancer IPs for a Service.
+	KubeVIPLoadBalancerIPsAnnotationKey = "kube-vip.io/loadbalancerIPs"
+)
+
+// GenericLoadbalancerProvider is a LoadbalancerProvider for load balancers,
+// like MetalLB or kube-vip, that implement Services of type LoadBalancer
+// in-cluster, such as on VDS networking where there is no NSX load balancer.
+type GenericLoadbalancerProvider struct {
+}
+
+// GenericLoadBalancerProvider returns a GenericLoadbalancerProvider instance.
+func GenericLoadBalancerProvider() *GenericLoadbalancerProvider {
+	return &GenericLoadbalancerProvider{}
+}
+
+func (gl *GenericLoadbalancerProvider) EnsureLoadBalancer(ctx context.Context, vmService *vmopv1.VirtualMachineService) error {
+	return nil
+}
+
+// GetServiceLabels returns no labels since the Service is implemented by the
+// cluster's load balancer and service proxy.
+func (gl *GenericLoadbalancerProvider) GetServiceLabels(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// GetToBeRemovedServiceLabels provides the labels that would cause the Service
+// to be implemented by another service proxy. The responsibility is left to
+// the caller to actually clear them.
+func (gl *GenericLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return map[string]string{
+		LabelServiceProxyName: NSXTServiceProxy,
+	}, nil
+}
+
+// GetServiceAnnotations provides the annotations that request the
+// VirtualMachineService's LoadBalancerIP from the load balancer, since
+// Service.Spec.LoadBalancerIP is deprecated. The responsibility is left to the
+// caller to actually set them.
+func (gl *GenericLoadbalancerProvider) GetServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	res := make(map[string]string)
+
+	if ip := vmService.Spec.LoadBalancerIP; ip != "" {
+		res[MetalLBLoadBalancerIPsAnnotationKey] = ip
+		res[KubeVIPLoadBalancerIPsAnnotationKey] = ip
+	}
+
+	return res, nil
+}
+
+// GetToBeRemovedServiceAnnotations provides the to be removed load balancer
+// IP annotations on Service. The responsibility is left to the caller to
+// actually clear them.
+func (gl *GenericLoadbalancerProvider) GetToBeRemovedServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	res := make(map[string]string)
+
+	if vmService.Spec.LoadBalancerIP == "" {
+		res[MetalLBLoadBalancerIPsAnnotationKey] = ""
+		res[KubeVIPLoadBalancerIPsAnnotationKey] = ""
+	}
+
+	return res, nil
+}
@@ -0,0 +1,211 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package providers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
+)
+
+// LoadbalancerProviderFactory returns a new LoadbalancerProvider.
+type LoadbalancerProviderFactory func(mgr manager.Manager) (LoadbalancerProvider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]LoadbalancerProviderFactory{
+		NSXTLoadBalancer: func(manager.Manager) (LoadbalancerProvider, error) {
+			return NsxtLoadBalancerProvider(), nil
+		},
+		GenericLoadBalancer: func(manager.Manager) (LoadbalancerProvider, error) {
+			return GenericLoadBalancerProvider(), nil
+		},
+	}
+)
+
+// RegisterLoadbalancerProvider registers a LoadbalancerProvider
+// implementation under the provided type. The type may be used as the value
+// of the LB_PROVIDER environment variable to select the default provider, or
+// as the value of the utils.AnnotationLoadBalancerProviderKey annotation of a
+// VirtualMachineService to select the provider for that service.
+func RegisterLoadbalancerProvider(providerType string, factory LoadbalancerProviderFactory) error {
+	if providerType == "" {
+		return fmt.Errorf("load balancer provider type is required")
+	}
+	if factory == nil {
+		return fmt.Errorf("load balancer provider %q factory is nil", providerType)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[providerType]; ok {
+		return fmt.Errorf("load balancer provider %q is already registered", providerType)
+	}
+	registry[providerType] = factory
+
+	return nil
+}
+
+// RegisteredLoadbalancerProviderTypes returns the sorted types of the
+// registered LoadbalancerProvider implementations.
+func RegisteredLoadbalancerProviderTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]string, 0, len(registry))
+	for t := range registry {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// GetLoadbalancerProviderByType returns the registered LoadbalancerProvider
+// for the provided type. A NoopLoadbalancerProvider is returned if no provider
+// is registered for the type.
+func GetLoadbalancerProviderByType(mgr manager.Manager, providerType string) (LoadbalancerProvider, error) {
+	registryMu.RLock()
+	factory, ok := registry[providerType]
+	registryMu.RUnlock()
+
+	if !ok {
+		return NoopLoadbalancerProvider{}, nil
+	}
+
+	return factory(mgr)
+}
+
+// GetLoadbalancerProviderByAnnotation returns a LoadbalancerProvider that
+// delegates to the registered provider whose type matches the
+// utils.AnnotationLoadBalancerProviderKey annotation of a
+// VirtualMachineService, or to the provider for defaultProviderType when the
+// service does not have the annotation or its value is not a registered
+// provider type.
+func GetLoadbalancerProviderByAnnotation(mgr manager.Manager, defaultProviderType string) (LoadbalancerProvider, error) {
+	defaultProvider, err := GetLoadbalancerProviderByType(mgr, defaultProviderType)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := map[string]LoadbalancerProvider{}
+	for _, t := range RegisteredLoadbalancerProviderTypes() {
+		if byType[t], err = GetLoadbalancerProviderByType(mgr, t); err != nil {
+			return nil, fmt.Errorf("failed to create load balancer provider %q: %w", t, err)
+		}
+	}
+
+	return &annotationLoadbalancerProvider{
+		defaultProvider: defaultProvider,
+		byType:          byType,
+	}, nil
+}
+
+// annotationLoadbalancerProvider selects the LoadbalancerProvider of a
+// VirtualMachineService by its utils.AnnotationLoadBalancerProviderKey
+// annotation.
+type annotationLoadbalancerProvider struct {
+	defaultProvider LoadbalancerProvider
+	byType          map[string]LoadbalancerProvider
+}
+
+func (al *annotationLoadbalancerProvider) providerFor(vmService *vmopv1.VirtualMachineService) LoadbalancerProvider {
+	if vmService != nil {
+		if t, ok := vmService.Annotations[utils.AnnotationLoadBalancerProviderKey]; ok {
+			if p, ok := al.byType[t]; ok {
+				return p
+			}
+		}
+	}
+	return al.defaultProvider
+}
+
+func (al *annotationLoadbalancerProvider) EnsureLoadBalancer(ctx context.Context, vmService *vmopv1.VirtualMachineService) error {
+	return al.providerFor(vmService).EnsureLoadBalancer(ctx, vmService)
+}
+
+func (al *annotationLoadbalancerProvider) GetServiceLabels(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return al.providerFor(vmService).GetServiceLabels(ctx, vmService)
+}
+
+// GetToBeRemovedServiceLabels returns the labels that any registered provider
+// sets or removes, excluding the labels that the selected provider sets, so
+// that switching the provider of a VirtualMachineService does not leave the
+// previous provider's labels on the Service.
+func (al *annotationLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return al.toBeRemoved(vmService,
+		func(p LoadbalancerProvider) (map[string]string, error) {
+			return p.GetServiceLabels(ctx, vmService)
+		},
+		func(p LoadbalancerProvider) (map[string]string, error) {
+			return p.GetToBeRemovedServiceLabels(ctx, vmService)
+		})
+}
+
+func (al *annotationLoadbalancerProvider) GetServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return al.providerFor(vmService).GetServiceAnnotations(ctx, vmService)
+}
+
+// GetToBeRemovedServiceAnnotations returns the annotations that any
+// registered provider sets or removes, excluding the annotations that the
+// selected provider sets, so that switching the provider of a
+// VirtualMachineService does not leave the previous provider's annotations on
+// the Service.
+func (al *annotationLoadbalancerProvider) GetToBeRemovedServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
+	return al.toBeRemoved(vmService,
+		func(p LoadbalancerProvider) (map[string]string, error) {
+			return p.GetServiceAnnotations(ctx, vmService)
+		},
+		func(p LoadbalancerProvider) (map[string]string, error) {
+			return p.GetToBeRemovedServiceAnnotations(ctx, vmService)
+		})
+}
+
+// toBeRemoved returns the union of the keys that the default and registered
+// providers set or remove, excluding the keys that the provider selected for
+// the VirtualMachineService sets.
+func (al *annotationLoadbalancerProvider) toBeRemoved(
+	vmService *vmopv1.VirtualMachineService,
+	setFn, removeFn func(LoadbalancerProvider) (map[string]string, error)) (map[string]string, error) {
+
+	keep, err := setFn(al.providerFor(vmService))
+	if err != nil {
+		return nil, err
+	}
+
+	providers := []LoadbalancerProvider{al.defaultProvider}
+	for _, t := range slices.Sorted(maps.Keys(al.byType)) {
+		providers = append(providers, al.byType[t])
+	}
+
+	res := make(map[string]string)
+	for _, p := range providers {
+		for _, fn := range []func(LoadbalancerProvider) (map[string]string, error){setFn, removeFn} {
+			m, err := fn(p)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range m {
+				if _, ok := keep[k]; ok {
+					continue
+				}
+				if _, ok := res[k]; !ok {
+					res[k] = v
+				}
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -12,10 +12,12 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
 const (
@@ -48,6 +50,186 @@ var _ = Describe(
 				Expect(err).NotTo(HaveOccurred())
 				Expect(lbProvider).To(Equal(NoopLoadbalancerProvider{}))
 			})
+
+			It("should successfully get generic load balancer provider", func() {
+				lbProvider, err := GetLoadbalancerProviderByType(nil, GenericLoadBalancer)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(lbProvider).To(Equal(GenericLoadBalancerProvider()))
+			})
+		})
+
+		Context("RegisterLoadbalancerProvider", func() {
+			const providerType = "example.com/test-lb"
+
+			AfterEach(func() {
+				registryMu.Lock()
+				delete(registry, providerType)
+				registryMu.Unlock()
+			})
+
+			It("should register the provider", func() {
+				Expect(RegisterLoadbalancerProvider(providerType, func(manager.Manager) (LoadbalancerProvider, error) {
+					return GenericLoadBalancerProvider(), nil
+				})).To(Succeed())
+				Expect(RegisteredLoadbalancerProviderTypes()).To(Equal([]string{providerType, GenericLoadBalancer, NSXTLoadBalancer}))
+
+				lbProvider, err := GetLoadbalancerProviderByType(nil, providerType)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lbProvider).To(Equal(GenericLoadBalancerProvider()))
+			})
+
+			It("should not register a provider twice", func() {
+				Expect(RegisterLoadbalancerProvider(NSXTLoadBalancer, func(manager.Manager) (LoadbalancerProvider, error) {
+					return GenericLoadBalancerProvider(), nil
+				})).To(MatchError(`load balancer provider "nsx-t-lb" is already registered`))
+			})
+
+			It("should not register a nil factory", func() {
+				Expect(RegisterLoadbalancerProvider(providerType, nil)).To(HaveOccurred())
+			})
+		})
+
+		Context("GetLoadbalancerProviderByAnnotation", func() {
+			BeforeEach(func() {
+				vmService = &vmopv1.VirtualMachineService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "dummy-vmservice",
+						Namespace:   dummyNamespace,
+						Annotations: make(map[string]string),
+					},
+					Spec: vmopv1.VirtualMachineServiceSpec{
+						Type:           vmopv1.VirtualMachineServiceTypeLoadBalancer,
+						LoadBalancerIP: "1.2.3.4",
+					},
+				}
+				vmService.Annotations[utils.AnnotationServiceHealthCheckNodePortKey] = "30012"
+
+				var err error
+				lbProvider, err = GetLoadbalancerProviderByAnnotation(nil, NSXTLoadBalancer)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should use the default provider when the annotation is not set", func() {
+				annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(Equal(map[string]string{ServiceLoadBalancerHealthCheckNodePortTagKey: "30012"}))
+			})
+
+			It("should use the default provider when the annotation is not a provider type", func() {
+				vmService.Annotations[utils.AnnotationLoadBalancerProviderKey] = "example.com/other-lb"
+				annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(HaveKey(ServiceLoadBalancerHealthCheckNodePortTagKey))
+			})
+
+			It("should not select the provider by the load balancer class", func() {
+				vmService.Spec.LoadBalancerClass = ptr.To(GenericLoadBalancer)
+				annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(Equal(map[string]string{ServiceLoadBalancerHealthCheckNodePortTagKey: "30012"}))
+			})
+
+			It("should use the provider for the annotation", func() {
+				vmService.Annotations[utils.AnnotationLoadBalancerProviderKey] = GenericLoadBalancer
+				annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(Equal(map[string]string{
+					MetalLBLoadBalancerIPsAnnotationKey: "1.2.3.4",
+					KubeVIPLoadBalancerIPsAnnotationKey: "1.2.3.4",
+				}))
+			})
+
+			It("should remove the annotations of the other providers", func() {
+				annotations, err := lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(HaveKey(MetalLBLoadBalancerIPsAnnotationKey))
+				Expect(annotations).To(HaveKey(KubeVIPLoadBalancerIPsAnnotationKey))
+				Expect(annotations).ToNot(HaveKey(ServiceLoadBalancerHealthCheckNodePortTagKey))
+
+				vmService.Annotations[utils.AnnotationLoadBalancerProviderKey] = GenericLoadBalancer
+				annotations, err = lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(annotations).To(HaveKey(ServiceLoadBalancerHealthCheckNodePortTagKey))
+				Expect(annotations).ToNot(HaveKey(MetalLBLoadBalancerIPsAnnotationKey))
+				Expect(annotations).ToNot(HaveKey(KubeVIPLoadBalancerIPsAnnotationKey))
+			})
+
+			It("should remove the labels of the other providers", func() {
+				vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+				labels, err := lbProvider.GetToBeRemovedServiceLabels(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(labels).ToNot(HaveKey(LabelServiceProxyName))
+
+				vmService.Annotations[utils.AnnotationLoadBalancerProviderKey] = GenericLoadBalancer
+				labels, err = lbProvider.GetToBeRemovedServiceLabels(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(labels).To(HaveKey(LabelServiceProxyName))
+			})
+		})
+
+		Context("generic loadbalancer provider", func() {
+			BeforeEach(func() {
+				vmService = &vmopv1.VirtualMachineService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vmservice",
+						Namespace: dummyNamespace,
+					},
+					Spec: vmopv1.VirtualMachineServiceSpec{
+						Type: vmopv1.VirtualMachineServiceTypeLoadBalancer,
+					},
+				}
+				lbProvider = GenericLoadBalancerProvider()
+			})
+
+			It("EnsureLoadBalancer should return success", func() {
+				Expect(lbProvider.EnsureLoadBalancer(ctx, vmService)).To(Succeed())
+			})
+
+			It("GetServiceLabels should return empty", func() {
+				labels, err := lbProvider.GetServiceLabels(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(labels).To(BeEmpty())
+			})
+
+			It("GetToBeRemovedServiceLabels should remove ServiceProxyName label", func() {
+				labels, err := lbProvider.GetToBeRemovedServiceLabels(ctx, vmService)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(labels).To(HaveKey(LabelServiceProxyName))
+			})
+
+			Context("LoadBalancerIP is not set", func() {
+				It("GetServiceAnnotations should return empty", func() {
+					annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(annotations).To(BeEmpty())
+				})
+
+				It("GetToBeRemovedServiceAnnotations should remove the load balancer IP annotations", func() {
+					annotations, err := lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(annotations).To(HaveKey(MetalLBLoadBalancerIPsAnnotationKey))
+					Expect(annotations).To(HaveKey(KubeVIPLoadBalancerIPsAnnotationKey))
+				})
+			})
+
+			Context("LoadBalancerIP is set", func() {
+				BeforeEach(func() {
+					vmService.Spec.LoadBalancerIP = "1.2.3.4"
+				})
+
+				It("GetServiceAnnotations should return the load balancer IP annotations", func() {
+					annotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(annotations).To(HaveKeyWithValue(MetalLBLoadBalancerIPsAnnotationKey, "1.2.3.4"))
+					Expect(annotations).To(HaveKeyWithValue(KubeVIPLoadBalancerIPsAnnotationKey, "1.2.3.4"))
+				})
+
+				It("GetToBeRemovedServiceAnnotations should return empty", func() {
+					annotations, err := lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(annotations).To(BeEmpty())
+				})
+			})
 		})
 
 		Context("noop loadbalancer provider", func() {
@@ -176,6 +358,33 @@ var _ = Describe(
 					Expect(labels[LabelServiceProxyName]).To(Equal(NSXTServiceProxy))
 				})
 			})
+
+			Context("etp is Local only in the spec", func() {
+				BeforeEach(func() {
+					delete(vmService.Annotations, utils.AnnotationServiceExternalTrafficPolicyKey)
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+				})
+
+				It("should create one label for ServiceProxyName", func() {
+					labels, err := lbProvider.GetServiceLabels(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(labels).To(HaveLen(1))
+					Expect(labels[LabelServiceProxyName]).To(Equal(NSXTServiceProxy))
+				})
+			})
+
+			Context("etp is Cluster in the spec and Local in the annotation", func() {
+				BeforeEach(func() {
+					vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey] = string(corev1.ServiceExternalTrafficPolicyTypeLocal)
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyCluster
+				})
+
+				It("should not create any label", func() {
+					labels, err := lbProvider.GetServiceLabels(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(labels).To(BeEmpty())
+				})
+			})
 		})
 
 		Context("GetToBeRemovedServiceLabels", func() {
@@ -235,5 +444,17 @@ var _ = Describe(
 					Expect(exists).To(BeTrue())
 				})
 			})
+
+			Context("etp is Local only in the spec", func() {
+				BeforeEach(func() {
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+				})
+
+				It("should not remove ServiceProxyName label", func() {
+					Expect(err).ToNot(HaveOccurred())
+					_, exists := labels[LabelServiceProxyName]
+					Expect(exists).To(BeFalse())
+				})
+			})
 		})
 	})
@@ -7,4 +7,8 @@ package utils
 const (
 	AnnotationServiceExternalTrafficPolicyKey = "virtualmachineservice.vmoperator.vmware.com/service.externalTrafficPolicy"
 	AnnotationServiceHealthCheckNodePortKey   = "virtualmachineservice.vmoperator.vmware.com/service.healthCheckNodePort"
+
+	// AnnotationLoadBalancerProviderKey selects the registered load balancer
+	// provider of a VirtualMachineService instead of the default one.
+	AnnotationLoadBalancerProviderKey = "virtualmachineservice.vmoperator.vmware.com/loadBalancerProvider"
 )
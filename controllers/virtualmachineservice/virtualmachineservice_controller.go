@@ -12,10 +12,12 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -29,6 +31,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
@@ -60,7 +63,7 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		}
 	}
 
-	lbProvider, err := providers.GetLoadbalancerProviderByType(mgr, lbProviderType)
+	lbProvider, err := providers.GetLoadbalancerProviderByAnnotation(mgr, lbProviderType)
 	if err != nil {
 		return err
 	}
@@ -80,6 +83,8 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{})).
 		Watches(&corev1.Endpoints{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{})).
+		Watches(&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{})).
 		Watches(&vmopv1.VirtualMachine{},
 			handler.EnqueueRequestsFromMapFunc(r.virtualMachineToVirtualMachineServiceMapper())).
 		Complete(r)
@@ -119,6 +124,7 @@ type ReconcileVirtualMachineService struct {
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete
 
 func (r *ReconcileVirtualMachineService) Reconcile(ctx context.Context, request reconcile.Request) (_ reconcile.Result, reterr error) {
 	ctx = pkgcfg.JoinContext(ctx, r.Context)
@@ -168,6 +174,11 @@ func (r *ReconcileVirtualMachineService) ReconcileDelete(ctx *pkgctx.VirtualMach
 			return err
 		}
 
+		if err := r.deleteEndpointSlices(ctx, nil); err != nil {
+			ctx.Logger.Error(err, "Failed to delete EndpointSlices")
+			return err
+		}
+
 		service := &corev1.Service{ObjectMeta: objectMeta}
 		if err := r.Client.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
 			ctx.Logger.Error(err, "Failed to delete Service")
@@ -191,7 +202,7 @@ func (r *ReconcileVirtualMachineService) ReconcileNormal(ctx *pkgctx.VirtualMach
 		}
 
 		controllerutil.AddFinalizer(ctx.VMService, finalizerName)
-		// NOTE: The VirtualMachineService is set as the OwnerReference of the Service and Endpoints.
+		// NOTE: The VirtualMachineService is set as the OwnerReference of the Service, Endpoints and EndpointSlices.
 		// So while ReconcileDelete() does delete them when our finalizer is set, the k8s GC will
 		// delete them if they still exist if the VirtualMachineService is deleted so we do not have
 		// to return here. The explicit delete in ReconcileDelete() just speeds up the ultimate removal
@@ -273,6 +284,12 @@ func (r *ReconcileVirtualMachineService) reconcileVMService(ctx *pkgctx.VirtualM
 		return err
 	}
 
+	err = r.createOrUpdateEndpointSlices(ctx, service)
+	if err != nil {
+		ctx.Logger.Error(err, "Failed to update VirtualMachineService EndpointSlices")
+		return err
+	}
+
 	err = r.updateVMService(ctx, service)
 	if err != nil {
 		ctx.Logger.Error(err, "Failed to update VirtualMachineService Status")
@@ -405,6 +422,7 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 		service.Spec.ExternalName = vmService.Spec.ExternalName
 		service.Spec.LoadBalancerIP = vmService.Spec.LoadBalancerIP
 		service.Spec.LoadBalancerSourceRanges = vmService.Spec.LoadBalancerSourceRanges
+		service.Spec.PublishNotReadyAddresses = vmService.Spec.PublishNotReadyAddresses
 		if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
 			service.Spec.AllocateLoadBalancerNodePorts = ptr.To(false)
 		} else {
@@ -416,6 +434,11 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 		if service.ResourceVersion == "" {
 			// ClusterIP cannot be changed through update.
 			service.Spec.ClusterIP = vmService.Spec.ClusterIP
+
+			// LoadBalancerClass cannot be changed through update.
+			if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				service.Spec.LoadBalancerClass = vmService.Spec.LoadBalancerClass
+			}
 		}
 
 		// Maintain the existing mapping of ServicePort -> NodePort as un-setting it will cause
@@ -434,13 +457,19 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 				TargetPort: intstr.FromInt(int(vmPort.TargetPort)),
 				NodePort:   nodePortMap[vmPort.Name],
 			}
+			if vmPort.TargetPortName != "" {
+				servicePort.TargetPort = intstr.FromString(vmPort.TargetPortName)
+			}
+			if vmPort.NodePort != 0 {
+				servicePort.NodePort = vmPort.NodePort
+			}
 			servicePorts = append(servicePorts, servicePort)
 		}
 		service.Spec.Ports = servicePorts
 
-		// This is the default that k8s would otherwise set (note that we don't really support NodePort).
-		// The only real purpose of this is if the AnnotationServiceExternalTrafficPolicyKey annotation
-		// below is removed, so that we switch the Service back to the default.
+		// This is the default that k8s would otherwise set. The only real purpose of this is if the
+		// AnnotationServiceExternalTrafficPolicyKey annotation below is removed, so that we switch
+		// the Service back to the default.
 		if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
 			service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
 		}
@@ -457,6 +486,14 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 			}
 		}
 
+		// An explicitly specified policy takes precedence over the annotation.
+		if vmService.Spec.ExternalTrafficPolicy != "" {
+			service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyType(vmService.Spec.ExternalTrafficPolicy)
+		}
+
+		setServiceSessionAffinity(vmService, service)
+		setServiceIPFamilies(vmService, service)
+
 		return nil
 	})
 
@@ -474,6 +511,66 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 	return service, nil
 }
 
+// setServiceSessionAffinity sets the Service's session affinity from the
+// VirtualMachineService. The k8s defaults are set explicitly so the Service
+// is not patched on every reconcile.
+func setServiceSessionAffinity(vmService *vmopv1.VirtualMachineService, service *corev1.Service) {
+	if vmService.Spec.SessionAffinity != vmopv1.VirtualMachineServiceAffinityClientIP {
+		service.Spec.SessionAffinity = corev1.ServiceAffinityNone
+		service.Spec.SessionAffinityConfig = nil
+		return
+	}
+
+	timeoutSeconds := int32(corev1.DefaultClientIPServiceAffinitySeconds)
+	if cfg := vmService.Spec.SessionAffinityConfig; cfg != nil && cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+		timeoutSeconds = *cfg.ClientIP.TimeoutSeconds
+	}
+
+	service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{
+			TimeoutSeconds: &timeoutSeconds,
+		},
+	}
+}
+
+// setServiceIPFamilies sets the Service's IP families and policy from the
+// VirtualMachineService. When the IP families are not specified, whatever k8s
+// assigned is left as-is. When the policy is not specified, the Service is
+// switched back to the k8s default SingleStack policy, dropping any secondary
+// IP family that k8s would otherwise reject.
+func setServiceIPFamilies(vmService *vmopv1.VirtualMachineService, service *corev1.Service) {
+	if vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeExternalName {
+		return
+	}
+
+	if len(vmService.Spec.IPFamilies) > 0 {
+		ipFamilies := make([]corev1.IPFamily, 0, len(vmService.Spec.IPFamilies))
+		for _, f := range vmService.Spec.IPFamilies {
+			ipFamilies = append(ipFamilies, corev1.IPFamily(f))
+		}
+		service.Spec.IPFamilies = ipFamilies
+	}
+
+	if p := vmService.Spec.IPFamilyPolicy; p != nil {
+		service.Spec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicy(*p))
+		return
+	}
+
+	if service.Spec.IPFamilyPolicy == nil {
+		// Not yet assigned by k8s.
+		return
+	}
+
+	service.Spec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicySingleStack)
+	if len(service.Spec.IPFamilies) > 1 {
+		service.Spec.IPFamilies = service.Spec.IPFamilies[:1]
+	}
+	if len(service.Spec.ClusterIPs) > 1 {
+		service.Spec.ClusterIPs = service.Spec.ClusterIPs[:1]
+	}
+}
+
 func (r *ReconcileVirtualMachineService) getVirtualMachinesSelectedByVMService(
 	ctx *pkgctx.VirtualMachineServiceContext) (*vmopv1.VirtualMachineList, error) {
 
@@ -538,7 +635,7 @@ func (r *ReconcileVirtualMachineService) getVirtualMachineServicesSelectingVirtu
 	return matchingVMServices, nil
 }
 
-// createOrUpdateEndpoints updates the Endpoints for VirtualMachineService.
+// createOrUpdateEndpoints updates the legacy Endpoints for VirtualMachineService.
 func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.VirtualMachineServiceContext, service *corev1.Service) error {
 	ctx.Logger.V(5).Info("Updating VirtualMachineService Endpoints")
 	defer ctx.Logger.V(5).Info("Finished updating VirtualMachineService Endpoints")
@@ -548,6 +645,17 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 		return nil
 	}
 
+	mode := getEndpointsMode(ctx)
+	if mode == pkgconst.VMServiceEndpointsModeEndpointSlices {
+		// Only the EndpointSlices are generated so remove the Endpoints, if any, so they
+		// are not mirrored into stale EndpointSlices.
+		endpoints := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: service.Name, Namespace: service.Namespace}}
+		if err := r.Client.Delete(ctx, endpoints); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		return nil
+	}
+
 	unpackedSubsets, err := r.generateSubsetsForService(ctx, service)
 	if err != nil {
 		return err
@@ -569,6 +677,15 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 		// NCP apparently needs the same Labels as what is present on the Service, and I'm not aware
 		// of anything else setting Labels, so just sync the Labels (and Annotations) with the Service.
 		endpoints.Labels = service.Labels
+		if mode == pkgconst.VMServiceEndpointsModeBoth {
+			// The EndpointSlices are generated as well, so prevent the Endpoints from also
+			// being mirrored into EndpointSlices.
+			endpoints.Labels = make(map[string]string, len(service.Labels)+1)
+			for k, v := range service.Labels {
+				endpoints.Labels[k] = v
+			}
+			endpoints.Labels[discoveryv1.LabelSkipMirror] = "true"
+		}
 		endpoints.Annotations = service.Annotations
 		endpoints.Subsets = subsets
 		return nil
@@ -588,10 +705,37 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 	return nil
 }
 
-func findVMPortNum(_ *vmopv1.VirtualMachine, port intstr.IntOrString, _ corev1.Protocol) (int, error) {
+// getFallbackTargetPorts returns the TargetPort of each VirtualMachineServicePort that has a
+// TargetPortName, keyed by the port's name. The TargetPort is used for the VMs that do not have
+// a port with the TargetPortName.
+func getFallbackTargetPorts(vmService *vmopv1.VirtualMachineService) map[string]int32 {
+	fallbackPorts := map[string]int32{}
+	for _, vmPort := range vmService.Spec.Ports {
+		if vmPort.TargetPortName != "" && vmPort.TargetPort != 0 {
+			fallbackPorts[vmPort.Name] = vmPort.TargetPort
+		}
+	}
+	return fallbackPorts
+}
+
+// findVMPortNum returns the port number on the VM for the target port. A named target port is
+// resolved from the VM's spec.ports, or is the fallback port if the VM does not have a port with
+// that name and protocol.
+func findVMPortNum(vm *vmopv1.VirtualMachine, port intstr.IntOrString, protocol corev1.Protocol, fallback int32) (int, error) {
 	switch port.Type {
 	case intstr.String:
-		// Not supported.
+		for _, vmPort := range vm.Spec.Ports {
+			vmPortProto := corev1.Protocol(vmPort.Protocol)
+			if vmPortProto == "" {
+				vmPortProto = corev1.ProtocolTCP
+			}
+			if vmPort.Name == port.StrVal && vmPortProto == protocol {
+				return int(vmPort.Port), nil
+			}
+		}
+		if fallback != 0 {
+			return int(fallback), nil
+		}
 	case intstr.Int:
 		return port.IntValue(), nil
 	}
@@ -599,6 +743,27 @@ func findVMPortNum(_ *vmopv1.VirtualMachine, port intstr.IntOrString, _ corev1.P
 	return 0, fmt.Errorf("no matching port on VM")
 }
 
+// getVMReadiness returns whether the VM is ready to be an endpoint.
+//
+// If the VM has a ReadinessProbe and Ready condition, ready is a reflection of the condition
+// status. If the VM has a ReadinessProbe but no condition, we assume that the prober just
+// hasn't run against the VM yet, so ok is false and the caller should infer the VM's readiness
+// from whether it was previously a ready endpoint; this is to handle upgrade scenarios.
+// Otherwise, a VM that does not have a ReadinessProbe is implicitly ready.
+func getVMReadiness(vm *vmopv1.VirtualMachine) (ready, ok bool) {
+	probe := vm.Spec.ReadinessProbe
+	if probe == nil || (probe.TCPSocket == nil && probe.HTTPGet == nil && probe.GuestHeartbeat == nil && len(probe.GuestInfo) == 0) {
+		return true, true
+	}
+
+	condition := conditions.Get(vm, vmopv1.ReadyConditionType)
+	if condition == nil {
+		return false, false
+	}
+
+	return condition.Status == metav1.ConditionTrue, true
+}
+
 // generateSubsetsForService generates Endpoints subsets for a given Service.
 func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 	ctx *pkgctx.VirtualMachineServiceContext,
@@ -611,6 +776,8 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 
 	var subsets = make([]corev1.EndpointSubset, 0, len(vmList.Items))
 	var vmInSubsetsMap map[types.UID]struct{}
+	fallbackPorts := getFallbackTargetPorts(ctx.VMService)
+	hostnames := getVMEndpointHostnames(ctx.VMService, vmList.Items)
 
 	for i := range vmList.Items {
 		vm := vmList.Items[i]
@@ -637,30 +804,21 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 			continue
 		}
 
-		// If the VM has a ReadinessProbe and Ready condition, ready is a reflection of the condition
-		// status. If the VM has a ReadinessProbe but no condition, we assume that the prober just
-		// hasn't run against the VM yet, so infer the VM's readiness if it was previously in the EP;
-		// this is to handle upgrade scenarios.
-		// Otherwise, a VM that does not have a ReadinessProbe is implicitly ready.
-		ready := true
-
-		if probe := vm.Spec.ReadinessProbe; probe != nil && (probe.TCPSocket != nil || probe.GuestHeartbeat != nil || len(probe.GuestInfo) != 0) {
-			if condition := conditions.Get(&vm, vmopv1.ReadyConditionType); condition == nil {
-				if vmInSubsetsMap == nil {
-					vmInSubsetsMap = r.getVMsReferencedByServiceEndpoints(ctx, service)
-				}
-
-				// If this VM was previously in the EP subset, preserve its readiness until prober
-				// updates the condition (the probe used to be done inline here before we had a
-				// Ready condition).
-				_, ready = vmInSubsetsMap[vm.UID]
-			} else {
-				ready = condition.Status == metav1.ConditionTrue
+		ready, ok := getVMReadiness(&vm)
+		if !ok {
+			if vmInSubsetsMap == nil {
+				vmInSubsetsMap = r.getVMsReferencedByServiceEndpoints(ctx, service)
 			}
+
+			// If this VM was previously in the EP subset, preserve its readiness until prober
+			// updates the condition (the probe used to be done inline here before we had a
+			// Ready condition).
+			_, ready = vmInSubsetsMap[vm.UID]
 		}
 
 		epa := corev1.EndpointAddress{
-			IP: vmIP,
+			IP:       vmIP,
+			Hostname: hostnames[vm.Name],
 			TargetRef: &corev1.ObjectReference{
 				APIVersion: vm.APIVersion,
 				Kind:       vm.Kind,
@@ -677,13 +835,12 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 		// Populate the EP subset for this VM. We create one subset for each VM, and then our
 		// caller will repack the subsets that have identical ports.
 		subset := corev1.EndpointSubset{}
-		if ready {
+		if ready || ctx.VMService.Spec.PublishNotReadyAddresses {
 			subset.Addresses = []corev1.EndpointAddress{epa}
 		} else {
 			subset.NotReadyAddresses = []corev1.EndpointAddress{epa}
 		}
 
-		// TODO: Headless support
 		for _, servicePort := range service.Spec.Ports {
 			portName := servicePort.Name
 			portProto := servicePort.Protocol
@@ -691,7 +848,7 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 			logger.V(5).Info("ServicePort for VirtualMachine",
 				"port name", portName, "port proto", portProto)
 
-			portNum, err := findVMPortNum(&vm, servicePort.TargetPort, portProto)
+			portNum, err := findVMPortNum(&vm, servicePort.TargetPort, portProto, fallbackPorts[portName])
 			if err != nil {
 				logger.Info("Failed to find port for service",
 					"name", portName, "protocol", portProto, "error", err)
@@ -712,6 +869,66 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 	return subsets, nil
 }
 
+// getVMEndpointHostnames returns the hostnames, by VM name, of the VMs'
+// endpoints when the VirtualMachineService is headless so that each VM has a
+// DNS record like <hostname>.<service>.<namespace>.svc.<cluster-domain>. The
+// hostname of a VM is its spec.network.hostName, or, if not specified or the
+// same as the hostname of another of the service's VMs, such as the VMs of a
+// VirtualMachineReplicaSet, the VM's name. A VM does not have a hostname if it
+// is not a valid DNS label.
+func getVMEndpointHostnames(
+	vmService *vmopv1.VirtualMachineService,
+	vms []vmopv1.VirtualMachine) map[string]string {
+
+	if vmService.Spec.ClusterIP != corev1.ClusterIPNone {
+		return nil
+	}
+
+	hostnames := make(map[string]string, len(vms))
+	for i := range vms {
+		hostnames[vms[i].Name] = vms[i].Name
+		if n := vms[i].Spec.Network; n != nil && n.HostName != "" {
+			hostnames[vms[i].Name] = n.HostName
+		}
+	}
+
+	// A VM falls back to its name when its hostname is the same as another
+	// VM's, which may in turn be the same as the hostname of yet another VM.
+	// Since the VMs' names are unique, this ends once only the VMs that use
+	// their names are left with a duplicate hostname, and none do.
+	for {
+		vmsByHostname := map[string][]string{}
+		for vmName, hostname := range hostnames {
+			vmsByHostname[hostname] = append(vmsByHostname[hostname], vmName)
+		}
+
+		changed := false
+		for hostname, vmNames := range vmsByHostname {
+			if len(vmNames) < 2 {
+				continue
+			}
+			for _, vmName := range vmNames {
+				if vmName != hostname {
+					hostnames[vmName] = vmName
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	for vmName, hostname := range hostnames {
+		if len(validation.IsDNS1123Label(hostname)) > 0 {
+			hostnames[vmName] = ""
+		}
+	}
+
+	return hostnames
+}
+
 // updateVMService syncs the VirtualMachineService Status from the Service status.
 //
 //nolint:unparam
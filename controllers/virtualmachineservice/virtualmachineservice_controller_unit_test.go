@@ -11,6 +11,7 @@ import (
 	"github.com/onsi/gomega/types"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apiEquality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +22,8 @@ import (
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/providers"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
@@ -254,6 +257,93 @@ func unitTestsReconcile() {
 					Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
 					Expect(service.Annotations).To(HaveKeyWithValue(utils.AnnotationServiceHealthCheckNodePortKey, "99"))
 				})
+
+				Context("ExternalTrafficPolicy is specified", func() {
+					BeforeEach(func() {
+						vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyCluster
+					})
+
+					It("Takes precedence over the annotation", func() {
+						Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeCluster))
+					})
+				})
+			})
+
+			Context("NodePort", func() {
+				BeforeEach(func() {
+					vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeNodePort
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+					port := vmServicePort1
+					port.NodePort = 30080
+					vmService.Spec.Ports = []vmopv1.VirtualMachineServicePort{port}
+				})
+
+				It("Expected Spec", func() {
+					Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeNodePort))
+					Expect(service.Spec.AllocateLoadBalancerNodePorts).To(BeNil())
+					Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
+					Expect(service.Spec.Ports).To(HaveLen(1))
+					Expect(service.Spec.Ports[0].NodePort).To(BeNumerically("==", 30080))
+				})
+			})
+
+			Context("LoadBalancerClass", func() {
+				BeforeEach(func() {
+					vmService.Spec.LoadBalancerClass = ptr.To("example.com/my-lb")
+				})
+
+				It("Expected Spec", func() {
+					Expect(service.Spec.LoadBalancerClass).To(HaveValue(Equal("example.com/my-lb")))
+				})
+			})
+
+			Context("Session affinity", func() {
+				It("Defaults to None", func() {
+					Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+					Expect(service.Spec.SessionAffinityConfig).To(BeNil())
+				})
+
+				Context("ClientIP", func() {
+					BeforeEach(func() {
+						vmService.Spec.SessionAffinity = vmopv1.VirtualMachineServiceAffinityClientIP
+					})
+
+					It("Has the default timeout", func() {
+						Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+						Expect(service.Spec.SessionAffinityConfig).ToNot(BeNil())
+						Expect(service.Spec.SessionAffinityConfig.ClientIP).ToNot(BeNil())
+						Expect(service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(HaveValue(BeEquivalentTo(corev1.DefaultClientIPServiceAffinitySeconds)))
+					})
+
+					Context("With timeout", func() {
+						BeforeEach(func() {
+							vmService.Spec.SessionAffinityConfig = &vmopv1.VirtualMachineServiceSessionAffinityConfig{
+								ClientIP: &vmopv1.VirtualMachineServiceClientIPConfig{
+									TimeoutSeconds: ptr.To[int32](600),
+								},
+							}
+						})
+
+						It("Has the timeout", func() {
+							Expect(service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(HaveValue(BeEquivalentTo(600)))
+						})
+					})
+				})
+			})
+
+			Context("IP families", func() {
+				BeforeEach(func() {
+					vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{
+						vmopv1.VirtualMachineServiceIPv6Protocol,
+						vmopv1.VirtualMachineServiceIPv4Protocol,
+					}
+					vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.VirtualMachineServiceIPFamilyPolicyRequireDualStack)
+				})
+
+				It("Expected Spec", func() {
+					Expect(service.Spec.IPFamilies).To(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+					Expect(service.Spec.IPFamilyPolicy).To(HaveValue(Equal(corev1.IPFamilyPolicyRequireDualStack)))
+				})
 			})
 		})
 
@@ -575,6 +665,189 @@ func unitTestsReconcile() {
 				})
 			})
 
+			Context("When Service has a named target port", func() {
+				var namedPort vmopv1.VirtualMachineServicePort
+
+				BeforeEach(func() {
+					namedPort = vmopv1.VirtualMachineServicePort{
+						Name:           "web",
+						Protocol:       "TCP",
+						Port:           80,
+						TargetPortName: "http",
+					}
+					vmService.Spec.Ports = []vmopv1.VirtualMachineServicePort{namedPort}
+
+					vm1.Spec.Ports = []vmopv1.VirtualMachinePortSpec{
+						{Name: "http", Port: 8080},
+					}
+					vm2.Spec.Ports = []vmopv1.VirtualMachinePortSpec{
+						{Name: "http", Port: 9090, Protocol: "TCP"},
+						{Name: "other", Port: 9191, Protocol: "TCP"},
+					}
+
+					initObjects = append(initObjects, vm1, vm2, vm3)
+				})
+
+				findSubset := func(vm *vmopv1.VirtualMachine) *corev1.EndpointSubset {
+					for i := range endpoints.Subsets {
+						for _, addr := range endpoints.Subsets[i].Addresses {
+							if addr.TargetRef != nil && addr.TargetRef.Name == vm.Name {
+								return &endpoints.Subsets[i]
+							}
+						}
+					}
+					return nil
+				}
+
+				It("Service has the named target port", func() {
+					service := &corev1.Service{}
+					Expect(ctx.Client.Get(ctx, objKey, service)).To(Succeed())
+					Expect(service.Spec.Ports).To(HaveLen(1))
+					Expect(service.Spec.Ports[0].TargetPort.StrVal).To(Equal("http"))
+				})
+
+				It("Resolves the target port from each VM", func() {
+					Expect(endpoints.Subsets).To(HaveLen(2))
+
+					subset := findSubset(vm1)
+					Expect(subset).ToNot(BeNil())
+					Expect(subset.Addresses).To(HaveLen(1))
+					Expect(subset.Ports).To(HaveLen(1))
+					Expect(subset.Ports[0].Name).To(Equal(namedPort.Name))
+					Expect(subset.Ports[0].Port).To(BeEquivalentTo(8080))
+
+					subset = findSubset(vm2)
+					Expect(subset).ToNot(BeNil())
+					Expect(subset.Addresses).To(HaveLen(1))
+					Expect(subset.Ports).To(HaveLen(1))
+					Expect(subset.Ports[0].Port).To(BeEquivalentTo(9090))
+				})
+
+				When("A VM does not have the named port", func() {
+					BeforeEach(func() {
+						vm2.Spec.Ports = nil
+					})
+
+					It("VM does not have the port", func() {
+						subset := findSubset(vm2)
+						Expect(subset).ToNot(BeNil())
+						Expect(subset.Ports).To(BeEmpty())
+					})
+
+					When("Service port has a fallback target port", func() {
+						BeforeEach(func() {
+							vmService.Spec.Ports[0].TargetPort = 8888
+						})
+
+						It("VM has the fallback port", func() {
+							subset := findSubset(vm2)
+							Expect(subset).ToNot(BeNil())
+							Expect(subset.Ports).To(HaveLen(1))
+							Expect(subset.Ports[0].Port).To(BeEquivalentTo(8888))
+
+							subset = findSubset(vm1)
+							Expect(subset).ToNot(BeNil())
+							Expect(subset.Ports).To(HaveLen(1))
+							Expect(subset.Ports[0].Port).To(BeEquivalentTo(8080))
+						})
+					})
+				})
+
+				When("The VM's named port has a different protocol", func() {
+					BeforeEach(func() {
+						vm2.Spec.Ports[0].Protocol = "UDP"
+					})
+
+					It("VM does not have the port", func() {
+						subset := findSubset(vm2)
+						Expect(subset).ToNot(BeNil())
+						Expect(subset.Ports).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("When Service is headless", func() {
+				BeforeEach(func() {
+					vmService.Spec.ClusterIP = corev1.ClusterIPNone
+					vm1.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+						HostName: "db-0",
+					}
+					initObjects = append(initObjects, vm1, vm2, vm3)
+				})
+
+				It("Addresses have the VM hostnames", func() {
+					Expect(endpoints.Subsets).To(HaveLen(1))
+					subset := endpoints.Subsets[0]
+
+					Expect(subset.Addresses).To(HaveLen(2))
+					Expect(subset.Addresses).To(ContainElements(
+						HaveField("Hostname", "db-0"),
+						HaveField("Hostname", "dummy-vm2"),
+					))
+				})
+
+				When("The VMs have the same hostname", func() {
+					BeforeEach(func() {
+						vm2.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							HostName: "db-0",
+						}
+					})
+
+					It("Addresses have the VM names", func() {
+						Expect(endpoints.Subsets).To(HaveLen(1))
+						Expect(endpoints.Subsets[0].Addresses).To(ConsistOf(
+							HaveField("Hostname", "dummy-vm1"),
+							HaveField("Hostname", "dummy-vm2"),
+						))
+					})
+				})
+
+				When("The VM hostname is the name of another VM", func() {
+					BeforeEach(func() {
+						vm1.Spec.Network.HostName = vm2.Name
+					})
+
+					It("Addresses have unique hostnames", func() {
+						Expect(endpoints.Subsets).To(HaveLen(1))
+						Expect(endpoints.Subsets[0].Addresses).To(ConsistOf(
+							HaveField("Hostname", "dummy-vm1"),
+							HaveField("Hostname", "dummy-vm2"),
+						))
+					})
+				})
+
+				When("The VM hostname is not a DNS label", func() {
+					BeforeEach(func() {
+						vm1.Spec.Network.HostName = "1.1.1.1"
+					})
+
+					It("Address does not have a hostname", func() {
+						Expect(endpoints.Subsets).To(HaveLen(1))
+						Expect(endpoints.Subsets[0].Addresses).To(ContainElements(
+							HaveField("Hostname", ""),
+							HaveField("Hostname", "dummy-vm2"),
+						))
+					})
+				})
+
+				When("Service publishes not ready addresses", func() {
+					BeforeEach(func() {
+						vmService.Spec.PublishNotReadyAddresses = true
+						vm1.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{},
+						}
+						conditions.MarkFalse(vm1, vmopv1.ReadyConditionType, "NotReady", "")
+					})
+
+					It("Not ready VM is included in Addresses", func() {
+						Expect(endpoints.Subsets).To(HaveLen(1))
+						subset := endpoints.Subsets[0]
+						Expect(subset.Addresses).To(HaveLen(2))
+						Expect(subset.NotReadyAddresses).To(BeEmpty())
+					})
+				})
+			})
+
 			Context("When VMs have Readiness Probe", func() {
 				BeforeEach(func() {
 					vm1.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
@@ -688,6 +961,371 @@ func unitTestsReconcile() {
 			})
 		})
 
+		Context("Creates expected EndpointSlices", func() {
+			var (
+				endpointsMode  string
+				endpointSlices *discoveryv1.EndpointSliceList
+				vm1, vm2, vm3  *vmopv1.VirtualMachine
+			)
+
+			const (
+				ipv4SliceName = "dummy-vm-service-ipv4-0"
+				ipv6SliceName = "dummy-vm-service-ipv6-0"
+			)
+
+			BeforeEach(func() {
+				endpointsMode = pkgconst.VMServiceEndpointsModeEndpointSlices
+				endpointSlices = &discoveryv1.EndpointSliceList{}
+				vmLabels := map[string]string{"my-app": "dummy-label"}
+
+				vmService.Annotations[annotationName1] = "bar1"
+				vmService.Labels[labelName1] = "bar2"
+				vmService.Spec.Selector = vmLabels
+				vmService.Spec.Ports = []vmopv1.VirtualMachineServicePort{
+					vmServicePort1,
+				}
+
+				vm1 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vm1",
+						Namespace: vmService.Namespace,
+						Labels:    vmLabels,
+						UID:       "uid-1",
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP4: "1.1.1.1",
+						},
+						Zone: "zone-a",
+					},
+				}
+
+				vm2 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vm2",
+						Namespace: vmService.Namespace,
+						Labels:    vmLabels,
+						UID:       "uid-2",
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP4: "2.2.2.2",
+							PrimaryIP6: "2001:db8::2",
+						},
+					},
+				}
+
+				vm3 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vm3",
+						Namespace: vmService.Namespace,
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP4: "3.3.3.3",
+						},
+					},
+				}
+
+				initObjects = append(initObjects, vm1, vm2, vm3)
+			})
+
+			JustBeforeEach(func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.VMServiceEndpointsMode = endpointsMode
+				})
+
+				Expect(reconciler.ReconcileNormal(vmServiceCtx)).To(Succeed())
+				Expect(ctx.Client.List(ctx, endpointSlices, client.InNamespace(vmService.Namespace))).To(Succeed())
+			})
+
+			getEndpointSlice := func(name string) *discoveryv1.EndpointSlice {
+				for i := range endpointSlices.Items {
+					if endpointSlices.Items[i].Name == name {
+						return &endpointSlices.Items[i]
+					}
+				}
+				return nil
+			}
+
+			Context("When Service is headless", func() {
+				BeforeEach(func() {
+					vmService.Spec.ClusterIP = corev1.ClusterIPNone
+					vm1.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+						HostName: "db-0",
+					}
+				})
+
+				It("Endpoints have the VM hostnames", func() {
+					ipv4Slice := getEndpointSlice(ipv4SliceName)
+					Expect(ipv4Slice).ToNot(BeNil())
+					Expect(ipv4Slice.Endpoints).To(HaveLen(2))
+					Expect(ipv4Slice.Endpoints[0].Hostname).To(HaveValue(Equal("db-0")))
+					Expect(ipv4Slice.Endpoints[1].Hostname).To(HaveValue(Equal("dummy-vm2")))
+
+					ipv6Slice := getEndpointSlice(ipv6SliceName)
+					Expect(ipv6Slice).ToNot(BeNil())
+					Expect(ipv6Slice.Endpoints).To(HaveLen(1))
+					Expect(ipv6Slice.Endpoints[0].Hostname).To(HaveValue(Equal("dummy-vm2")))
+				})
+
+				When("The VM hostname is the name of another VM", func() {
+					BeforeEach(func() {
+						vm1.Spec.Network.HostName = vm2.Name
+					})
+
+					It("Endpoints have unique hostnames", func() {
+						ipv4Slice := getEndpointSlice(ipv4SliceName)
+						Expect(ipv4Slice).ToNot(BeNil())
+						Expect(ipv4Slice.Endpoints).To(HaveLen(2))
+						Expect(ipv4Slice.Endpoints[0].Hostname).To(HaveValue(Equal("dummy-vm1")))
+						Expect(ipv4Slice.Endpoints[1].Hostname).To(HaveValue(Equal("dummy-vm2")))
+					})
+				})
+
+				When("Service publishes not ready addresses", func() {
+					BeforeEach(func() {
+						vmService.Spec.PublishNotReadyAddresses = true
+						vm1.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{},
+						}
+						conditions.MarkFalse(vm1, vmopv1.ReadyConditionType, "NotReady", "")
+					})
+
+					It("Not ready VM endpoint is ready but not serving", func() {
+						ipv4Slice := getEndpointSlice(ipv4SliceName)
+						Expect(ipv4Slice).ToNot(BeNil())
+						ep := ipv4Slice.Endpoints[0]
+						Expect(ep.Addresses).To(ConsistOf("1.1.1.1"))
+						Expect(ep.Conditions.Ready).To(HaveValue(BeTrue()))
+						Expect(ep.Conditions.Serving).To(HaveValue(BeFalse()))
+					})
+				})
+			})
+
+			It("Endpoints do not have hostnames when Service is not headless", func() {
+				ipv4Slice := getEndpointSlice(ipv4SliceName)
+				Expect(ipv4Slice).ToNot(BeNil())
+				for _, ep := range ipv4Slice.Endpoints {
+					Expect(ep.Hostname).To(BeNil())
+				}
+			})
+
+			It("With Expected EndpointSlices", func() {
+				Expect(endpointSlices.Items).To(HaveLen(2))
+
+				ipv4Slice := getEndpointSlice(ipv4SliceName)
+				Expect(ipv4Slice).ToNot(BeNil())
+				Expect(ipv4Slice.AddressType).To(Equal(discoveryv1.AddressTypeIPv4))
+				Expect(ipv4Slice.Labels).To(HaveKeyWithValue(discoveryv1.LabelServiceName, vmService.Name))
+				Expect(ipv4Slice.Labels).To(HaveKeyWithValue(discoveryv1.LabelManagedBy, "vmoperator.vmware.com"))
+				Expect(ipv4Slice.Labels).To(HaveKeyWithValue(labelName1, "bar2"))
+				Expect(ipv4Slice.Annotations).To(HaveKeyWithValue(annotationName1, "bar1"))
+				Expect(ipv4Slice.OwnerReferences).To(HaveLen(1))
+				Expect(ipv4Slice.OwnerReferences[0].Name).To(Equal(vmService.Name))
+				Expect(ipv4Slice.OwnerReferences[0].Controller).To(Equal(ptr.To(true)))
+
+				Expect(ipv4Slice.Ports).To(HaveLen(1))
+				Expect(ipv4Slice.Ports[0].Name).To(HaveValue(Equal(vmServicePort1.Name)))
+				Expect(ipv4Slice.Ports[0].Port).To(HaveValue(Equal(vmServicePort1.TargetPort)))
+				Expect(ipv4Slice.Ports[0].Protocol).To(HaveValue(BeEquivalentTo(vmServicePort1.Protocol)))
+
+				Expect(ipv4Slice.Endpoints).To(HaveLen(2))
+				ep := ipv4Slice.Endpoints[0]
+				Expect(ep.Addresses).To(ConsistOf("1.1.1.1"))
+				Expect(ep.TargetRef).ToNot(BeNil())
+				Expect(ep.TargetRef.Name).To(Equal(vm1.Name))
+				Expect(ep.Conditions.Ready).To(HaveValue(BeTrue()))
+				Expect(ep.Conditions.Serving).To(HaveValue(BeTrue()))
+				Expect(ep.Conditions.Terminating).To(HaveValue(BeFalse()))
+				Expect(ep.Zone).To(HaveValue(Equal("zone-a")))
+				Expect(ep.Hints).To(BeNil())
+				ep = ipv4Slice.Endpoints[1]
+				Expect(ep.Addresses).To(ConsistOf("2.2.2.2"))
+				Expect(ep.TargetRef.Name).To(Equal(vm2.Name))
+				Expect(ep.Zone).To(BeNil())
+				Expect(ep.Hints).To(BeNil())
+
+				ipv6Slice := getEndpointSlice(ipv6SliceName)
+				Expect(ipv6Slice).ToNot(BeNil())
+				Expect(ipv6Slice.AddressType).To(Equal(discoveryv1.AddressTypeIPv6))
+				Expect(ipv6Slice.Endpoints).To(HaveLen(1))
+				Expect(ipv6Slice.Endpoints[0].Addresses).To(ConsistOf("2001:db8::2"))
+				Expect(ipv6Slice.Endpoints[0].TargetRef.Name).To(Equal(vm2.Name))
+			})
+
+			It("Does not create Endpoints", func() {
+				err := ctx.Client.Get(ctx, objKey, &corev1.Endpoints{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("VM is unready", func() {
+				BeforeEach(func() {
+					vm1.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+						TCPSocket: &vmopv1.TCPSocketAction{},
+					}
+					conditions.MarkFalse(vm1, vmopv1.ReadyConditionType, "reason", "")
+				})
+
+				It("Endpoint is not ready or serving", func() {
+					ipv4Slice := getEndpointSlice(ipv4SliceName)
+					Expect(ipv4Slice).ToNot(BeNil())
+					ep := ipv4Slice.Endpoints[0]
+					Expect(ep.TargetRef.Name).To(Equal(vm1.Name))
+					Expect(ep.Conditions.Ready).To(HaveValue(BeFalse()))
+					Expect(ep.Conditions.Serving).To(HaveValue(BeFalse()))
+					Expect(ep.Conditions.Terminating).To(HaveValue(BeFalse()))
+				})
+			})
+
+			When("VM is being deleted", func() {
+				BeforeEach(func() {
+					vm1.Finalizers = []string{"dummy-finalizer"}
+					vm1.DeletionTimestamp = ptr.To(metav1.Now())
+				})
+
+				It("Endpoint is terminating", func() {
+					ipv4Slice := getEndpointSlice(ipv4SliceName)
+					Expect(ipv4Slice).ToNot(BeNil())
+					ep := ipv4Slice.Endpoints[0]
+					Expect(ep.TargetRef.Name).To(Equal(vm1.Name))
+					Expect(ep.Conditions.Ready).To(HaveValue(BeFalse()))
+					Expect(ep.Conditions.Serving).To(HaveValue(BeTrue()))
+					Expect(ep.Conditions.Terminating).To(HaveValue(BeTrue()))
+				})
+			})
+
+			When("VMs have different ports for a named target port", func() {
+				BeforeEach(func() {
+					vmService.Spec.Ports[0].TargetPortName = "http"
+					vm1.Spec.Ports = []vmopv1.VirtualMachinePortSpec{{Name: "http", Port: 8080}}
+					vm2.Spec.Ports = []vmopv1.VirtualMachinePortSpec{{Name: "http", Port: 9090}}
+					vm2.Status.Network.PrimaryIP6 = ""
+				})
+
+				It("VMs are in separate EndpointSlices", func() {
+					Expect(endpointSlices.Items).To(HaveLen(2))
+
+					for _, endpointSlice := range endpointSlices.Items {
+						Expect(endpointSlice.AddressType).To(Equal(discoveryv1.AddressTypeIPv4))
+						Expect(endpointSlice.Endpoints).To(HaveLen(1))
+						Expect(endpointSlice.Ports).To(HaveLen(1))
+
+						switch endpointSlice.Endpoints[0].TargetRef.Name {
+						case vm1.Name:
+							Expect(endpointSlice.Ports[0].Port).To(HaveValue(BeEquivalentTo(8080)))
+						case vm2.Name:
+							Expect(endpointSlice.Ports[0].Port).To(HaveValue(BeEquivalentTo(9090)))
+						default:
+							Fail("unexpected endpoint")
+						}
+					}
+				})
+			})
+
+			When("Topology aware routing is enabled", func() {
+				BeforeEach(func() {
+					vmService.Annotations[corev1.AnnotationTopologyMode] = "Auto"
+				})
+
+				It("Endpoints have zone hints", func() {
+					ipv4Slice := getEndpointSlice(ipv4SliceName)
+					Expect(ipv4Slice).ToNot(BeNil())
+					ep := ipv4Slice.Endpoints[0]
+					Expect(ep.TargetRef.Name).To(Equal(vm1.Name))
+					Expect(ep.Hints).ToNot(BeNil())
+					Expect(ep.Hints.ForZones).To(ConsistOf(discoveryv1.ForZone{Name: "zone-a"}))
+					Expect(ipv4Slice.Endpoints[1].Hints).To(BeNil())
+				})
+			})
+
+			When("The selector is removed", func() {
+				It("Deletes the generated EndpointSlices", func() {
+					Expect(endpointSlices.Items).To(HaveLen(2))
+
+					vmService.Spec.Selector = nil
+					Expect(reconciler.ReconcileNormal(vmServiceCtx)).To(Succeed())
+
+					Expect(ctx.Client.List(ctx, endpointSlices, client.InNamespace(vmService.Namespace))).To(Succeed())
+					Expect(endpointSlices.Items).To(BeEmpty())
+				})
+			})
+
+			When("VM no longer has an IPv6 address", func() {
+				It("Deletes the stale EndpointSlice", func() {
+					Expect(getEndpointSlice(ipv6SliceName)).ToNot(BeNil())
+
+					vm2.Status.Network.PrimaryIP6 = ""
+					Expect(ctx.Client.Status().Update(ctx, vm2)).To(Succeed())
+					Expect(reconciler.ReconcileNormal(vmServiceCtx)).To(Succeed())
+
+					Expect(ctx.Client.List(ctx, endpointSlices, client.InNamespace(vmService.Namespace))).To(Succeed())
+					Expect(endpointSlices.Items).To(HaveLen(1))
+					Expect(getEndpointSlice(ipv4SliceName)).ToNot(BeNil())
+				})
+			})
+
+			When("Endpoints exist from before the migration", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, &corev1.Endpoints{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      vmService.Name,
+							Namespace: vmService.Namespace,
+						},
+					})
+				})
+
+				It("Deletes the Endpoints", func() {
+					err := ctx.Client.Get(ctx, objKey, &corev1.Endpoints{})
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("Mode is both", func() {
+				BeforeEach(func() {
+					endpointsMode = pkgconst.VMServiceEndpointsModeBoth
+				})
+
+				It("Creates both Endpoints and EndpointSlices", func() {
+					Expect(endpointSlices.Items).To(HaveLen(2))
+
+					endpoints := &corev1.Endpoints{}
+					Expect(ctx.Client.Get(ctx, objKey, endpoints)).To(Succeed())
+					Expect(endpoints.Labels).To(HaveKeyWithValue(discoveryv1.LabelSkipMirror, "true"))
+					Expect(endpoints.Labels).To(HaveKeyWithValue(labelName1, "bar2"))
+					Expect(endpoints.Subsets).To(HaveLen(1))
+					Expect(endpoints.Subsets[0].Addresses).To(HaveLen(2))
+
+					Expect(vmService.Labels).ToNot(HaveKey(discoveryv1.LabelSkipMirror))
+				})
+			})
+
+			When("Mode is endpoints", func() {
+				BeforeEach(func() {
+					endpointsMode = pkgconst.VMServiceEndpointsModeEndpoints
+					initObjects = append(initObjects, &discoveryv1.EndpointSlice{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      ipv4SliceName,
+							Namespace: vmService.Namespace,
+							Labels: map[string]string{
+								discoveryv1.LabelServiceName: vmService.Name,
+								discoveryv1.LabelManagedBy:   "vmoperator.vmware.com",
+							},
+						},
+						AddressType: discoveryv1.AddressTypeIPv4,
+					})
+				})
+
+				It("Deletes the EndpointSlices", func() {
+					Expect(endpointSlices.Items).To(BeEmpty())
+
+					endpoints := &corev1.Endpoints{}
+					Expect(ctx.Client.Get(ctx, objKey, endpoints)).To(Succeed())
+					Expect(endpoints.Labels).ToNot(HaveKey(discoveryv1.LabelSkipMirror))
+				})
+			})
+		})
+
 		Context("Selectorless VirtualMachineService", func() {
 			var vm1 *vmopv1.VirtualMachine
 			var labelSelector, vmLabels map[string]string
@@ -774,7 +1412,18 @@ func unitTestsReconcile() {
 				}
 				endpoint := &corev1.Endpoints{ObjectMeta: objectMeta}
 				service := &corev1.Service{ObjectMeta: objectMeta}
-				initObjects = append(initObjects, endpoint, service)
+				endpointSlice := &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      vmService.Name + "-ipv4-0",
+						Namespace: vmService.Namespace,
+						Labels: map[string]string{
+							discoveryv1.LabelServiceName: vmService.Name,
+							discoveryv1.LabelManagedBy:   "vmoperator.vmware.com",
+						},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+				}
+				initObjects = append(initObjects, endpoint, service, endpointSlice)
 			})
 
 			It("Deletes Endpoint and Service", func() {
@@ -788,6 +1437,10 @@ func unitTestsReconcile() {
 				service := &corev1.Service{}
 				err = ctx.Client.Get(ctx, objKey, service)
 				Expect(errors.IsNotFound(err)).To(BeTrue())
+
+				endpointSlices := &discoveryv1.EndpointSliceList{}
+				Expect(ctx.Client.List(ctx, endpointSlices, client.InNamespace(vmService.Namespace))).To(Succeed())
+				Expect(endpointSlices.Items).To(BeEmpty())
 			})
 		})
 	})
@@ -962,6 +1615,20 @@ func nsxtLBProviderTestsReconcile() {
 				Expect(newService.Labels).ToNot(HaveKey(LabelServiceProxyName))
 			})
 
+			It("Should update the k8s Service to match with the VirtualMachineService when ipFamilyPolicy is cleared", func() {
+				service.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+				service.Spec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicyPreferDualStack)
+				Expect(ctx.Client.Update(ctx, service)).To(Succeed())
+
+				err := reconciler.ReconcileNormal(vmServiceCtx)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				newService := &corev1.Service{}
+				Expect(ctx.Client.Get(ctx, objKey, newService)).To(Succeed())
+				Expect(newService.Spec.IPFamilyPolicy).To(HaveValue(Equal(corev1.IPFamilyPolicySingleStack)))
+				Expect(newService.Spec.IPFamilies).To(Equal([]corev1.IPFamily{corev1.IPv4Protocol}))
+			})
+
 			It("Should update the k8s Service to remove the provider specific annotations regarding healthCheckNodePort", func() {
 				if service.Annotations == nil {
 					service.Annotations = make(map[string]string)
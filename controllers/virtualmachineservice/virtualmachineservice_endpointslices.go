@@ -0,0 +1,370 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// endpointSliceManagedBy is the value of the managed-by label on the
+	// EndpointSlices generated for a VirtualMachineService.
+	endpointSliceManagedBy = "vmoperator.vmware.com"
+
+	// maxEndpointsPerSlice is the maximum number of endpoints in each
+	// EndpointSlice. This is the same default as the Kubernetes EndpointSlice
+	// controller.
+	maxEndpointsPerSlice = 100
+)
+
+// getEndpointsMode returns the configured VirtualMachineService endpoints
+// mode, defaulting to the legacy Endpoints for unknown values.
+func getEndpointsMode(ctx context.Context) string {
+	switch m := pkgcfg.FromContext(ctx).VMServiceEndpointsMode; m {
+	case pkgconst.VMServiceEndpointsModeEndpointSlices, pkgconst.VMServiceEndpointsModeBoth:
+		return m
+	default:
+		return pkgconst.VMServiceEndpointsModeEndpoints
+	}
+}
+
+// isTopologyAwareRoutingEnabled returns true if the VirtualMachineService opts
+// into topology aware routing with the same annotation as a Service. Zone hints
+// are only set on the endpoints when enabled, since consumers that honor the
+// hints route traffic to the endpoints in the same zone.
+func isTopologyAwareRoutingEnabled(vmService *vmopv1.VirtualMachineService) bool {
+	switch strings.ToLower(vmService.Annotations[corev1.AnnotationTopologyMode]) {
+	case "", "disabled":
+		return false
+	default:
+		return true
+	}
+}
+
+// endpointSliceLabels returns the labels that identify the EndpointSlices
+// generated for the VirtualMachineService.
+func endpointSliceLabels(vmService *vmopv1.VirtualMachineService) client.MatchingLabels {
+	return client.MatchingLabels{
+		discoveryv1.LabelServiceName: vmService.Name,
+		discoveryv1.LabelManagedBy:   endpointSliceManagedBy,
+	}
+}
+
+// createOrUpdateEndpointSlices updates the EndpointSlices for VirtualMachineService.
+func (r *ReconcileVirtualMachineService) createOrUpdateEndpointSlices(ctx *pkgctx.VirtualMachineServiceContext, service *corev1.Service) error {
+	ctx.Logger.V(5).Info("Updating VirtualMachineService EndpointSlices")
+	defer ctx.Logger.V(5).Info("Finished updating VirtualMachineService EndpointSlices")
+
+	// The EndpointSlices of a selectorless VirtualMachineService are managed
+	// by the user, so delete any EndpointSlices that were generated before the
+	// selector was removed, or before the endpoints mode was changed.
+	if len(ctx.VMService.Spec.Selector) == 0 ||
+		getEndpointsMode(ctx) == pkgconst.VMServiceEndpointsModeEndpoints {
+
+		return r.deleteEndpointSlices(ctx, nil)
+	}
+
+	desiredSlices, err := r.generateEndpointSlicesForService(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	sliceNames := make(map[string]struct{}, len(desiredSlices))
+	for i := range desiredSlices {
+		desired := desiredSlices[i]
+		sliceNames[desired.Name] = struct{}{}
+
+		endpointSlice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      desired.Name,
+				Namespace: service.Namespace,
+			},
+		}
+
+		result, err := controllerutil.CreateOrPatch(ctx, r.Client, endpointSlice, func() error {
+			if err := controllerutil.SetControllerReference(ctx.VMService, endpointSlice, r.Client.Scheme()); err != nil {
+				return err
+			}
+
+			// Like the Endpoints, sync the Labels and Annotations with the Service.
+			endpointSlice.Labels = make(map[string]string, len(service.Labels)+2)
+			for k, v := range service.Labels {
+				endpointSlice.Labels[k] = v
+			}
+			for k, v := range endpointSliceLabels(ctx.VMService) {
+				endpointSlice.Labels[k] = v
+			}
+			endpointSlice.Annotations = service.Annotations
+
+			endpointSlice.AddressType = desired.AddressType
+			endpointSlice.Endpoints = desired.Endpoints
+			endpointSlice.Ports = desired.Ports
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		switch result {
+		case controllerutil.OperationResultCreated:
+			ctx.Logger.Info("Creating Service EndpointSlice", "endpointSlice", endpointSlice)
+		case controllerutil.OperationResultUpdated:
+			ctx.Logger.Info("Updating Service EndpointSlice", "endpointSlice", endpointSlice)
+		}
+	}
+
+	return r.deleteEndpointSlices(ctx, sliceNames)
+}
+
+// deleteEndpointSlices deletes the EndpointSlices for VirtualMachineService,
+// except for those whose names are in the provided set.
+func (r *ReconcileVirtualMachineService) deleteEndpointSlices(
+	ctx *pkgctx.VirtualMachineServiceContext,
+	keep map[string]struct{}) error {
+
+	list := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, list, client.InNamespace(ctx.VMService.Namespace), endpointSliceLabels(ctx.VMService)); err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		endpointSlice := &list.Items[i]
+		if _, ok := keep[endpointSlice.Name]; ok {
+			continue
+		}
+
+		ctx.Logger.Info("Deleting Service EndpointSlice", "endpointSlice", endpointSlice.Name)
+		if err := r.Delete(ctx, endpointSlice); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getVMsReferencedByServiceEndpointSlices gets all VMs that are ready endpoints in the
+// EndpointSlices for the Service. If there are no EndpointSlices yet, the legacy Endpoints
+// are used instead so the VMs' readiness is preserved when migrating to EndpointSlices.
+func (r *ReconcileVirtualMachineService) getVMsReferencedByServiceEndpointSlices(
+	ctx *pkgctx.VirtualMachineServiceContext,
+	service *corev1.Service) map[types.UID]struct{} {
+
+	list := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, list, client.InNamespace(ctx.VMService.Namespace), endpointSliceLabels(ctx.VMService)); err != nil {
+		ctx.Logger.Error(err, "Failed to list EndpointSlices")
+		return nil
+	}
+
+	if len(list.Items) == 0 {
+		return r.getVMsReferencedByServiceEndpoints(ctx, service)
+	}
+
+	vmToSlicesMap := make(map[types.UID]struct{})
+	for _, endpointSlice := range list.Items {
+		for _, ep := range endpointSlice.Endpoints {
+			if ep.TargetRef != nil && ep.Conditions.Ready != nil && *ep.Conditions.Ready {
+				vmToSlicesMap[ep.TargetRef.UID] = struct{}{}
+			}
+		}
+	}
+	return vmToSlicesMap
+}
+
+type endpointAddress struct {
+	addressType discoveryv1.AddressType
+	ip          string
+}
+
+type endpointSliceKey struct {
+	addressType discoveryv1.AddressType
+	ports       string
+}
+
+// generateEndpointSlicesForService generates the EndpointSlices for a given Service.
+// The VMs are grouped into EndpointSlices by their address type and ports, and each
+// group is split into EndpointSlices of at most maxEndpointsPerSlice endpoints.
+func (r *ReconcileVirtualMachineService) generateEndpointSlicesForService(
+	ctx *pkgctx.VirtualMachineServiceContext,
+	service *corev1.Service) ([]discoveryv1.EndpointSlice, error) {
+
+	vmList, err := r.getVirtualMachinesSelectedByVMService(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		vmInSlicesMap map[types.UID]struct{}
+		setZoneHints  = isTopologyAwareRoutingEnabled(ctx.VMService)
+		fallbackPorts = getFallbackTargetPorts(ctx.VMService)
+		hostnames     = getVMEndpointHostnames(ctx.VMService, vmList.Items)
+		endpointsMap  = map[endpointSliceKey][]discoveryv1.Endpoint{}
+		portsMap      = map[endpointSliceKey][]discoveryv1.EndpointPort{}
+	)
+
+	for i := range vmList.Items {
+		vm := &vmList.Items[i]
+		logger := ctx.Logger.WithValues("virtualMachine", vm.NamespacedName())
+
+		var addresses []endpointAddress
+		if vm.Status.Network != nil {
+			if ip := vm.Status.Network.PrimaryIP4; ip != "" {
+				addresses = append(addresses, endpointAddress{discoveryv1.AddressTypeIPv4, ip})
+			}
+			if ip := vm.Status.Network.PrimaryIP6; ip != "" {
+				addresses = append(addresses, endpointAddress{discoveryv1.AddressTypeIPv6, ip})
+			}
+		}
+
+		if len(addresses) == 0 {
+			logger.Info("Skipping VM without primary IP assigned")
+			continue
+		}
+
+		ready, ok := getVMReadiness(vm)
+		if !ok {
+			if vmInSlicesMap == nil {
+				vmInSlicesMap = r.getVMsReferencedByServiceEndpointSlices(ctx, service)
+			}
+
+			// If this VM was previously a ready endpoint, preserve its readiness until
+			// prober updates the condition.
+			_, ready = vmInSlicesMap[vm.UID]
+		}
+
+		// Unlike the Endpoints, a VM marked for deletion remains in the EndpointSlices
+		// as a terminating endpoint so that consumers may drain its connections.
+		terminating := !vm.DeletionTimestamp.IsZero()
+
+		ports, portsKey := generateEndpointSlicePorts(logger, vm, service, fallbackPorts)
+		hostname := hostnames[vm.Name]
+
+		for _, addr := range addresses {
+			ep := discoveryv1.Endpoint{
+				Addresses: []string{addr.ip},
+				Conditions: discoveryv1.EndpointConditions{
+					Ready:       ptr.To(ctx.VMService.Spec.PublishNotReadyAddresses || (ready && !terminating)),
+					Serving:     ptr.To(ready),
+					Terminating: ptr.To(terminating),
+				},
+				TargetRef: &corev1.ObjectReference{
+					APIVersion: vm.APIVersion,
+					Kind:       vm.Kind,
+					Namespace:  vm.Namespace,
+					Name:       vm.Name,
+					UID:        vm.UID,
+				},
+			}
+
+			if hostname != "" {
+				ep.Hostname = ptr.To(hostname)
+			}
+
+			if zone := vm.Status.Zone; zone != "" {
+				ep.Zone = ptr.To(zone)
+				if setZoneHints {
+					ep.Hints = &discoveryv1.EndpointHints{
+						ForZones: []discoveryv1.ForZone{{Name: zone}},
+					}
+				}
+			}
+
+			key := endpointSliceKey{addressType: addr.addressType, ports: portsKey}
+			endpointsMap[key] = append(endpointsMap[key], ep)
+			portsMap[key] = ports
+		}
+	}
+
+	keys := make([]endpointSliceKey, 0, len(endpointsMap))
+	for k := range endpointsMap {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].addressType != keys[j].addressType {
+			return keys[i].addressType < keys[j].addressType
+		}
+		return keys[i].ports < keys[j].ports
+	})
+
+	var (
+		endpointSlices []discoveryv1.EndpointSlice
+		sliceIndex     = map[discoveryv1.AddressType]int{}
+	)
+
+	for _, k := range keys {
+		endpoints := endpointsMap[k]
+		sort.SliceStable(endpoints, func(i, j int) bool {
+			return endpoints[i].TargetRef.Name < endpoints[j].TargetRef.Name
+		})
+
+		for start := 0; start < len(endpoints); start += maxEndpointsPerSlice {
+			end := min(start+maxEndpointsPerSlice, len(endpoints))
+
+			name := fmt.Sprintf("%s-%s-%d", service.Name, strings.ToLower(string(k.addressType)), sliceIndex[k.addressType])
+			sliceIndex[k.addressType]++
+
+			endpointSlices = append(endpointSlices, discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: service.Namespace,
+				},
+				AddressType: k.addressType,
+				Endpoints:   endpoints[start:end],
+				Ports:       portsMap[k],
+			})
+		}
+	}
+
+	return endpointSlices, nil
+}
+
+// generateEndpointSlicePorts returns the EndpointSlice ports of the VM for the Service,
+// and a key that uniquely identifies the ports.
+func generateEndpointSlicePorts(
+	logger logr.Logger,
+	vm *vmopv1.VirtualMachine,
+	service *corev1.Service,
+	fallbackPorts map[string]int32) ([]discoveryv1.EndpointPort, string) {
+
+	var (
+		ports []discoveryv1.EndpointPort
+		keys  []string
+	)
+
+	for _, servicePort := range service.Spec.Ports {
+		portNum, err := findVMPortNum(vm, servicePort.TargetPort, servicePort.Protocol, fallbackPorts[servicePort.Name])
+		if err != nil {
+			logger.Info("Failed to find port for service",
+				"name", servicePort.Name, "protocol", servicePort.Protocol, "error", err)
+			continue
+		}
+
+		ports = append(ports, discoveryv1.EndpointPort{
+			Name:     ptr.To(servicePort.Name),
+			Port:     ptr.To(int32(portNum)), //nolint:gosec // disable G115
+			Protocol: ptr.To(servicePort.Protocol),
+		})
+		keys = append(keys, fmt.Sprintf("%s/%d/%s", servicePort.Name, portNum, servicePort.Protocol))
+	}
+
+	return ports, strings.Join(keys, ",")
+}
@@ -0,0 +1,271 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+)
+
+const (
+	finalizerName = "vmoperator.vmware.com/virtualmachinesnapshot"
+
+	// vmNameIndex is the name of the field index used to look up the
+	// snapshots of a VM.
+	vmNameIndex = "spec.vmName"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineSnapshot{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		ctx.VMProvider,
+	)
+
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&vmopv1.VirtualMachineSnapshot{},
+		vmNameIndex,
+		func(rawObj client.Object) []string {
+			vmSnapshot := rawObj.(*vmopv1.VirtualMachineSnapshot)
+			return []string{vmSnapshot.Spec.VMName}
+		}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Watches(
+			&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(vmToSnapshots(mgr.GetClient())),
+			builder.WithPredicates(vmSnapshotsChangedPredicate())).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		VMProvider: vmProvider,
+	}
+}
+
+// vmToSnapshots returns the reconcile requests for the snapshots of a VM so
+// the snapshots are reconciled when the VM is created, or when the VM's
+// snapshot tree changes.
+func vmToSnapshots(
+	c client.Client) func(context.Context, client.Object) []reconcile.Request {
+
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		vm := o.(*vmopv1.VirtualMachine)
+
+		list := vmopv1.VirtualMachineSnapshotList{}
+		if err := c.List(
+			ctx,
+			&list,
+			client.InNamespace(vm.Namespace),
+			client.MatchingFields{vmNameIndex: vm.Name}); err != nil {
+
+			return nil
+		}
+
+		reconcileRequests := make([]reconcile.Request, 0, len(list.Items))
+		for i := range list.Items {
+			reconcileRequests = append(reconcileRequests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&list.Items[i]),
+			})
+		}
+		return reconcileRequests
+	}
+}
+
+// vmSnapshotsChangedPredicate filters the VM events to those that may affect
+// the VM's snapshots, i.e. when the VM is created in vSphere, or when the VM's
+// snapshot tree changes.
+func vmSnapshotsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldVM, oldOK := e.ObjectOld.(*vmopv1.VirtualMachine)
+			newVM, newOK := e.ObjectNew.(*vmopv1.VirtualMachine)
+			if !oldOK || !newOK {
+				return false
+			}
+			return oldVM.Status.UniqueID != newVM.Status.UniqueID ||
+				oldVM.Status.CurrentSnapshot != newVM.Status.CurrentSnapshot ||
+				!slices.Equal(oldVM.Status.RootSnapshots, newVM.Status.RootSnapshots)
+		},
+	}
+}
+
+// Reconciler reconciles a VirtualMachineSnapshot object.
+type Reconciler struct {
+	Context context.Context
+	client.Client
+	Logger     logr.Logger
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, vmSnapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmSnapshotCtx := &pkgctx.VirtualMachineSnapshotContext{
+		Context:    ctx,
+		Logger:     r.Logger.WithName("VirtualMachineSnapshot").WithValues("name", vmSnapshot.NamespacedName()),
+		VMSnapshot: vmSnapshot,
+	}
+
+	patchHelper, err := patch.NewHelper(vmSnapshot, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vmSnapshotCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmSnapshot); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmSnapshotCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	vm := &vmopv1.VirtualMachine{}
+	vmKey := client.ObjectKey{Namespace: vmSnapshot.Namespace, Name: vmSnapshot.Spec.VMName}
+	if err := r.Get(ctx, vmKey, vm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	} else {
+		vmSnapshotCtx.VM = vm
+	}
+
+	if !vmSnapshot.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.ReconcileDelete(vmSnapshotCtx)
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(vmSnapshotCtx)
+}
+
+// ReconcileNormal reconciles a VirtualMachineSnapshot.
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	if !controllerutil.ContainsFinalizer(ctx.VMSnapshot, finalizerName) {
+		// Return here so the VirtualMachineSnapshot can be patched
+		// immediately. This ensures the snapshot is deleted from the VM when
+		// the VirtualMachineSnapshot is deleted.
+		controllerutil.AddFinalizer(ctx.VMSnapshot, finalizerName)
+		return nil
+	}
+
+	if ctx.VM == nil {
+		conditions.MarkFalse(
+			ctx.VMSnapshot,
+			vmopv1.ReadyConditionType,
+			vmopv1.VirtualMachineSnapshotVMNotFoundReason,
+			"VirtualMachine %q does not exist",
+			ctx.VMSnapshot.Spec.VMName)
+		return nil
+	}
+
+	// The VM owns its snapshots so they are garbage collected when the VM is
+	// deleted.
+	if err := controllerutil.SetOwnerReference(ctx.VM, ctx.VMSnapshot, r.Client.Scheme()); err != nil {
+		return err
+	}
+
+	if ctx.VM.Status.UniqueID == "" {
+		conditions.MarkFalse(
+			ctx.VMSnapshot,
+			vmopv1.ReadyConditionType,
+			vmopv1.VirtualMachineSnapshotVMNotCreatedReason,
+			"VirtualMachine %q has not been created",
+			ctx.VM.Name)
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineSnapshot")
+	defer func() {
+		ctx.Logger.Info("Finished Reconciling VirtualMachineSnapshot")
+	}()
+
+	if err := r.VMProvider.CreateOrUpdateVirtualMachineSnapshot(ctx, ctx.VM, ctx.VMSnapshot); err != nil {
+		ctx.Logger.Error(err, "Provider failed to reconcile VirtualMachineSnapshot")
+		conditions.MarkFalse(
+			ctx.VMSnapshot,
+			vmopv1.ReadyConditionType,
+			vmopv1.VirtualMachineSnapshotCreateFailedReason,
+			"%v",
+			err)
+		return err
+	}
+
+	conditions.MarkTrue(ctx.VMSnapshot, vmopv1.ReadyConditionType)
+
+	return nil
+}
+
+// ReconcileDelete reconciles the deletion of a VirtualMachineSnapshot.
+func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	if !controllerutil.ContainsFinalizer(ctx.VMSnapshot, finalizerName) {
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineSnapshot Deletion")
+	defer func() {
+		ctx.Logger.Info("Finished Reconciling VirtualMachineSnapshot Deletion")
+	}()
+
+	// There is nothing to delete if the VM no longer exists or was never
+	// created.
+	if ctx.VM != nil && ctx.VM.Status.UniqueID != "" {
+		if err := r.VMProvider.DeleteVirtualMachineSnapshot(ctx, ctx.VM, ctx.VMSnapshot); err != nil {
+			ctx.Logger.Error(err, "Provider failed to delete VirtualMachineSnapshot")
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(ctx.VMSnapshot, finalizerName)
+
+	return nil
+}
@@ -0,0 +1,142 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot_test
+
+import (
+	"context"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx *builder.IntegrationTestContext
+
+		vm            *vmopv1.VirtualMachine
+		vmSnapshot    *vmopv1.VirtualMachineSnapshot
+		vmSnapshotKey client.ObjectKey
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = builder.DummyBasicVirtualMachine("dummy-vm", ctx.Namespace)
+		vmSnapshot = builder.DummyVirtualMachineSnapshot(ctx.Namespace, "dummy-snapshot", vm.Name)
+		vmSnapshotKey = client.ObjectKeyFromObject(vmSnapshot)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		intgFakeVMProvider.Reset()
+	})
+
+	getVMSnapshot := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineSnapshot {
+		s := &vmopv1.VirtualMachineSnapshot{}
+		if err := ctx.Client.Get(ctx, objKey, s); err != nil {
+			return nil
+		}
+		return s
+	}
+
+	Context("Reconcile", func() {
+		var createCalled, deleteCalled atomic.Bool
+
+		BeforeEach(func() {
+			createCalled.Store(false)
+			deleteCalled.Store(false)
+
+			intgFakeVMProvider.Lock()
+			intgFakeVMProvider.CreateOrUpdateVirtualMachineSnapshotFn = func(
+				_ context.Context,
+				_ *vmopv1.VirtualMachine,
+				vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+				createCalled.Store(true)
+				vmSnapshot.Status.UniqueID = "snapshot-1"
+				return nil
+			}
+			intgFakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+				_ context.Context,
+				_ *vmopv1.VirtualMachine,
+				_ *vmopv1.VirtualMachineSnapshot) error {
+
+				deleteCalled.Store(true)
+				return nil
+			}
+			intgFakeVMProvider.Unlock()
+		})
+
+		It("Reconciles after VirtualMachineSnapshot creation", func() {
+			Expect(ctx.Client.Create(ctx, vmSnapshot)).To(Succeed())
+
+			By("VirtualMachineSnapshot should have finalizer added", func() {
+				Eventually(func(g Gomega) {
+					s := getVMSnapshot(ctx, vmSnapshotKey)
+					g.Expect(s).ToNot(BeNil())
+					g.Expect(s.GetFinalizers()).To(ContainElement(finalizer))
+				}).Should(Succeed())
+			})
+
+			By("VirtualMachineSnapshot should not be ready without the VM", func() {
+				Eventually(func(g Gomega) {
+					s := getVMSnapshot(ctx, vmSnapshotKey)
+					g.Expect(s).ToNot(BeNil())
+					c := conditions.Get(s, vmopv1.ReadyConditionType)
+					g.Expect(c).ToNot(BeNil())
+					g.Expect(c.Reason).To(Equal(vmopv1.VirtualMachineSnapshotVMNotFoundReason))
+				}).Should(Succeed())
+			})
+
+			By("Creating the VM", func() {
+				Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+				vm.Status.UniqueID = "vm-42"
+				Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+			})
+
+			By("VirtualMachineSnapshot should be ready", func() {
+				Eventually(createCalled.Load).Should(BeTrue())
+				Eventually(func(g Gomega) {
+					s := getVMSnapshot(ctx, vmSnapshotKey)
+					g.Expect(s).ToNot(BeNil())
+					g.Expect(s.Status.UniqueID).To(Equal("snapshot-1"))
+					g.Expect(conditions.IsTrue(s, vmopv1.ReadyConditionType)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			By("Deleting the VirtualMachineSnapshot", func() {
+				Expect(ctx.Client.Delete(ctx, vmSnapshot)).To(Succeed())
+			})
+
+			By("VirtualMachineSnapshot should be deleted", func() {
+				Eventually(deleteCalled.Load).Should(BeTrue())
+				Eventually(func() *vmopv1.VirtualMachineSnapshot {
+					return getVMSnapshot(ctx, vmSnapshotKey)
+				}).Should(BeNil())
+			})
+		})
+	})
+}
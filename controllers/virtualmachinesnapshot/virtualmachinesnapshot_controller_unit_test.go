@@ -0,0 +1,251 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesnapshot"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const (
+	finalizer = "vmoperator.vmware.com/virtualmachinesnapshot"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.API,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects    []client.Object
+		ctx            *builder.UnitTestContextForController
+		reconciler     *virtualmachinesnapshot.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+
+		vmSnapshotCtx *pkgctx.VirtualMachineSnapshotContext
+		vmSnapshot    *vmopv1.VirtualMachineSnapshot
+		vm            *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+				UID:       "dummy-uid",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				UniqueID: "vm-42",
+			},
+		}
+		vmSnapshot = builder.DummyVirtualMachineSnapshot("dummy-ns", "dummy-snapshot", vm.Name)
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+
+		reconciler = virtualmachinesnapshot.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.VMProvider,
+		)
+
+		vmSnapshotCtx = &pkgctx.VirtualMachineSnapshotContext{
+			Context:    ctx.Context,
+			Logger:     ctx.Logger.WithName(vmSnapshot.Namespace).WithName(vmSnapshot.Name),
+			VMSnapshot: vmSnapshot,
+			VM:         vm,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		vmSnapshotCtx = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+		BeforeEach(func() {
+			initObjects = append(initObjects, vm, vmSnapshot)
+		})
+
+		When("the finalizer is not present", func() {
+			It("will set the finalizer", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.GetFinalizers()).To(ContainElement(finalizer))
+			})
+		})
+
+		When("the finalizer is present", func() {
+			BeforeEach(func() {
+				vmSnapshot.Finalizers = []string{finalizer}
+			})
+
+			It("will create the snapshot and mark it ready", func() {
+				var called bool
+				fakeVMProvider.CreateOrUpdateVirtualMachineSnapshotFn = func(
+					_ context.Context,
+					vm *vmopv1.VirtualMachine,
+					vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+					called = true
+					Expect(vm.Name).To(Equal(vmSnapshot.Spec.VMName))
+					vmSnapshot.Status.UniqueID = "snapshot-1"
+					return nil
+				}
+
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(called).To(BeTrue())
+				Expect(vmSnapshot.Status.UniqueID).To(Equal("snapshot-1"))
+				Expect(conditions.IsTrue(vmSnapshot, vmopv1.ReadyConditionType)).To(BeTrue())
+
+				Expect(vmSnapshot.OwnerReferences).To(HaveLen(1))
+				Expect(vmSnapshot.OwnerReferences[0].Name).To(Equal(vm.Name))
+				Expect(vmSnapshot.OwnerReferences[0].UID).To(Equal(vm.UID))
+			})
+
+			When("the provider fails to create the snapshot", func() {
+				It("will mark the snapshot not ready", func() {
+					fakeVMProvider.CreateOrUpdateVirtualMachineSnapshotFn = func(
+						_ context.Context,
+						_ *vmopv1.VirtualMachine,
+						_ *vmopv1.VirtualMachineSnapshot) error {
+
+						return errors.New("fake error")
+					}
+
+					Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(MatchError("fake error"))
+
+					c := conditions.Get(vmSnapshot, vmopv1.ReadyConditionType)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineSnapshotCreateFailedReason))
+					Expect(c.Message).To(Equal("fake error"))
+				})
+			})
+
+			When("the VM does not exist", func() {
+				JustBeforeEach(func() {
+					vmSnapshotCtx.VM = nil
+				})
+
+				It("will mark the snapshot not ready", func() {
+					Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+
+					c := conditions.Get(vmSnapshot, vmopv1.ReadyConditionType)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineSnapshotVMNotFoundReason))
+				})
+			})
+
+			When("the VM has not been created", func() {
+				BeforeEach(func() {
+					vm.Status.UniqueID = ""
+				})
+
+				It("will mark the snapshot not ready without calling the provider", func() {
+					fakeVMProvider.CreateOrUpdateVirtualMachineSnapshotFn = func(
+						_ context.Context,
+						_ *vmopv1.VirtualMachine,
+						_ *vmopv1.VirtualMachineSnapshot) error {
+
+						Fail("provider should not be called")
+						return nil
+					}
+
+					Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+
+					c := conditions.Get(vmSnapshot, vmopv1.ReadyConditionType)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineSnapshotVMNotCreatedReason))
+				})
+			})
+		})
+	})
+
+	Context("ReconcileDelete", func() {
+		var deleteCalled bool
+
+		BeforeEach(func() {
+			deleteCalled = false
+			vmSnapshot.Finalizers = []string{finalizer}
+			initObjects = append(initObjects, vm, vmSnapshot)
+		})
+
+		JustBeforeEach(func() {
+			fakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+				_ context.Context,
+				_ *vmopv1.VirtualMachine,
+				_ *vmopv1.VirtualMachineSnapshot) error {
+
+				deleteCalled = true
+				return nil
+			}
+		})
+
+		It("will delete the snapshot and remove the finalizer", func() {
+			Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(Succeed())
+			Expect(deleteCalled).To(BeTrue())
+			Expect(vmSnapshot.GetFinalizers()).ToNot(ContainElement(finalizer))
+		})
+
+		When("the provider fails to delete the snapshot", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachine,
+					_ *vmopv1.VirtualMachineSnapshot) error {
+
+					return errors.New("fake error")
+				}
+			})
+
+			It("will not remove the finalizer", func() {
+				Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(MatchError("fake error"))
+				Expect(vmSnapshot.GetFinalizers()).To(ContainElement(finalizer))
+			})
+		})
+
+		When("the VM does not exist", func() {
+			JustBeforeEach(func() {
+				vmSnapshotCtx.VM = nil
+			})
+
+			It("will remove the finalizer without calling the provider", func() {
+				Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(Succeed())
+				Expect(deleteCalled).To(BeFalse())
+				Expect(vmSnapshot.GetFinalizers()).ToNot(ContainElement(finalizer))
+			})
+		})
+	})
+}
@@ -73,6 +73,14 @@ type Config struct {
 	// Defaults to 10 seconds.
 	SyncImageRequeueDelay time.Duration
 
+	// VMMetricsRequeueDelay is the requeue delay that is used to refresh the
+	// CPU and memory utilization metrics of a powered on VM that is controlled
+	// by a VirtualMachineReplicaSet. This is used so the metrics are fresh
+	// enough to autoscale the replica set, and is also the minimum interval
+	// at which the metrics of a VM are refreshed from vSphere.
+	// Defaults to 30 seconds.
+	VMMetricsRequeueDelay time.Duration
+
 	NetworkProviderType  NetworkProviderType
 	VSphereNetworking    bool
 	LoadBalancerProvider string
@@ -148,6 +156,23 @@ type Config struct {
 	// Defaults to "direct".
 	FastDeployMode string
 
+	// VMServiceEndpointsMode determines which endpoint resources are
+	// generated for the backing Service of a VirtualMachineService.
+	//
+	// The valid values are "endpoints," "endpointslices," and "both." If the
+	// value is:
+	//
+	//   - "endpoints," then only the legacy Endpoints are generated.
+	//   - "endpointslices," then only the discovery.k8s.io/v1 EndpointSlices
+	//     are generated.
+	//   - "both," then both the Endpoints and EndpointSlices are generated.
+	//     This is meant to be used while migrating from Endpoints to
+	//     EndpointSlices.
+	//   - anything else, then "endpoints" is used.
+	//
+	// Defaults to "endpoints".
+	VMServiceEndpointsMode string
+
 	// VCCredsSecretName is the name of the secret in the pod namespace that
 	// contains the VC credentials.
 	//
@@ -183,6 +208,13 @@ type FeatureStates struct {
 	BringYourOwnEncryptionKey bool // FSS_WCP_VMSERVICE_BYOK
 	SVAsyncUpgrade            bool // FSS_WCP_SUPERVISOR_ASYNC_UPGRADE
 	FastDeploy                bool // FSS_WCP_VMSERVICE_FAST_DEPLOY
+	VMSnapshots               bool // FSS_WCP_VMSERVICE_VM_SNAPSHOTS
+	VMClone                   bool // FSS_WCP_VMSERVICE_VM_CLONE
+	MutableNetworks           bool // FSS_WCP_VMSERVICE_MUTABLE_NETWORKS
+	VMNetworkPolicy           bool // FSS_WCP_VMSERVICE_VM_NETWORK_POLICY
+	VMVolumeExpansion         bool // FSS_WCP_VMSERVICE_VOLUME_EXPANSION
+	VMBootDiskResize          bool // FSS_WCP_VMSERVICE_BOOT_DISK_RESIZE
+	VMIPPools                 bool // FSS_WCP_VMSERVICE_IP_POOLS
 }
 
 type InstanceStorage struct {
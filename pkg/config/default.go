@@ -44,9 +44,11 @@ func Default() Config {
 		MemStatsPeriod:               10 * time.Minute,
 		FastDeployMode:               pkgconst.FastDeployModeDirect,
 		VCCredsSecretName:            pkgconst.VCCredsSecretName,
+		VMServiceEndpointsMode:       pkgconst.VMServiceEndpointsModeEndpoints,
 		CreateVMRequeueDelay:         10 * time.Second,
 		PoweredOnVMHasIPRequeueDelay: 10 * time.Second,
 		SyncImageRequeueDelay:        10 * time.Second,
+		VMMetricsRequeueDelay:        30 * time.Second,
 		NetworkProviderType:          NetworkProviderTypeNamed,
 		SIGUSR2RestartEnabled:        false,
 		DeploymentName:               defaultPrefix + "controller-manager",
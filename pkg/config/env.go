@@ -24,6 +24,7 @@ func FromEnv() Config {
 	setDuration(env.CreateVMRequeueDelay, &config.CreateVMRequeueDelay)
 	setDuration(env.PoweredOnVMHasIPRequeueDelay, &config.PoweredOnVMHasIPRequeueDelay)
 	setDuration(env.SyncImageRequeueDelay, &config.SyncImageRequeueDelay)
+	setDuration(env.VMMetricsRequeueDelay, &config.VMMetricsRequeueDelay)
 	setNetworkProviderType(env.NetworkProviderType, &config.NetworkProviderType)
 	setString(env.LoadBalancerProvider, &config.LoadBalancerProvider)
 	setBool(env.VSphereNetworking, &config.VSphereNetworking)
@@ -34,6 +35,7 @@ func FromEnv() Config {
 	setDuration(env.MemStatsPeriod, &config.MemStatsPeriod)
 	setString(env.FastDeployMode, &config.FastDeployMode)
 	setString(env.VCCredsSecretName, &config.VCCredsSecretName)
+	setString(env.VMServiceEndpointsMode, &config.VMServiceEndpointsMode)
 
 	setDuration(env.InstanceStoragePVPlacementFailedTTL, &config.InstanceStorage.PVPlacementFailedTTL)
 	setFloat64(env.InstanceStorageJitterMaxFactor, &config.InstanceStorage.JitterMaxFactor)
@@ -69,6 +71,13 @@ func FromEnv() Config {
 	setBool(env.FSSVMIncrementalRestore, &config.Features.VMIncrementalRestore)
 	setBool(env.FSSBringYourOwnEncryptionKey, &config.Features.BringYourOwnEncryptionKey)
 	setBool(env.FSSFastDeploy, &config.Features.FastDeploy)
+	setBool(env.FSSVMSnapshots, &config.Features.VMSnapshots)
+	setBool(env.FSSVMClone, &config.Features.VMClone)
+	setBool(env.FSSMutableNetworks, &config.Features.MutableNetworks)
+	setBool(env.FSSVMNetworkPolicy, &config.Features.VMNetworkPolicy)
+	setBool(env.FSSVMVolumeExpansion, &config.Features.VMVolumeExpansion)
+	setBool(env.FSSVMBootDiskResize, &config.Features.VMBootDiskResize)
+	setBool(env.FSSVMIPPools, &config.Features.VMIPPools)
 	setBool(env.FSSSVAsyncUpgrade, &config.Features.SVAsyncUpgrade)
 	if !config.Features.SVAsyncUpgrade {
 		// When SVAsyncUpgrade is enabled, we'll later use the capability CM to determine if
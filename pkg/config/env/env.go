@@ -19,6 +19,7 @@ const (
 	CreateVMRequeueDelay
 	PoweredOnVMHasIPRequeueDelay
 	SyncImageRequeueDelay
+	VMMetricsRequeueDelay
 	PrivilegedUsers
 	NetworkProviderType
 	LoadBalancerProvider
@@ -30,6 +31,7 @@ const (
 	AsyncCreateEnabled
 	FastDeployMode
 	VCCredsSecretName
+	VMServiceEndpointsMode
 	InstanceStoragePVPlacementFailedTTL
 	InstanceStorageJitterMaxFactor
 	InstanceStorageSeedRequeueDuration
@@ -64,6 +66,13 @@ const (
 	FSSBringYourOwnEncryptionKey
 	FSSSVAsyncUpgrade
 	FSSFastDeploy
+	FSSVMSnapshots
+	FSSVMClone
+	FSSMutableNetworks
+	FSSVMNetworkPolicy
+	FSSVMVolumeExpansion
+	FSSVMBootDiskResize
+	FSSVMIPPools
 	_varNameEnd
 )
 
@@ -101,6 +110,8 @@ func (n VarName) String() string {
 		return "POWERED_ON_VM_HAS_IP_REQUEUE_DELAY"
 	case SyncImageRequeueDelay:
 		return "SYNC_IMAGE_REQUEUE_DELAY"
+	case VMMetricsRequeueDelay:
+		return "VM_METRICS_REQUEUE_DELAY"
 	case PrivilegedUsers:
 		return "PRIVILEGED_USERS"
 	case NetworkProviderType:
@@ -123,6 +134,8 @@ func (n VarName) String() string {
 		return "FAST_DEPLOY_MODE"
 	case VCCredsSecretName:
 		return "VC_CREDS_SECRET_NAME"
+	case VMServiceEndpointsMode:
+		return "VMSERVICE_ENDPOINTS_MODE"
 	case InstanceStoragePVPlacementFailedTTL:
 		return "INSTANCE_STORAGE_PV_PLACEMENT_FAILED_TTL"
 	case InstanceStorageJitterMaxFactor:
@@ -191,6 +204,20 @@ func (n VarName) String() string {
 		return "FSS_WCP_SUPERVISOR_ASYNC_UPGRADE"
 	case FSSFastDeploy:
 		return "FSS_WCP_VMSERVICE_FAST_DEPLOY"
+	case FSSVMSnapshots:
+		return "FSS_WCP_VMSERVICE_VM_SNAPSHOTS"
+	case FSSVMClone:
+		return "FSS_WCP_VMSERVICE_VM_CLONE"
+	case FSSMutableNetworks:
+		return "FSS_WCP_VMSERVICE_MUTABLE_NETWORKS"
+	case FSSVMNetworkPolicy:
+		return "FSS_WCP_VMSERVICE_VM_NETWORK_POLICY"
+	case FSSVMVolumeExpansion:
+		return "FSS_WCP_VMSERVICE_VOLUME_EXPANSION"
+	case FSSVMBootDiskResize:
+		return "FSS_WCP_VMSERVICE_BOOT_DISK_RESIZE"
+	case FSSVMIPPools:
+		return "FSS_WCP_VMSERVICE_IP_POOLS"
 	}
 	panic("unknown environment variable")
 }
@@ -82,6 +82,7 @@ var _ = Describe(
 					Expect(os.Setenv("ASYNC_CREATE_ENABLED", "false")).To(Succeed())
 					Expect(os.Setenv("FAST_DEPLOY_MODE", pkgconst.FastDeployModeLinked)).To(Succeed())
 					Expect(os.Setenv("VC_CREDS_SECRET_NAME", pkgconst.VCCredsSecretName)).To(Succeed())
+					Expect(os.Setenv("VMSERVICE_ENDPOINTS_MODE", pkgconst.VMServiceEndpointsModeBoth)).To(Succeed())
 					Expect(os.Setenv("LEADER_ELECTION_ID", "115")).To(Succeed())
 					Expect(os.Setenv("POD_NAME", "116")).To(Succeed())
 					Expect(os.Setenv("POD_NAMESPACE", "117")).To(Succeed())
@@ -104,12 +105,20 @@ var _ = Describe(
 					Expect(os.Setenv("FSS_WCP_VMSERVICE_BYOK", "true")).To(Succeed())
 					Expect(os.Setenv("FSS_WCP_SUPERVISOR_ASYNC_UPGRADE", "false")).To(Succeed())
 					Expect(os.Setenv("FSS_WCP_VMSERVICE_FAST_DEPLOY", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_SNAPSHOTS", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_CLONE", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_MUTABLE_NETWORKS", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_NETWORK_POLICY", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VOLUME_EXPANSION", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_BOOT_DISK_RESIZE", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_IP_POOLS", "true")).To(Succeed())
 					Expect(os.Setenv("CREATE_VM_REQUEUE_DELAY", "125h")).To(Succeed())
 					Expect(os.Setenv("POWERED_ON_VM_HAS_IP_REQUEUE_DELAY", "126h")).To(Succeed())
 					Expect(os.Setenv("MEM_STATS_PERIOD", "127h")).To(Succeed())
 					Expect(os.Setenv("SYNC_IMAGE_REQUEUE_DELAY", "128h")).To(Succeed())
 					Expect(os.Setenv("DEPLOYMENT_NAME", "129")).To(Succeed())
 					Expect(os.Setenv("SIGUSR2_RESTART_ENABLED", "true")).To(Succeed())
+					Expect(os.Setenv("VM_METRICS_REQUEUE_DELAY", "130h")).To(Succeed())
 				})
 				It("Should return a default config overridden by the environment", func() {
 					Expect(config).To(BeComparableTo(pkgcfg.Config{
@@ -136,6 +145,7 @@ var _ = Describe(
 						AsyncCreateEnabled:           false,
 						FastDeployMode:               pkgconst.FastDeployModeLinked,
 						VCCredsSecretName:            pkgconst.VCCredsSecretName,
+						VMServiceEndpointsMode:       pkgconst.VMServiceEndpointsModeBoth,
 						LeaderElectionID:             "115",
 						PodName:                      "116",
 						PodNamespace:                 "117",
@@ -159,6 +169,13 @@ var _ = Describe(
 							SVAsyncUpgrade:            false, // Capability gate so tested below
 							WorkloadDomainIsolation:   true,
 							FastDeploy:                true,
+							VMSnapshots:               true,
+							VMClone:                   true,
+							MutableNetworks:           true,
+							VMNetworkPolicy:           true,
+							VMVolumeExpansion:         true,
+							VMBootDiskResize:          true,
+							VMIPPools:                 true,
 						},
 						CreateVMRequeueDelay:         125 * time.Hour,
 						PoweredOnVMHasIPRequeueDelay: 126 * time.Hour,
@@ -166,6 +183,7 @@ var _ = Describe(
 						SyncImageRequeueDelay:        128 * time.Hour,
 						DeploymentName:               "129",
 						SIGUSR2RestartEnabled:        true,
+						VMMetricsRequeueDelay:        130 * time.Hour,
 					}))
 				})
 			})
@@ -74,6 +74,18 @@ const (
 	// for more information.
 	FastDeployModeLinked = "linked"
 
+	// VMServiceEndpointsModeEndpoints is the VirtualMachineService endpoints
+	// mode that generates only the legacy Endpoints.
+	VMServiceEndpointsModeEndpoints = "endpoints"
+
+	// VMServiceEndpointsModeEndpointSlices is the VirtualMachineService
+	// endpoints mode that generates only the EndpointSlices.
+	VMServiceEndpointsModeEndpointSlices = "endpointslices"
+
+	// VMServiceEndpointsModeBoth is the VirtualMachineService endpoints mode
+	// that generates both the legacy Endpoints and the EndpointSlices.
+	VMServiceEndpointsModeBoth = "both"
+
 	// LastRestartTimeAnnotationKey is applied to a Deployment's pod template
 	// spec when the pod needs to restart itself, ex. the capabilities change.
 	// The application of this annotation causes the Deployment to do a rollout
@@ -0,0 +1,25 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+// VirtualMachineDeploymentContext is the context used for VirtualMachineDeployment reconciliation.
+type VirtualMachineDeploymentContext struct {
+	context.Context
+	Logger     logr.Logger
+	Deployment *vmopv1.VirtualMachineDeployment
+}
+
+func (v *VirtualMachineDeploymentContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.Deployment.GroupVersionKind(), v.Deployment.Namespace, v.Deployment.Name)
+}
@@ -22,6 +22,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 
 	netopv1alpha1 "github.com/vmware-tanzu/net-operator-api/api/v1alpha1"
@@ -34,9 +35,11 @@ import (
 	cnsv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis"
 
 	vmopapi "github.com/vmware-tanzu/vm-operator/api"
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 // Manager is a VM Operator controller manager.
@@ -66,8 +69,11 @@ func New(ctx context.Context, opts Options) (Manager, error) {
 
 	_ = vmopapi.AddToScheme(opts.Scheme)
 
-	if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
+	switch pkgcfg.FromContext(ctx).NetworkProviderType {
+	case pkgcfg.NetworkProviderTypeVPC:
 		_ = vpcv1alpha1.AddToScheme(opts.Scheme)
+	case pkgcfg.NetworkProviderTypeNSXT:
+		_ = nsxv1alpha1.AddToScheme(opts.Scheme)
 	}
 
 	// Build the controller manager.
@@ -118,6 +124,18 @@ func New(ctx context.Context, opts Options) (Manager, error) {
 		return nil, fmt.Errorf("unable to create manager: %w", err)
 	}
 
+	// Index the VMs by the PVCs referred to by their volumes. The index is
+	// used by the VM webhooks, controllers, and provider, so it is added here
+	// rather than by any one of them.
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&vmopv1.VirtualMachine{},
+		vmopv1util.PVCClaimNameIndex,
+		vmopv1util.PVCClaimNames); err != nil {
+
+		return nil, fmt.Errorf("unable to index VMs by PVC claim name: %w", err)
+	}
+
 	// Prefix the logger with the pod name.
 	logger := opts.Logger.WithName(opts.PodName)
 
@@ -16,6 +16,7 @@ const (
 	phaseLabel           = "phase"
 	specLabel            = "spec"
 	statusLabel          = "status"
+	vmReplicaSetLabel    = "vm_replicaset"
 
 	// VMImage related metrics labels (from image registry service).
 	vmiNameLabel      = "vmi_name"
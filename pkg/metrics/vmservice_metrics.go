@@ -26,6 +26,26 @@ type VMMetrics struct {
 	statusPhase           *prometheus.GaugeVec
 	powerState            *prometheus.GaugeVec
 	statusIP              *prometheus.GaugeVec
+	cpuUsage              *prometheus.GaugeVec
+	cpuUtilization        *prometheus.GaugeVec
+	memoryUsage           *prometheus.GaugeVec
+	memoryUtilization     *prometheus.GaugeVec
+}
+
+// VMUtilization is the CPU and memory utilization of a VM, as reported by the
+// quick statistics of the underlying vSphere VM.
+type VMUtilization struct {
+	// CPUUsageMHz is the CPU usage of the VM in MHz.
+	CPUUsageMHz int32
+
+	// MaxCPUUsageMHz is the upper bound of the CPU usage of the VM in MHz.
+	MaxCPUUsageMHz int32
+
+	// MemoryUsageMB is the guest memory usage of the VM in MB.
+	MemoryUsageMB int32
+
+	// MemorySizeMB is the configured memory size of the VM in MB.
+	MemorySizeMB int32
 }
 
 func NewVMMetrics() *VMMetrics {
@@ -60,6 +80,35 @@ func NewVMMetrics() *VMMetrics {
 					Help:      "IP address assignment status of a VM resource"},
 				[]string{vmNameLabel, vmNamespaceLabel},
 			),
+
+			cpuUsage: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricsNamespace,
+					Name:      "vm_cpu_usage_mhz",
+					Help:      "CPU usage in MHz of a powered on VM resource"},
+				[]string{vmNameLabel, vmNamespaceLabel, vmReplicaSetLabel},
+			),
+			cpuUtilization: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricsNamespace,
+					Name:      "vm_cpu_utilization_percent",
+					Help:      "CPU usage of a powered on VM resource as a percentage of its maximum CPU usage"},
+				[]string{vmNameLabel, vmNamespaceLabel, vmReplicaSetLabel},
+			),
+			memoryUsage: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricsNamespace,
+					Name:      "vm_memory_usage_mb",
+					Help:      "Guest memory usage in MB of a powered on VM resource"},
+				[]string{vmNameLabel, vmNamespaceLabel, vmReplicaSetLabel},
+			),
+			memoryUtilization: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricsNamespace,
+					Name:      "vm_memory_utilization_percent",
+					Help:      "Guest memory usage of a powered on VM resource as a percentage of its memory size"},
+				[]string{vmNameLabel, vmNamespaceLabel, vmReplicaSetLabel},
+			),
 		}
 
 		metrics.Registry.MustRegister(
@@ -67,6 +116,10 @@ func NewVMMetrics() *VMMetrics {
 			vmMetrics.statusPhase,
 			vmMetrics.powerState,
 			vmMetrics.statusIP,
+			vmMetrics.cpuUsage,
+			vmMetrics.cpuUtilization,
+			vmMetrics.memoryUsage,
+			vmMetrics.memoryUtilization,
 		)
 	})
 
@@ -102,6 +155,59 @@ func (vmm *VMMetrics) DeleteMetrics(vmCtx *pkgctx.VirtualMachineContext) {
 
 	// Delete the 'vm.status.ip' metrics.
 	vmm.statusIP.DeletePartialMatch(labels)
+
+	// Delete the utilization metrics.
+	vmm.DeleteVMUtilizationMetrics(vmCtx)
+}
+
+// RegisterVMUtilizationMetrics publishes the CPU and memory utilization of a
+// VM. The metrics are labeled with the name of the VirtualMachineReplicaSet
+// that controls the VM, if any, so the utilization of the replicas may be
+// aggregated, ex. by an external metrics adapter used to autoscale the replica
+// set.
+func (vmm *VMMetrics) RegisterVMUtilizationMetrics(
+	vmCtx *pkgctx.VirtualMachineContext,
+	utilization VMUtilization) {
+
+	vm := vmCtx.VM
+	vmCtx.Logger.V(5).Info("Adding metrics for VM utilization")
+
+	labels := prometheus.Labels{
+		vmNameLabel:       vm.Name,
+		vmNamespaceLabel:  vm.Namespace,
+		vmReplicaSetLabel: "",
+	}
+	if ref := metav1.GetControllerOfNoCopy(vm); ref != nil &&
+		ref.Kind == "VirtualMachineReplicaSet" {
+
+		labels[vmReplicaSetLabel] = ref.Name
+	}
+
+	vmm.cpuUsage.With(labels).Set(float64(utilization.CPUUsageMHz))
+	vmm.memoryUsage.With(labels).Set(float64(utilization.MemoryUsageMB))
+
+	if utilization.MaxCPUUsageMHz > 0 {
+		vmm.cpuUtilization.With(labels).Set(
+			100 * float64(utilization.CPUUsageMHz) / float64(utilization.MaxCPUUsageMHz))
+	}
+	if utilization.MemorySizeMB > 0 {
+		vmm.memoryUtilization.With(labels).Set(
+			100 * float64(utilization.MemoryUsageMB) / float64(utilization.MemorySizeMB))
+	}
+}
+
+// DeleteVMUtilizationMetrics deletes the utilization metrics for a VM, ex.
+// when the VM is no longer powered on.
+func (vmm *VMMetrics) DeleteVMUtilizationMetrics(vmCtx *pkgctx.VirtualMachineContext) {
+	labels := prometheus.Labels{
+		vmNameLabel:      vmCtx.VM.Name,
+		vmNamespaceLabel: vmCtx.VM.Namespace,
+	}
+
+	vmm.cpuUsage.DeletePartialMatch(labels)
+	vmm.cpuUtilization.DeletePartialMatch(labels)
+	vmm.memoryUsage.DeletePartialMatch(labels)
+	vmm.memoryUtilization.DeletePartialMatch(labels)
 }
 
 func (vmm *VMMetrics) registerVMStatusConditions(vmCtx *pkgctx.VirtualMachineContext) {
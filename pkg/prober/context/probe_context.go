@@ -13,6 +13,16 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/patch"
 )
 
+const (
+	// ReadinessProbeType is the type of the probe used to determine if a VM
+	// is ready.
+	ReadinessProbeType = "readiness"
+
+	// LivenessProbeType is the type of the probe used to determine if a VM's
+	// guest is alive.
+	LivenessProbeType = "liveness"
+)
+
 // ProbeContext is the context used for VM Probes.
 type ProbeContext struct {
 	context.Context
@@ -23,7 +33,44 @@ type ProbeContext struct {
 	PeriodSeconds int32
 }
 
+// ProbeSpec describes the action of the probe being run.
+type ProbeSpec struct {
+	TCPSocket      *vmopv1.TCPSocketAction
+	HTTPGet        *vmopv1.HTTPGetAction
+	GuestHeartbeat *vmopv1.GuestHeartbeatAction
+	GuestInfo      []vmopv1.GuestInfoAction
+	TimeoutSeconds int32
+}
+
 // String returns probe type.
 func (p *ProbeContext) String() string {
 	return p.ProbeType
 }
+
+// ProbeSpec returns the action of the probe being run. The readiness probe
+// is returned unless the probe type is liveness.
+func (p *ProbeContext) ProbeSpec() ProbeSpec {
+	if p.ProbeType == LivenessProbeType {
+		if lp := p.VM.Spec.LivenessProbe; lp != nil {
+			return ProbeSpec{
+				TCPSocket:      lp.TCPSocket,
+				HTTPGet:        lp.HTTPGet,
+				GuestHeartbeat: lp.GuestHeartbeat,
+				GuestInfo:      lp.GuestInfo,
+				TimeoutSeconds: lp.TimeoutSeconds,
+			}
+		}
+		return ProbeSpec{}
+	}
+
+	if rp := p.VM.Spec.ReadinessProbe; rp != nil {
+		return ProbeSpec{
+			TCPSocket:      rp.TCPSocket,
+			HTTPGet:        rp.HTTPGet,
+			GuestHeartbeat: rp.GuestHeartbeat,
+			GuestInfo:      rp.GuestInfo,
+			TimeoutSeconds: rp.TimeoutSeconds,
+		}
+	}
+	return ProbeSpec{}
+}
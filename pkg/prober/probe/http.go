@@ -0,0 +1,151 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+)
+
+const (
+	// defaultMinSuccessStatusCode and defaultMaxSuccessStatusCode are the
+	// bounds of the range of status codes considered successful when the
+	// probe does not specify one.
+	defaultMinSuccessStatusCode = http.StatusOK
+	defaultMaxSuccessStatusCode = 399
+
+	// maxRespBodyLength is the maximum number of bytes read from the
+	// response body before the connection is closed.
+	maxRespBodyLength = 10 * 1 << 10
+
+	probeUserAgent = "vm-operator-probe"
+)
+
+// httpProber implements the Probe interface.
+type httpProber struct{}
+
+// NewHTTPProber creates a new http prober which implements the Probe interface to execute http get probes.
+func NewHTTPProber() Probe {
+	return &httpProber{}
+}
+
+func (pr httpProber) Probe(ctx *context.ProbeContext) (Result, error) {
+	vm := ctx.VM
+	p := ctx.ProbeSpec()
+	action := p.HTTPGet
+
+	portNum, err := findPort(vm, action.Port, corev1.ProtocolTCP)
+	if err != nil {
+		return Failure, err
+	}
+
+	host := action.Host
+	if host == "" {
+		ctx.Logger.V(4).Info("HTTPGet Host not specified, using VM IP", "probe", ctx.String())
+		if host, err = getVMIP(vm); err != nil {
+			return Failure, err
+		}
+	}
+
+	var timeout time.Duration
+	if p.TimeoutSeconds <= 0 {
+		timeout = defaultConnectTimeout
+	} else {
+		timeout = time.Duration(p.TimeoutSeconds) * time.Second
+	}
+
+	req, err := newHTTPGetRequest(action, host, portNum)
+	if err != nil {
+		return Failure, err
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: action.InsecureSkipTLSVerify, //nolint:gosec // opt-in by the user
+			},
+			DisableKeepAlives: true,
+			Proxy:             nil,
+		},
+		// Redirects are not followed. The status code of the redirect is
+		// evaluated instead.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Failure, err
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount of the body so the connection can be closed
+	// cleanly.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxRespBodyLength))
+
+	minCode, maxCode := defaultMinSuccessStatusCode, defaultMaxSuccessStatusCode
+	if r := action.SuccessStatusCodes; r != nil {
+		minCode, maxCode = int(r.Min), int(r.Max)
+	}
+
+	if resp.StatusCode < minCode || resp.StatusCode > maxCode {
+		return Failure, fmt.Errorf("HTTP probe failed with statuscode: %d", resp.StatusCode)
+	}
+
+	return Success, nil
+}
+
+// newHTTPGetRequest returns the request for the provided HTTPGet action.
+func newHTTPGetRequest(action *vmopv1.HTTPGetAction, host string, port int) (*http.Request, error) {
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	path := action.Path
+	if path == "" {
+		path = "/"
+	} else if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTPGet path %q: %w", action.Path, err)
+	}
+	u.Scheme = scheme
+	u.Host = net.JoinHostPort(host, strconv.Itoa(port))
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", probeUserAgent)
+	req.Header.Set("Accept", "*/*")
+	for _, h := range action.HTTPHeaders {
+		if strings.EqualFold(h.Name, "Host") {
+			req.Host = h.Value
+			continue
+		}
+		req.Header.Add(h.Name, h.Value)
+	}
+
+	return req, nil
+}
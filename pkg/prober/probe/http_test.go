@@ -0,0 +1,177 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+)
+
+var _ = Describe("HTTP probe", func() {
+	var (
+		vm            *vmopv1.VirtualMachine
+		testHTTPProbe Probe
+		probeCtx      *context.ProbeContext
+
+		testServer *httptest.Server
+		testHost   string
+		testPort   int
+
+		statusCode    int
+		requestPath   string
+		requestHeader http.Header
+	)
+
+	startServer := func(tlsServer bool) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestPath = r.URL.Path
+			requestHeader = r.Header.Clone()
+			w.WriteHeader(statusCode)
+		})
+		if tlsServer {
+			testServer = httptest.NewTLSServer(handler)
+		} else {
+			testServer = httptest.NewServer(handler)
+		}
+		host, port, err := net.SplitHostPort(testServer.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		testHost = host
+		testPort, err = strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		statusCode = http.StatusOK
+		requestPath = ""
+		requestHeader = nil
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Network: &vmopv1.VirtualMachineNetworkStatus{},
+			},
+		}
+
+		testHTTPProbe = NewHTTPProber()
+		startServer(false)
+
+		vm.Status.Network.PrimaryIP4 = testHost
+		vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+			HTTPGet: &vmopv1.HTTPGetAction{
+				Path: "/healthz",
+				Port: intstr.FromInt(testPort),
+			},
+			PeriodSeconds: 1,
+		}
+
+		probeCtx = &context.ProbeContext{
+			VM:     vm,
+			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+		}
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	It("HTTP probe succeeds, using the VM IP", func() {
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+		Expect(requestPath).To(Equal("/healthz"))
+	})
+
+	It("HTTP probe sends the custom headers", func() {
+		vm.Spec.ReadinessProbe.HTTPGet.HTTPHeaders = []vmopv1.HTTPHeader{
+			{Name: "X-Custom-Header", Value: "hello"},
+		}
+
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+		Expect(requestHeader.Get("X-Custom-Header")).To(Equal("hello"))
+	})
+
+	It("HTTP probe fails when the server returns an error", func() {
+		statusCode = http.StatusInternalServerError
+
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("500"))
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTP probe succeeds when the status code is in the success range", func() {
+		statusCode = http.StatusServiceUnavailable
+		vm.Spec.ReadinessProbe.HTTPGet.SuccessStatusCodes = &vmopv1.HTTPStatusCodeRange{
+			Min: 200,
+			Max: 503,
+		}
+
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+
+	It("HTTP probe fails when the VM has no IP", func() {
+		vm.Status.Network.PrimaryIP4 = ""
+
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTP probe fails when the port is closed", func() {
+		vm.Spec.ReadinessProbe.HTTPGet.Host = testHost
+		vm.Spec.ReadinessProbe.HTTPGet.Port = intstr.FromInt(10001)
+
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	Context("HTTPS", func() {
+		BeforeEach(func() {
+			testServer.Close()
+			startServer(true)
+			vm.Status.Network.PrimaryIP4 = testHost
+			vm.Spec.ReadinessProbe.HTTPGet.Port = intstr.FromInt(testPort)
+			vm.Spec.ReadinessProbe.HTTPGet.Scheme = vmopv1.URISchemeHTTPS
+		})
+
+		It("HTTP probe fails to verify a self-signed certificate", func() {
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).Should(HaveOccurred())
+			Expect(res).To(Equal(Failure))
+		})
+
+		It("HTTP probe succeeds when TLS verification is skipped", func() {
+			vm.Spec.ReadinessProbe.HTTPGet.InsecureSkipTLSVerify = true
+
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(Success))
+		})
+	})
+})
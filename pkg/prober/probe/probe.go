@@ -45,6 +45,7 @@ type vmProviderProber interface {
 // Prober contains the different type of probes.
 type Prober struct {
 	TCPProbe       Probe
+	HTTPGetProbe   Probe
 	GuestHeartbeat Probe
 	GuestInfo      Probe
 }
@@ -53,6 +54,7 @@ type Prober struct {
 func NewProber(vmProvider vmProviderProber) *Prober {
 	return &Prober{
 		TCPProbe:       NewTCPProber(),
+		HTTPGetProbe:   NewHTTPProber(),
 		GuestHeartbeat: NewGuestHeartbeatProber(vmProvider),
 		GuestInfo:      NewGuestInfoProber(vmProvider),
 	}
@@ -27,7 +27,7 @@ func NewTCPProber() Probe {
 
 func (pr tcpProber) Probe(ctx *context.ProbeContext) (Result, error) {
 	vm := ctx.VM
-	p := ctx.VM.Spec.ReadinessProbe
+	p := ctx.ProbeSpec()
 
 	portProto := corev1.ProtocolTCP
 	portNum, err := findPort(vm, p.TCPSocket.Port, portProto)
@@ -38,14 +38,8 @@ func (pr tcpProber) Probe(ctx *context.ProbeContext) (Result, error) {
 	ip := p.TCPSocket.Host
 	if ip == "" {
 		ctx.Logger.V(4).Info("TCPSocket Host not specified, using VM IP", "probe", ctx.String())
-		if vm.Status.Network != nil {
-			ip = vm.Status.Network.PrimaryIP4
-			if ip == "" {
-				ip = vm.Status.Network.PrimaryIP6
-			}
-		}
-		if ip == "" {
-			return Failure, fmt.Errorf("VM %s doesn't have an IP assigned", vm.NamespacedName())
+		if ip, err = getVMIP(vm); err != nil {
+			return Failure, err
 		}
 	}
 
@@ -63,6 +57,21 @@ func (pr tcpProber) Probe(ctx *context.ProbeContext) (Result, error) {
 	return Success, nil
 }
 
+// getVMIP returns the primary IP address of the VM, preferring IPv4.
+func getVMIP(vm *vmopv1.VirtualMachine) (string, error) {
+	var ip string
+	if vm.Status.Network != nil {
+		ip = vm.Status.Network.PrimaryIP4
+		if ip == "" {
+			ip = vm.Status.Network.PrimaryIP6
+		}
+	}
+	if ip == "" {
+		return "", fmt.Errorf("VM %s doesn't have an IP assigned", vm.NamespacedName())
+	}
+	return ip, nil
+}
+
 func findPort(vm *vmopv1.VirtualMachine, portName intstr.IntOrString, _ corev1.Protocol) (int, error) {
 	switch portName.Type {
 	case intstr.String:
@@ -20,16 +20,19 @@ import (
 	"github.com/go-logr/logr"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober/worker"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 const (
 	proberManagerName       = "virtualmachine-prober-manager"
 	readinessProbeQueueName = "readinessProbeQueue"
+	livenessProbeQueueName  = "livenessProbeQueue"
 
 	// defaultPeriodSeconds represents the default value for the frequency (in seconds) to perform the probe.
 	// We use the same default value as the kubernetes container probe.
@@ -38,6 +41,9 @@ const (
 	// the number of readiness workers.
 	// TODO: find a way to calibrate it.
 	numberOfReadinessWorkers = 5
+
+	// the number of liveness workers.
+	numberOfLivenessWorkers = 5
 )
 
 // Manager represents a prober manager interface.
@@ -51,6 +57,7 @@ type Manager interface {
 type manager struct {
 	client         client.Client
 	readinessQueue worker.DelayingInterface
+	livenessQueue  worker.DelayingInterface
 	prober         *probe.Prober
 	log            logr.Logger
 	recorder       vmoprecord.Recorder
@@ -62,6 +69,25 @@ type manager struct {
 	// adding VMs to the readiness queue when this VM is already in the heap but not in the queue.
 	readinessMutex       sync.Mutex
 	vmReadinessProbeList map[string]vmopv1.VirtualMachineReadinessProbeSpec
+
+	// readinessResults tracks the consecutive readiness probe results of each
+	// VM. It is shared by all of the readiness workers.
+	readinessResults *worker.ProbeResults
+
+	// asyncSignalEnabled indicates the GuestHeartbeat and GuestInfo readiness
+	// probes are evaluated when the VM's status is updated, so only the TCP
+	// and HTTPGet readiness probes, and the probes that require state, are
+	// run by the probe manager.
+	asyncSignalEnabled bool
+
+	// livenessMutex and vmLivenessProbeList serve the same purpose for the
+	// liveness queue as their readiness counterparts.
+	livenessMutex       sync.Mutex
+	vmLivenessProbeList map[string]vmopv1.VirtualMachineLivenessProbeSpec
+
+	// livenessResults tracks the consecutive liveness probe failures of each
+	// VM. It is shared by all of the liveness workers.
+	livenessResults *worker.ProbeResults
 }
 
 // NewManager initializes a prober manager.
@@ -73,20 +99,29 @@ func NewManager(
 	probeManager := &manager{
 		client:               client,
 		readinessQueue:       workqueue.NewNamedDelayingQueue(readinessProbeQueueName),
+		livenessQueue:        workqueue.NewNamedDelayingQueue(livenessProbeQueueName),
 		prober:               probe.NewProber(vmProvider),
 		log:                  ctrl.Log.WithName(proberManagerName),
 		recorder:             record,
 		vmReadinessProbeList: make(map[string]vmopv1.VirtualMachineReadinessProbeSpec),
+		vmLivenessProbeList:  make(map[string]vmopv1.VirtualMachineLivenessProbeSpec),
+		readinessResults:     worker.NewProbeResults(),
+		livenessResults:      worker.NewProbeResults(),
 	}
 	return probeManager
 }
 
 // AddToManager adds the probe manager controller manager.
-func AddToManager(mgr ctrlmgr.Manager, vmProvider providers.VirtualMachineProviderInterface) (Manager, error) {
+func AddToManager(
+	ctx context.Context,
+	mgr ctrlmgr.Manager,
+	vmProvider providers.VirtualMachineProviderInterface) (Manager, error) {
+
 	probeRecorder := vmoprecord.New(mgr.GetEventRecorderFor(proberManagerName))
 
 	// Add the probe manager explicitly as runnable in order to receive a Start() event.
 	m := NewManager(mgr.GetClient(), probeRecorder, vmProvider)
+	m.(*manager).asyncSignalEnabled = pkgcfg.FromContext(ctx).AsyncSignalEnabled
 	if err := mgr.Add(m); err != nil {
 		return nil, err
 	}
@@ -99,11 +134,22 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 	vmName := vm.NamespacedName()
 	m.log.V(4).Info("Add to prober manager", "vm", vmName)
 
+	m.addToReadinessQueue(vm)
+	m.addToLivenessQueue(vm)
+}
+
+// addToReadinessQueue adds a VM to the readiness queue if the VM has a
+// readiness probe that is run by the probe manager.
+func (m *manager) addToReadinessQueue(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
 	m.readinessMutex.Lock()
 	defer m.readinessMutex.Unlock()
 
-	if vm.Spec.ReadinessProbe != nil &&
-		(vm.Spec.ReadinessProbe.TCPSocket != nil || vm.Spec.ReadinessProbe.GuestHeartbeat != nil || len(vm.Spec.ReadinessProbe.GuestInfo) != 0) {
+	if p := vm.Spec.ReadinessProbe; p != nil &&
+		(p.TCPSocket != nil || p.HTTPGet != nil ||
+			((!m.asyncSignalEnabled || vmopv1util.IsReadinessProbeStateful(p)) &&
+				(p.GuestHeartbeat != nil || len(p.GuestInfo) != 0))) {
 		// if the VM is not in the list, or its readiness probe spec has been updated, immediately add it to the queue
 		// otherwise, ignore it.
 		if oldProbe, ok := m.vmReadinessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, vm.Spec.ReadinessProbe) {
@@ -115,6 +161,30 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 		m.vmReadinessProbeList[vmName] = *vm.Spec.ReadinessProbe
 	} else {
 		delete(m.vmReadinessProbeList, vmName)
+		m.readinessResults.Delete(vmName)
+	}
+}
+
+// addToLivenessQueue adds a VM to the liveness queue if the VM has a liveness
+// probe.
+func (m *manager) addToLivenessQueue(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
+	m.livenessMutex.Lock()
+	defer m.livenessMutex.Unlock()
+
+	if p := vm.Spec.LivenessProbe; p != nil &&
+		(p.TCPSocket != nil || p.HTTPGet != nil || p.GuestHeartbeat != nil || len(p.GuestInfo) != 0) {
+		if oldProbe, ok := m.vmLivenessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, *p) {
+			m.log.V(4).Info("VM is already in the liveness probe list and its probe spec is not updated, skip it", "vm", vmName)
+			return
+		}
+
+		m.livenessQueue.Add(client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace})
+		m.vmLivenessProbeList[vmName] = *p
+	} else {
+		delete(m.vmLivenessProbeList, vmName)
+		m.livenessResults.Delete(vmName)
 	}
 }
 
@@ -124,8 +194,14 @@ func (m *manager) RemoveFromProberManager(vm *vmopv1.VirtualMachine) {
 	m.log.V(4).Info("Remove from prober manager", "vm", vmName)
 
 	m.readinessMutex.Lock()
-	defer m.readinessMutex.Unlock()
 	delete(m.vmReadinessProbeList, vmName)
+	m.readinessResults.Delete(vmName)
+	m.readinessMutex.Unlock()
+
+	m.livenessMutex.Lock()
+	delete(m.vmLivenessProbeList, vmName)
+	m.livenessResults.Delete(vmName)
+	m.livenessMutex.Unlock()
 }
 
 // Start starts the probe manager.
@@ -136,13 +212,21 @@ func (m *manager) Start(ctx context.Context) error {
 	m.log.Info("Starting readiness workers", "count", numberOfReadinessWorkers)
 	m.workersWG.Add(numberOfReadinessWorkers)
 	for i := 0; i < numberOfReadinessWorkers; i++ {
-		readinessWorker := worker.NewReadinessWorker(m.readinessQueue, m.prober, m.client, m.recorder)
+		readinessWorker := worker.NewReadinessWorker(m.readinessQueue, m.prober, m.client, m.recorder, m.readinessResults)
 		m.worker(readinessWorker)
 	}
 
+	m.log.Info("Starting liveness workers", "count", numberOfLivenessWorkers)
+	m.workersWG.Add(numberOfLivenessWorkers)
+	for i := 0; i < numberOfLivenessWorkers; i++ {
+		livenessWorker := worker.NewLivenessWorker(m.livenessQueue, m.prober, m.client, m.recorder, m.livenessResults)
+		m.worker(livenessWorker)
+	}
+
 	<-ctx.Done()
 
 	m.readinessQueue.ShutDown()
+	m.livenessQueue.ShutDown()
 	m.workersWG.Wait()
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgmgr "github.com/vmware-tanzu/vm-operator/pkg/manager"
 	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
 	fakeworker "github.com/vmware-tanzu/vm-operator/pkg/prober/fake/worker"
@@ -52,7 +53,7 @@ var _ = Describe("VirtualMachine probes", func() {
 	)
 
 	BeforeEach(func() {
-		ctx = context.Background()
+		ctx = pkgcfg.NewContext()
 		periodSeconds = 1
 
 		vm = &vmopv1.VirtualMachine{
@@ -111,13 +112,13 @@ var _ = Describe("VirtualMachine probes", func() {
 	}
 
 	Specify("Adding prober to controller manager should succeed", func() {
-		m, err := AddToManager(fakeCtrlManager, fakeVMProvider)
+		m, err := AddToManager(ctx, fakeCtrlManager, fakeVMProvider)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(m).ToNot(BeNil())
 	})
 
 	Specify("Starting probe manager should succeed", func() {
-		m, err := AddToManager(fakeCtrlManager, fakeVMProvider)
+		m, err := AddToManager(ctx, fakeCtrlManager, fakeVMProvider)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(m).ToNot(BeNil())
 
@@ -296,6 +297,72 @@ var _ = Describe("VirtualMachine probes", func() {
 				testManager.readinessMutex.Unlock()
 			})
 		})
+
+		When("async signal is enabled", func() {
+			JustBeforeEach(func() {
+				testManager.asyncSignalEnabled = true
+			})
+
+			It("Should add to the queue if the VM has a TCP probe", func() {
+				testManager.AddToProberManager(vm)
+				Expect(testManager.readinessQueue.Len()).To(Equal(1))
+			})
+
+			It("Should not add to the queue if the VM has a GuestHeartbeat probe", func() {
+				vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+					GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+				}
+				testManager.AddToProberManager(vm)
+				Expect(testManager.readinessQueue.Len()).To(Equal(0))
+			})
+
+			It("Should add to the queue if the VM has a GuestHeartbeat probe with thresholds", func() {
+				vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+					GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+					FailureThreshold: 3,
+				}
+				testManager.AddToProberManager(vm)
+				Expect(testManager.readinessQueue.Len()).To(Equal(1))
+			})
+		})
+
+		When("VM has a liveness probe", func() {
+			JustBeforeEach(func() {
+				vm.Spec.ReadinessProbe = nil
+				vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+					GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+				}
+			})
+
+			It("Should add to the liveness queue and list", func() {
+				testManager.AddToProberManager(vm)
+
+				Expect(testManager.readinessQueue.Len()).To(Equal(0))
+				Expect(testManager.livenessQueue.Len()).To(Equal(1))
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).Should(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+
+			It("Should do nothing if VM probe is not updated", func() {
+				testManager.AddToProberManager(vm)
+				Expect(testManager.livenessQueue.Len()).To(Equal(1))
+				item, _ := testManager.livenessQueue.Get()
+				testManager.livenessQueue.Done(item)
+
+				testManager.AddToProberManager(vm)
+				Expect(testManager.livenessQueue.Len()).To(Equal(0))
+			})
+
+			It("Should remove from the list when the VM is removed from the manager", func() {
+				testManager.AddToProberManager(vm)
+				testManager.RemoveFromProberManager(vm)
+
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).ShouldNot(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+		})
 	})
 })
 
@@ -0,0 +1,219 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	// livenessProbeFailedReason represents the reason for the event emitted
+	// when a VM is restarted because its liveness probe failed.
+	livenessProbeFailedReason string = "LivenessProbeFailed"
+
+	// defaultFailureThreshold is the default number of consecutive failures
+	// after which a VM is restarted. We use the same default value as the
+	// kubernetes container probe.
+	defaultFailureThreshold = 3
+)
+
+// livenessWorker implements Worker interface.
+type livenessWorker struct {
+	queue    DelayingInterface
+	prober   *probe.Prober
+	client   client.Client
+	recorder vmoprecord.Recorder
+	results  *ProbeResults
+}
+
+// NewLivenessWorker creates a new liveness worker to run liveness probes. The
+// provided results are used to track the consecutive failures of each VM and
+// must be shared by all of the workers that process the same queue.
+func NewLivenessWorker(
+	queue DelayingInterface,
+	prober *probe.Prober,
+	client client.Client,
+	recorder vmoprecord.Recorder,
+	results *ProbeResults,
+) Worker {
+	return &livenessWorker{
+		queue:    queue,
+		prober:   prober,
+		client:   client,
+		recorder: recorder,
+		results:  results,
+	}
+}
+
+func (w *livenessWorker) GetQueue() DelayingInterface {
+	return w.queue
+}
+
+// CreateProbeContext creates a probe context for liveness probe.
+func (w *livenessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*proberctx.ProbeContext, error) {
+	p := vm.Spec.LivenessProbe
+
+	if p == nil || (p.TCPSocket == nil && p.HTTPGet == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0) {
+		w.results.Delete(vm.NamespacedName())
+		return nil, nil
+	}
+
+	patchHelper, err := patch.NewHelper(vm, w.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proberctx.ProbeContext{
+		Context:       context.Background(),
+		Logger:        ctrl.Log.WithName("liveness-probe").WithValues("vmName", vm.NamespacedName()),
+		PatchHelper:   patchHelper,
+		VM:            vm,
+		ProbeType:     proberctx.LivenessProbeType,
+		PeriodSeconds: p.PeriodSeconds,
+	}, nil
+}
+
+// ProcessProbeResult records the probe result and restarts the VM once the
+// number of consecutive failures reaches the failure threshold.
+func (w *livenessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res probe.Result, resErr error) error {
+	vm := ctx.VM
+	vmName := vm.NamespacedName()
+
+	// A VM that is not powered on cannot be restarted, and the failures that
+	// occurred before it was powered off no longer apply.
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		w.results.Delete(vmName)
+		return nil
+	}
+
+	switch res {
+	case probe.Success:
+		w.results.Add(vmName, probe.Success)
+		return nil
+	case probe.Unknown:
+		// An unknown result neither resets nor increments the number of
+		// consecutive failures.
+		return nil
+	}
+
+	failureThreshold := vm.Spec.LivenessProbe.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = defaultFailureThreshold
+	}
+
+	// Please note, adding a failure resets the number of consecutive
+	// successes, and vice versa.
+	failures := w.results.Add(vmName, probe.Failure)
+	if failures < failureThreshold {
+		ctx.Logger.V(4).Info("VM resource LIVENESS probe failed",
+			"failures", failures, "failureThreshold", failureThreshold)
+		return nil
+	}
+
+	msg := fmt.Sprintf("Liveness probe failed %d consecutive times, restarting VM", failures)
+	if resErr != nil {
+		msg = fmt.Sprintf("%s: %v", msg, resErr)
+	}
+	ctx.Logger.Info("VM resource LIVENESS probe failed, restarting VM",
+		"failures", failures, "restartMode", vm.Spec.RestartMode)
+
+	// The VM is restarted by the VM controller in accordance with
+	// spec.restartMode. Setting spec.nextRestartTime to "now" results in the
+	// mutation webhook replacing the value with the current time.
+	vm.Spec.NextRestartTime = "now"
+	vm.Status.LivenessRestartCount++
+
+	if err := ctx.PatchHelper.Patch(ctx, vm); err != nil {
+		return fmt.Errorf("patched failed: %w", err)
+	}
+
+	// Deleting the results also resets the time from which the initial delay
+	// is measured once the restart has completed.
+	w.results.Delete(vmName)
+	w.recorder.Event(vm, livenessProbeFailedReason, msg)
+
+	return nil
+}
+
+func (w *livenessWorker) DoProbe(ctx *proberctx.ProbeContext) error {
+	if isRestartPending(ctx.VM) {
+		ctx.Logger.V(4).Info("VM restart is pending, skip running the liveness probe")
+		return nil
+	}
+
+	p := ctx.VM.Spec.LivenessProbe
+	startTime := w.results.StartTime(ctx.VM.NamespacedName())
+	if delay := time.Duration(p.InitialDelaySeconds) * time.Second; time.Since(startTime) < delay {
+		ctx.Logger.V(4).Info("Initial delay has not elapsed, skip running the liveness probe",
+			"initialDelaySeconds", p.InitialDelaySeconds)
+		return nil
+	}
+
+	res, err := w.runProbe(ctx)
+	if err != nil {
+		ctx.Logger.V(4).Info("liveness probe fails", "result", res, "error", err.Error())
+	}
+	return w.ProcessProbeResult(ctx, res, err)
+}
+
+// getProbe returns a specific type of probe method.
+func (w *livenessWorker) getProbe(probeSpec *vmopv1.VirtualMachineLivenessProbeSpec) probe.Probe {
+	if probeSpec == nil {
+		return nil
+	}
+
+	if probeSpec.TCPSocket != nil {
+		return w.prober.TCPProbe
+	}
+	if probeSpec.HTTPGet != nil {
+		return w.prober.HTTPGetProbe
+	}
+	if probeSpec.GuestHeartbeat != nil {
+		return w.prober.GuestHeartbeat
+	}
+	if len(probeSpec.GuestInfo) != 0 {
+		return w.prober.GuestInfo
+	}
+
+	return nil
+}
+
+// runProbe runs a specific type of probe based on the VM probe spec.
+func (w *livenessWorker) runProbe(ctx *proberctx.ProbeContext) (probe.Result, error) {
+	if p := w.getProbe(ctx.VM.Spec.LivenessProbe); p != nil {
+		return p.Probe(ctx)
+	}
+
+	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s liveness probe", ctx.VM.NamespacedName())
+}
+
+// isRestartPending returns true if the VM has been asked to restart but the
+// restart has not yet completed.
+func isRestartPending(vm *vmopv1.VirtualMachine) bool {
+	if vm.Spec.NextRestartTime == "" {
+		return false
+	}
+
+	nextRestartTime, err := time.Parse(time.RFC3339Nano, vm.Spec.NextRestartTime)
+	if err != nil {
+		// The value has not yet been replaced with a timestamp.
+		return true
+	}
+
+	// The status's lastRestartTime is serialized with a precision of seconds.
+	lastRestartTime := vm.Status.LastRestartTime
+	return lastRestartTime == nil || lastRestartTime.Time.Before(nextRestartTime.Truncate(time.Second))
+}
@@ -0,0 +1,224 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgorecord "k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+
+	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	fakeprobe "github.com/vmware-tanzu/vm-operator/pkg/prober/fake/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("VirtualMachine liveness probes", func() {
+	var (
+		testWorker Worker
+
+		vm    *vmopv1.VirtualMachine
+		vmKey client.ObjectKey
+		ctx   *proberctx.ProbeContext
+
+		fakeClient         client.Client
+		fakeRecorder       record.Recorder
+		fakeEvents         chan string
+		fakeHeartbeatProbe *fakeprobe.FakeProbe
+		probeResults       *ProbeResults
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+				LivenessProbe: &vmopv1.VirtualMachineLivenessProbeSpec{
+					GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+					PeriodSeconds:    1,
+					FailureThreshold: 2,
+				},
+			},
+		}
+
+		vmKey = client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace}
+
+		fakeClient = builder.NewFakeClient()
+		eventRecorder := clientgorecord.NewFakeRecorder(1024)
+		fakeRecorder = record.New(eventRecorder)
+		fakeEvents = eventRecorder.Events
+
+		queue := workqueue.NewNamedDelayingQueue("test")
+		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		prober := &probe.Prober{
+			GuestHeartbeat: fakeHeartbeatProbe,
+		}
+		probeResults = NewProbeResults()
+		testWorker = NewLivenessWorker(queue, prober, fakeClient, fakeRecorder, probeResults)
+	})
+
+	JustBeforeEach(func() {
+		Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+		Expect(fakeClient.Status().Update(context.Background(), vm)).Should(Succeed())
+		Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+	})
+
+	// doProbe runs the probe against the latest version of the VM.
+	doProbe := func() {
+		Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+		var err error
+		ctx, err = testWorker.CreateProbeContext(vm)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ctx).ToNot(BeNil())
+		Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+		Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+	}
+
+	When("the VM does not have a liveness probe", func() {
+		BeforeEach(func() {
+			vm.Spec.LivenessProbe = nil
+		})
+
+		It("Should not create a probe context", func() {
+			ctx, err := testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ctx).To(BeNil())
+		})
+	})
+
+	When("the probe succeeds", func() {
+		BeforeEach(func() {
+			fakeHeartbeatProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Success, nil
+			}
+		})
+
+		It("Should not restart the VM", func() {
+			doProbe()
+			doProbe()
+			Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+			Expect(vm.Status.LivenessRestartCount).To(BeZero())
+			Expect(fakeEvents).ShouldNot(Receive())
+		})
+	})
+
+	When("the probe fails", func() {
+		BeforeEach(func() {
+			fakeHeartbeatProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("heartbeat status %q is below threshold", vmopv1.RedHeartbeatStatus)
+			}
+		})
+
+		It("Should restart the VM once the failure threshold is reached", func() {
+			By("Should not restart the VM before the threshold is reached", func() {
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+				Expect(vm.Status.LivenessRestartCount).To(BeZero())
+			})
+
+			By("Should restart the VM when the threshold is reached", func() {
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(Equal("now"))
+				Expect(vm.Status.LivenessRestartCount).To(Equal(int32(1)))
+				Expect(fakeEvents).Should(Receive(And(
+					ContainSubstring(livenessProbeFailedReason),
+					ContainSubstring("below threshold"))))
+			})
+
+			By("Should not run the probe while the restart is pending", func() {
+				doProbe()
+				Expect(vm.Status.LivenessRestartCount).To(Equal(int32(1)))
+				Expect(fakeEvents).ShouldNot(Receive())
+			})
+		})
+
+		When("a probe succeeds before the threshold is reached", func() {
+			It("Should reset the number of consecutive failures", func() {
+				doProbe()
+
+				fakeHeartbeatProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+					return probe.Success, nil
+				}
+				doProbe()
+
+				fakeHeartbeatProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+					return probe.Failure, nil
+				}
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+				Expect(vm.Status.LivenessRestartCount).To(BeZero())
+			})
+		})
+
+		When("a previous restart has completed", func() {
+			BeforeEach(func() {
+				now := time.Now().UTC()
+				vm.Spec.NextRestartTime = now.Format(time.RFC3339Nano)
+				vm.Status.LivenessRestartCount = 1
+			})
+
+			JustBeforeEach(func() {
+				lastRestartTime := metav1.NewTime(time.Now().UTC().Add(time.Second))
+				vm.Status.LastRestartTime = &lastRestartTime
+				vm.Status.LivenessRestartCount = 1
+				Expect(fakeClient.Status().Update(context.Background(), vm)).Should(Succeed())
+			})
+
+			It("Should restart the VM again", func() {
+				doProbe()
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(Equal("now"))
+				Expect(vm.Status.LivenessRestartCount).To(Equal(int32(2)))
+			})
+		})
+
+		When("the VM has an initial delay", func() {
+			BeforeEach(func() {
+				vm.Spec.LivenessProbe.InitialDelaySeconds = 60
+			})
+
+			It("Should not run the probe until the initial delay has elapsed", func() {
+				doProbe()
+				doProbe()
+				Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+				Expect(vm.Status.LivenessRestartCount).To(BeZero())
+			})
+		})
+
+		When("the VM is not powered on", func() {
+			It("Should not restart the VM", func() {
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				Expect(fakeClient.Status().Update(context.Background(), vm)).Should(Succeed())
+
+				for i := 0; i < 3; i++ {
+					Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+					var err error
+					ctx, err = testWorker.CreateProbeContext(vm)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(testWorker.ProcessProbeResult(ctx, probe.Failure, fmt.Errorf("virtual machine is not powered on"))).To(Succeed())
+				}
+
+				Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+				Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+				Expect(vm.Status.LivenessRestartCount).To(BeZero())
+			})
+		})
+	})
+})
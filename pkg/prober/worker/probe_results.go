@@ -0,0 +1,77 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+)
+
+// ProbeResults tracks the consecutive results of the probes run against VMs.
+// It is shared by the workers that process the same queue since a VM may be
+// processed by any of them.
+type ProbeResults struct {
+	mu     sync.Mutex
+	states map[string]probeState
+}
+
+type probeState struct {
+	// startTime is the time at which the VM was first observed as powered
+	// on by the workers.
+	startTime time.Time
+
+	result probe.Result
+	count  int32
+}
+
+// NewProbeResults returns a new ProbeResults.
+func NewProbeResults() *ProbeResults {
+	return &ProbeResults{
+		states: map[string]probeState{},
+	}
+}
+
+// Add records the result of a probe run against the VM with the provided
+// name and returns the number of consecutive times the result was observed.
+func (r *ProbeResults) Add(vmName string, res probe.Result) int32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.states[vmName]
+	if s.count == 0 || s.result != res {
+		s.result = res
+		s.count = 0
+	}
+	s.count++
+	r.states[vmName] = s
+
+	return s.count
+}
+
+// StartTime returns the time at which the VM with the provided name was first
+// observed as powered on. The current time is recorded and returned if the VM
+// has not been observed before.
+func (r *ProbeResults) StartTime(vmName string) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.states[vmName]
+	if s.startTime.IsZero() {
+		s.startTime = time.Now()
+		r.states[vmName] = s
+	}
+
+	return s.startTime
+}
+
+// Delete removes the results recorded for the VM with the provided name.
+func (r *ProbeResults) Delete(vmName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.states, vmName)
+}
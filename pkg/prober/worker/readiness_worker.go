@@ -7,6 +7,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -25,6 +26,12 @@ const (
 	readyReason    string = "Ready"
 	notReadyReason string = "NotReady"
 	unknownReason  string = "Unknown"
+
+	// defaultSuccessThreshold and defaultReadinessFailureThreshold are the
+	// default number of consecutive results required for the Ready condition
+	// to transition.
+	defaultSuccessThreshold          = 1
+	defaultReadinessFailureThreshold = 1
 )
 
 // readinessWorker implements Worker interface.
@@ -33,20 +40,25 @@ type readinessWorker struct {
 	prober   *probe.Prober
 	client   client.Client
 	recorder vmoprecord.Recorder
+	results  *ProbeResults
 }
 
 // NewReadinessWorker creates a new readiness worker to run readiness probes.
+// The provided results are used to track the consecutive results of each VM
+// and must be shared by all of the workers that process the same queue.
 func NewReadinessWorker(
 	queue DelayingInterface,
 	prober *probe.Prober,
 	client client.Client,
 	recorder vmoprecord.Recorder,
+	results *ProbeResults,
 ) Worker {
 	return &readinessWorker{
 		queue:    queue,
 		prober:   prober,
 		client:   client,
 		recorder: recorder,
+		results:  results,
 	}
 }
 
@@ -58,7 +70,8 @@ func (w *readinessWorker) GetQueue() DelayingInterface {
 func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*proberctx.ProbeContext, error) {
 	p := vm.Spec.ReadinessProbe
 
-	if p.TCPSocket == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0 {
+	if p.TCPSocket == nil && p.HTTPGet == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0 {
+		w.results.Delete(vm.NamespacedName())
 		return nil, nil
 	}
 
@@ -72,7 +85,7 @@ func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*prober
 		Logger:        ctrl.Log.WithName("readiness-probe").WithValues("vmName", vm.NamespacedName()),
 		PatchHelper:   patchHelper,
 		VM:            vm,
-		ProbeType:     "readiness",
+		ProbeType:     proberctx.ReadinessProbeType,
 		PeriodSeconds: p.PeriodSeconds,
 	}, nil
 }
@@ -81,7 +94,7 @@ func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*prober
 // sets the ReadyCondition in vm status if the new condition status is a transition.
 func (w *readinessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res probe.Result, resErr error) error {
 	vm := ctx.VM
-	condition := w.getCondition(res, resErr)
+	condition := w.getConditionWithThresholds(vm, res, resErr)
 
 	// We only send event when either the condition type is added or its status changes, not
 	// if either its reason, severity, or message changes.
@@ -110,6 +123,14 @@ func (w *readinessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res pr
 }
 
 func (w *readinessWorker) DoProbe(ctx *proberctx.ProbeContext) error {
+	p := ctx.VM.Spec.ReadinessProbe
+	startTime := w.results.StartTime(ctx.VM.NamespacedName())
+	if delay := time.Duration(p.InitialDelaySeconds) * time.Second; time.Since(startTime) < delay {
+		ctx.Logger.V(4).Info("Initial delay has not elapsed, skip running the readiness probe",
+			"initialDelaySeconds", p.InitialDelaySeconds)
+		return nil
+	}
+
 	res, err := w.runProbe(ctx)
 	if err != nil {
 		ctx.Logger.Error(err, "readiness probe fails", "result", res)
@@ -126,6 +147,9 @@ func (w *readinessWorker) getProbe(probeSpec *vmopv1.VirtualMachineReadinessProb
 	if probeSpec.TCPSocket != nil {
 		return w.prober.TCPProbe
 	}
+	if probeSpec.HTTPGet != nil {
+		return w.prober.HTTPGetProbe
+	}
 	if probeSpec.GuestHeartbeat != nil {
 		return w.prober.GuestHeartbeat
 	}
@@ -145,6 +169,66 @@ func (w *readinessWorker) runProbe(ctx *proberctx.ProbeContext) (probe.Result, e
 	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s readiness probe", ctx.VM.NamespacedName())
 }
 
+// getConditionWithThresholds returns condition based on VM probe results and
+// the success and failure thresholds of the VM's readiness probe. The status
+// of the existing condition is retained until the number of consecutive
+// results that disagree with it reaches the corresponding threshold.
+func (w *readinessWorker) getConditionWithThresholds(
+	vm *vmopv1.VirtualMachine,
+	res probe.Result,
+	err error) *metav1.Condition {
+
+	vmName := vm.NamespacedName()
+
+	// A VM that is not powered on is never ready, and the results observed
+	// before it was powered off no longer apply.
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		w.results.Delete(vmName)
+		return w.getCondition(res, err)
+	}
+
+	successThreshold, failureThreshold := int32(defaultSuccessThreshold), int32(defaultReadinessFailureThreshold)
+	if p := vm.Spec.ReadinessProbe; p != nil {
+		if p.SuccessThreshold > 0 {
+			successThreshold = p.SuccessThreshold
+		}
+		if p.FailureThreshold > 0 {
+			failureThreshold = p.FailureThreshold
+		}
+	}
+
+	threshold := failureThreshold
+	if res == probe.Success {
+		threshold = successThreshold
+	}
+
+	count := w.results.Add(vmName, res)
+	condition := w.getCondition(res, err)
+	if threshold > 1 && condition.Status != metav1.ConditionTrue {
+		condition.Message = probeResultMessage(res, count, threshold, err)
+	}
+	if count >= threshold {
+		return condition
+	}
+
+	current := conditions.Get(vm, vmopv1.ReadyConditionType)
+	switch {
+	case current == nil && res == probe.Success:
+		// A VM that has not yet been probed is not ready until the probe has
+		// succeeded the required number of consecutive times.
+		return conditions.FalseCondition(vmopv1.ReadyConditionType, notReadyReason,
+			"%s", probeResultMessage(res, count, threshold, err))
+	case current == nil || current.Status == condition.Status:
+		return condition
+	}
+
+	// The threshold has not been reached, so retain the status of the
+	// current condition while surfacing the consecutive results.
+	condition = current.DeepCopy()
+	condition.Message = probeResultMessage(res, count, threshold, err)
+	return condition
+}
+
 // getCondition returns condition based on VM probe results.
 func (w *readinessWorker) getCondition(res probe.Result, err error) *metav1.Condition {
 	msg := ""
@@ -161,3 +245,27 @@ func (w *readinessWorker) getCondition(res probe.Result, err error) *metav1.Cond
 		return conditions.UnknownCondition(vmopv1.ReadyConditionType, unknownReason, msg)
 	}
 }
+
+// probeResultMessage returns a message that describes the number of
+// consecutive times the probe result was observed relative to the threshold.
+// The count is capped at the threshold so the message does not change every
+// time the probe is run once the threshold is reached.
+func probeResultMessage(res probe.Result, count, threshold int32, err error) string {
+	count = min(count, threshold)
+
+	var msg string
+	switch res {
+	case probe.Success:
+		msg = fmt.Sprintf("probe succeeded %d of %d consecutive times", count, threshold)
+	case probe.Failure:
+		msg = fmt.Sprintf("probe failed %d of %d consecutive times", count, threshold)
+	default: // probe.Unknown
+		msg = fmt.Sprintf("probe result was unknown %d of %d consecutive times", count, threshold)
+	}
+
+	if err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, err)
+	}
+
+	return msg
+}
@@ -40,6 +40,7 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		fakeRecorder       record.Recorder
 		fakeEvents         chan string
 		fakeTCPProbe       *fakeprobe.FakeProbe
+		fakeHTTPGetProbe   *fakeprobe.FakeProbe
 		fakeHeartbeatProbe *fakeprobe.FakeProbe
 	)
 
@@ -63,12 +64,14 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 
 		queue := workqueue.NewNamedDelayingQueue("test")
 		fakeTCPProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHTTPGetProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		prober := &probe.Prober{
 			TCPProbe:       fakeTCPProbe,
+			HTTPGetProbe:   fakeHTTPGetProbe,
 			GuestHeartbeat: fakeHeartbeatProbe,
 		}
-		testWorker = NewReadinessWorker(queue, prober, fakeClient, fakeRecorder)
+		testWorker = NewReadinessWorker(queue, prober, fakeClient, fakeRecorder, NewProbeResults())
 	})
 
 	checkReadyCondition := func(c client.Client, objKey client.ObjectKey, expectedCondition metav1.ConditionStatus) {
@@ -161,6 +164,99 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		})
 	})
 
+	Context("VM has readiness probe with thresholds", func() {
+		var (
+			probeResult probe.Result
+		)
+
+		BeforeEach(func() {
+			probeResult = probe.Success
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				if probeResult == probe.Failure {
+					return probeResult, fmt.Errorf("connection refused")
+				}
+				return probeResult, nil
+			}
+
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(10001)
+			vm.Spec.ReadinessProbe.SuccessThreshold = 2
+			vm.Spec.ReadinessProbe.FailureThreshold = 3
+			Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			Expect(fakeClient.Status().Update(context.Background(), vm)).Should(Succeed())
+		})
+
+		doProbe := func() *metav1.Condition {
+			Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+			return conditions.Get(vm, vmopv1.ReadyConditionType)
+		}
+
+		It("Should only transition the ReadyCondition once a threshold is reached", func() {
+			By("Should not be ready until the success threshold is reached", func() {
+				c := doProbe()
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Message).To(Equal("probe succeeded 1 of 2 consecutive times"))
+				Expect(fakeEvents).Should(Receive(ContainSubstring(notReadyReason)))
+
+				c = doProbe()
+				Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				Expect(c.Message).To(BeEmpty())
+				Expect(fakeEvents).Should(Receive(Equal("Normal " + readyReason + " ")))
+			})
+
+			By("Should remain ready until the failure threshold is reached", func() {
+				probeResult = probe.Failure
+
+				c := doProbe()
+				Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				Expect(c.Message).To(Equal("probe failed 1 of 3 consecutive times: connection refused"))
+
+				c = doProbe()
+				Expect(c.Status).To(Equal(metav1.ConditionTrue))
+				Expect(c.Message).To(Equal("probe failed 2 of 3 consecutive times: connection refused"))
+
+				c = doProbe()
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Message).To(Equal("probe failed 3 of 3 consecutive times: connection refused"))
+				Expect(fakeEvents).Should(Receive(ContainSubstring(notReadyReason)))
+
+				c = doProbe()
+				Expect(c.Message).To(Equal("probe failed 3 of 3 consecutive times: connection refused"))
+			})
+
+			By("Should reset the consecutive failures when the probe succeeds", func() {
+				probeResult = probe.Success
+
+				c := doProbe()
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Message).To(Equal("probe succeeded 1 of 2 consecutive times"))
+
+				probeResult = probe.Failure
+
+				c = doProbe()
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Message).To(Equal("probe failed 1 of 3 consecutive times: connection refused"))
+			})
+		})
+
+		When("the VM has an initial delay", func() {
+			BeforeEach(func() {
+				vm.Spec.ReadinessProbe.InitialDelaySeconds = 60
+				Expect(fakeClient.Update(context.Background(), vm)).Should(Succeed())
+			})
+
+			It("Should not run the probe until the initial delay has elapsed", func() {
+				Expect(doProbe()).To(BeNil())
+			})
+		})
+	})
+
 	Context("Guest heartbeat Probe", func() {
 
 		BeforeEach(func() {
@@ -185,6 +281,32 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 			Expect(condition.Message).To(ContainSubstring("heartbeat error"))
 		})
 	})
+
+	Context("HTTPGet Probe", func() {
+
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineHTTPGetProbe(8080)
+			Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+			Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		// Just need to test for probe selection.
+		It("Should update ReadyCondition when probe fails", func() {
+			fakeHTTPGetProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("HTTP probe failed with statuscode: 500")
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			condition := conditions.Get(vm, vmopv1.ReadyConditionType)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("statuscode: 500"))
+		})
+	})
 })
 
 func TestReadinessProbeWorker(t *testing.T) {
@@ -207,3 +329,12 @@ func getVirtualMachineHeartbeatProbe() *vmopv1.VirtualMachineReadinessProbeSpec
 		PeriodSeconds:  1,
 	}
 }
+
+func getVirtualMachineHTTPGetProbe(port int) *vmopv1.VirtualMachineReadinessProbeSpec {
+	return &vmopv1.VirtualMachineReadinessProbeSpec{
+		HTTPGet: &vmopv1.HTTPGetAction{
+			Port: intstr.FromInt(port),
+		},
+		PeriodSeconds: 1,
+	}
+}
@@ -35,10 +35,14 @@ type funcs struct {
 	DeleteVirtualMachineFn              func(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachineFn             func(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
-	GetVirtualMachineGuestHeartbeatFn  func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
-	GetVirtualMachinePropertiesFn      func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
-	GetVirtualMachineWebMKSTicketFn    func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
-	GetVirtualMachineHardwareVersionFn func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+	GetVirtualMachineGuestHeartbeatFn             func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
+	GetVirtualMachinePropertiesFn                 func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
+	GetVirtualMachineWebMKSTicketFn               func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
+	GetVirtualMachineHardwareVersionFn            func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+	UpdateVirtualMachineDiskStorageIOAllocationFn func(ctx context.Context, vm *vmopv1.VirtualMachine, diskUUID string, storageIO vmopv1.VirtualMachineStorageIOAllocation) error
+
+	CreateOrUpdateVirtualMachineSnapshotFn func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+	DeleteVirtualMachineSnapshotFn         func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
 
 	GetItemFromLibraryByNameFn func(ctx context.Context, contentLibrary, itemName string) (*library.Item, error)
 	UpdateContentLibraryItemFn func(ctx context.Context, itemID, newName string, newDescription *string) error
@@ -180,6 +184,39 @@ func (s *VMProvider) GetVirtualMachineHardwareVersion(ctx context.Context, vm *v
 	return vimtypes.VMX15, nil
 }
 
+func (s *VMProvider) UpdateVirtualMachineDiskStorageIOAllocation(ctx context.Context, vm *vmopv1.VirtualMachine, diskUUID string, storageIO vmopv1.VirtualMachineStorageIOAllocation) error {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.UpdateVirtualMachineDiskStorageIOAllocationFn != nil {
+		return s.UpdateVirtualMachineDiskStorageIOAllocationFn(ctx, vm, diskUUID, storageIO)
+	}
+	return nil
+}
+
+func (s *VMProvider) CreateOrUpdateVirtualMachineSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.CreateOrUpdateVirtualMachineSnapshotFn != nil {
+		return s.CreateOrUpdateVirtualMachineSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return nil
+}
+
+func (s *VMProvider) DeleteVirtualMachineSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.DeleteVirtualMachineSnapshotFn != nil {
+		return s.DeleteVirtualMachineSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return nil
+}
+
 func (s *VMProvider) CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
 	_ = pkgcfg.FromContext(ctx)
 
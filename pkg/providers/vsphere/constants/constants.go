@@ -33,10 +33,20 @@ const (
 	VMOperatorV1Alpha1ConfigReady    = "ready"
 	VMOperatorV1Alpha1ConfigEnabled  = "enabled"
 
+	// GuestVolumeCapacityExtraConfigKey is the ExtraConfig key used to publish
+	// the capacities of a VM's volumes to the guest so an agent in the guest
+	// may grow the file systems on volumes that have been expanded.
+	GuestVolumeCapacityExtraConfigKey = "guestinfo.vmservice.volumes.capacity"
+
 	// GOSCPendingExtraConfigKey and GOSCIgnoreToolsCheckExtraConfigKey are GOSC Related ExtraConfig keys.
 	GOSCPendingExtraConfigKey          = "tools.deployPkg.fileName"
 	GOSCIgnoreToolsCheckExtraConfigKey = "vmware.tools.gosc.ignoretoolscheck"
 
+	// RevertedSnapshotExtraConfigKey is the ExtraConfig key used to record
+	// the snapshot to which the VM was reverted, and the generation of the VM
+	// that requested the revert, so the revert is not repeated.
+	RevertedSnapshotExtraConfigKey = "vmservice.snapshot.reverted"
+
 	// EnableDiskUUIDExtraConfigKey Enable UUID ExtraConfig key.
 	EnableDiskUUIDExtraConfigKey = "disk.enableUUID"
 
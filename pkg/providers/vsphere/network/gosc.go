@@ -6,6 +6,7 @@ package network
 
 import (
 	"net"
+	"slices"
 
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 )
@@ -42,13 +43,16 @@ func GuestOSCustomization(results NetworkInterfaceResults) ([]vimtypes.Customiza
 			}
 		}
 
+		var ipV6Spec vimtypes.CustomizationIPSettingsIpV6AddressSpec
+
 		if r.DHCP6 {
-			adapter.IpV6Spec = &vimtypes.CustomizationIPSettingsIpV6AddressSpec{
-				Ip: []vimtypes.BaseCustomizationIpV6Generator{
-					&vimtypes.CustomizationDhcpIpV6Generator{},
-				},
-			}
-		} else {
+			ipV6Spec.Ip = append(ipV6Spec.Ip, &vimtypes.CustomizationDhcpIpV6Generator{})
+		}
+		if r.SLAAC {
+			ipV6Spec.Ip = append(ipV6Spec.Ip, &vimtypes.CustomizationAutoIpV6Generator{})
+		}
+
+		if !r.DHCP6 {
 			for _, ipConfig := range r.IPConfigs {
 				if ipConfig.IsIPv4 {
 					continue
@@ -60,17 +64,20 @@ func GuestOSCustomization(results NetworkInterfaceResults) ([]vimtypes.Customiza
 				}
 				ones, _ := ipNet.Mask.Size()
 
-				if adapter.IpV6Spec == nil {
-					adapter.IpV6Spec = &vimtypes.CustomizationIPSettingsIpV6AddressSpec{}
-				}
-				adapter.IpV6Spec.Ip = append(adapter.IpV6Spec.Ip, &vimtypes.CustomizationFixedIpV6{
+				ipV6Spec.Ip = append(ipV6Spec.Ip, &vimtypes.CustomizationFixedIpV6{
 					IpAddress:  ip.String(),
 					SubnetMask: int32(ones), //nolint:gosec // disable G115
 				})
-				adapter.IpV6Spec.Gateway = append(adapter.IpV6Spec.Gateway, ipConfig.Gateway)
+				if gw := ipConfig.Gateway; gw != "" && !slices.Contains(ipV6Spec.Gateway, gw) {
+					ipV6Spec.Gateway = append(ipV6Spec.Gateway, gw)
+				}
 			}
 		}
 
+		if len(ipV6Spec.Ip) > 0 {
+			adapter.IpV6Spec = &ipV6Spec
+		}
+
 		mappings = append(mappings, vimtypes.CustomizationAdapterMapping{
 			MacAddress: r.MacAddress,
 			Adapter:    adapter,
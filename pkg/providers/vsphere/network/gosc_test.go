@@ -129,5 +129,42 @@ var _ = Describe("GOSC", func() {
 				Expect(ipv6Spec.Ip[0]).To(BeAssignableToTypeOf(&vimtypes.CustomizationDhcpIpV6Generator{}))
 			})
 		})
+
+		Context("IPv6 Static adapter with SLAAC", func() {
+			BeforeEach(func() {
+				results.Results = []network.NetworkInterfaceResult{
+					{
+						IPConfigs: []network.NetworkInterfaceIPConfig{
+							{
+								IPCIDR:  ipv6 + fmt.Sprintf("/%d", ipv6Subnet),
+								IsIPv4:  false,
+								Gateway: ipv6Gateway,
+							},
+						},
+						MacAddress: macAddr1,
+						Name:       "eth0",
+						SLAAC:      true,
+					},
+				}
+			})
+
+			It("returns success", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(adapterMappings).To(HaveLen(1))
+				adapter := adapterMappings[0].Adapter
+
+				Expect(adapter.Ip).To(BeNil())
+
+				ipv6Spec := adapter.IpV6Spec
+				Expect(ipv6Spec).ToNot(BeNil())
+				Expect(ipv6Spec.Gateway).To(Equal([]string{ipv6Gateway}))
+				Expect(ipv6Spec.Ip).To(HaveLen(2))
+				Expect(ipv6Spec.Ip[0]).To(BeAssignableToTypeOf(&vimtypes.CustomizationAutoIpV6Generator{}))
+				Expect(ipv6Spec.Ip[1]).To(BeAssignableToTypeOf(&vimtypes.CustomizationFixedIpV6{}))
+				addressSpec := ipv6Spec.Ip[1].(*vimtypes.CustomizationFixedIpV6)
+				Expect(addressSpec.IpAddress).To(Equal(ipv6))
+				Expect(addressSpec.SubnetMask).To(BeEquivalentTo(ipv6Subnet))
+			})
+		})
 	})
 })
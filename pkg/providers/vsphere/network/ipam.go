@@ -0,0 +1,251 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+var ipAddressClaimNameReplacer = strings.NewReplacer(".", "-", ":", "-")
+
+// IPAddressClaimName returns the name of the IPAddressClaim for the address
+// allocated from the IPPool. Since the name is derived from the address, the
+// API server guarantees an address is not allocated more than once.
+func IPAddressClaimName(poolName string, addr netip.Addr) string {
+	s := addr.String()
+	if addr.Is6() {
+		s = addr.StringExpanded()
+	}
+	return poolName + "-" + ipAddressClaimNameReplacer.Replace(s)
+}
+
+// allocateIPPoolAddresses allocates the addresses of a network interface from
+// the IPPools of its network, at most one address per IP family. An IP family
+// for which the interface uses DHCP, or SLAAC for IPv6, is not allocated an
+// address, and no address is allocated if the interface specifies static
+// addresses. The IPPools are considered in order of their names. Each
+// allocation is recorded by an IPAddressClaim owned by the VM so the address
+// is released when the VM is deleted, and an existing allocation is reused on
+// subsequent calls. The interface's claims from IPPools that are no longer
+// used, for example because the interface now uses DHCP or another network,
+// are deleted.
+func allocateIPPoolAddresses(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	networkName string,
+	interfaceSpec *vmopv1.VirtualMachineNetworkInterfaceSpec,
+	result *NetworkInterfaceResult) error {
+
+	vmClaimList := &vmopv1.IPAddressClaimList{}
+	if err := client.List(
+		vmCtx,
+		vmClaimList,
+		ctrlclient.InNamespace(vmCtx.VM.Namespace),
+		ctrlclient.MatchingLabels{
+			VMNameLabel:                             vmCtx.VM.Name,
+			vmopv1.IPAddressClaimInterfaceNameLabel: interfaceSpec.Name,
+		}); err != nil {
+		return fmt.Errorf("failed to list IPAddressClaims: %w", err)
+	}
+
+	var pools []vmopv1.IPPool
+
+	// Static addresses in the InterfaceSpec take precedence over the network's IPPools.
+	if len(interfaceSpec.Addresses) == 0 {
+		poolList := &vmopv1.IPPoolList{}
+		if err := client.List(vmCtx, poolList, ctrlclient.InNamespace(vmCtx.VM.Namespace)); err != nil {
+			return fmt.Errorf("failed to list IPPools: %w", err)
+		}
+
+		pools = slices.DeleteFunc(poolList.Items, func(p vmopv1.IPPool) bool {
+			return p.Spec.NetworkName != networkName
+		})
+		slices.SortFunc(pools, func(a, b vmopv1.IPPool) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	var (
+		usedPools        = sets.New[string]()
+		hasIPv4, hasIPv6 bool
+	)
+
+	for i := range pools {
+		pool := &pools[i]
+
+		ranges, err := util.ParseIPPoolAddresses(pool.Spec.Addresses)
+		if err != nil {
+			return fmt.Errorf("invalid IPPool %q: %w", pool.Name, err)
+		}
+		if len(ranges) == 0 {
+			continue
+		}
+
+		isIPv4 := ranges[0].Start.Is4()
+		if isIPv4 && (hasIPv4 || interfaceSpec.DHCP4) ||
+			!isIPv4 && (hasIPv6 || interfaceSpec.DHCP6 || interfaceSpec.SLAAC) {
+			continue
+		}
+
+		addr, err := claimIPPoolAddress(vmCtx, client, pool, ranges, interfaceSpec.Name, vmClaimList.Items)
+		if err != nil {
+			return err
+		}
+
+		usedPools.Insert(pool.Name)
+		if isIPv4 {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+
+		result.IPConfigs = append(result.IPConfigs, NetworkInterfaceIPConfig{
+			IPCIDR:  netip.PrefixFrom(addr, int(pool.Spec.Prefix)).String(),
+			IsIPv4:  isIPv4,
+			Gateway: pool.Spec.Gateway,
+		})
+
+		if len(result.Nameservers) == 0 {
+			result.Nameservers = pool.Spec.Nameservers
+		}
+		if len(result.SearchDomains) == 0 {
+			result.SearchDomains = pool.Spec.SearchDomains
+		}
+	}
+
+	return releaseIPPoolAddresses(vmCtx, client, vmClaimList.Items, usedPools)
+}
+
+// releaseIPPoolAddresses deletes the VM's claims that are not from one of the
+// provided IPPools.
+func releaseIPPoolAddresses(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	vmClaims []vmopv1.IPAddressClaim,
+	usedPools sets.Set[string]) error {
+
+	for i := range vmClaims {
+		claim := &vmClaims[i]
+		if usedPools.Has(claim.Spec.PoolName) || !isOwnedBy(claim, vmCtx.VM) {
+			continue
+		}
+
+		vmCtx.Logger.Info("Releasing address from IPPool",
+			"pool", claim.Spec.PoolName, "address", claim.Spec.Address, "interface", claim.Spec.InterfaceName)
+
+		if err := client.Delete(vmCtx, claim); ctrlclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete IPAddressClaim %q: %w", claim.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// claimIPPoolAddress returns the address of the VM's existing claim from the
+// IPPool, or otherwise claims the first available address of the IPPool.
+func claimIPPoolAddress(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	pool *vmopv1.IPPool,
+	ranges []util.IPRange,
+	interfaceName string,
+	vmClaims []vmopv1.IPAddressClaim) (netip.Addr, error) {
+
+	for i := range vmClaims {
+		claim := &vmClaims[i]
+		if claim.Spec.PoolName != pool.Name || !isOwnedBy(claim, vmCtx.VM) {
+			continue
+		}
+		if addr, err := netip.ParseAddr(claim.Spec.Address); err == nil {
+			return addr, nil
+		}
+	}
+
+	poolClaimList := &vmopv1.IPAddressClaimList{}
+	if err := client.List(
+		vmCtx,
+		poolClaimList,
+		ctrlclient.InNamespace(pool.Namespace),
+		ctrlclient.MatchingLabels{vmopv1.IPPoolNameLabel: pool.Name}); err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to list IPAddressClaims of IPPool %q: %w", pool.Name, err)
+	}
+
+	used := make(map[netip.Addr]struct{}, len(poolClaimList.Items))
+	for _, c := range poolClaimList.Items {
+		if addr, err := netip.ParseAddr(c.Spec.Address); err == nil {
+			used[addr] = struct{}{}
+		}
+	}
+	if gateway, err := netip.ParseAddr(pool.Spec.Gateway); err == nil {
+		used[gateway] = struct{}{}
+	}
+
+	for _, r := range ranges {
+		for addr := r.Start; addr.IsValid() && r.Contains(addr); addr = addr.Next() {
+			if _, ok := used[addr]; ok {
+				continue
+			}
+
+			claim := &vmopv1.IPAddressClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      IPAddressClaimName(pool.Name, addr),
+					Namespace: pool.Namespace,
+					Labels: map[string]string{
+						VMNameLabel:                             vmCtx.VM.Name,
+						vmopv1.IPPoolNameLabel:                  pool.Name,
+						vmopv1.IPAddressClaimInterfaceNameLabel: interfaceName,
+					},
+				},
+				Spec: vmopv1.IPAddressClaimSpec{
+					PoolName:      pool.Name,
+					Address:       addr.String(),
+					VMName:        vmCtx.VM.Name,
+					InterfaceName: interfaceName,
+				},
+			}
+
+			if err := controllerutil.SetOwnerReference(vmCtx.VM, claim, client.Scheme()); err != nil {
+				return netip.Addr{}, err
+			}
+
+			if err := client.Create(vmCtx, claim); err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					// Lost the race for this address so try the next one.
+					continue
+				}
+				return netip.Addr{}, fmt.Errorf("failed to create IPAddressClaim %q: %w", claim.Name, err)
+			}
+
+			vmCtx.Logger.Info("Allocated address from IPPool",
+				"pool", pool.Name, "address", claim.Spec.Address, "interface", interfaceName)
+
+			return addr, nil
+		}
+	}
+
+	return netip.Addr{}, fmt.Errorf("IPPool %q has no available addresses", pool.Name)
+}
+
+func isOwnedBy(obj metav1.Object, owner metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
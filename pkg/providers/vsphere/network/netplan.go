@@ -5,8 +5,10 @@
 package network
 
 import (
+	"fmt"
 	"strings"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
@@ -18,6 +20,20 @@ func NetPlanCustomization(result NetworkInterfaceResults) (*netplan.Network, err
 		Ethernets: make(map[string]netplan.Ethernet),
 	}
 
+	// The interfaces that are members of a bond or ports of a bridge do not
+	// have an IP configuration of their own.
+	memberNames := map[string]struct{}{}
+	for _, bond := range result.Bonds {
+		for _, name := range bond.Interfaces {
+			memberNames[name] = struct{}{}
+		}
+	}
+	for _, bridge := range result.Bridges {
+		for _, name := range bridge.Interfaces {
+			memberNames[name] = struct{}{}
+		}
+	}
+
 	for _, r := range result.Results {
 		npEth := netplan.Ethernet{
 			Match: &netplan.Match{
@@ -25,23 +41,32 @@ func NetPlanCustomization(result NetworkInterfaceResults) (*netplan.Network, err
 			},
 			SetName: &r.GuestDeviceName,
 			MTU:     &r.MTU,
-			Nameservers: &netplan.Nameserver{
-				Addresses: r.Nameservers,
-				Search:    r.SearchDomains,
-			},
+		}
+
+		if _, ok := memberNames[r.Name]; ok {
+			npEth.Dhcp4 = ptr.To(false)
+			npEth.Dhcp6 = ptr.To(false)
+			npEth.AcceptRa = ptr.To(false)
+			netPlan.Ethernets[r.Name] = npEth
+			continue
+		}
+
+		npEth.Nameservers = &netplan.Nameserver{
+			Addresses: r.Nameservers,
+			Search:    r.SearchDomains,
 		}
 
 		npEth.Dhcp4 = &r.DHCP4
 		npEth.Dhcp6 = &r.DHCP6
-		// Right now we can set the same value as DHCPv6 configuration
-		// and in some future separate/specialize if required
-		npEth.AcceptRa = &r.DHCP6
+		// Router advertisements are required for SLAAC, and are typically
+		// required for DHCPv6 since they tell the guest to use DHCPv6.
+		npEth.AcceptRa = ptr.To(r.DHCP6 || r.SLAAC)
 
 		if !*npEth.Dhcp4 {
 			for i := range r.IPConfigs {
 				ipConfig := r.IPConfigs[i]
 				if ipConfig.IsIPv4 {
-					if npEth.Gateway4 == nil || *npEth.Gateway4 == "" {
+					if ipConfig.Gateway != "" && (npEth.Gateway4 == nil || *npEth.Gateway4 == "") {
 						npEth.Gateway4 = &ipConfig.Gateway
 					}
 					npEth.Addresses = append(
@@ -57,7 +82,7 @@ func NetPlanCustomization(result NetworkInterfaceResults) (*netplan.Network, err
 			for i := range r.IPConfigs {
 				ipConfig := r.IPConfigs[i]
 				if !ipConfig.IsIPv4 {
-					if npEth.Gateway6 == nil || *npEth.Gateway6 == "" {
+					if ipConfig.Gateway != "" && (npEth.Gateway6 == nil || *npEth.Gateway6 == "") {
 						npEth.Gateway6 = &ipConfig.Gateway
 					}
 					npEth.Addresses = append(
@@ -85,9 +110,160 @@ func NetPlanCustomization(result NetworkInterfaceResults) (*netplan.Network, err
 		netPlan.Ethernets[r.Name] = npEth
 	}
 
+	for _, bond := range result.Bonds {
+		if netPlan.Bonds == nil {
+			netPlan.Bonds = make(map[string]netplan.Bond)
+		}
+
+		ipConfig := toNetPlanDeviceIPConfig(bond.VirtualMachineNetworkDeviceIPSpec)
+		npBond := netplan.Bond{
+			Interfaces:  bond.Interfaces,
+			Addresses:   ipConfig.addresses,
+			Dhcp4:       ipConfig.dhcp4,
+			Dhcp6:       ipConfig.dhcp6,
+			AcceptRa:    ipConfig.acceptRa,
+			Gateway4:    ipConfig.gateway4,
+			Gateway6:    ipConfig.gateway6,
+			MTU:         ipConfig.mtu,
+			Nameservers: ipConfig.nameservers,
+			Routes:      ipConfig.routes,
+		}
+
+		if bond.Mode != "" || bond.Primary != "" || bond.MIIMonitorInterval != nil ||
+			bond.LACPRate != "" || bond.TransmitHashPolicy != "" {
+
+			npBond.Parameters = &netplan.BondParameters{}
+			if bond.Mode != "" {
+				npBond.Parameters.Mode = ptr.To(netplan.BondMode(bond.Mode))
+			}
+			if bond.Primary != "" {
+				npBond.Parameters.Primary = &bond.Primary
+			}
+			if bond.MIIMonitorInterval != nil {
+				npBond.Parameters.MiiMonitorInterval = ptr.To(fmt.Sprintf("%dms", *bond.MIIMonitorInterval))
+			}
+			if bond.LACPRate != "" {
+				npBond.Parameters.LACPRate = ptr.To(netplan.LACPRate(bond.LACPRate))
+			}
+			if bond.TransmitHashPolicy != "" {
+				npBond.Parameters.TransmitHashPolicy = ptr.To(netplan.TransmitHashPolicy(bond.TransmitHashPolicy))
+			}
+		}
+
+		netPlan.Bonds[bond.Name] = npBond
+	}
+
+	for _, vlan := range result.VLANs {
+		if netPlan.Vlans == nil {
+			netPlan.Vlans = make(map[string]netplan.VLAN)
+		}
+
+		ipConfig := toNetPlanDeviceIPConfig(vlan.VirtualMachineNetworkDeviceIPSpec)
+		netPlan.Vlans[vlan.Name] = netplan.VLAN{
+			ID:          ptr.To(int64(vlan.ID)),
+			Link:        &vlan.Link,
+			Addresses:   ipConfig.addresses,
+			Dhcp4:       ipConfig.dhcp4,
+			Dhcp6:       ipConfig.dhcp6,
+			AcceptRa:    ipConfig.acceptRa,
+			Gateway4:    ipConfig.gateway4,
+			Gateway6:    ipConfig.gateway6,
+			MTU:         ipConfig.mtu,
+			Nameservers: ipConfig.nameservers,
+			Routes:      ipConfig.routes,
+		}
+	}
+
+	for _, bridge := range result.Bridges {
+		if netPlan.Bridges == nil {
+			netPlan.Bridges = make(map[string]netplan.Bridge)
+		}
+
+		ipConfig := toNetPlanDeviceIPConfig(bridge.VirtualMachineNetworkDeviceIPSpec)
+		npBridge := netplan.Bridge{
+			Interfaces:  bridge.Interfaces,
+			Addresses:   ipConfig.addresses,
+			Dhcp4:       ipConfig.dhcp4,
+			Dhcp6:       ipConfig.dhcp6,
+			AcceptRa:    ipConfig.acceptRa,
+			Gateway4:    ipConfig.gateway4,
+			Gateway6:    ipConfig.gateway6,
+			MTU:         ipConfig.mtu,
+			Nameservers: ipConfig.nameservers,
+			Routes:      ipConfig.routes,
+		}
+		if bridge.STP != nil {
+			npBridge.Parameters = &netplan.BridgeParameters{
+				Stp: bridge.STP,
+			}
+		}
+
+		netPlan.Bridges[bridge.Name] = npBridge
+	}
+
 	return netPlan, nil
 }
 
+// netPlanDeviceIPConfig is the IP configuration shared by the netplan bond,
+// VLAN, and bridge device types.
+type netPlanDeviceIPConfig struct {
+	addresses   []netplan.Address
+	dhcp4       *bool
+	dhcp6       *bool
+	acceptRa    *bool
+	gateway4    *string
+	gateway6    *string
+	mtu         *int64
+	nameservers *netplan.Nameserver
+	routes      []netplan.Route
+}
+
+func toNetPlanDeviceIPConfig(spec vmopv1.VirtualMachineNetworkDeviceIPSpec) netPlanDeviceIPConfig {
+	ipConfig := netPlanDeviceIPConfig{
+		dhcp4:    ptr.To(spec.DHCP4),
+		dhcp6:    ptr.To(spec.DHCP6),
+		acceptRa: ptr.To(spec.DHCP6),
+		mtu:      spec.MTU,
+	}
+
+	for i := range spec.Addresses {
+		ipConfig.addresses = append(
+			ipConfig.addresses,
+			netplan.Address{
+				String: &spec.Addresses[i],
+			},
+		)
+	}
+
+	if spec.Gateway4 != "" {
+		ipConfig.gateway4 = &spec.Gateway4
+	}
+	if spec.Gateway6 != "" {
+		ipConfig.gateway6 = &spec.Gateway6
+	}
+
+	if len(spec.Nameservers) > 0 || len(spec.SearchDomains) > 0 {
+		ipConfig.nameservers = &netplan.Nameserver{
+			Addresses: spec.Nameservers,
+			Search:    spec.SearchDomains,
+		}
+	}
+
+	for i := range spec.Routes {
+		route := spec.Routes[i]
+		ipConfig.routes = append(
+			ipConfig.routes,
+			netplan.Route{
+				To:     &route.To,
+				Metric: ptr.To(int64(route.Metric)),
+				Via:    &route.Via,
+			},
+		)
+	}
+
+	return ipConfig
+}
+
 // NormalizeNetplanMac normalizes the mac address format to one compatible with netplan.
 func NormalizeNetplanMac(mac string) string {
 	mac = strings.ReplaceAll(mac, "-", ":")
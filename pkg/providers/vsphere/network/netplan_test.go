@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
@@ -41,7 +42,7 @@ var _ = Describe("Netplan", func() {
 		)
 
 		BeforeEach(func() {
-			results.Results = nil
+			results = network.NetworkInterfaceResults{}
 			config = nil
 		})
 
@@ -150,5 +151,162 @@ var _ = Describe("Netplan", func() {
 				Expect(np.Routes).To(BeEmpty())
 			})
 		})
+
+		Context("IPv6 Static adapter with SLAAC", func() {
+			BeforeEach(func() {
+				results.Results = []network.NetworkInterfaceResult{
+					{
+						IPConfigs: []network.NetworkInterfaceIPConfig{
+							{
+								IPCIDR: ipv6 + fmt.Sprintf("/%d", ipv6Subnet),
+								IsIPv4: false,
+							},
+						},
+						MacAddress:      macAddr1,
+						Name:            ifName,
+						GuestDeviceName: guestDevName,
+						SLAAC:           true,
+					},
+				}
+			})
+
+			It("returns success", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).ToNot(BeNil())
+				Expect(config.Ethernets).To(HaveKey(ifName))
+
+				np := config.Ethernets[ifName]
+				Expect(*np.Dhcp4).To(BeFalse())
+				Expect(*np.Dhcp6).To(BeFalse())
+				Expect(*np.AcceptRa).To(BeTrue())
+				Expect(np.Addresses).To(Equal([]netplan.Address{{String: ptr.To(ipv6 + fmt.Sprintf("/%d", ipv6Subnet))}}))
+				Expect(np.Gateway4).To(BeNil())
+				Expect(np.Gateway6).To(BeNil())
+			})
+		})
+
+		Context("Bond with VLAN and bridge", func() {
+			const (
+				ifName2       = "my-interface2"
+				guestDevName2 = "eth43"
+				macAddr2      = "50-8A-80-9D-28-23"
+				macAddr2Norm  = "50:8a:80:9d:28:23"
+			)
+
+			BeforeEach(func() {
+				results.Results = []network.NetworkInterfaceResult{
+					{
+						IPConfigs: []network.NetworkInterfaceIPConfig{
+							{
+								IPCIDR:  ipv4CIDR,
+								IsIPv4:  true,
+								Gateway: ipv4Gateway,
+							},
+						},
+						MacAddress:      macAddr1,
+						Name:            ifName,
+						GuestDeviceName: guestDevName,
+						MTU:             9000,
+						Nameservers:     []string{dnsServer1},
+					},
+					{
+						MacAddress:      macAddr2,
+						Name:            ifName2,
+						GuestDeviceName: guestDevName2,
+						DHCP4:           true,
+						MTU:             9000,
+					},
+				}
+				results.Bonds = []vmopv1.VirtualMachineNetworkBondSpec{
+					{
+						Name:               "bond0",
+						Interfaces:         []string{ifName, ifName2},
+						Mode:               "802.3ad",
+						MIIMonitorInterval: ptr.To[int32](100),
+						LACPRate:           "fast",
+						TransmitHashPolicy: "layer3+4",
+						VirtualMachineNetworkDeviceIPSpec: vmopv1.VirtualMachineNetworkDeviceIPSpec{
+							Addresses:   []string{ipv4CIDR},
+							Gateway4:    ipv4Gateway,
+							MTU:         ptr.To[int64](9000),
+							Nameservers: []string{dnsServer1},
+						},
+					},
+				}
+				results.VLANs = []vmopv1.VirtualMachineNetworkVLANSpec{
+					{
+						Name: "bond0.100",
+						ID:   100,
+						Link: "bond0",
+					},
+				}
+				results.Bridges = []vmopv1.VirtualMachineNetworkBridgeSpec{
+					{
+						Name:       "br0",
+						Interfaces: []string{"bond0.100"},
+						STP:        ptr.To(false),
+						VirtualMachineNetworkDeviceIPSpec: vmopv1.VirtualMachineNetworkDeviceIPSpec{
+							DHCP4:         true,
+							SearchDomains: []string{searchDomain1},
+						},
+					},
+				}
+			})
+
+			It("returns success", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).ToNot(BeNil())
+				Expect(config.Ethernets).To(HaveLen(2))
+
+				for name, mac := range map[string]string{ifName: macAddr1Norm, ifName2: macAddr2Norm} {
+					Expect(config.Ethernets).To(HaveKey(name))
+					np := config.Ethernets[name]
+					Expect(np.Match).ToNot(BeNil())
+					Expect(np.Match.Macaddress).To(HaveValue(Equal(mac)))
+					Expect(np.MTU).To(HaveValue(BeEquivalentTo(9000)))
+					Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+					Expect(np.Dhcp6).To(HaveValue(BeFalse()))
+					Expect(np.AcceptRa).To(HaveValue(BeFalse()))
+					Expect(np.Addresses).To(BeEmpty())
+					Expect(np.Gateway4).To(BeNil())
+					Expect(np.Nameservers).To(BeNil())
+				}
+
+				Expect(config.Bonds).To(HaveLen(1))
+				Expect(config.Bonds).To(HaveKey("bond0"))
+				bond := config.Bonds["bond0"]
+				Expect(bond.Interfaces).To(Equal([]string{ifName, ifName2}))
+				Expect(bond.Addresses).To(Equal([]netplan.Address{{String: ptr.To(ipv4CIDR)}}))
+				Expect(bond.Gateway4).To(HaveValue(Equal(ipv4Gateway)))
+				Expect(bond.Dhcp4).To(HaveValue(BeFalse()))
+				Expect(bond.MTU).To(HaveValue(BeEquivalentTo(9000)))
+				Expect(bond.Nameservers).ToNot(BeNil())
+				Expect(bond.Nameservers.Addresses).To(Equal([]string{dnsServer1}))
+				Expect(bond.Parameters).ToNot(BeNil())
+				Expect(bond.Parameters.Mode).To(HaveValue(Equal(netplan.BondMode("802.3ad"))))
+				Expect(bond.Parameters.MiiMonitorInterval).To(HaveValue(Equal("100ms")))
+				Expect(bond.Parameters.LACPRate).To(HaveValue(Equal(netplan.LACPRate("fast"))))
+				Expect(bond.Parameters.TransmitHashPolicy).To(HaveValue(Equal(netplan.TransmitHashPolicy("layer3+4"))))
+				Expect(bond.Parameters.Primary).To(BeNil())
+
+				Expect(config.Vlans).To(HaveLen(1))
+				Expect(config.Vlans).To(HaveKey("bond0.100"))
+				vlan := config.Vlans["bond0.100"]
+				Expect(vlan.ID).To(HaveValue(BeEquivalentTo(100)))
+				Expect(vlan.Link).To(HaveValue(Equal("bond0")))
+				Expect(vlan.Dhcp4).To(HaveValue(BeFalse()))
+				Expect(vlan.Addresses).To(BeEmpty())
+
+				Expect(config.Bridges).To(HaveLen(1))
+				Expect(config.Bridges).To(HaveKey("br0"))
+				bridge := config.Bridges["br0"]
+				Expect(bridge.Interfaces).To(Equal([]string{"bond0.100"}))
+				Expect(bridge.Dhcp4).To(HaveValue(BeTrue()))
+				Expect(bridge.Nameservers).ToNot(BeNil())
+				Expect(bridge.Nameservers.Search).To(Equal([]string{searchDomain1}))
+				Expect(bridge.Parameters).ToNot(BeNil())
+				Expect(bridge.Parameters.Stp).To(HaveValue(BeFalse()))
+			})
+		})
 	})
 })
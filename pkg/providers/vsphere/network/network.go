@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,6 +39,12 @@ import (
 
 type NetworkInterfaceResults struct {
 	Results []NetworkInterfaceResult
+
+	// Bonds, VLANs, and Bridges are the virtual devices configured in the
+	// guest on top of the network interfaces in Results.
+	Bonds   []vmopv1.VirtualMachineNetworkBondSpec
+	VLANs   []vmopv1.VirtualMachineNetworkVLANSpec
+	Bridges []vmopv1.VirtualMachineNetworkBridgeSpec
 }
 
 type NetworkInterfaceResult struct {
@@ -54,6 +61,7 @@ type NetworkInterfaceResult struct {
 	GuestDeviceName string
 	DHCP4           bool
 	DHCP6           bool
+	SLAAC           bool
 	MTU             int64
 	Nameservers     []string
 	SearchDomains   []string
@@ -144,7 +152,7 @@ func CreateAndWaitForNetworkInterfaces(
 		case pkgcfg.NetworkProviderTypeVPC:
 			result, err = createVPCNetworkInterface(vmCtx, client, vimClient, clusterMoRef, interfaceSpec)
 		case pkgcfg.NetworkProviderTypeNamed:
-			result, err = createNamedNetworkInterface(vmCtx, finder, interfaceSpec)
+			result, err = createNamedNetworkInterface(vmCtx, client, finder, interfaceSpec)
 		default:
 			err = fmt.Errorf("unsupported network provider envvar value: %q", networkType)
 		}
@@ -168,11 +176,67 @@ func CreateAndWaitForNetworkInterfaces(
 	// unused network interface CRDs so they can be deleted after they're removed from the VM
 	// via Reconfigure, instead of delaying that until the VM is deleted via GC.
 
+	bonds, vlans, bridges := applyNetworkSpecToDevices(
+		networkSpec,
+		defaultToGlobalNameservers,
+		defaultToGlobalSearchDomains)
+
 	return NetworkInterfaceResults{
 		Results: results,
+		Bonds:   bonds,
+		VLANs:   vlans,
+		Bridges: bridges,
 	}, nil
 }
 
+// applyNetworkSpecToDevices returns copies of the bonds, VLANs, and bridges from the NetworkSpec
+// with the global nameservers and search domains applied to the devices that have an IP
+// configuration but do not specify their own.
+func applyNetworkSpecToDevices(
+	networkSpec *vmopv1.VirtualMachineNetworkSpec,
+	defaultToGlobalNameservers bool,
+	defaultToGlobalSearchDomains bool) (
+	[]vmopv1.VirtualMachineNetworkBondSpec,
+	[]vmopv1.VirtualMachineNetworkVLANSpec,
+	[]vmopv1.VirtualMachineNetworkBridgeSpec) {
+
+	applyDefaults := func(ipSpec *vmopv1.VirtualMachineNetworkDeviceIPSpec) {
+		if len(ipSpec.Addresses) == 0 && !ipSpec.DHCP4 && !ipSpec.DHCP6 {
+			return
+		}
+		if defaultToGlobalNameservers && len(ipSpec.Nameservers) == 0 {
+			ipSpec.Nameservers = networkSpec.Nameservers
+		}
+		if defaultToGlobalSearchDomains && len(ipSpec.SearchDomains) == 0 {
+			ipSpec.SearchDomains = networkSpec.SearchDomains
+		}
+	}
+
+	var (
+		bonds   []vmopv1.VirtualMachineNetworkBondSpec
+		vlans   []vmopv1.VirtualMachineNetworkVLANSpec
+		bridges []vmopv1.VirtualMachineNetworkBridgeSpec
+	)
+
+	for i := range networkSpec.Bonds {
+		bond := *networkSpec.Bonds[i].DeepCopy()
+		applyDefaults(&bond.VirtualMachineNetworkDeviceIPSpec)
+		bonds = append(bonds, bond)
+	}
+	for i := range networkSpec.VLANs {
+		vlan := *networkSpec.VLANs[i].DeepCopy()
+		applyDefaults(&vlan.VirtualMachineNetworkDeviceIPSpec)
+		vlans = append(vlans, vlan)
+	}
+	for i := range networkSpec.Bridges {
+		bridge := *networkSpec.Bridges[i].DeepCopy()
+		applyDefaults(&bridge.VirtualMachineNetworkDeviceIPSpec)
+		bridges = append(bridges, bridge)
+	}
+
+	return bonds, vlans, bridges
+}
+
 // applyInterfaceSpecToResult applies the InterfaceSpec to results. Much of the InterfaceSpec - like DHCP -
 // cannot be specified to the underlying network provider so apply those overrides to the results.
 func applyInterfaceSpecToResult(
@@ -182,7 +246,8 @@ func applyInterfaceSpecToResult(
 	defaultToGlobalSearchDomains bool,
 	result *NetworkInterfaceResult) {
 
-	// We don't really support IPv6 yet so don't enable it when the underlying provider didn't return any IPs.
+	// Fallback to DHCP4 when the underlying provider didn't return any IPs. DHCP6 and SLAAC are
+	// only enabled when explicitly requested since not every network has an IPv6 router.
 	dhcp4 := interfaceSpec.DHCP4 || len(result.IPConfigs) == 0
 	dhcp6 := interfaceSpec.DHCP6
 
@@ -223,16 +288,17 @@ func applyInterfaceSpecToResult(
 
 	result.DHCP4 = dhcp4
 	result.DHCP6 = dhcp6
+	result.SLAAC = interfaceSpec.SLAAC
 
 	if n := interfaceSpec.Nameservers; len(n) > 0 {
 		result.Nameservers = n
-	} else if defaultToGlobalNameservers {
+	} else if defaultToGlobalNameservers && len(result.Nameservers) == 0 {
 		result.Nameservers = networkSpec.Nameservers
 	}
 
 	if d := interfaceSpec.SearchDomains; len(d) > 0 {
 		result.SearchDomains = d
-	} else if defaultToGlobalSearchDomains {
+	} else if defaultToGlobalSearchDomains && len(result.SearchDomains) == 0 {
 		result.SearchDomains = networkSpec.SearchDomains
 	}
 
@@ -246,6 +312,7 @@ func applyInterfaceSpecToResult(
 
 func createNamedNetworkInterface(
 	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
 	finder *find.Finder,
 	interfaceSpec *vmopv1.VirtualMachineNetworkInterfaceSpec) (*NetworkInterfaceResult, error) {
 
@@ -271,10 +338,18 @@ func createNamedNetworkInterface(
 		return nil, fmt.Errorf("unable to find named network %q: %w", networkRefName, err)
 	}
 
-	return &NetworkInterfaceResult{
+	result := &NetworkInterfaceResult{
 		NetworkID: networkRefName,
 		Backing:   backing,
-	}, nil
+	}
+
+	if pkgcfg.FromContext(vmCtx).Features.VMIPPools {
+		if err := allocateIPPoolAddresses(vmCtx, client, networkRefName, interfaceSpec, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
 
 // NetOPCRName returns the name to be used for the NetOP NetworkInterface CR.
@@ -374,9 +449,14 @@ func netOpNetIfToResult(
 
 	ipConfigs := make([]NetworkInterfaceIPConfig, 0, len(netIf.Status.IPConfigs))
 	for _, ip := range netIf.Status.IPConfigs {
+		isIPv4 := ip.IPFamily == corev1.IPv4Protocol
+		if ip.IPFamily == "" {
+			isIPv4 = net.ParseIP(ip.IP).To4() != nil
+		}
+
 		ipConfig := NetworkInterfaceIPConfig{
-			IPCIDR:  ipCIDRNotation(ip.IP, ip.SubnetMask, ip.IPFamily == corev1.IPv4Protocol),
-			IsIPv4:  ip.IPFamily == corev1.IPv4Protocol,
+			IPCIDR:  ipCIDRNotation(ip.IP, ip.SubnetMask, isIPv4),
+			IsIPv4:  isIPv4,
 			Gateway: ip.Gateway,
 		}
 		ipConfigs = append(ipConfigs, ipConfig)
@@ -696,7 +776,10 @@ func vpcSubnetPortToResult(
 			continue
 		}
 		// IPAddresses have CIDR format.
-		ip, _, _ := net.ParseCIDR(ipAddr.IPAddress)
+		ip, _, err := net.ParseCIDR(ipAddr.IPAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SubnetPort IP address %q: %w", ipAddr.IPAddress, err)
+		}
 		isIPv4 := ip.To4() != nil
 		ipConfig := NetworkInterfaceIPConfig{
 			IPCIDR:  ipAddr.IPAddress,
@@ -805,19 +888,34 @@ func waitForReadyNCPNetworkInterface(
 }
 
 // ipCIDRNotation takes the IP and subnet mask and returns the IP in CIDR notation.
-// TODO: Better error checking. Nail down exactly how we want handle IPv4inV6 addresses.
+// The subnet mask may be either a mask, ex. 255.255.255.0 or ffff:ffff:ffff:ffff::,
+// or a prefix length, ex. 24 or 64. If the IP already includes the prefix length,
+// then the subnet mask is ignored.
+// TODO: Nail down exactly how we want handle IPv4inV6 addresses.
 func ipCIDRNotation(ip string, mask string, isIPv4 bool) string {
+	if _, _, err := net.ParseCIDR(ip); err == nil {
+		return ip
+	}
+
+	bits := net.IPv6len * 8
+	parsedIP := net.ParseIP(ip).To16()
 	if isIPv4 {
-		ipNet := net.IPNet{
-			IP:   net.ParseIP(ip).To4(),
-			Mask: net.IPMask(net.ParseIP(mask).To4()),
-		}
-		return ipNet.String()
+		bits = net.IPv4len * 8
+		parsedIP = net.ParseIP(ip).To4()
+	}
+
+	var ipMask net.IPMask
+	if prefixLen, err := strconv.Atoi(mask); err == nil {
+		ipMask = net.CIDRMask(prefixLen, bits)
+	} else if isIPv4 {
+		ipMask = net.IPMask(net.ParseIP(mask).To4())
+	} else {
+		ipMask = net.IPMask(net.ParseIP(mask).To16())
 	}
 
 	ipNet := net.IPNet{
-		IP:   net.ParseIP(ip).To16(),
-		Mask: net.IPMask(net.ParseIP(mask).To16()),
+		IP:   parsedIP,
+		Mask: ipMask,
 	}
 
 	return ipNet.String()
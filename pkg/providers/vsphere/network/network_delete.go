@@ -0,0 +1,129 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	netopv1alpha1 "github.com/vmware-tanzu/net-operator-api/api/v1alpha1"
+	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	ncpv1alpha1 "github.com/vmware-tanzu/vm-operator/external/ncp/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// DeleteRemovedNetworkInterfaces deletes the network interface CRs, and the
+// IPAddressClaims, that belong to the VM's network interfaces that are no
+// longer in the network spec. Otherwise, these objects would not be deleted
+// until the VM is deleted since they are owned by the VM.
+func DeleteRemovedNetworkInterfaces(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	networkSpec *vmopv1.VirtualMachineNetworkSpec) error {
+
+	var interfaces []vmopv1.VirtualMachineNetworkInterfaceSpec
+	if networkSpec != nil && !networkSpec.Disabled {
+		interfaces = networkSpec.Interfaces
+	}
+
+	networkType := pkgcfg.FromContext(vmCtx).NetworkProviderType
+	vmName := vmCtx.VM.Name
+
+	crNames := sets.New[string]()
+	interfaceNames := sets.New[string]()
+	for i := range interfaces {
+		interfaceSpec := &interfaces[i]
+		interfaceNames.Insert(interfaceSpec.Name)
+
+		var networkName string
+		if netRef := interfaceSpec.Network; netRef != nil {
+			networkName = netRef.Name
+		}
+
+		switch networkType {
+		case pkgcfg.NetworkProviderTypeVDS:
+			crNames.Insert(
+				NetOPCRName(vmName, networkName, interfaceSpec.Name, true),
+				NetOPCRName(vmName, networkName, interfaceSpec.Name, false))
+		case pkgcfg.NetworkProviderTypeNSXT:
+			crNames.Insert(
+				NCPCRName(vmName, networkName, interfaceSpec.Name, true),
+				NCPCRName(vmName, networkName, interfaceSpec.Name, false))
+		case pkgcfg.NetworkProviderTypeVPC:
+			crNames.Insert(VPCCRName(vmName, networkName, interfaceSpec.Name))
+		}
+	}
+
+	listOpts := []ctrlclient.ListOption{
+		ctrlclient.InNamespace(vmCtx.VM.Namespace),
+		ctrlclient.MatchingLabels{VMNameLabel: vmName},
+	}
+
+	var removed []ctrlclient.Object
+
+	switch networkType {
+	case pkgcfg.NetworkProviderTypeVDS:
+		list := &netopv1alpha1.NetworkInterfaceList{}
+		if err := client.List(vmCtx, list, listOpts...); err != nil {
+			return fmt.Errorf("failed to list NetworkInterfaces: %w", err)
+		}
+		for i := range list.Items {
+			if !crNames.Has(list.Items[i].Name) {
+				removed = append(removed, &list.Items[i])
+			}
+		}
+	case pkgcfg.NetworkProviderTypeNSXT:
+		list := &ncpv1alpha1.VirtualNetworkInterfaceList{}
+		if err := client.List(vmCtx, list, listOpts...); err != nil {
+			return fmt.Errorf("failed to list VirtualNetworkInterfaces: %w", err)
+		}
+		for i := range list.Items {
+			if !crNames.Has(list.Items[i].Name) {
+				removed = append(removed, &list.Items[i])
+			}
+		}
+	case pkgcfg.NetworkProviderTypeVPC:
+		list := &vpcv1alpha1.SubnetPortList{}
+		if err := client.List(vmCtx, list, listOpts...); err != nil {
+			return fmt.Errorf("failed to list SubnetPorts: %w", err)
+		}
+		for i := range list.Items {
+			if !crNames.Has(list.Items[i].Name) {
+				removed = append(removed, &list.Items[i])
+			}
+		}
+	case pkgcfg.NetworkProviderTypeNamed:
+		list := &vmopv1.IPAddressClaimList{}
+		if err := client.List(vmCtx, list, listOpts...); err != nil {
+			return fmt.Errorf("failed to list IPAddressClaims: %w", err)
+		}
+		for i := range list.Items {
+			if !interfaceNames.Has(list.Items[i].Labels[vmopv1.IPAddressClaimInterfaceNameLabel]) {
+				removed = append(removed, &list.Items[i])
+			}
+		}
+	}
+
+	for _, obj := range removed {
+		// Only delete the objects created for this VM.
+		if !isOwnedBy(obj, vmCtx.VM) {
+			continue
+		}
+
+		vmCtx.Logger.Info("Deleting removed network interface object",
+			"kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+
+		if err := client.Delete(vmCtx, obj); ctrlclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %q: %w", obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	netopv1alpha1 "github.com/vmware-tanzu/net-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("DeleteRemovedNetworkInterfaces", func() {
+	const (
+		namespace   = "network-delete-ns"
+		networkName = "my-network"
+	)
+
+	var (
+		ctx         context.Context
+		vmCtx       pkgctx.VirtualMachineContext
+		vm          *vmopv1.VirtualMachine
+		networkSpec *vmopv1.VirtualMachineNetworkSpec
+
+		fakeClient  client.Client
+		initObjects []client.Object
+		err         error
+	)
+
+	ownedObjectMeta := func(name string, labels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: vmopv1.GroupVersion.String(),
+					Kind:       "VirtualMachine",
+					Name:       vm.Name,
+					UID:        vm.UID,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = pkgcfg.NewContextWithDefaultConfig()
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "network-delete-vm",
+				Namespace: namespace,
+				UID:       "network-delete-vm-uid",
+			},
+		}
+
+		networkSpec = &vmopv1.VirtualMachineNetworkSpec{
+			Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+				{
+					Name:    "eth0",
+					Network: &common.PartialObjectRef{Name: networkName},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = builder.NewFakeClient(initObjects...)
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithName("network_delete_test"),
+			VM:      vm,
+		}
+
+		err = network.DeleteRemovedNetworkInterfaces(vmCtx, fakeClient, networkSpec)
+	})
+
+	AfterEach(func() {
+		initObjects = nil
+	})
+
+	Context("VDS", func() {
+		var (
+			keptNetIf, removedNetIf, otherNetIf *netopv1alpha1.NetworkInterface
+		)
+
+		BeforeEach(func() {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.NetworkProviderType = pkgcfg.NetworkProviderTypeVDS
+			})
+
+			labels := map[string]string{network.VMNameLabel: vm.Name}
+
+			keptNetIf = &netopv1alpha1.NetworkInterface{
+				ObjectMeta: ownedObjectMeta(network.NetOPCRName(vm.Name, networkName, "eth0", false), labels),
+			}
+			removedNetIf = &netopv1alpha1.NetworkInterface{
+				ObjectMeta: ownedObjectMeta(network.NetOPCRName(vm.Name, networkName, "eth1", false), labels),
+			}
+			otherNetIf = &netopv1alpha1.NetworkInterface{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      network.NetOPCRName(vm.Name, networkName, "eth2", false),
+					Namespace: namespace,
+					Labels:    labels,
+				},
+			}
+
+			initObjects = append(initObjects, keptNetIf, removedNetIf, otherNetIf)
+		})
+
+		It("deletes the NetworkInterface of the removed interface", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			netIf := &netopv1alpha1.NetworkInterface{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(keptNetIf), netIf)).To(Succeed())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(otherNetIf), netIf)).To(Succeed())
+
+			err := fakeClient.Get(ctx, client.ObjectKeyFromObject(removedNetIf), netIf)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		When("the network is disabled", func() {
+			BeforeEach(func() {
+				networkSpec.Disabled = true
+			})
+
+			It("deletes all the NetworkInterfaces owned by the VM", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				netIf := &netopv1alpha1.NetworkInterface{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(otherNetIf), netIf)).To(Succeed())
+
+				err := fakeClient.Get(ctx, client.ObjectKeyFromObject(keptNetIf), netIf)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(removedNetIf), netIf)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("Named Network", func() {
+		var (
+			keptClaim, removedClaim *vmopv1.IPAddressClaim
+		)
+
+		BeforeEach(func() {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.NetworkProviderType = pkgcfg.NetworkProviderTypeNamed
+			})
+
+			keptClaim = &vmopv1.IPAddressClaim{
+				ObjectMeta: ownedObjectMeta("my-pool-192-168-10-10", map[string]string{
+					network.VMNameLabel:                     vm.Name,
+					vmopv1.IPAddressClaimInterfaceNameLabel: "eth0",
+				}),
+			}
+			removedClaim = &vmopv1.IPAddressClaim{
+				ObjectMeta: ownedObjectMeta("my-pool-192-168-10-11", map[string]string{
+					network.VMNameLabel:                     vm.Name,
+					vmopv1.IPAddressClaimInterfaceNameLabel: "eth1",
+				}),
+			}
+
+			initObjects = append(initObjects, keptClaim, removedClaim)
+		})
+
+		It("deletes the IPAddressClaim of the removed interface", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			claim := &vmopv1.IPAddressClaim{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(keptClaim), claim)).To(Succeed())
+
+			err := fakeClient.Get(ctx, client.ObjectKeyFromObject(removedClaim), claim)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -5,6 +5,7 @@
 package network_test
 
 import (
+	"net/netip"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -21,6 +22,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
@@ -42,10 +44,12 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 		results     network.NetworkInterfaceResults
 		err         error
 		initObjects []client.Object
+		withIPPools bool
 	)
 
 	BeforeEach(func() {
 		testConfig = builder.VCSimTestConfig{}
+		withIPPools = false
 
 		vm = &vmopv1.VirtualMachine{
 			ObjectMeta: metav1.ObjectMeta{
@@ -60,6 +64,12 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 	JustBeforeEach(func() {
 		ctx = suite.NewTestContextForVCSim(testConfig, initObjects...)
 
+		if withIPPools {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMIPPools = true
+			})
+		}
+
 		vmCtx = pkgctx.VirtualMachineContext{
 			Context: ctx,
 			Logger:  suite.GetLogger().WithName("network_test"),
@@ -214,6 +224,288 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 						})
 					})
 				})
+
+				Context("NetworkSpec has a bond and a VLAN", func() {
+					BeforeEach(func() {
+						vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+
+						networkSpec.Nameservers = []string{"149.112.112.112"}
+						networkSpec.SearchDomains = []string{"broadcom.net"}
+						networkSpec.Bonds = []vmopv1.VirtualMachineNetworkBondSpec{
+							{
+								Name:       "bond0",
+								Interfaces: []string{"my-network-interface"},
+								Mode:       "active-backup",
+								VirtualMachineNetworkDeviceIPSpec: vmopv1.VirtualMachineNetworkDeviceIPSpec{
+									Addresses: []string{"192.168.1.110/24"},
+									Gateway4:  "192.168.1.1",
+								},
+							},
+						}
+						networkSpec.VLANs = []vmopv1.VirtualMachineNetworkVLANSpec{
+							{
+								Name: "bond0.100",
+								ID:   100,
+								Link: "bond0",
+							},
+						}
+					})
+
+					It("returns the devices with the global defaults applied", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(results.Results).To(HaveLen(1))
+
+						Expect(results.Bonds).To(HaveLen(1))
+						bond := results.Bonds[0]
+						Expect(bond.Name).To(Equal("bond0"))
+						Expect(bond.Interfaces).To(HaveExactElements("my-network-interface"))
+						Expect(bond.Nameservers).To(HaveExactElements("149.112.112.112"))
+						Expect(bond.SearchDomains).To(HaveExactElements("broadcom.net"))
+
+						Expect(results.VLANs).To(HaveLen(1))
+						vlan := results.VLANs[0]
+						Expect(vlan.Name).To(Equal("bond0.100"))
+						Expect(vlan.Nameservers).To(BeEmpty(), "device without an IP configuration")
+						Expect(vlan.SearchDomains).To(BeEmpty(), "device without an IP configuration")
+
+						Expect(results.Bridges).To(BeEmpty())
+						Expect(networkSpec.Bonds[0].Nameservers).To(BeEmpty(), "spec is not modified")
+					})
+				})
+			})
+		})
+
+		Context("network has IPPools", func() {
+			var (
+				ipv4Pool *vmopv1.IPPool
+				ipv6Pool *vmopv1.IPPool
+			)
+
+			BeforeEach(func() {
+				withIPPools = true
+				vm.UID = "network-test-vm-uid"
+
+				networkSpec.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{
+						Name:    "eth0",
+						Network: &common.PartialObjectRef{Name: networkName},
+					},
+				}
+
+				ipv4Pool = builder.DummyIPPool(vm.Namespace, "ipv4-pool", networkName)
+				ipv4Pool.Spec.Addresses = []string{"192.168.10.10-192.168.10.12"}
+				ipv4Pool.Spec.SearchDomains = []string{"vmware.com"}
+				ipv6Pool = builder.DummyIPPool(vm.Namespace, "ipv6-pool", networkName)
+				ipv6Pool.Spec.Addresses = []string{"fd00::10-fd00::11"}
+				ipv6Pool.Spec.Prefix = 64
+				ipv6Pool.Spec.Gateway = "fd00::1"
+				ipv6Pool.Spec.Nameservers = []string{"fd00::2"}
+				otherPool := builder.DummyIPPool(vm.Namespace, "a-other-pool", "other-network")
+
+				usedClaim := &vmopv1.IPAddressClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      network.IPAddressClaimName(ipv4Pool.Name, netip.MustParseAddr("192.168.10.10")),
+						Namespace: vm.Namespace,
+						Labels: map[string]string{
+							vmopv1.IPPoolNameLabel: ipv4Pool.Name,
+						},
+					},
+					Spec: vmopv1.IPAddressClaimSpec{
+						PoolName: ipv4Pool.Name,
+						Address:  "192.168.10.10",
+						VMName:   "other-vm",
+					},
+				}
+
+				initObjects = append(initObjects, ipv4Pool, ipv6Pool, otherPool, usedClaim)
+			})
+
+			It("allocates an address from each IPPool", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(results.Results).To(HaveLen(1))
+
+				result := results.Results[0]
+				Expect(result.DHCP4).To(BeFalse())
+				Expect(result.DHCP6).To(BeFalse())
+				Expect(result.IPConfigs).To(HaveExactElements(
+					network.NetworkInterfaceIPConfig{
+						IPCIDR:  "192.168.10.11/24",
+						IsIPv4:  true,
+						Gateway: "192.168.10.1",
+					},
+					network.NetworkInterfaceIPConfig{
+						IPCIDR:  "fd00::10/64",
+						IsIPv4:  false,
+						Gateway: "fd00::1",
+					},
+				))
+				Expect(result.Nameservers).To(HaveExactElements("192.168.10.2"))
+				Expect(result.SearchDomains).To(HaveExactElements("vmware.com"))
+
+				By("claims are owned by the VM", func() {
+					claims := &vmopv1.IPAddressClaimList{}
+					Expect(ctx.Client.List(ctx, claims, client.InNamespace(vm.Namespace),
+						client.MatchingLabels{network.VMNameLabel: vm.Name})).To(Succeed())
+					Expect(claims.Items).To(HaveLen(2))
+					for _, c := range claims.Items {
+						Expect(c.Spec.VMName).To(Equal(vm.Name))
+						Expect(c.Spec.InterfaceName).To(Equal("eth0"))
+						Expect(c.Labels).To(HaveKeyWithValue(vmopv1.IPAddressClaimInterfaceNameLabel, "eth0"))
+						Expect(c.OwnerReferences).To(HaveLen(1))
+						Expect(c.OwnerReferences[0].UID).To(Equal(vm.UID))
+					}
+				})
+
+				By("existing claims are reused", func() {
+					results, err = network.CreateAndWaitForNetworkInterfaces(
+						vmCtx,
+						ctx.Client,
+						ctx.VCClient.Client,
+						ctx.Finder,
+						nil,
+						networkSpec)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+					Expect(results.Results[0].IPConfigs).To(HaveLen(2))
+					Expect(results.Results[0].IPConfigs[0].IPCIDR).To(Equal("192.168.10.11/24"))
+					Expect(results.Results[0].IPConfigs[1].IPCIDR).To(Equal("fd00::10/64"))
+
+					claims := &vmopv1.IPAddressClaimList{}
+					Expect(ctx.Client.List(ctx, claims, client.InNamespace(vm.Namespace))).To(Succeed())
+					Expect(claims.Items).To(HaveLen(3))
+				})
+			})
+
+			It("releases the addresses the interface no longer uses", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				listVMClaims := func() []vmopv1.IPAddressClaim {
+					claims := &vmopv1.IPAddressClaimList{}
+					Expect(ctx.Client.List(ctx, claims, client.InNamespace(vm.Namespace),
+						client.MatchingLabels{network.VMNameLabel: vm.Name})).To(Succeed())
+					return claims.Items
+				}
+				Expect(listVMClaims()).To(HaveLen(2))
+
+				By("the interface uses DHCP6", func() {
+					networkSpec.Interfaces[0].DHCP6 = true
+					_, err = network.CreateAndWaitForNetworkInterfaces(
+						vmCtx,
+						ctx.Client,
+						ctx.VCClient.Client,
+						ctx.Finder,
+						nil,
+						networkSpec)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(listVMClaims()).To(ConsistOf(HaveField("Spec.PoolName", ipv4Pool.Name)))
+				})
+
+				By("the interface specifies addresses", func() {
+					networkSpec.Interfaces[0].Addresses = []string{"172.42.1.100/24"}
+					_, err = network.CreateAndWaitForNetworkInterfaces(
+						vmCtx,
+						ctx.Client,
+						ctx.VCClient.Client,
+						ctx.Finder,
+						nil,
+						networkSpec)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(listVMClaims()).To(BeEmpty())
+				})
+
+				By("the claim of another VM is not released", func() {
+					claims := &vmopv1.IPAddressClaimList{}
+					Expect(ctx.Client.List(ctx, claims, client.InNamespace(vm.Namespace))).To(Succeed())
+					Expect(claims.Items).To(ConsistOf(HaveField("Spec.VMName", "other-vm")))
+				})
+			})
+
+			Context("interface uses DHCP6", func() {
+				BeforeEach(func() {
+					networkSpec.Interfaces[0].DHCP6 = true
+				})
+
+				It("only allocates an IPv4 address", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+
+					result := results.Results[0]
+					Expect(result.DHCP4).To(BeFalse())
+					Expect(result.DHCP6).To(BeTrue())
+					Expect(result.IPConfigs).To(HaveLen(1))
+					Expect(result.IPConfigs[0].IPCIDR).To(Equal("192.168.10.11/24"))
+				})
+			})
+
+			Context("interface uses SLAAC", func() {
+				BeforeEach(func() {
+					networkSpec.Interfaces[0].SLAAC = true
+				})
+
+				It("only allocates an IPv4 address", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+
+					result := results.Results[0]
+					Expect(result.SLAAC).To(BeTrue())
+					Expect(result.IPConfigs).To(HaveLen(1))
+					Expect(result.IPConfigs[0].IPCIDR).To(Equal("192.168.10.11/24"))
+				})
+			})
+
+			Context("IPPools feature is disabled", func() {
+				BeforeEach(func() {
+					withIPPools = false
+				})
+
+				It("does not allocate an address", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+
+					result := results.Results[0]
+					Expect(result.DHCP4).To(BeTrue())
+					Expect(result.IPConfigs).To(BeEmpty())
+
+					claims := &vmopv1.IPAddressClaimList{}
+					Expect(ctx.Client.List(ctx, claims, client.InNamespace(vm.Namespace),
+						client.MatchingLabels{network.VMNameLabel: vm.Name})).To(Succeed())
+					Expect(claims.Items).To(BeEmpty())
+				})
+			})
+
+			Context("interface specifies addresses and nameservers", func() {
+				BeforeEach(func() {
+					networkSpec.Interfaces[0].Addresses = []string{"172.42.1.100/24"}
+					networkSpec.Interfaces[0].Nameservers = []string{"9.9.9.9"}
+				})
+
+				It("does not allocate an address", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+
+					result := results.Results[0]
+					Expect(result.IPConfigs).To(HaveLen(1))
+					Expect(result.IPConfigs[0].IPCIDR).To(Equal("172.42.1.100/24"))
+					Expect(result.Nameservers).To(HaveExactElements("9.9.9.9"))
+
+					claims := &vmopv1.IPAddressClaimList{}
+					Expect(ctx.Client.List(ctx, claims, client.InNamespace(vm.Namespace),
+						client.MatchingLabels{network.VMNameLabel: vm.Name})).To(Succeed())
+					Expect(claims.Items).To(BeEmpty())
+				})
+			})
+
+			Context("IPPool is exhausted", func() {
+				BeforeEach(func() {
+					ipv4Pool.Spec.Addresses = []string{"192.168.10.10"}
+				})
+
+				It("returns error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(`IPPool "ipv4-pool" has no available addresses`))
+				})
 			})
 		})
 
@@ -332,6 +624,71 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 				Expect(ipConfig.Gateway).To(Equal("fd1a:6c85:79fe:7c98:0000:0000:0000:0001"))
 			})
 
+			When("the IPConfigs have prefix lengths and no IP family and the interface uses SLAAC", func() {
+				BeforeEach(func() {
+					networkSpec.Interfaces[0].SLAAC = true
+				})
+
+				It("returns success", func() {
+					Expect(err).To(HaveOccurred())
+
+					By("simulate successful NetOP reconcile", func() {
+						netInterface := &netopv1alpha1.NetworkInterface{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      network.NetOPCRName(vm.Name, networkName, interfaceName, false),
+								Namespace: vm.Namespace,
+							},
+						}
+						Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(netInterface), netInterface)).To(Succeed())
+
+						netInterface.Status.NetworkID = ctx.NetworkRef.Reference().Value
+						netInterface.Status.IPConfigs = []netopv1alpha1.IPConfig{
+							{
+								IP:         "192.168.1.110",
+								Gateway:    "192.168.1.1",
+								SubnetMask: "24",
+							},
+							{
+								IP:         "fd1a:6c85:79fe:7c98::f",
+								Gateway:    "fd1a:6c85:79fe:7c98::1",
+								SubnetMask: "64",
+							},
+						}
+						netInterface.Status.Conditions = []netopv1alpha1.NetworkInterfaceCondition{
+							{
+								Type:   netopv1alpha1.NetworkInterfaceReady,
+								Status: corev1.ConditionTrue,
+							},
+						}
+						Expect(ctx.Client.Status().Update(ctx, netInterface)).To(Succeed())
+					})
+
+					results, err = network.CreateAndWaitForNetworkInterfaces(
+						vmCtx,
+						ctx.Client,
+						ctx.VCClient.Client,
+						ctx.Finder,
+						nil,
+						networkSpec)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(results.Results).To(HaveLen(1))
+					result := results.Results[0]
+					Expect(result.DHCP4).To(BeFalse())
+					Expect(result.DHCP6).To(BeFalse())
+					Expect(result.SLAAC).To(BeTrue())
+
+					Expect(result.IPConfigs).To(HaveLen(2))
+					ipConfig := result.IPConfigs[0]
+					Expect(ipConfig.IPCIDR).To(Equal("192.168.1.110/24"))
+					Expect(ipConfig.IsIPv4).To(BeTrue())
+					ipConfig = result.IPConfigs[1]
+					Expect(ipConfig.IPCIDR).To(Equal("fd1a:6c85:79fe:7c98::f/64"))
+					Expect(ipConfig.IsIPv4).To(BeFalse())
+					Expect(ipConfig.Gateway).To(Equal("fd1a:6c85:79fe:7c98::1"))
+				})
+			})
+
 			When("v1a1 network interface exists", func() {
 				BeforeEach(func() {
 					netIf := &netopv1alpha1.NetworkInterface{
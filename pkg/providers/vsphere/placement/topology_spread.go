@@ -0,0 +1,157 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/maps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// applyTopologySpreadConstraints filters the candidate zones by the VM's
+// topology spread constraints, using the zones of the VM's peers to determine
+// the skew of each zone. The first return value is the candidates that
+// satisfy the constraints, and the second is the subset of those candidates
+// with the lowest skew, i.e. the zones that should be preferred so the VMs
+// are spread as evenly as possible.
+//
+// The zoneNames are all of the zones available to the VM's namespace, and are
+// used to determine the minimum number of peers in any zone even if the zone
+// is not a candidate, ex. due to the zones of the VM's PVCs.
+func applyTopologySpreadConstraints(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	zoneNames []string,
+	candidates map[string][]string) (map[string][]string, map[string][]string, error) {
+
+	constraints := vmCtx.VM.Spec.TopologySpreadConstraints
+	if len(constraints) == 0 {
+		return candidates, candidates, nil
+	}
+
+	skews := make(map[string]int32, len(candidates))
+	for zoneName := range candidates {
+		skews[zoneName] = 0
+	}
+
+	for i := range constraints {
+		c := constraints[i]
+
+		if c.TopologyKey != "" && c.TopologyKey != topology.KubernetesTopologyZoneLabelKey {
+			vmCtx.Logger.V(4).Info("Skipping topology spread constraint with unsupported topology key",
+				"topologyKey", c.TopologyKey)
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid label selector for topology spread constraint: %w", err)
+		}
+
+		zoneCounts, err := getPeerZoneCounts(vmCtx, client, selector, zoneNames)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		minCount := int32(-1)
+		for _, count := range zoneCounts {
+			if minCount < 0 || count < minCount {
+				minCount = count
+			}
+		}
+
+		for zoneName := range skews {
+			// The skew is the difference between the number of peers in the
+			// zone, including this VM, and the minimum number of peers in any
+			// zone.
+			skew := zoneCounts[zoneName] + 1 - minCount
+
+			if skew > c.MaxSkew && c.WhenUnsatisfiable != vmopv1.ScheduleAnyway {
+				vmCtx.Logger.V(5).Info("Removed candidate zone due to topology spread constraint",
+					"zone", zoneName, "skew", skew, "maxSkew", c.MaxSkew)
+				delete(skews, zoneName)
+				continue
+			}
+
+			skews[zoneName] += skew
+		}
+	}
+
+	if len(skews) == 0 {
+		return nil, nil, fmt.Errorf("no placement candidates available after applying topology spread constraints")
+	}
+
+	minSkew := int32(-1)
+	for _, skew := range skews {
+		if minSkew < 0 || skew < minSkew {
+			minSkew = skew
+		}
+	}
+
+	allowed := make(map[string][]string, len(skews))
+	preferred := map[string][]string{}
+	for zoneName, skew := range skews {
+		allowed[zoneName] = candidates[zoneName]
+		if skew == minSkew {
+			preferred[zoneName] = candidates[zoneName]
+		}
+	}
+
+	vmCtx.Logger.V(5).Info("Applied topology spread constraints",
+		"skews", skews, "preferredZones", maps.Keys(preferred))
+
+	return allowed, preferred, nil
+}
+
+// getPeerZoneCounts returns the number of VMs in each of the provided zones
+// that are selected by the provided selector, not including the VM itself.
+func getPeerZoneCounts(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	selector labels.Selector,
+	zoneNames []string) (map[string]int32, error) {
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := client.List(
+		vmCtx,
+		vmList,
+		ctrlclient.InNamespace(vmCtx.VM.Namespace),
+		ctrlclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+
+		return nil, fmt.Errorf("failed to list VMs for topology spread constraint: %w", err)
+	}
+
+	zoneCounts := make(map[string]int32, len(zoneNames))
+	for _, zoneName := range zoneNames {
+		zoneCounts[zoneName] = 0
+	}
+
+	for i := range vmList.Items {
+		peer := &vmList.Items[i]
+		if peer.Name == vmCtx.VM.Name || !peer.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// The zone label is assigned during placement, before the VM has
+		// been created and its status updated.
+		zoneName := peer.Status.Zone
+		if zoneName == "" {
+			zoneName = peer.Labels[topology.KubernetesTopologyZoneLabelKey]
+		}
+
+		if _, ok := zoneCounts[zoneName]; ok {
+			zoneCounts[zoneName]++
+		}
+	}
+
+	return zoneCounts, nil
+}
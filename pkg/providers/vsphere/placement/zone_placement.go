@@ -397,6 +397,8 @@ func Placement(
 		return nil, fmt.Errorf("no placement candidates available")
 	}
 
+	zoneNames := maps.Keys(candidates)
+
 	if constraints.Zones.Len() > 0 {
 		// The VM's candidates may be limited due to external constraints, such as the
 		// requested zones of its PVCs. Apply those constraints here.
@@ -427,14 +429,37 @@ func Placement(
 	// TBD: May want to get the host for vGPU and other passthru devices too.
 	var recommendations map[string][]Recommendation
 	if curResult.needZonePlacement {
+		// The VM's peers are spread across the zones by first considering only
+		// the preferred zones, falling back to all of the zones that satisfy
+		// the VM's topology spread constraints.
+		allowedCandidates, preferredCandidates, err := applyTopologySpreadConstraints(
+			vmCtx,
+			client,
+			zoneNames,
+			candidates)
+		if err != nil {
+			return nil, err
+		}
+
 		recommendations = getZonalPlacementRecommendations(
 			vmCtx,
 			vcClient,
 			finder,
-			candidates,
+			preferredCandidates,
 			configSpec,
 			curResult.needHostPlacement,
 			curResult.needDatastorePlacement)
+
+		if len(recommendations) == 0 && len(preferredCandidates) != len(allowedCandidates) {
+			recommendations = getZonalPlacementRecommendations(
+				vmCtx,
+				vcClient,
+				finder,
+				allowedCandidates,
+				configSpec,
+				curResult.needHostPlacement,
+				curResult.needDatastorePlacement)
+		}
 	} else /* needHostPlacement or needDatastorePlacement */ {
 		recommendations = getPlacementRecommendations(vmCtx, vcClient, candidates, configSpec)
 	}
@@ -6,6 +6,7 @@ package placement_test
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -295,6 +296,75 @@ func vcSimPlacement() {
 				})
 			})
 
+			Context("Topology Spread Constraints", func() {
+				const peerLabel = "my-replicaset"
+
+				createPeer := func(name, zoneName string, viaStatus bool) {
+					peer := builder.DummyVirtualMachine()
+					peer.Name = name
+					peer.Namespace = vm.Namespace
+					peer.Labels = map[string]string{"app": peerLabel}
+					if !viaStatus {
+						peer.Labels[topology.KubernetesTopologyZoneLabelKey] = zoneName
+					}
+					Expect(ctx.Client.Create(ctx, peer)).To(Succeed())
+					if viaStatus {
+						peer.Status.Zone = zoneName
+						Expect(ctx.Client.Status().Update(ctx, peer)).To(Succeed())
+					}
+				}
+
+				BeforeEach(func() {
+					vm.Labels["app"] = peerLabel
+					vm.Spec.TopologySpreadConstraints = []vmopv1.VirtualMachineTopologySpreadConstraint{
+						{
+							MaxSkew:     1,
+							TopologyKey: topology.KubernetesTopologyZoneLabelKey,
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": peerLabel},
+							},
+						},
+					}
+				})
+
+				JustBeforeEach(func() {
+					Expect(len(ctx.ZoneNames)).To(BeNumerically(">", 1))
+					for i, zoneName := range ctx.ZoneNames[:len(ctx.ZoneNames)-1] {
+						createPeer(fmt.Sprintf("peer-%d", i), zoneName, i%2 == 0)
+					}
+				})
+
+				It("returns success with the zone that has no peers", func() {
+					result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.ZonePlacement).To(BeTrue())
+					Expect(result.ZoneName).To(Equal(ctx.ZoneNames[len(ctx.ZoneNames)-1]))
+				})
+
+				When("the constraint cannot be satisfied", func() {
+					JustBeforeEach(func() {
+						constraints.Zones = sets.New(ctx.ZoneNames[0])
+					})
+
+					It("returns an error", func() {
+						_, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+						Expect(err).To(MatchError("no placement candidates available after applying topology spread constraints"))
+					})
+
+					When("the constraint may be violated", func() {
+						BeforeEach(func() {
+							vm.Spec.TopologySpreadConstraints[0].WhenUnsatisfiable = vmopv1.ScheduleAnyway
+						})
+
+						It("returns success", func() {
+							result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(result.ZoneName).To(Equal(ctx.ZoneNames[0]))
+						})
+					})
+				})
+			})
+
 			Context("Instance Storage Placement", func() {
 
 				BeforeEach(func() {
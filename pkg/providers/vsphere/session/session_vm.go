@@ -53,6 +53,15 @@ func updateVirtualDiskDeviceChanges(
 		}
 
 		if vmDisk.CapacityInBytes < newCapacityInBytes {
+			// A disk with snapshots or a parent disk, i.e. a linked clone,
+			// cannot be extended.
+			if vmCtx.MoVM.Snapshot != nil {
+				return nil, fmt.Errorf("cannot resize boot disk of a VM with snapshots")
+			}
+			if hasParentDisk(vmDisk) {
+				return nil, fmt.Errorf("cannot resize boot disk of a linked clone")
+			}
+
 			vmDisk.CapacityInBytes = newCapacityInBytes
 			deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
 				Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
@@ -70,3 +79,18 @@ func updateVirtualDiskDeviceChanges(
 
 	return deviceChanges, nil
 }
+
+// hasParentDisk returns true if the disk is a delta disk, i.e. it has a parent.
+func hasParentDisk(disk *vimtypes.VirtualDisk) bool {
+	switch tb := disk.Backing.(type) {
+	case *vimtypes.VirtualDiskFlatVer2BackingInfo:
+		return tb.Parent != nil
+	case *vimtypes.VirtualDiskSeSparseBackingInfo:
+		return tb.Parent != nil
+	case *vimtypes.VirtualDiskSparseVer2BackingInfo:
+		return tb.Parent != nil
+	case *vimtypes.VirtualDiskRawDiskMappingVer1BackingInfo:
+		return tb.Parent != nil
+	}
+	return false
+}
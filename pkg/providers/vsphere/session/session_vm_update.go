@@ -6,9 +6,11 @@ package session
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,6 +19,7 @@ import (
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	apiEquality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
@@ -57,8 +60,8 @@ type VMResizeArgs struct {
 	ConfigSpec vimtypes.VirtualMachineConfigSpec
 }
 
-func ethCardMatch(newBaseEthCard, curBaseEthCard vimtypes.BaseVirtualEthernetCard) bool {
-	if reflect.TypeOf(curBaseEthCard) != reflect.TypeOf(newBaseEthCard) {
+func ethCardMatch(newBaseEthCard, curBaseEthCard vimtypes.BaseVirtualEthernetCard, matchCardType bool) bool {
+	if matchCardType && reflect.TypeOf(curBaseEthCard) != reflect.TypeOf(newBaseEthCard) {
 		return false
 	}
 
@@ -88,6 +91,28 @@ func UpdateEthCardDeviceChanges(
 	expectedEthCards object.VirtualDeviceList,
 	currentEthCards object.VirtualDeviceList) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
 
+	return updateEthCardDeviceChanges(ctx, expectedEthCards, currentEthCards, true)
+}
+
+// UpdatePoweredOnEthCardDeviceChanges returns the device changes that hot-add
+// and hot-remove the ethernet cards of a powered-on VM. Unlike
+// UpdateEthCardDeviceChanges, the type of the cards is not compared since the
+// existing cards may have been created from the VM class, and the type of a
+// card cannot be changed while the VM is powered on.
+func UpdatePoweredOnEthCardDeviceChanges(
+	ctx context.Context,
+	expectedEthCards object.VirtualDeviceList,
+	currentEthCards object.VirtualDeviceList) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
+
+	return updateEthCardDeviceChanges(ctx, expectedEthCards, currentEthCards, false)
+}
+
+func updateEthCardDeviceChanges(
+	_ context.Context,
+	expectedEthCards object.VirtualDeviceList,
+	currentEthCards object.VirtualDeviceList,
+	matchCardType bool) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
+
 	var deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
 	for _, expectedDev := range expectedEthCards {
 		expectedNic := expectedDev.(vimtypes.BaseVirtualEthernetCard)
@@ -111,7 +136,7 @@ func UpdateEthCardDeviceChanges(
 			// This assumes we don't have multiple NICs in the same backing network. This is kind of, sort
 			// of enforced by the webhook, but we lack a guaranteed way to match up the NICs.
 
-			if !ethCardMatch(expectedNic, nic) {
+			if !ethCardMatch(expectedNic, nic, matchCardType) {
 				continue
 			}
 
@@ -277,6 +302,16 @@ func UpdateConfigSpecExtraConfig(
 	// loop below.
 	linuxPrepAndVAppConfig := isLinuxPrepAndVAppConfig(vm)
 
+	// Publish the capacities of the VM's volumes to the guest if requested.
+	// This is also used in the loop below to remove the capacities when they
+	// are no longer requested.
+	guestVolumeCapacityHint := vm != nil &&
+		pkgcfg.FromContext(ctx).Features.VMVolumeExpansion &&
+		metav1.HasAnnotation(vm.ObjectMeta, vmopv1.GuestVolumeResizeHintAnnotation)
+	if guestVolumeCapacityHint {
+		extraConfig[constants.GuestVolumeCapacityExtraConfigKey] = getGuestVolumeCapacities(vm)
+	}
+
 	for i := range config.ExtraConfig {
 		if o := config.ExtraConfig[i].GetOptionValue(); o != nil {
 
@@ -303,6 +338,13 @@ func UpdateConfigSpecExtraConfig(
 						extraConfig[o.Key] = constants.VMOperatorV1Alpha1ConfigEnabled
 					}
 				}
+
+			// Remove the capacities of the VM's volumes from the guest when
+			// they are no longer requested.
+			case constants.GuestVolumeCapacityExtraConfigKey:
+				if !guestVolumeCapacityHint && o.Value != "" {
+					extraConfig[o.Key] = ""
+				}
 			}
 		}
 	}
@@ -314,6 +356,35 @@ func UpdateConfigSpecExtraConfig(
 		Diff(pkgutil.OptionValuesFromMap(extraConfig)...)
 }
 
+// guestVolumeCapacity is the capacity of a volume as published to the guest.
+type guestVolumeCapacity struct {
+	Name     string `json:"name"`
+	DiskUUID string `json:"diskUUID"`
+	Capacity int64  `json:"capacity"`
+}
+
+// getGuestVolumeCapacities returns the JSON encoded capacities, in bytes, of the
+// VM's attached, managed volumes. The capacity of a volume that is being
+// expanded is not updated until its virtual disk has been resized.
+func getGuestVolumeCapacities(vm *vmopv1.VirtualMachine) string {
+	capacities := []guestVolumeCapacity{}
+	for _, vol := range vm.Status.Volumes {
+		if vol.Type != vmopv1.VirtualMachineStorageDiskTypeManaged ||
+			!vol.Attached || vol.DiskUUID == "" || vol.Limit == nil {
+
+			continue
+		}
+		capacities = append(capacities, guestVolumeCapacity{
+			Name:     vol.Name,
+			DiskUUID: vol.DiskUUID,
+			Capacity: vol.Limit.Value(),
+		})
+	}
+
+	data, _ := json.Marshal(capacities)
+	return string(data)
+}
+
 func isLinuxPrepAndVAppConfig(vm *vmopv1.VirtualMachine) bool {
 	if vm == nil {
 		return false
@@ -549,6 +620,32 @@ func (s *Session) prePowerOnVMReconfigure(
 		return err
 	}
 
+	// Disks for volumes are attached by CNS, which selects the controller and
+	// unit number for the disk. Move the disks to the controller and unit
+	// number requested by their volume while the VM is still powered off.
+	placementDeviceChanges, err := virtualmachine.UpdateVolumeDiskPlacementDeviceChanges(
+		vmCtx.VM,
+		configSpec,
+		config.Hardware.Device)
+	if err != nil {
+		return fmt.Errorf("update volume disk placement device changes error: %w", err)
+	}
+	configSpec.DeviceChange = append(configSpec.DeviceChange, placementDeviceChanges...)
+
+	// Update the storage I/O allocation of the VM's disks. The allocation from
+	// the VM's class is only applied when the class is applied to the VM.
+	var classStorageIO *vmopv1.VirtualMachineStorageIOAllocation
+	if !features.VMResize || needsResize {
+		classStorageIO = updateArgs.VMClass.Spec.Hardware.StorageIO
+	}
+	var storageIOConfigSpec vimtypes.VirtualMachineConfigSpec
+	virtualmachine.UpdateConfigSpecStorageIOAllocation(
+		vmCtx.VM,
+		classStorageIO,
+		config.Hardware.Device,
+		&storageIOConfigSpec)
+	resize.CompareStorageIOAllocation(*config, storageIOConfigSpec, configSpec)
+
 	if _, err := doReconfigure(
 		logr.NewContext(
 			vmCtx,
@@ -732,6 +829,12 @@ func (s *Session) prepareVMForPowerOn(
 	}
 	updateArgs.NetworkResults = netIfList
 
+	if pkgcfg.FromContext(vmCtx).Features.MutableNetworks {
+		if err := network.DeleteRemovedNetworkInterfaces(vmCtx, s.K8sClient, vmCtx.VM.Spec.Network); err != nil {
+			return err
+		}
+	}
+
 	if err := s.prePowerOnVMReconfigure(vmCtx, resVM, cfg, updateArgs); err != nil {
 		return err
 	}
@@ -791,6 +894,17 @@ func (s *Session) poweredOnVMReconfigure(
 		return false, fmt.Errorf("update CD-ROM device connection error: %w", err)
 	}
 
+	if pkgcfg.FromContext(vmCtx).Features.VMBootDiskResize {
+		// Grow the boot disk of the powered on VM. The disk is otherwise
+		// resized prior to the VM being powered on.
+		currentDisks := object.VirtualDeviceList(config.Hardware.Device).SelectByType((*vimtypes.VirtualDisk)(nil))
+		diskDeviceChanges, err := updateVirtualDiskDeviceChanges(vmCtx, currentDisks)
+		if err != nil {
+			return false, err
+		}
+		configSpec.DeviceChange = append(configSpec.DeviceChange, diskDeviceChanges...)
+	}
+
 	refetchProps, err := doReconfigure(
 		logr.NewContext(
 			vmCtx,
@@ -818,6 +932,123 @@ func (s *Session) poweredOnVMReconfigure(
 	return refetchProps, nil
 }
 
+// poweredOnVMReconfigureNetwork hot-adds and hot-removes the network
+// interfaces of a powered-on VM so they match spec.network.interfaces. The
+// network interface CRs of added interfaces are created, and those of removed
+// interfaces are deleted. If the VM is bootstrapped with Cloud-Init via
+// GuestInfo, the guest's network configuration is updated as well.
+func (s *Session) poweredOnVMReconfigureNetwork(
+	vmCtx pkgctx.VirtualMachineContext,
+	resVM *res.VirtualMachine,
+	config *vimtypes.VirtualMachineConfigInfo,
+	getUpdateArgsFn func() (*VMUpdateArgs, error)) (bool, error) {
+
+	networkSpec := vmCtx.VM.Spec.Network
+	if networkSpec == nil || networkSpec.Disabled {
+		return false, nil
+	}
+
+	currentEthCards := object.VirtualDeviceList(config.Hardware.Device).SelectByType((*vimtypes.VirtualEthernetCard)(nil))
+	if !networkInterfacesChanged(vmCtx.VM, currentEthCards) {
+		return false, nil
+	}
+
+	networkResults, err := s.ensureNetworkInterfaces(vmCtx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var expectedEthCards object.VirtualDeviceList
+	for idx := range networkResults.Results {
+		expectedEthCards = append(expectedEthCards, networkResults.Results[idx].Device)
+	}
+
+	deviceChanges, err := UpdatePoweredOnEthCardDeviceChanges(vmCtx, expectedEthCards, currentEthCards)
+	if err != nil {
+		return false, err
+	}
+
+	var refetchProps bool
+
+	if len(deviceChanges) > 0 {
+		vmCtx.Logger.Info("Reconfiguring network interfaces of powered on VM",
+			"deviceChanges", len(deviceChanges))
+
+		configSpec := &vimtypes.VirtualMachineConfigSpec{DeviceChange: deviceChanges}
+		if _, err := resVM.Reconfigure(vmCtx, configSpec); err != nil {
+			return false, fmt.Errorf("failed to reconfigure network interfaces: %w", err)
+		}
+		refetchProps = true
+	}
+
+	if err := network.DeleteRemovedNetworkInterfaces(vmCtx, s.K8sClient, networkSpec); err != nil {
+		return refetchProps, err
+	}
+
+	updateArgs, err := getUpdateArgsFn()
+	if err != nil {
+		return refetchProps, err
+	}
+	updateArgs.NetworkResults = networkResults
+
+	if err := s.fixupMacAddresses(vmCtx, resVM, updateArgs); err != nil {
+		return refetchProps, err
+	}
+
+	bootstrapArgs, err := vmlifecycle.GetBootstrapArgs(
+		vmCtx,
+		s.K8sClient,
+		updateArgs.NetworkResults,
+		updateArgs.BootstrapData)
+	if err != nil {
+		return refetchProps, err
+	}
+
+	// Record the interfaces in the VM's intended network config so they are
+	// not reconciled again until spec.network.interfaces changes.
+	vmlifecycle.UpdateNetworkStatusConfig(vmCtx.VM, bootstrapArgs)
+
+	configSpec, err := vmlifecycle.GetCloudInitGuestInfoNetworkConfigSpec(vmCtx, config, &bootstrapArgs)
+	if err != nil {
+		return refetchProps, err
+	}
+
+	if configSpec != nil && len(configSpec.ExtraConfig) > 0 {
+		if _, err := resVM.Reconfigure(vmCtx, configSpec); err != nil {
+			return refetchProps, fmt.Errorf("failed to reconfigure guest network config: %w", err)
+		}
+		refetchProps = true
+	}
+
+	return refetchProps, nil
+}
+
+// networkInterfacesChanged returns true if the network interfaces in the VM's
+// spec differ from those in the VM's intended network config, which is updated
+// each time the interfaces are reconciled. If the VM does not have an intended
+// network config, the number of interfaces in the spec is compared to the
+// number of the VM's ethernet cards instead.
+func networkInterfacesChanged(
+	vm *vmopv1.VirtualMachine,
+	currentEthCards object.VirtualDeviceList) bool {
+
+	specNames := sets.New[string]()
+	for _, interfaceSpec := range vm.Spec.Network.Interfaces {
+		specNames.Insert(interfaceSpec.Name)
+	}
+
+	if vm.Status.Network == nil || vm.Status.Network.Config == nil {
+		return specNames.Len() != len(currentEthCards)
+	}
+
+	statusNames := sets.New[string]()
+	for _, ifc := range vm.Status.Network.Config.Interfaces {
+		statusNames.Insert(ifc.Name)
+	}
+
+	return !specNames.Equal(statusNames)
+}
+
 func (s *Session) attachClusterModule(
 	vmCtx pkgctx.VirtualMachineContext,
 	resVM *res.VirtualMachine,
@@ -857,7 +1088,15 @@ func (s *Session) resizeVMWhenPoweredStateOff(
 		needsResize = vmopv1util.ResizeNeeded(*vmCtx.VM, *resizeArgs.VMClass)
 		if needsResize {
 			if pkgcfg.FromContext(vmCtx).Features.VMResize {
-				configSpec, err = resize.CreateResizeConfigSpec(vmCtx, *moVM.Config, resizeArgs.ConfigSpec)
+				desiredConfigSpec := resizeArgs.ConfigSpec
+				desiredConfigSpec.DeviceChange = slices.Clone(desiredConfigSpec.DeviceChange)
+				virtualmachine.UpdateConfigSpecStorageIOAllocation(
+					vmCtx.VM,
+					resizeArgs.VMClass.Spec.Hardware.StorageIO,
+					moVM.Config.Hardware.Device,
+					&desiredConfigSpec)
+
+				configSpec, err = resize.CreateResizeConfigSpec(vmCtx, *moVM.Config, desiredConfigSpec)
 			} else {
 				configSpec, err = resize.CreateResizeCPUMemoryConfigSpec(vmCtx, *moVM.Config, resizeArgs.ConfigSpec)
 			}
@@ -1097,6 +1336,14 @@ func (s *Session) updateVMDesiredPowerStateOn(
 		}
 		refetchProps = refetchProps || reconfigured
 
+		if pkgcfg.FromContext(vmCtx).Features.MutableNetworks {
+			reconfigured, err = s.poweredOnVMReconfigureNetwork(vmCtx, resVM, config, getUpdateArgsFn)
+			if err != nil {
+				return refetchProps || reconfigured, err
+			}
+			refetchProps = refetchProps || reconfigured
+		}
+
 		return refetchProps, err
 	}
 
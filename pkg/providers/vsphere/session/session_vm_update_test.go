@@ -220,6 +220,75 @@ var _ = Describe("Update ConfigSpec", func() {
 			})
 		})
 
+		Context("Guest volume capacities", func() {
+			BeforeEach(func() {
+				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+					config.Features.VMVolumeExpansion = true
+				})
+				vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+					{
+						Name:     "my-disk-0",
+						Type:     vmopv1.VirtualMachineStorageDiskTypeClassic,
+						Attached: true,
+						DiskUUID: "100",
+						Limit:    ptr.To(resource.MustParse("10Gi")),
+					},
+					{
+						Name:     "my-pvc-0",
+						Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+						Attached: true,
+						DiskUUID: "200",
+						Limit:    ptr.To(resource.MustParse("1Gi")),
+					},
+					{
+						Name: "my-pvc-1",
+						Type: vmopv1.VirtualMachineStorageDiskTypeManaged,
+					},
+				}
+			})
+
+			It("Should not publish the capacities", func() {
+				Expect(ecMap).ToNot(HaveKey(constants.GuestVolumeCapacityExtraConfigKey))
+			})
+
+			When("The VM has the guest volume resize hint annotation", func() {
+				BeforeEach(func() {
+					vm.Annotations[vmopv1.GuestVolumeResizeHintAnnotation] = ""
+				})
+
+				It("Should publish the capacities of the attached managed volumes", func() {
+					Expect(ecMap).To(HaveKeyWithValue(
+						constants.GuestVolumeCapacityExtraConfigKey,
+						`[{"name":"my-pvc-0","diskUUID":"200","capacity":1073741824}]`))
+				})
+
+				When("Volume expansion is disabled", func() {
+					BeforeEach(func() {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMVolumeExpansion = false
+						})
+					})
+
+					It("Should not publish the capacities", func() {
+						Expect(ecMap).ToNot(HaveKey(constants.GuestVolumeCapacityExtraConfigKey))
+					})
+				})
+			})
+
+			When("The capacities were previously published", func() {
+				BeforeEach(func() {
+					config.ExtraConfig = append(config.ExtraConfig, &vimtypes.OptionValue{
+						Key:   constants.GuestVolumeCapacityExtraConfigKey,
+						Value: "[]",
+					})
+				})
+
+				It("Should be set to an empty value", func() {
+					Expect(ecMap).To(HaveKeyWithValue(constants.GuestVolumeCapacityExtraConfigKey, ""))
+				})
+			})
+		})
+
 		Context("ExtraConfig value already exists", func() {
 			BeforeEach(func() {
 				config.ExtraConfig = append(config.ExtraConfig, &vimtypes.OptionValue{Key: "foo", Value: "bar"})
@@ -723,6 +792,86 @@ var _ = Describe("Update ConfigSpec", func() {
 		})
 	})
 
+	Context("Powered On Ethernet Card Changes", func() {
+		var expectedList object.VirtualDeviceList
+		var currentList object.VirtualDeviceList
+		var deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+		var dvpg1 *vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo
+		var dvpg2 *vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo
+		var err error
+
+		BeforeEach(func() {
+			dvpg1 = &vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo{
+				Port: vimtypes.DistributedVirtualSwitchPortConnection{
+					PortgroupKey: "key1",
+					SwitchUuid:   "uuid1",
+				},
+			}
+
+			dvpg2 = &vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo{
+				Port: vimtypes.DistributedVirtualSwitchPortConnection{
+					PortgroupKey: "key2",
+					SwitchUuid:   "uuid2",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			deviceChanges, err = session.UpdatePoweredOnEthCardDeviceChanges(ctx, expectedList, currentList)
+		})
+
+		AfterEach(func() {
+			currentList = nil
+			expectedList = nil
+		})
+
+		Context("Keeps existing device when card type is different", func() {
+			BeforeEach(func() {
+				card1, err := object.EthernetCardTypes().CreateEthernetCard("vmxnet3", dvpg1)
+				Expect(err).ToNot(HaveOccurred())
+				expectedList = append(expectedList, card1)
+
+				card2, err := object.EthernetCardTypes().CreateEthernetCard("e1000", dvpg1)
+				Expect(err).ToNot(HaveOccurred())
+				currentList = append(currentList, card2)
+			})
+
+			It("returns empty list", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deviceChanges).To(BeEmpty())
+			})
+		})
+
+		Context("Hot-adds and hot-removes devices", func() {
+			var card1, card2, card3 vimtypes.BaseVirtualDevice
+
+			BeforeEach(func() {
+				var err error
+				card1, err = object.EthernetCardTypes().CreateEthernetCard("vmxnet3", dvpg1)
+				Expect(err).ToNot(HaveOccurred())
+				card1.GetVirtualDevice().Key = 100
+				expectedList = append(expectedList, card1)
+
+				card2, err = object.EthernetCardTypes().CreateEthernetCard("e1000", dvpg1)
+				Expect(err).ToNot(HaveOccurred())
+				card2.GetVirtualDevice().Key = 200
+				card3, err = object.EthernetCardTypes().CreateEthernetCard("vmxnet3", dvpg2)
+				Expect(err).ToNot(HaveOccurred())
+				card3.GetVirtualDevice().Key = 300
+				currentList = append(currentList, card2, card3)
+			})
+
+			It("returns remove device change", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deviceChanges).To(HaveLen(1))
+
+				configSpec := deviceChanges[0].GetVirtualDeviceConfigSpec()
+				Expect(configSpec.Device.GetVirtualDevice().Key).To(Equal(card3.GetVirtualDevice().Key))
+				Expect(configSpec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+			})
+		})
+	})
+
 	Context("Create vSphere PCI device", func() {
 		var vgpuDevices = []vmopv1.VGPUDevice{
 			{
@@ -1181,6 +1330,67 @@ var _ = Describe("UpdateVirtualMachine", func() {
 				Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)).To(Succeed())
 				assertNoUpdate()
 			})
+
+			When("the boot disk size is increased", func() {
+				const (
+					oldDiskSizeBytes = int64(10 * 1024 * 1024 * 1024)
+					newDiskSizeBytes = int64(20 * 1024 * 1024 * 1024)
+				)
+
+				var bootDiskResize bool
+
+				BeforeEach(func() {
+					bootDiskResize = true
+					vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+						BootDiskCapacity: ptr.To(resource.MustParse("20Gi")),
+					}
+				})
+
+				JustBeforeEach(func() {
+					pkgcfg.UpdateContext(vmCtx, func(config *pkgcfg.Config) {
+						config.Features.VMBootDiskResize = bootDiskResize
+					})
+				})
+
+				getBootDiskCapacity := func() int64 {
+					ExpectWithOffset(1, vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+					disks := object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device).SelectByType(&vimtypes.VirtualDisk{})
+					ExpectWithOffset(1, disks).To(HaveLen(1))
+					return disks[0].(*vimtypes.VirtualDisk).CapacityInBytes
+				}
+
+				It("should resize the boot disk", func() {
+					Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)).To(Succeed())
+					Expect(getBootDiskCapacity()).To(Equal(newDiskSizeBytes))
+					Expect(vmCtx.MoVM.Summary.Runtime.PowerState).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+				})
+
+				When("the VM has a snapshot", func() {
+					JustBeforeEach(func() {
+						t, err := vcVM.CreateSnapshot(ctx, "snap-1", "", false, false)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(t.Wait(ctx)).To(Succeed())
+						Expect(vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+					})
+
+					It("should return an error", func() {
+						err := sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)
+						Expect(err).To(MatchError(ContainSubstring("cannot resize boot disk of a VM with snapshots")))
+						Expect(getBootDiskCapacity()).To(Equal(oldDiskSizeBytes))
+					})
+				})
+
+				When("boot disk resize is disabled", func() {
+					BeforeEach(func() {
+						bootDiskResize = false
+					})
+
+					It("should not resize the boot disk", func() {
+						Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)).To(Succeed())
+						Expect(getBootDiskCapacity()).To(Equal(oldDiskSizeBytes))
+					})
+				})
+			})
 		})
 
 		When("powering off the VM", func() {
@@ -232,6 +232,49 @@ func BackupVirtualMachine(opts BackupVirtualMachineOptions) (result error) {
 	return nil
 }
 
+// ResetBackupAfterRevert ensures the backup data in the VM's ExtraConfig
+// remains consistent after the VM is reverted to a snapshot. Reverting restores
+// the ExtraConfig from when the snapshot was taken, so the backup version may
+// be older than the VM's backup version annotation, which would otherwise be
+// detected as a vendor triggered restore and pause subsequent backups.
+//
+// The backup version in ExtraConfig is set to the VM's backup version
+// annotation, and the remaining backup data is cleared so the next backup
+// reflects the reverted VM.
+func ResetBackupAfterRevert(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) error {
+
+	ecToUpdate := pkgutil.OptionValues{
+		&vimtypes.OptionValue{Key: backupapi.VMResourceYAMLExtraConfigKey, Value: ""},
+		&vimtypes.OptionValue{Key: backupapi.AdditionalResourcesYAMLExtraConfigKey, Value: ""},
+		&vimtypes.OptionValue{Key: backupapi.PVCDiskDataExtraConfigKey, Value: ""},
+		&vimtypes.OptionValue{Key: backupapi.ClassicDiskDataExtraConfigKey, Value: ""},
+	}
+
+	if v := vmCtx.VM.Annotations[vmopv1.VirtualMachineBackupVersionAnnotation]; v != "" {
+		ecToUpdate = append(ecToUpdate, &vimtypes.OptionValue{
+			Key:   backupapi.BackupVersionExtraConfigKey,
+			Value: v,
+		})
+	}
+
+	configSpec := &vimtypes.VirtualMachineConfigSpec{
+		ExtraConfig: ecToUpdate,
+	}
+
+	// Like the backup itself, resetting the backup data is not an update from
+	// the user's point of view.
+	ctx := ctxop.WithContext(vmCtx)
+
+	if _, err := res.NewVMFromObject(vcVM).Reconfigure(ctx, configSpec); err != nil {
+		vmCtx.Logger.Error(err, "failed to reset VM ExtraConfig backup data after revert")
+		return err
+	}
+
+	return nil
+}
+
 // canPerformBackup checks if a backup can be performed. The method returns true when
 // the backup version annotation matches (or) is older (lesser) than the backup version in extraConfig.
 // It returns false when the backup version annotation is newer (greater) than the backup extraConfig version which indicates a vendor
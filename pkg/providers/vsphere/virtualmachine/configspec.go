@@ -238,10 +238,13 @@ func CreateConfigSpecForPlacement(
 		})
 	}
 
+	volumeDisks := map[string]*vimtypes.VirtualDisk{}
+
 	if pkgcfg.FromContext(vmCtx).Features.InstanceStorage {
 		isVolumes := vmopv1util.FilterInstanceStorageVolumes(vmCtx.VM)
 
 		for idx, dev := range CreateInstanceStorageDiskDevices(isVolumes) {
+			volumeDisks[isVolumes[idx].Name] = dev.(*vimtypes.VirtualDisk)
 			configSpec.DeviceChange = append(configSpec.DeviceChange, &vimtypes.VirtualDeviceConfigSpec{
 				Operation:     vimtypes.VirtualDeviceConfigSpecOperationAdd,
 				FileOperation: vimtypes.VirtualDeviceConfigSpecFileOperationCreate,
@@ -258,6 +261,17 @@ func CreateConfigSpecForPlacement(
 		}
 	}
 
+	// Add the controllers requested by the VM's volumes, and attach the disks
+	// created above to the controller and unit number requested by their
+	// volume.
+	if err := EnsureVolumeDiskControllers(
+		&configSpec,
+		vmCtx.VM.Spec.Volumes,
+		volumeDisks); err != nil {
+
+		return vimtypes.VirtualMachineConfigSpec{}, err
+	}
+
 	if err := util.EnsureDisksHaveControllers(&configSpec); err != nil {
 		return vimtypes.VirtualMachineConfigSpec{}, err
 	}
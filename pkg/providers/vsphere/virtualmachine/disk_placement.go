@@ -0,0 +1,636 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// maxControllersPerType is the maximum number of controllers of a given
+	// type a VM may have.
+	maxControllersPerType = 4
+
+	// scsiControllerUnitNumber is the unit number reserved for a SCSI
+	// controller on its own bus.
+	scsiControllerUnitNumber = 7
+)
+
+// HasVolumeDiskPlacement returns true if the volume specifies where its disk
+// should be attached or how the disk is shared.
+func HasVolumeDiskPlacement(volume vmopv1.VirtualMachineVolume) bool {
+	return volume.ControllerType != "" ||
+		volume.SharingMode != "" ||
+		volume.ControllerSharingMode != ""
+}
+
+// EnsureVolumeDiskControllers adds to the ConfigSpec any controllers
+// requested by the provided volumes that do not exist in the ConfigSpec or
+// existing devices. The provided disks, keyed by volume name, are attached to
+// the controller and unit number requested by their volume.
+//
+// This function should be called prior to util.EnsureDisksHaveControllers so
+// the latter does not select controllers or unit numbers for disks whose
+// placement was requested by their volumes.
+func EnsureVolumeDiskControllers(
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	volumes []vmopv1.VirtualMachineVolume,
+	disks map[string]*vimtypes.VirtualDisk,
+	existingDevices ...vimtypes.BaseVirtualDevice) error {
+
+	p := newVolumeDiskPlacer(configSpec, existingDevices)
+
+	for _, vol := range volumes {
+		if !HasVolumeDiskPlacement(vol) {
+			continue
+		}
+
+		if disk, ok := disks[vol.Name]; ok {
+			if _, err := p.place(disk, vol); err != nil {
+				return fmt.Errorf("failed to place disk for volume %q: %w", vol.Name, err)
+			}
+			continue
+		}
+
+		if vol.ControllerType != "" {
+			controller, err := p.controllerFor(vol.ControllerType, vol.ControllerBusNumber)
+			if err != nil {
+				return fmt.Errorf("failed to ensure controller for volume %q: %w", vol.Name, err)
+			}
+			p.ensureControllerSharing(controller, vol.ControllerSharingMode)
+		}
+	}
+
+	configSpec.DeviceChange = append(configSpec.DeviceChange, p.deviceChanges...)
+
+	return nil
+}
+
+// UpdateVolumeDiskPlacementDeviceChanges returns the device changes required
+// to move the disks of the VM's attached, managed volumes to the controller
+// and unit number requested by the volume, as well as to update the sharing
+// mode of the disks and the bus sharing mode of their controllers. The disks
+// are attached by CNS, which selects a controller and unit number on its own,
+// so the disks may only be moved while the VM is powered off.
+//
+// Any negative device keys used by the returned changes are less than the
+// keys of the devices in the provided ConfigSpec.
+func UpdateVolumeDiskPlacementDeviceChanges(
+	vm *vmopv1.VirtualMachine,
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	devices object.VirtualDeviceList) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
+
+	diskUUIDs := map[string]string{}
+	for _, vol := range vm.Status.Volumes {
+		if vol.Type == vmopv1.VirtualMachineStorageDiskTypeManaged &&
+			vol.Attached && vol.DiskUUID != "" {
+
+			diskUUIDs[vol.Name] = vol.DiskUUID
+		}
+	}
+
+	p := newVolumeDiskPlacer(configSpec, devices)
+
+	for _, vol := range vm.Spec.Volumes {
+		if !HasVolumeDiskPlacement(vol) {
+			continue
+		}
+
+		diskUUID, ok := diskUUIDs[vol.Name]
+		if !ok {
+			// The volume is not attached yet. Ensure the requested controller
+			// exists so the disk may be moved to it on a later power on.
+			if vol.ControllerType != "" {
+				controller, err := p.controllerFor(vol.ControllerType, vol.ControllerBusNumber)
+				if err != nil {
+					return nil, fmt.Errorf("failed to ensure controller for volume %q: %w", vol.Name, err)
+				}
+				p.ensureControllerSharing(controller, vol.ControllerSharingMode)
+			}
+			continue
+		}
+
+		var disk *vimtypes.VirtualDisk
+		for _, dev := range p.devices {
+			if vd, ok := dev.(*vimtypes.VirtualDisk); ok && getDiskUUID(vd) == diskUUID {
+				disk = vd
+				break
+			}
+		}
+		if disk == nil {
+			return nil, fmt.Errorf("disk %s for volume %q not found", diskUUID, vol.Name)
+		}
+
+		changed, err := p.place(disk, vol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to place disk for volume %q: %w", vol.Name, err)
+		}
+
+		if changed {
+			p.deviceChanges = append(p.deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+				Device:    disk,
+			})
+		}
+	}
+
+	return p.deviceChanges, nil
+}
+
+// VolumesWithPendingDiskPlacement returns the names of the VM's attached,
+// managed volumes whose disk is not attached to the controller and unit number,
+// or does not have the sharing mode, requested by the volume. The disks of
+// these volumes are moved the next time the VM is powered on.
+func VolumesWithPendingDiskPlacement(
+	vm *vmopv1.VirtualMachine,
+	devices object.VirtualDeviceList) []string {
+
+	diskUUIDs := map[string]string{}
+	for _, vol := range vm.Status.Volumes {
+		if vol.Type == vmopv1.VirtualMachineStorageDiskTypeManaged &&
+			vol.Attached && vol.DiskUUID != "" {
+
+			diskUUIDs[vol.Name] = vol.DiskUUID
+		}
+	}
+
+	var pending []string
+
+	for _, vol := range vm.Spec.Volumes {
+		if !HasVolumeDiskPlacement(vol) {
+			continue
+		}
+
+		diskUUID, ok := diskUUIDs[vol.Name]
+		if !ok {
+			continue
+		}
+
+		for _, dev := range devices {
+			if vd, ok := dev.(*vimtypes.VirtualDisk); ok && getDiskUUID(vd) == diskUUID {
+				if !isVolumeDiskPlaced(devices, vd, vol) {
+					pending = append(pending, vol.Name)
+				}
+				break
+			}
+		}
+	}
+
+	return pending
+}
+
+// isVolumeDiskPlaced returns true if the disk is attached to the controller
+// and unit number, and has the sharing mode, requested by the volume, and the
+// controller has the requested bus sharing mode.
+func isVolumeDiskPlaced(
+	devices object.VirtualDeviceList,
+	disk *vimtypes.VirtualDisk,
+	vol vmopv1.VirtualMachineVolume) bool {
+
+	if vol.ControllerType != "" {
+		dev := devices.FindByKey(disk.ControllerKey)
+		if dev == nil || !isControllerType(dev, vol.ControllerType) {
+			return false
+		}
+		if vol.ControllerBusNumber != nil &&
+			dev.(vimtypes.BaseVirtualController).GetVirtualController().BusNumber != *vol.ControllerBusNumber {
+
+			return false
+		}
+		if vol.UnitNumber != nil &&
+			(disk.UnitNumber == nil || *disk.UnitNumber != *vol.UnitNumber) {
+
+			return false
+		}
+		if scsi, ok := dev.(vimtypes.BaseVirtualSCSIController); ok && vol.ControllerSharingMode != "" &&
+			scsi.GetVirtualSCSIController().SharedBus != controllerSharedBus(vol.ControllerSharingMode) {
+
+			return false
+		}
+	}
+
+	if vol.SharingMode != "" {
+		sharing, ok := getDiskSharing(disk)
+		if !ok || sharing != volumeDiskSharing(vol.SharingMode) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type volumeDiskPlacer struct {
+	// devices are the VM's existing devices plus the ones being added.
+	devices object.VirtualDeviceList
+
+	// existingKeys are the keys of the VM's existing devices.
+	existingKeys map[int32]bool
+
+	deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+	pciController *vimtypes.VirtualPCIController
+	nextKey       int32
+}
+
+func newVolumeDiskPlacer(
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	existingDevices []vimtypes.BaseVirtualDevice) *volumeDiskPlacer {
+
+	p := &volumeDiskPlacer{
+		existingKeys: map[int32]bool{},
+	}
+	p.devices = append(p.devices, existingDevices...)
+	for _, dev := range existingDevices {
+		p.existingKeys[dev.GetVirtualDevice().Key] = true
+	}
+
+	for _, bdc := range configSpec.DeviceChange {
+		dc := bdc.GetVirtualDeviceConfigSpec()
+		if dc == nil || dc.Device == nil {
+			continue
+		}
+		if key := dc.Device.GetVirtualDevice().Key; key < p.nextKey {
+			p.nextKey = key
+		}
+		if dc.Operation == vimtypes.VirtualDeviceConfigSpecOperationAdd {
+			p.devices = append(p.devices, dc.Device)
+		}
+	}
+
+	for _, dev := range p.devices {
+		if key := dev.GetVirtualDevice().Key; key < p.nextKey {
+			p.nextKey = key
+		}
+		if pci, ok := dev.(*vimtypes.VirtualPCIController); ok && p.pciController == nil {
+			p.pciController = pci
+		}
+	}
+
+	// Decrement the nextKey so the next device has a unique key.
+	p.nextKey--
+
+	return p
+}
+
+func (p *volumeDiskPlacer) newKey() int32 {
+	key := p.nextKey
+	p.nextKey--
+	return key
+}
+
+func (p *volumeDiskPlacer) add(dev vimtypes.BaseVirtualDevice) {
+	p.devices = append(p.devices, dev)
+	p.deviceChanges = append(p.deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+		Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+		Device:    dev,
+	})
+}
+
+// controllers returns the controllers of the provided type.
+func (p *volumeDiskPlacer) controllers(
+	controllerType vmopv1.VirtualControllerType) []vimtypes.BaseVirtualController {
+
+	var controllers []vimtypes.BaseVirtualController
+	for _, dev := range p.devices {
+		if isControllerType(dev, controllerType) {
+			controllers = append(controllers, dev.(vimtypes.BaseVirtualController))
+		}
+	}
+	return controllers
+}
+
+// controllerFor returns the controller of the provided type and bus number,
+// adding the controller if it does not exist. If no bus number is provided,
+// the controller of the provided type with the lowest bus number and a free
+// unit number is returned.
+func (p *volumeDiskPlacer) controllerFor(
+	controllerType vmopv1.VirtualControllerType,
+	busNumber *int32) (vimtypes.BaseVirtualController, error) {
+
+	controllers := p.controllers(controllerType)
+
+	var usedBusNumbers [maxControllersPerType]bool
+	for _, c := range controllers {
+		bus := c.GetVirtualController().BusNumber
+		if busNumber != nil && bus == *busNumber {
+			return c, nil
+		}
+		if bus >= 0 && bus < maxControllersPerType {
+			usedBusNumbers[bus] = true
+		}
+	}
+
+	if busNumber == nil {
+		var found vimtypes.BaseVirtualController
+		for _, c := range controllers {
+			if _, ok := p.nextUnitNumber(c, controllerType, 0); !ok {
+				continue
+			}
+			if found == nil ||
+				c.GetVirtualController().BusNumber < found.GetVirtualController().BusNumber {
+				found = c
+			}
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		for i := range usedBusNumbers {
+			if !usedBusNumbers[i] {
+				busNumber = ptr.To(int32(i))
+				break
+			}
+		}
+		if busNumber == nil {
+			return nil, fmt.Errorf("no %s controllers available", controllerType)
+		}
+	}
+
+	if p.pciController == nil {
+		// Add a PCI controller if one is not present.
+		p.pciController = &vimtypes.VirtualPCIController{
+			VirtualController: vimtypes.VirtualController{
+				VirtualDevice: vimtypes.VirtualDevice{
+					Key: p.newKey(),
+				},
+			},
+		}
+		p.add(p.pciController)
+	}
+
+	controller := newVolumeDiskController(
+		controllerType, p.pciController.Key, p.newKey(), *busNumber)
+	p.add(controller.(vimtypes.BaseVirtualDevice))
+
+	return controller, nil
+}
+
+// place attaches the disk to the controller and unit number requested by the
+// volume and updates the disk's sharing mode. True is returned if the disk was
+// modified.
+func (p *volumeDiskPlacer) place(
+	disk *vimtypes.VirtualDisk,
+	vol vmopv1.VirtualMachineVolume) (bool, error) {
+
+	changed := false
+
+	if controllerType := vol.ControllerType; controllerType != "" {
+		var controller vimtypes.BaseVirtualController
+		if vol.ControllerBusNumber == nil && vol.UnitNumber == nil {
+			// Keep the disk on its current controller if it is of the
+			// requested type.
+			if dev := p.devices.FindByKey(disk.ControllerKey); dev != nil &&
+				isControllerType(dev, controllerType) {
+
+				controller = dev.(vimtypes.BaseVirtualController)
+			}
+		}
+		if controller == nil {
+			c, err := p.controllerFor(controllerType, vol.ControllerBusNumber)
+			if err != nil {
+				return false, err
+			}
+			controller = c
+		}
+		p.ensureControllerSharing(controller, vol.ControllerSharingMode)
+
+		controllerKey := controller.GetVirtualController().Key
+		busNumber := controller.GetVirtualController().BusNumber
+
+		var unitNumber int32
+		switch {
+		case vol.UnitNumber != nil:
+			unitNumber = *vol.UnitNumber
+			if p.isUnitNumberInUse(controller, controllerType, unitNumber, disk.Key) {
+				return false, fmt.Errorf(
+					"unit number %d on %s controller %d is in use",
+					unitNumber, controllerType, busNumber)
+			}
+		case disk.ControllerKey == controllerKey && disk.UnitNumber != nil:
+			unitNumber = *disk.UnitNumber
+		default:
+			un, ok := p.nextUnitNumber(controller, controllerType, disk.Key)
+			if !ok {
+				return false, fmt.Errorf(
+					"no available unit number on %s controller %d",
+					controllerType, busNumber)
+			}
+			unitNumber = un
+		}
+
+		if disk.ControllerKey != controllerKey ||
+			disk.UnitNumber == nil || *disk.UnitNumber != unitNumber {
+
+			disk.ControllerKey = controllerKey
+			disk.UnitNumber = ptr.To(unitNumber)
+			changed = true
+		}
+	}
+
+	if vol.SharingMode != "" {
+		sharing := volumeDiskSharing(vol.SharingMode)
+
+		switch tb := disk.Backing.(type) {
+		case *vimtypes.VirtualDiskFlatVer2BackingInfo:
+			if tb.Sharing != sharing {
+				tb.Sharing = sharing
+				changed = true
+			}
+		case *vimtypes.VirtualDiskRawDiskMappingVer1BackingInfo:
+			if tb.Sharing != sharing {
+				tb.Sharing = sharing
+				changed = true
+			}
+		case *vimtypes.VirtualDiskRawDiskVer2BackingInfo:
+			if tb.Sharing != sharing {
+				tb.Sharing = sharing
+				changed = true
+			}
+		default:
+			return false, fmt.Errorf("disk backing %T does not support sharing", disk.Backing)
+		}
+	}
+
+	return changed, nil
+}
+
+// ensureControllerSharing updates the bus sharing mode of the SCSI controller
+// to the provided mode. An existing controller is edited by the returned device
+// changes, and may only be edited while the VM is powered off. A controller
+// that is being added, including one added by the ConfigSpec, is updated in
+// place.
+func (p *volumeDiskPlacer) ensureControllerSharing(
+	controller vimtypes.BaseVirtualController,
+	sharingMode vmopv1.VirtualControllerSharingMode) {
+
+	if sharingMode == "" {
+		return
+	}
+
+	scsi, ok := controller.(vimtypes.BaseVirtualSCSIController)
+	if !ok {
+		return
+	}
+
+	sharedBus := controllerSharedBus(sharingMode)
+
+	sc := scsi.GetVirtualSCSIController()
+	if sc.SharedBus == sharedBus {
+		return
+	}
+	sc.SharedBus = sharedBus
+
+	if p.existingKeys[sc.Key] {
+		p.deviceChanges = append(p.deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+			Device:    controller.(vimtypes.BaseVirtualDevice),
+		})
+	}
+}
+
+// isUnitNumberInUse returns true if a device other than the one with the
+// provided key is attached to the controller at the provided unit number.
+func (p *volumeDiskPlacer) isUnitNumberInUse(
+	controller vimtypes.BaseVirtualController,
+	controllerType vmopv1.VirtualControllerType,
+	unitNumber, excludeKey int32) bool {
+
+	if controllerType == vmopv1.VirtualControllerTypeSCSI &&
+		unitNumber == scsiControllerUnitNumber {
+
+		return true
+	}
+
+	controllerKey := controller.GetVirtualController().Key
+	for _, dev := range p.devices {
+		d := dev.GetVirtualDevice()
+		if d.Key != excludeKey && d.ControllerKey == controllerKey &&
+			d.UnitNumber != nil && *d.UnitNumber == unitNumber {
+
+			return true
+		}
+	}
+	return false
+}
+
+// nextUnitNumber returns the lowest unit number on the controller not used by
+// a device other than the one with the provided key.
+func (p *volumeDiskPlacer) nextUnitNumber(
+	controller vimtypes.BaseVirtualController,
+	controllerType vmopv1.VirtualControllerType,
+	excludeKey int32) (int32, bool) {
+
+	for un := int32(0); un < controllerType.MaxUnitNumber(); un++ {
+		if !p.isUnitNumberInUse(controller, controllerType, un, excludeKey) {
+			return un, true
+		}
+	}
+	return -1, false
+}
+
+// controllerSharedBus returns the SCSI bus sharing mode for the volume's
+// controller sharing mode.
+func controllerSharedBus(sharingMode vmopv1.VirtualControllerSharingMode) vimtypes.VirtualSCSISharing {
+	switch sharingMode {
+	case vmopv1.VirtualControllerSharingModePhysical:
+		return vimtypes.VirtualSCSISharingPhysicalSharing
+	case vmopv1.VirtualControllerSharingModeVirtual:
+		return vimtypes.VirtualSCSISharingVirtualSharing
+	}
+	return vimtypes.VirtualSCSISharingNoSharing
+}
+
+// volumeDiskSharing returns the disk sharing mode for the volume's sharing
+// mode.
+func volumeDiskSharing(sharingMode vmopv1.VolumeSharingMode) string {
+	if sharingMode == vmopv1.VolumeSharingModeMultiWriter {
+		return string(vimtypes.VirtualDiskSharingSharingMultiWriter)
+	}
+	return string(vimtypes.VirtualDiskSharingSharingNone)
+}
+
+// getDiskSharing returns the sharing mode of the disk. False is returned if
+// the disk's backing does not support sharing.
+func getDiskSharing(disk *vimtypes.VirtualDisk) (string, bool) {
+	var sharing string
+	switch tb := disk.Backing.(type) {
+	case *vimtypes.VirtualDiskFlatVer2BackingInfo:
+		sharing = tb.Sharing
+	case *vimtypes.VirtualDiskRawDiskMappingVer1BackingInfo:
+		sharing = tb.Sharing
+	case *vimtypes.VirtualDiskRawDiskVer2BackingInfo:
+		sharing = tb.Sharing
+	default:
+		return "", false
+	}
+	if sharing == "" {
+		sharing = string(vimtypes.VirtualDiskSharingSharingNone)
+	}
+	return sharing, true
+}
+
+func isControllerType(
+	dev vimtypes.BaseVirtualDevice,
+	controllerType vmopv1.VirtualControllerType) bool {
+
+	switch dev.(type) {
+	case *vimtypes.ParaVirtualSCSIController,
+		*vimtypes.VirtualBusLogicController,
+		*vimtypes.VirtualLsiLogicController,
+		*vimtypes.VirtualLsiLogicSASController,
+		*vimtypes.VirtualSCSIController:
+
+		return controllerType == vmopv1.VirtualControllerTypeSCSI
+
+	case *vimtypes.VirtualSATAController,
+		*vimtypes.VirtualAHCIController:
+
+		return controllerType == vmopv1.VirtualControllerTypeSATA
+
+	case *vimtypes.VirtualNVMEController:
+		return controllerType == vmopv1.VirtualControllerTypeNVME
+	}
+	return false
+}
+
+func newVolumeDiskController(
+	controllerType vmopv1.VirtualControllerType,
+	pciControllerKey, key, busNumber int32) vimtypes.BaseVirtualController {
+
+	vc := vimtypes.VirtualController{
+		VirtualDevice: vimtypes.VirtualDevice{
+			ControllerKey: pciControllerKey,
+			Key:           key,
+		},
+		BusNumber: busNumber,
+	}
+
+	switch controllerType {
+	case vmopv1.VirtualControllerTypeSATA:
+		return &vimtypes.VirtualAHCIController{
+			VirtualSATAController: vimtypes.VirtualSATAController{
+				VirtualController: vc,
+			},
+		}
+	case vmopv1.VirtualControllerTypeNVME:
+		return &vimtypes.VirtualNVMEController{
+			VirtualController: vc,
+			SharedBus:         string(vimtypes.VirtualNVMEControllerSharingNoSharing),
+		}
+	default:
+		return &vimtypes.ParaVirtualSCSIController{
+			VirtualSCSIController: vimtypes.VirtualSCSIController{
+				VirtualController: vc,
+				HotAddRemove:      ptr.To(true),
+				SharedBus:         vimtypes.VirtualSCSISharingNoSharing,
+			},
+		}
+	}
+}
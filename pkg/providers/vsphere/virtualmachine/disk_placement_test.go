@@ -0,0 +1,432 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+var _ = Describe("EnsureVolumeDiskControllers", func() {
+	var (
+		configSpec *vimtypes.VirtualMachineConfigSpec
+		volumes    []vmopv1.VirtualMachineVolume
+		disks      map[string]*vimtypes.VirtualDisk
+		err        error
+	)
+
+	BeforeEach(func() {
+		configSpec = &vimtypes.VirtualMachineConfigSpec{}
+		volumes = nil
+		disks = map[string]*vimtypes.VirtualDisk{}
+	})
+
+	JustBeforeEach(func() {
+		err = virtualmachine.EnsureVolumeDiskControllers(configSpec, volumes, disks)
+	})
+
+	addedDevices := func() []vimtypes.BaseVirtualDevice {
+		var devices []vimtypes.BaseVirtualDevice
+		for _, bdc := range configSpec.DeviceChange {
+			dc := bdc.GetVirtualDeviceConfigSpec()
+			Expect(dc.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+			devices = append(devices, dc.Device)
+		}
+		return devices
+	}
+
+	When("no volumes request a placement", func() {
+		BeforeEach(func() {
+			volumes = []vmopv1.VirtualMachineVolume{{Name: "vol1"}}
+		})
+
+		It("does not add any devices", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configSpec.DeviceChange).To(BeEmpty())
+		})
+	})
+
+	When("a volume requests a controller", func() {
+		BeforeEach(func() {
+			volumes = []vmopv1.VirtualMachineVolume{
+				{
+					Name:                "vol1",
+					ControllerType:      vmopv1.VirtualControllerTypeNVME,
+					ControllerBusNumber: ptr.To[int32](2),
+				},
+			}
+		})
+
+		It("adds the PCI controller and the requested controller", func() {
+			Expect(err).ToNot(HaveOccurred())
+			devices := addedDevices()
+			Expect(devices).To(HaveLen(2))
+
+			pci, ok := devices[0].(*vimtypes.VirtualPCIController)
+			Expect(ok).To(BeTrue())
+
+			nvme, ok := devices[1].(*vimtypes.VirtualNVMEController)
+			Expect(ok).To(BeTrue())
+			Expect(nvme.BusNumber).To(Equal(int32(2)))
+			Expect(nvme.ControllerKey).To(Equal(pci.Key))
+			Expect(nvme.Key).ToNot(Equal(pci.Key))
+		})
+	})
+
+	When("a disk requests a controller and unit number", func() {
+		var disk *vimtypes.VirtualDisk
+
+		BeforeEach(func() {
+			disk = &vimtypes.VirtualDisk{
+				VirtualDevice: vimtypes.VirtualDevice{
+					Key:     -300,
+					Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{},
+				},
+			}
+			configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device:    disk,
+				},
+			}
+			disks["vol1"] = disk
+			volumes = []vmopv1.VirtualMachineVolume{
+				{
+					Name:                "vol1",
+					ControllerType:      vmopv1.VirtualControllerTypeSCSI,
+					ControllerBusNumber: ptr.To[int32](1),
+					UnitNumber:          ptr.To[int32](9),
+					SharingMode:         vmopv1.VolumeSharingModeMultiWriter,
+				},
+			}
+		})
+
+		It("attaches the disk to the requested controller and unit number", func() {
+			Expect(err).ToNot(HaveOccurred())
+			devices := addedDevices()
+			Expect(devices).To(HaveLen(3))
+
+			scsi, ok := devices[2].(*vimtypes.ParaVirtualSCSIController)
+			Expect(ok).To(BeTrue())
+			Expect(scsi.BusNumber).To(Equal(int32(1)))
+			Expect(scsi.Key).To(BeNumerically("<", int32(-300)))
+
+			Expect(disk.ControllerKey).To(Equal(scsi.Key))
+			Expect(disk.UnitNumber).To(HaveValue(Equal(int32(9))))
+			Expect(disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo).Sharing).
+				To(Equal(string(vimtypes.VirtualDiskSharingSharingMultiWriter)))
+		})
+	})
+
+	When("a volume requests the bus sharing mode of a controller being added", func() {
+		var scsi *vimtypes.ParaVirtualSCSIController
+
+		BeforeEach(func() {
+			// The controllers from an image's OVF have positive keys.
+			scsi = &vimtypes.ParaVirtualSCSIController{
+				VirtualSCSIController: vimtypes.VirtualSCSIController{
+					VirtualController: vimtypes.VirtualController{
+						VirtualDevice: vimtypes.VirtualDevice{Key: 1000},
+						BusNumber:     1,
+					},
+					SharedBus: vimtypes.VirtualSCSISharingNoSharing,
+				},
+			}
+			configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device:    scsi,
+				},
+			}
+			volumes = []vmopv1.VirtualMachineVolume{
+				{
+					Name:                  "vol1",
+					ControllerType:        vmopv1.VirtualControllerTypeSCSI,
+					ControllerBusNumber:   ptr.To[int32](1),
+					ControllerSharingMode: vmopv1.VirtualControllerSharingModePhysical,
+				},
+			}
+		})
+
+		It("updates the controller being added instead of editing it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			devices := addedDevices()
+			Expect(devices).To(HaveLen(1))
+			Expect(devices[0]).To(BeIdenticalTo(scsi))
+			Expect(scsi.SharedBus).To(Equal(vimtypes.VirtualSCSISharingPhysicalSharing))
+		})
+	})
+
+	When("two disks request the same unit number", func() {
+		BeforeEach(func() {
+			for i, name := range []string{"vol1", "vol2"} {
+				disk := &vimtypes.VirtualDisk{
+					VirtualDevice: vimtypes.VirtualDevice{
+						Key: -300 - int32(i),
+					},
+				}
+				configSpec.DeviceChange = append(configSpec.DeviceChange,
+					&vimtypes.VirtualDeviceConfigSpec{
+						Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+						Device:    disk,
+					})
+				disks[name] = disk
+				volumes = append(volumes, vmopv1.VirtualMachineVolume{
+					Name:                name,
+					ControllerType:      vmopv1.VirtualControllerTypeSATA,
+					ControllerBusNumber: ptr.To[int32](0),
+					UnitNumber:          ptr.To[int32](3),
+				})
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(`failed to place disk for volume "vol2": unit number 3 on SATA controller 0 is in use`))
+		})
+	})
+})
+
+var _ = Describe("UpdateVolumeDiskPlacementDeviceChanges", func() {
+	const (
+		pciKey    = int32(100)
+		scsiKey   = int32(1000)
+		diskUUID1 = "disk-uuid-1"
+		diskUUID2 = "disk-uuid-2"
+	)
+
+	var (
+		vm            *vmopv1.VirtualMachine
+		configSpec    *vimtypes.VirtualMachineConfigSpec
+		devices       object.VirtualDeviceList
+		disk1, disk2  *vimtypes.VirtualDisk
+		deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+		err           error
+	)
+
+	newDisk := func(key, unitNumber int32, uuid string) *vimtypes.VirtualDisk {
+		return &vimtypes.VirtualDisk{
+			VirtualDevice: vimtypes.VirtualDevice{
+				Key:           key,
+				ControllerKey: scsiKey,
+				UnitNumber:    ptr.To(unitNumber),
+				Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+					Uuid: uuid,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		disk1 = newDisk(2000, 0, diskUUID1)
+		disk2 = newDisk(2001, 1, diskUUID2)
+		devices = object.VirtualDeviceList{
+			&vimtypes.VirtualPCIController{
+				VirtualController: vimtypes.VirtualController{
+					VirtualDevice: vimtypes.VirtualDevice{Key: pciKey},
+				},
+			},
+			&vimtypes.ParaVirtualSCSIController{
+				VirtualSCSIController: vimtypes.VirtualSCSIController{
+					VirtualController: vimtypes.VirtualController{
+						VirtualDevice: vimtypes.VirtualDevice{
+							Key:           scsiKey,
+							ControllerKey: pciKey,
+						},
+						BusNumber: 0,
+					},
+				},
+			},
+			disk1,
+			disk2,
+		}
+		configSpec = &vimtypes.VirtualMachineConfigSpec{}
+
+		vm = &vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Volumes: []vmopv1.VirtualMachineVolume{
+					{Name: "vol1"},
+					{Name: "vol2"},
+				},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Volumes: []vmopv1.VirtualMachineVolumeStatus{
+					{
+						Name:     "vol1",
+						Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+						Attached: true,
+						DiskUUID: diskUUID1,
+					},
+					{
+						Name:     "vol2",
+						Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+						Attached: true,
+						DiskUUID: diskUUID2,
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		deviceChanges, err = virtualmachine.UpdateVolumeDiskPlacementDeviceChanges(vm, configSpec, devices)
+	})
+
+	When("no volumes request a placement", func() {
+		It("returns no changes", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(BeEmpty())
+		})
+	})
+
+	When("the disk is already placed as requested", func() {
+		BeforeEach(func() {
+			vm.Spec.Volumes[1].ControllerType = vmopv1.VirtualControllerTypeSCSI
+			vm.Spec.Volumes[1].ControllerBusNumber = ptr.To[int32](0)
+			vm.Spec.Volumes[1].UnitNumber = ptr.To[int32](1)
+		})
+
+		It("returns no changes", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(BeEmpty())
+		})
+	})
+
+	When("the disk is requested on a different unit number", func() {
+		BeforeEach(func() {
+			vm.Spec.Volumes[1].ControllerType = vmopv1.VirtualControllerTypeSCSI
+			vm.Spec.Volumes[1].ControllerBusNumber = ptr.To[int32](0)
+			vm.Spec.Volumes[1].UnitNumber = ptr.To[int32](8)
+		})
+
+		It("edits the disk", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(1))
+			dc := deviceChanges[0].GetVirtualDeviceConfigSpec()
+			Expect(dc.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationEdit))
+			Expect(dc.Device).To(BeIdenticalTo(disk2))
+			Expect(disk2.ControllerKey).To(Equal(scsiKey))
+			Expect(disk2.UnitNumber).To(HaveValue(Equal(int32(8))))
+		})
+	})
+
+	When("the disk is requested on a unit number that is in use", func() {
+		BeforeEach(func() {
+			vm.Spec.Volumes[1].ControllerType = vmopv1.VirtualControllerTypeSCSI
+			vm.Spec.Volumes[1].ControllerBusNumber = ptr.To[int32](0)
+			vm.Spec.Volumes[1].UnitNumber = ptr.To[int32](0)
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(`failed to place disk for volume "vol2": unit number 0 on SCSI controller 0 is in use`))
+		})
+	})
+
+	When("the disk is requested on a controller that does not exist", func() {
+		BeforeEach(func() {
+			configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device: &vimtypes.VirtualE1000{
+						VirtualEthernetCard: vimtypes.VirtualEthernetCard{
+							VirtualDevice: vimtypes.VirtualDevice{Key: -10},
+						},
+					},
+				},
+			}
+			vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeNVME
+		})
+
+		It("adds the controller and moves the disk to it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(2))
+
+			dc := deviceChanges[0].GetVirtualDeviceConfigSpec()
+			Expect(dc.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+			nvme, ok := dc.Device.(*vimtypes.VirtualNVMEController)
+			Expect(ok).To(BeTrue())
+			Expect(nvme.Key).To(Equal(int32(-11)))
+			Expect(nvme.ControllerKey).To(Equal(pciKey))
+			Expect(nvme.BusNumber).To(Equal(int32(0)))
+
+			dc = deviceChanges[1].GetVirtualDeviceConfigSpec()
+			Expect(dc.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationEdit))
+			Expect(dc.Device).To(BeIdenticalTo(disk1))
+			Expect(disk1.ControllerKey).To(Equal(nvme.Key))
+			Expect(disk1.UnitNumber).To(HaveValue(Equal(int32(0))))
+		})
+	})
+
+	When("the disk sharing mode is changed", func() {
+		BeforeEach(func() {
+			vm.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+		})
+
+		It("edits the disk", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(1))
+			Expect(deviceChanges[0].GetVirtualDeviceConfigSpec().Device).To(BeIdenticalTo(disk1))
+			Expect(disk1.ControllerKey).To(Equal(scsiKey))
+			Expect(disk1.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo).Sharing).
+				To(Equal(string(vimtypes.VirtualDiskSharingSharingMultiWriter)))
+		})
+	})
+
+	When("the controller bus sharing mode is changed", func() {
+		BeforeEach(func() {
+			vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeSCSI
+			vm.Spec.Volumes[0].ControllerBusNumber = ptr.To[int32](0)
+			vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+		})
+
+		It("edits the controller", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(1))
+			dc := deviceChanges[0].GetVirtualDeviceConfigSpec()
+			Expect(dc.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationEdit))
+			scsi, ok := dc.Device.(*vimtypes.ParaVirtualSCSIController)
+			Expect(ok).To(BeTrue())
+			Expect(scsi.Key).To(Equal(scsiKey))
+			Expect(scsi.SharedBus).To(Equal(vimtypes.VirtualSCSISharingPhysicalSharing))
+		})
+
+		When("another volume requests the same sharing mode", func() {
+			BeforeEach(func() {
+				vm.Spec.Volumes[1].ControllerType = vmopv1.VirtualControllerTypeSCSI
+				vm.Spec.Volumes[1].ControllerBusNumber = ptr.To[int32](0)
+				vm.Spec.Volumes[1].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+			})
+
+			It("edits the controller once", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deviceChanges).To(HaveLen(1))
+			})
+		})
+	})
+
+	When("the volume is not attached", func() {
+		BeforeEach(func() {
+			vm.Status.Volumes = nil
+			vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeSATA
+			vm.Spec.Volumes[0].ControllerBusNumber = ptr.To[int32](3)
+		})
+
+		It("adds the requested controller", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(1))
+			dc := deviceChanges[0].GetVirtualDeviceConfigSpec()
+			Expect(dc.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+			sata, ok := dc.Device.(*vimtypes.VirtualAHCIController)
+			Expect(ok).To(BeTrue())
+			Expect(sata.BusNumber).To(Equal(int32(3)))
+		})
+	})
+})
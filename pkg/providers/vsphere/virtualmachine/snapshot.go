@@ -0,0 +1,261 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+// FindSnapshot returns the node from the VM's snapshot tree for the snapshot
+// with the provided managed object ID. The node's parent is also returned, or
+// nil if the snapshot is a root snapshot. Nil is returned for both if the ID
+// is empty or no such snapshot exists. Snapshots are not looked up by name
+// since a snapshot with the same name may have been created by someone else.
+func FindSnapshot(
+	moVM mo.VirtualMachine,
+	snapshotID string) (*vimtypes.VirtualMachineSnapshotTree, *vimtypes.VirtualMachineSnapshotTree) {
+
+	if moVM.Snapshot == nil || snapshotID == "" {
+		return nil, nil
+	}
+
+	var find func(
+		parent *vimtypes.VirtualMachineSnapshotTree,
+		nodes []vimtypes.VirtualMachineSnapshotTree) (*vimtypes.VirtualMachineSnapshotTree, *vimtypes.VirtualMachineSnapshotTree)
+
+	find = func(
+		parent *vimtypes.VirtualMachineSnapshotTree,
+		nodes []vimtypes.VirtualMachineSnapshotTree) (*vimtypes.VirtualMachineSnapshotTree, *vimtypes.VirtualMachineSnapshotTree) {
+
+		for i := range nodes {
+			n := &nodes[i]
+			if n.Snapshot.Value == snapshotID {
+				return n, parent
+			}
+			if node, nodeParent := find(n, n.ChildSnapshotList); node != nil {
+				return node, nodeParent
+			}
+		}
+		return nil, nil
+	}
+
+	return find(nil, moVM.Snapshot.RootSnapshotList)
+}
+
+// CreateSnapshot creates a snapshot of the VM as described by the provided
+// VirtualMachineSnapshot and returns the reference to the new snapshot. The
+// name of the VirtualMachineSnapshot is used as the name of the snapshot.
+func CreateSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) (*vimtypes.ManagedObjectReference, error) {
+
+	var (
+		t   *object.Task
+		err error
+	)
+
+	spec := vmSnapshot.Spec
+	if q := spec.Quiesce; q != nil && q.Timeout != nil && !spec.Memory {
+		// The timeout for quiescing may only be specified with the extended
+		// API, which accepts the timeout in minutes.
+		res, err := methods.CreateSnapshotEx_Task(vmCtx, vcVM.Client(), &vimtypes.CreateSnapshotEx_Task{
+			This:        vcVM.Reference(),
+			Name:        vmSnapshot.Name,
+			Description: spec.Description,
+			Memory:      spec.Memory,
+			QuiesceSpec: &vimtypes.VirtualMachineGuestQuiesceSpec{
+				Timeout: int32(math.Round(q.Timeout.Minutes())),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot: %w", err)
+		}
+		t = object.NewTask(vcVM.Client(), res.Returnval)
+	} else {
+		t, err = vcVM.CreateSnapshot(
+			vmCtx,
+			vmSnapshot.Name,
+			spec.Description,
+			spec.Memory,
+			spec.Quiesce != nil && !spec.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot: %w", err)
+		}
+	}
+
+	taskInfo, err := t.WaitForResult(vmCtx)
+	if err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "create snapshot task failed", "taskInfo", taskInfo)
+		}
+		return nil, fmt.Errorf("create snapshot task failed: %w", err)
+	}
+
+	snapRef, ok := taskInfo.Result.(vimtypes.ManagedObjectReference)
+	if !ok {
+		return nil, fmt.Errorf("create snapshot task returned unexpected result: %T", taskInfo.Result)
+	}
+
+	return &snapRef, nil
+}
+
+// DeleteSnapshot deletes the provided snapshot. The snapshot's children are
+// not deleted, and the snapshot's disks are consolidated into its children.
+func DeleteSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	snapRef vimtypes.ManagedObjectReference) error {
+
+	res, err := methods.RemoveSnapshot_Task(vmCtx, vcVM.Client(), &vimtypes.RemoveSnapshot_Task{
+		This:           snapRef,
+		RemoveChildren: false,
+		Consolidate:    ptr.To(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+
+	if taskInfo, err := object.NewTask(vcVM.Client(), res.Returnval).WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "delete snapshot task failed", "taskInfo", taskInfo)
+		}
+		return fmt.Errorf("delete snapshot task failed: %w", err)
+	}
+
+	return nil
+}
+
+// RevertToSnapshot reverts the VM to the provided snapshot. If the snapshot
+// includes the VM's memory, the VM is powered on after the revert unless
+// suppressPowerOn is true.
+func RevertToSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	snapRef vimtypes.ManagedObjectReference,
+	suppressPowerOn bool) error {
+
+	res, err := methods.RevertToSnapshot_Task(vmCtx, vcVM.Client(), &vimtypes.RevertToSnapshot_Task{
+		This:            snapRef,
+		SuppressPowerOn: ptr.To(suppressPowerOn),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revert to snapshot: %w", err)
+	}
+
+	if taskInfo, err := object.NewTask(vcVM.Client(), res.Returnval).WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "revert to snapshot task failed", "taskInfo", taskInfo)
+		}
+		return fmt.Errorf("revert to snapshot task failed: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevertedToSnapshot returns true if the VM was already reverted to the
+// provided snapshot for the VM's current generation, ex. when the revert
+// succeeded but clearing the VM's spec.currentSnapshot field did not. The VM
+// must include the config and snapshot properties.
+func IsRevertedToSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	snapRef vimtypes.ManagedObjectReference) bool {
+
+	moVM := vmCtx.MoVM
+	if moVM.Snapshot == nil || moVM.Snapshot.CurrentSnapshot == nil ||
+		moVM.Snapshot.CurrentSnapshot.Value != snapRef.Value || moVM.Config == nil {
+
+		return false
+	}
+
+	v, _ := pkgutil.OptionValues(moVM.Config.ExtraConfig).GetString(
+		constants.RevertedSnapshotExtraConfigKey)
+	return v == revertedSnapshotValue(vmCtx.VM, snapRef)
+}
+
+// SetRevertedSnapshot records in the VM's ExtraConfig that the VM was reverted
+// to the provided snapshot for the VM's current generation.
+func SetRevertedSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	snapRef vimtypes.ManagedObjectReference) error {
+
+	t, err := vcVM.Reconfigure(vmCtx, vimtypes.VirtualMachineConfigSpec{
+		ExtraConfig: []vimtypes.BaseOptionValue{
+			&vimtypes.OptionValue{
+				Key:   constants.RevertedSnapshotExtraConfigKey,
+				Value: revertedSnapshotValue(vmCtx.VM, snapRef),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record reverted snapshot: %w", err)
+	}
+
+	return t.Wait(vmCtx)
+}
+
+func revertedSnapshotValue(
+	vm *vmopv1.VirtualMachine,
+	snapRef vimtypes.ManagedObjectReference) string {
+
+	return fmt.Sprintf("%s:%d", snapRef.Value, vm.Generation)
+}
+
+// UpdateSnapshotStatus updates the status of the VirtualMachineSnapshot from
+// the provided node of the VM's snapshot tree. The VM must include the
+// snapshot and layoutEx properties.
+func UpdateSnapshotStatus(
+	vmSnapshot *vmopv1.VirtualMachineSnapshot,
+	moVM mo.VirtualMachine,
+	node, parent *vimtypes.VirtualMachineSnapshotTree) {
+
+	status := &vmSnapshot.Status
+	status.UniqueID = node.Snapshot.Value
+	status.Quiesced = node.Quiesced
+
+	switch node.State {
+	case vimtypes.VirtualMachinePowerStatePoweredOn:
+		status.PowerState = vmopv1.VirtualMachinePowerStateOn
+	case vimtypes.VirtualMachinePowerStateSuspended:
+		status.PowerState = vmopv1.VirtualMachinePowerStateSuspended
+	default:
+		status.PowerState = vmopv1.VirtualMachinePowerStateOff
+	}
+
+	status.Parent = ""
+	var parentRef *vimtypes.ManagedObjectReference
+	if parent != nil {
+		status.Parent = parent.Name
+		parentRef = &parent.Snapshot
+	}
+
+	status.Children = nil
+	for i := range node.ChildSnapshotList {
+		status.Children = append(status.Children, node.ChildSnapshotList[i].Name)
+	}
+
+	status.Size = nil
+	if moVM.LayoutEx != nil {
+		isCurrent := moVM.Snapshot != nil &&
+			moVM.Snapshot.CurrentSnapshot != nil &&
+			moVM.Snapshot.CurrentSnapshot.Value == node.Snapshot.Value
+		size := object.SnapshotSize(node.Snapshot, parentRef, moVM.LayoutEx, isCurrent)
+		status.Size = resource.NewQuantity(int64(size), resource.BinarySI)
+	}
+}
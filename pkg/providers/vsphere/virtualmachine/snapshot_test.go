@@ -0,0 +1,128 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+)
+
+var _ = Describe("IsRevertedToSnapshot", func() {
+	var (
+		vmCtx   pkgctx.VirtualMachineContext
+		snapRef vimtypes.ManagedObjectReference
+	)
+
+	BeforeEach(func() {
+		snapRef = vimtypes.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-1"}
+
+		vm := &vmopv1.VirtualMachine{}
+		vm.Generation = 2
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: context.Background(),
+			VM:      vm,
+			MoVM: mo.VirtualMachine{
+				Config: &vimtypes.VirtualMachineConfigInfo{
+					ExtraConfig: []vimtypes.BaseOptionValue{
+						&vimtypes.OptionValue{
+							Key:   constants.RevertedSnapshotExtraConfigKey,
+							Value: "snapshot-1:2",
+						},
+					},
+				},
+				Snapshot: &vimtypes.VirtualMachineSnapshotInfo{
+					CurrentSnapshot: &snapRef,
+				},
+			},
+		}
+	})
+
+	It("returns true if the VM was reverted to the snapshot for its generation", func() {
+		Expect(virtualmachine.IsRevertedToSnapshot(vmCtx, snapRef)).To(BeTrue())
+	})
+
+	It("returns false if the VM's current snapshot is a different snapshot", func() {
+		vmCtx.MoVM.Snapshot.CurrentSnapshot = &vimtypes.ManagedObjectReference{
+			Type:  "VirtualMachineSnapshot",
+			Value: "snapshot-2",
+		}
+		Expect(virtualmachine.IsRevertedToSnapshot(vmCtx, snapRef)).To(BeFalse())
+	})
+
+	It("returns false if the revert was requested by a newer generation", func() {
+		vmCtx.VM.Generation = 3
+		Expect(virtualmachine.IsRevertedToSnapshot(vmCtx, snapRef)).To(BeFalse())
+	})
+
+	It("returns false if the VM does not have snapshots", func() {
+		vmCtx.MoVM.Snapshot = nil
+		Expect(virtualmachine.IsRevertedToSnapshot(vmCtx, snapRef)).To(BeFalse())
+	})
+})
+
+var _ = Describe("FindSnapshot", func() {
+	var moVM mo.VirtualMachine
+
+	snapTree := func(id, name string, children ...vimtypes.VirtualMachineSnapshotTree) vimtypes.VirtualMachineSnapshotTree {
+		return vimtypes.VirtualMachineSnapshotTree{
+			Snapshot:          vimtypes.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: id},
+			Name:              name,
+			ChildSnapshotList: children,
+		}
+	}
+
+	BeforeEach(func() {
+		moVM = mo.VirtualMachine{
+			Snapshot: &vimtypes.VirtualMachineSnapshotInfo{
+				RootSnapshotList: []vimtypes.VirtualMachineSnapshotTree{
+					snapTree("snapshot-1", "snap-1",
+						snapTree("snapshot-2", "snap-2")),
+				},
+			},
+		}
+	})
+
+	It("returns the snapshot and its parent by ID", func() {
+		node, parent := virtualmachine.FindSnapshot(moVM, "snapshot-2")
+		Expect(node).ToNot(BeNil())
+		Expect(node.Name).To(Equal("snap-2"))
+		Expect(parent).ToNot(BeNil())
+		Expect(parent.Name).To(Equal("snap-1"))
+	})
+
+	It("returns a nil parent for a root snapshot", func() {
+		node, parent := virtualmachine.FindSnapshot(moVM, "snapshot-1")
+		Expect(node).ToNot(BeNil())
+		Expect(parent).To(BeNil())
+	})
+
+	It("does not return a snapshot for an empty ID", func() {
+		moVM.Snapshot.RootSnapshotList = append(moVM.Snapshot.RootSnapshotList, snapTree("snapshot-3", ""))
+		node, parent := virtualmachine.FindSnapshot(moVM, "")
+		Expect(node).To(BeNil())
+		Expect(parent).To(BeNil())
+	})
+
+	It("does not return a snapshot for an unknown ID", func() {
+		node, _ := virtualmachine.FindSnapshot(moVM, "snap-1")
+		Expect(node).To(BeNil())
+	})
+
+	It("does not return a snapshot for a VM without snapshots", func() {
+		node, _ := virtualmachine.FindSnapshot(mo.VirtualMachine{}, "snapshot-1")
+		Expect(node).To(BeNil())
+	})
+})
@@ -45,3 +45,19 @@ func GetDefaultDiskProvisioningType(
 
 	return string(vimtypes.OvfCreateImportSpecParamsDiskProvisioningTypeThin), nil
 }
+
+func getDiskUUID(disk *vimtypes.VirtualDisk) string {
+	switch tb := disk.Backing.(type) {
+	case *vimtypes.VirtualDiskFlatVer2BackingInfo:
+		return tb.Uuid
+	case *vimtypes.VirtualDiskSeSparseBackingInfo:
+		return tb.Uuid
+	case *vimtypes.VirtualDiskRawDiskMappingVer1BackingInfo:
+		return tb.Uuid
+	case *vimtypes.VirtualDiskSparseVer2BackingInfo:
+		return tb.Uuid
+	case *vimtypes.VirtualDiskRawDiskVer2BackingInfo:
+		return tb.Uuid
+	}
+	return ""
+}
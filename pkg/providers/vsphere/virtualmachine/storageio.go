@@ -0,0 +1,191 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+// StorageIOAllocationInfo returns the vSphere storage I/O allocation for the
+// provided allocation. An omitted limit is unlimited, an omitted reservation
+// is zero, and omitted shares are the Normal level.
+func StorageIOAllocationInfo(
+	in vmopv1.VirtualMachineStorageIOAllocation) *vimtypes.StorageIOAllocationInfo {
+
+	out := &vimtypes.StorageIOAllocationInfo{
+		Limit:       ptr.To(int64(-1)),
+		Reservation: ptr.To(int32(0)),
+		Shares:      &vimtypes.SharesInfo{Level: vimtypes.SharesLevelNormal},
+	}
+
+	if in.Limit != nil {
+		out.Limit = ptr.To(*in.Limit)
+	}
+	if in.Reservation != nil {
+		out.Reservation = ptr.To(*in.Reservation)
+	}
+	if in.Shares != nil {
+		switch in.Shares.Level {
+		case vmopv1.VirtualMachineStorageIOSharesLevelLow:
+			out.Shares.Level = vimtypes.SharesLevelLow
+		case vmopv1.VirtualMachineStorageIOSharesLevelHigh:
+			out.Shares.Level = vimtypes.SharesLevelHigh
+		case vmopv1.VirtualMachineStorageIOSharesLevelCustom:
+			out.Shares.Level = vimtypes.SharesLevelCustom
+			out.Shares.Shares = in.Shares.Shares
+		}
+	}
+
+	return out
+}
+
+// StorageIOAllocationStatus returns the observed storage I/O allocation for
+// the provided vSphere storage I/O allocation. Nil is returned if the provided
+// allocation is nil.
+func StorageIOAllocationStatus(
+	in *vimtypes.StorageIOAllocationInfo) *vmopv1.VirtualMachineStorageIOAllocation {
+
+	if in == nil {
+		return nil
+	}
+
+	out := &vmopv1.VirtualMachineStorageIOAllocation{}
+
+	if in.Limit != nil && *in.Limit >= 0 {
+		out.Limit = ptr.To(*in.Limit)
+	}
+	if in.Reservation != nil {
+		out.Reservation = ptr.To(*in.Reservation)
+	}
+	if in.Shares != nil {
+		out.Shares = &vmopv1.VirtualMachineStorageIOShares{}
+		switch in.Shares.Level {
+		case vimtypes.SharesLevelLow:
+			out.Shares.Level = vmopv1.VirtualMachineStorageIOSharesLevelLow
+		case vimtypes.SharesLevelNormal:
+			out.Shares.Level = vmopv1.VirtualMachineStorageIOSharesLevelNormal
+		case vimtypes.SharesLevelHigh:
+			out.Shares.Level = vmopv1.VirtualMachineStorageIOSharesLevelHigh
+		default:
+			out.Shares.Level = vmopv1.VirtualMachineStorageIOSharesLevelCustom
+			out.Shares.Shares = in.Shares.Shares
+		}
+	}
+
+	return out
+}
+
+// UpdateConfigSpecStorageIOAllocation adds to the ConfigSpec an Edit
+// DeviceChange for each of the provided disks with the storage I/O allocation
+// it should have. The disks of the VM's attached, managed volumes use the
+// volume's allocation if specified, and all other disks use the provided
+// allocation from the VM's class. Disks without an allocation are not added.
+//
+// The ConfigSpec describes the desired state of the disks, and is meant to be
+// compared to the VM's current state with resize.CompareStorageIOAllocation.
+func UpdateConfigSpecStorageIOAllocation(
+	vm *vmopv1.VirtualMachine,
+	classStorageIO *vmopv1.VirtualMachineStorageIOAllocation,
+	devices object.VirtualDeviceList,
+	configSpec *vimtypes.VirtualMachineConfigSpec) {
+
+	volumes := map[string]vmopv1.VirtualMachineVolume{}
+	for _, vol := range vm.Spec.Volumes {
+		volumes[vol.Name] = vol
+	}
+
+	diskStorageIO := map[string]*vmopv1.VirtualMachineStorageIOAllocation{}
+	for _, vol := range vm.Status.Volumes {
+		if vol.Type != vmopv1.VirtualMachineStorageDiskTypeManaged || vol.DiskUUID == "" {
+			continue
+		}
+		if specVol, ok := volumes[vol.Name]; ok {
+			diskStorageIO[vol.DiskUUID] = vmopv1util.GetVolumeStorageIOAllocation(specVol, classStorageIO)
+		}
+	}
+
+	for _, disk := range devices.SelectByType((*vimtypes.VirtualDisk)(nil)) {
+		disk := disk.(*vimtypes.VirtualDisk)
+
+		storageIO := classStorageIO
+		if s, ok := diskStorageIO[getDiskUUID(disk)]; ok {
+			storageIO = s
+		}
+		if storageIO == nil {
+			continue
+		}
+
+		expectedDisk := *disk
+		expectedDisk.StorageIOAllocation = StorageIOAllocationInfo(*storageIO)
+
+		configSpec.DeviceChange = append(configSpec.DeviceChange, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+			Device:    &expectedDisk,
+		})
+	}
+}
+
+// UpdateDiskStorageIOAllocation updates the storage I/O allocation of the VM's
+// virtual disk with the provided UUID. Nothing is done if the disk already has
+// the provided allocation. The allocation may be updated while the VM is
+// powered on.
+func UpdateDiskStorageIOAllocation(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	diskUUID string,
+	storageIO vmopv1.VirtualMachineStorageIOAllocation) error {
+
+	var moVM mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"config.hardware.device"}, &moVM); err != nil {
+		return fmt.Errorf("failed to get VM properties for disk storage I/O allocation: %w", err)
+	}
+
+	var disk *vimtypes.VirtualDisk
+	if moVM.Config != nil {
+		for _, dev := range moVM.Config.Hardware.Device {
+			if vd, ok := dev.(*vimtypes.VirtualDisk); ok && getDiskUUID(vd) == diskUUID {
+				disk = vd
+				break
+			}
+		}
+	}
+
+	if disk == nil {
+		return fmt.Errorf("disk %s not found", diskUUID)
+	}
+
+	if vmopv1util.StorageIOAllocationsEqual(&storageIO, StorageIOAllocationStatus(disk.StorageIOAllocation)) {
+		return nil
+	}
+
+	vmCtx.Logger.Info("Updating virtual disk storage I/O allocation",
+		"diskUUID", diskUUID,
+		"storageIO", storageIO)
+
+	disk.StorageIOAllocation = StorageIOAllocationInfo(storageIO)
+
+	t, err := vcVM.Reconfigure(vmCtx, vimtypes.VirtualMachineConfigSpec{
+		DeviceChange: []vimtypes.BaseVirtualDeviceConfigSpec{
+			&vimtypes.VirtualDeviceConfigSpec{
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+				Device:    disk,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.Wait(vmCtx)
+}
@@ -0,0 +1,211 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+var _ = Describe("StorageIOAllocationInfo", func() {
+	It("defaults omitted fields", func() {
+		Expect(virtualmachine.StorageIOAllocationInfo(vmopv1.VirtualMachineStorageIOAllocation{})).To(Equal(
+			&vimtypes.StorageIOAllocationInfo{
+				Limit:       ptr.To(int64(-1)),
+				Reservation: ptr.To(int32(0)),
+				Shares:      &vimtypes.SharesInfo{Level: vimtypes.SharesLevelNormal},
+			}))
+	})
+
+	It("converts the specified fields", func() {
+		Expect(virtualmachine.StorageIOAllocationInfo(vmopv1.VirtualMachineStorageIOAllocation{
+			Limit:       ptr.To(int64(1000)),
+			Reservation: ptr.To(int32(100)),
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level:  vmopv1.VirtualMachineStorageIOSharesLevelCustom,
+				Shares: 1500,
+			},
+		})).To(Equal(
+			&vimtypes.StorageIOAllocationInfo{
+				Limit:       ptr.To(int64(1000)),
+				Reservation: ptr.To(int32(100)),
+				Shares:      &vimtypes.SharesInfo{Level: vimtypes.SharesLevelCustom, Shares: 1500},
+			}))
+	})
+
+	DescribeTable("shares level",
+		func(level vmopv1.VirtualMachineStorageIOSharesLevel, expected vimtypes.SharesLevel) {
+			out := virtualmachine.StorageIOAllocationInfo(vmopv1.VirtualMachineStorageIOAllocation{
+				Shares: &vmopv1.VirtualMachineStorageIOShares{Level: level},
+			})
+			Expect(out.Shares).To(Equal(&vimtypes.SharesInfo{Level: expected}))
+		},
+		Entry("Low", vmopv1.VirtualMachineStorageIOSharesLevelLow, vimtypes.SharesLevelLow),
+		Entry("Normal", vmopv1.VirtualMachineStorageIOSharesLevelNormal, vimtypes.SharesLevelNormal),
+		Entry("High", vmopv1.VirtualMachineStorageIOSharesLevelHigh, vimtypes.SharesLevelHigh),
+	)
+})
+
+var _ = Describe("StorageIOAllocationStatus", func() {
+	It("returns nil for a nil allocation", func() {
+		Expect(virtualmachine.StorageIOAllocationStatus(nil)).To(BeNil())
+	})
+
+	It("omits an unlimited limit", func() {
+		Expect(virtualmachine.StorageIOAllocationStatus(&vimtypes.StorageIOAllocationInfo{
+			Limit:       ptr.To(int64(-1)),
+			Reservation: ptr.To(int32(0)),
+			Shares:      &vimtypes.SharesInfo{Level: vimtypes.SharesLevelHigh, Shares: 2000},
+		})).To(Equal(&vmopv1.VirtualMachineStorageIOAllocation{
+			Reservation: ptr.To(int32(0)),
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level: vmopv1.VirtualMachineStorageIOSharesLevelHigh,
+			},
+		}))
+	})
+
+	It("converts the custom shares", func() {
+		Expect(virtualmachine.StorageIOAllocationStatus(&vimtypes.StorageIOAllocationInfo{
+			Limit:  ptr.To(int64(1000)),
+			Shares: &vimtypes.SharesInfo{Level: vimtypes.SharesLevelCustom, Shares: 1500},
+		})).To(Equal(&vmopv1.VirtualMachineStorageIOAllocation{
+			Limit: ptr.To(int64(1000)),
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level:  vmopv1.VirtualMachineStorageIOSharesLevelCustom,
+				Shares: 1500,
+			},
+		}))
+	})
+})
+
+var _ = Describe("UpdateConfigSpecStorageIOAllocation", func() {
+	const (
+		classicDiskUUID = "classic-disk-uuid"
+		volumeDiskUUID  = "volume-disk-uuid"
+	)
+
+	var (
+		vm             *vmopv1.VirtualMachine
+		classStorageIO *vmopv1.VirtualMachineStorageIOAllocation
+		devices        object.VirtualDeviceList
+		configSpec     *vimtypes.VirtualMachineConfigSpec
+	)
+
+	newDisk := func(key int32, uuid string) *vimtypes.VirtualDisk {
+		return &vimtypes.VirtualDisk{
+			VirtualDevice: vimtypes.VirtualDevice{
+				Key: key,
+				Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+					Uuid: uuid,
+				},
+			},
+		}
+	}
+
+	expectedDisks := func() map[int32]*vimtypes.VirtualDisk {
+		disks := map[int32]*vimtypes.VirtualDisk{}
+		for _, bdc := range configSpec.DeviceChange {
+			dc := bdc.GetVirtualDeviceConfigSpec()
+			ExpectWithOffset(1, dc.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationEdit))
+			disk, ok := dc.Device.(*vimtypes.VirtualDisk)
+			ExpectWithOffset(1, ok).To(BeTrue())
+			disks[disk.Key] = disk
+		}
+		return disks
+	}
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Volumes: []vmopv1.VirtualMachineVolume{
+					{
+						Name: "my-vol",
+					},
+				},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Volumes: []vmopv1.VirtualMachineVolumeStatus{
+					{
+						Name:     "my-vol",
+						Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+						Attached: true,
+						DiskUUID: volumeDiskUUID,
+					},
+				},
+			},
+		}
+		classStorageIO = nil
+		devices = object.VirtualDeviceList{
+			newDisk(2000, classicDiskUUID),
+			newDisk(2001, volumeDiskUUID),
+		}
+		configSpec = &vimtypes.VirtualMachineConfigSpec{}
+	})
+
+	JustBeforeEach(func() {
+		virtualmachine.UpdateConfigSpecStorageIOAllocation(vm, classStorageIO, devices, configSpec)
+	})
+
+	When("neither the class nor the volume specify an allocation", func() {
+		It("does not add any disks", func() {
+			Expect(configSpec.DeviceChange).To(BeEmpty())
+		})
+	})
+
+	When("the class specifies an allocation", func() {
+		BeforeEach(func() {
+			classStorageIO = &vmopv1.VirtualMachineStorageIOAllocation{
+				Limit: ptr.To(int64(100)),
+			}
+		})
+
+		It("adds all disks with the class's allocation", func() {
+			disks := expectedDisks()
+			Expect(disks).To(HaveLen(2))
+			Expect(disks[2000].StorageIOAllocation.Limit).To(HaveValue(Equal(int64(100))))
+			Expect(disks[2001].StorageIOAllocation.Limit).To(HaveValue(Equal(int64(100))))
+		})
+
+		It("does not modify the current disks", func() {
+			Expect(devices[0].(*vimtypes.VirtualDisk).StorageIOAllocation).To(BeNil())
+		})
+
+		When("the volume specifies an allocation", func() {
+			BeforeEach(func() {
+				vm.Spec.Volumes[0].StorageIO = &vmopv1.VirtualMachineStorageIOAllocation{
+					Limit: ptr.To(int64(200)),
+				}
+			})
+
+			It("adds the volume's disk with the volume's allocation", func() {
+				disks := expectedDisks()
+				Expect(disks).To(HaveLen(2))
+				Expect(disks[2000].StorageIOAllocation.Limit).To(HaveValue(Equal(int64(100))))
+				Expect(disks[2001].StorageIOAllocation.Limit).To(HaveValue(Equal(int64(200))))
+			})
+		})
+	})
+
+	When("only the volume specifies an allocation", func() {
+		BeforeEach(func() {
+			vm.Spec.Volumes[0].StorageIO = &vmopv1.VirtualMachineStorageIOAllocation{
+				Limit: ptr.To(int64(200)),
+			}
+		})
+
+		It("adds only the volume's disk", func() {
+			disks := expectedDisks()
+			Expect(disks).To(HaveLen(1))
+			Expect(disks[2001].StorageIOAllocation.Limit).To(HaveValue(Equal(int64(200))))
+		})
+	})
+})
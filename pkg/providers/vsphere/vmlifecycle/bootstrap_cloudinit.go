@@ -106,15 +106,10 @@ func BootStrapCloudInit(
 		return nil, nil, fmt.Errorf("failed to create NetPlan customization: %w", err)
 	}
 
-	sshPublicKeys := bsArgs.BootstrapData.Data["ssh-public-keys"]
-	if len(cloudInitSpec.SSHAuthorizedKeys) > 0 {
-		sshPublicKeys = strings.Join(cloudInitSpec.SSHAuthorizedKeys, "\n")
-	}
-
 	iid := BootStrapCloudInitInstanceID(vmCtx, cloudInitSpec)
 
 	metadata, err := GetCloudInitMetadata(
-		iid, bsArgs.HostName, bsArgs.DomainName, netPlan, sshPublicKeys)
+		iid, bsArgs.HostName, bsArgs.DomainName, netPlan, getCloudInitSSHPublicKeys(cloudInitSpec, bsArgs))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,6 +155,64 @@ func BootStrapCloudInit(
 	return configSpec, customSpec, nil
 }
 
+// GetCloudInitGuestInfoNetworkConfigSpec returns the ConfigSpec that updates
+// the Cloud-Init metadata of a powered-on VM with the VM's current network
+// configuration, ex. after a network interface is hot-added or hot-removed.
+// Cloud-Init's VMware datasource applies the updated metadata when the guest
+// is notified of the hot-plugged interface. Nil is returned if the VM is not
+// bootstrapped with Cloud-Init via GuestInfo, since the network configuration
+// of a powered-on VM cannot otherwise be updated.
+func GetCloudInitGuestInfoNetworkConfigSpec(
+	vmCtx pkgctx.VirtualMachineContext,
+	config *vimtypes.VirtualMachineConfigInfo,
+	bsArgs *BootstrapArgs) (*vimtypes.VirtualMachineConfigSpec, error) {
+
+	bootstrap := vmCtx.VM.Spec.Bootstrap
+	if bootstrap == nil || bootstrap.CloudInit == nil {
+		return nil, nil
+	}
+
+	switch vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation] {
+	case constants.CloudInitTypeValueGuestInfo, "":
+	default:
+		return nil, nil
+	}
+
+	cloudInitSpec := bootstrap.CloudInit
+
+	netPlan, err := network.NetPlanCustomization(bsArgs.NetworkResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NetPlan customization: %w", err)
+	}
+
+	iid := BootStrapCloudInitInstanceID(vmCtx, cloudInitSpec)
+
+	metadata, err := GetCloudInitMetadata(
+		iid, bsArgs.HostName, bsArgs.DomainName, netPlan, getCloudInitSSHPublicKeys(cloudInitSpec, bsArgs))
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the metadata is updated so the userdata is not provided.
+	configSpec, err := GetCloudInitGuestInfoCustSpec(config, metadata, "")
+	if err != nil {
+		return nil, err
+	}
+	configSpec.VAppConfigRemoved = nil
+
+	return configSpec, nil
+}
+
+func getCloudInitSSHPublicKeys(
+	cloudInitSpec *vmopv1.VirtualMachineBootstrapCloudInitSpec,
+	bsArgs *BootstrapArgs) string {
+
+	if len(cloudInitSpec.SSHAuthorizedKeys) > 0 {
+		return strings.Join(cloudInitSpec.SSHAuthorizedKeys, "\n")
+	}
+	return bsArgs.BootstrapData.Data["ssh-public-keys"]
+}
+
 func GetCloudInitMetadata(
 	instanceID, hostName, domainName string,
 	netplan *netplan.Network,
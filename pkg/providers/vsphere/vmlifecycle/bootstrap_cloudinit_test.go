@@ -464,6 +464,79 @@ var _ = Describe("CloudInit Bootstrap", func() {
 		})
 	})
 
+	Context("GetCloudInitGuestInfoNetworkConfigSpec", func() {
+		var (
+			configSpec *vimtypes.VirtualMachineConfigSpec
+			err        error
+
+			vmCtx pkgctx.VirtualMachineContext
+		)
+
+		BeforeEach(func() {
+			vmCtx = pkgctx.VirtualMachineContext{
+				Context: context.Background(),
+				Logger:  suite.GetLogger(),
+				VM: &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cloud-init-network-test",
+						Namespace:   "test-ns",
+						UID:         "my-vm-uuid",
+						Annotations: map[string]string{},
+					},
+					Spec: vmopv1.VirtualMachineSpec{
+						Bootstrap: &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						},
+					},
+				},
+			}
+			configInfo.VAppConfig = &vimtypes.VmConfigInfo{}
+		})
+
+		JustBeforeEach(func() {
+			configSpec, err = vmlifecycle.GetCloudInitGuestInfoNetworkConfigSpec(vmCtx, configInfo, &bsArgs)
+		})
+
+		Context("VM is not bootstrapped with Cloud-Init", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Spec.Bootstrap = nil
+			})
+
+			It("returns nil ConfigSpec", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).To(BeNil())
+			})
+		})
+
+		Context("Via CloudInitPrep", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation] = constants.CloudInitTypeValueCloudInitPrep
+			})
+
+			It("returns nil ConfigSpec", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).To(BeNil())
+			})
+		})
+
+		Context("Via GuestInfo", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation] = constants.CloudInitTypeValueGuestInfo
+			})
+
+			It("ConfigSpec.ExtraConfig to only have metadata", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).ToNot(BeNil())
+				Expect(configSpec.VAppConfigRemoved).To(BeNil())
+
+				extraConfig := pkgutil.OptionValues(configSpec.ExtraConfig).StringMap()
+				Expect(extraConfig).To(HaveLen(2))
+				Expect(extraConfig).To(HaveKey(constants.CloudInitGuestInfoMetadata))
+				Expect(extraConfig).To(HaveKeyWithValue(constants.CloudInitGuestInfoMetadataEncoding, "gzip+base64"))
+			})
+		})
+	})
+
 	Context("GetCloudInitPrepCustSpec", func() {
 		var (
 			configSpec *vimtypes.VirtualMachineConfigSpec
@@ -19,6 +19,12 @@ type CreateArgs struct {
 	UseContentLibrary bool
 	ProviderItemID    string
 
+	// CloneSourceMoID is the managed object ID of the VM that is cloned when
+	// UseContentLibrary is false. If empty, the source VM is found in the
+	// inventory by ProviderItemID.
+	CloneSourceMoID string
+	LinkedClone     bool
+
 	ConfigSpec          vimtypes.VirtualMachineConfigSpec
 	StorageProvisioning string
 	DatacenterMoID      string
@@ -55,5 +61,5 @@ func CreateVirtualMachine(
 	if createArgs.UseContentLibrary {
 		return deployFromContentLibrary(vmCtx, restClient, vimClient, createArgs)
 	}
-	return cloneVMFromInventory(vmCtx, finder, createArgs)
+	return cloneVMFromInventory(vmCtx, vimClient, finder, createArgs)
 }
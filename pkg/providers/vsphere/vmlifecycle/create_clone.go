@@ -9,26 +9,39 @@ import (
 
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 
+	backupapi "github.com/vmware-tanzu/vm-operator/pkg/backup/api"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
-// CloneVMFromInventory creates a new VM by cloning the source VM. This is not reachable/used
-// in production because we only really support deploying an OVF via content library.
-// Maybe someday we'll use clone to speed up VM deployment so keep this code around and unit tested.
+// CloneVMFromInventory creates a new VM by cloning the source VM. The source VM
+// is either the VM of another VirtualMachine resource, or, when testing, a VM
+// found in the inventory by the name of the image.
 func cloneVMFromInventory(
 	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
 	finder *find.Finder,
 	createArgs *CreateArgs) (*vimtypes.ManagedObjectReference, error) {
 
-	srcVMName := createArgs.ProviderItemID // AKA: vmCtx.VM.Spec.Image.Name
+	var srcVM *object.VirtualMachine
 
-	srcVM, err := finder.VirtualMachine(vmCtx, srcVMName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find clone source VM: %s: %w", srcVMName, err)
+	if moID := createArgs.CloneSourceMoID; moID != "" {
+		srcVM = object.NewVirtualMachine(vimClient, vimtypes.ManagedObjectReference{
+			Type:  "VirtualMachine",
+			Value: moID,
+		})
+	} else {
+		srcVMName := createArgs.ProviderItemID // AKA: vmCtx.VM.Spec.Image.Name
+
+		var err error
+		if srcVM, err = finder.VirtualMachine(vmCtx, srcVMName); err != nil {
+			return nil, fmt.Errorf("failed to find clone source VM: %s: %w", srcVMName, err)
+		}
 	}
 
 	cloneSpec, err := createCloneSpec(vmCtx, createArgs, srcVM)
@@ -70,7 +83,26 @@ func createCloneSpec(
 
 	virtualDisks := virtualDevices.SelectByType((*vimtypes.VirtualDisk)(nil))
 
-	for _, deviceChange := range resizeBootDiskDeviceChange(vmCtx, virtualDisks) {
+	if createArgs.CloneSourceMoID != "" {
+		// The source VM's network interfaces are replaced by the interfaces
+		// in the ConfigSpec, and its PVC disks are not cloned as their data is
+		// cloned by CSI.
+		virtualDisks = cloneVMRemoveSourceDevices(cloneSpec, virtualDevices)
+		cloneVMResetBackup(cloneSpec)
+	}
+
+	if createArgs.LinkedClone {
+		var moSrcVM mo.VirtualMachine
+		if err := srcVM.Properties(vmCtx, srcVM.Reference(), []string{"snapshot"}, &moSrcVM); err != nil {
+			return nil, fmt.Errorf("failed to get clone source VM snapshot: %w", err)
+		}
+		if moSrcVM.Snapshot == nil || moSrcVM.Snapshot.CurrentSnapshot == nil {
+			return nil, fmt.Errorf("linked clone requires the source VM to have a snapshot")
+		}
+		cloneSpec.Snapshot = moSrcVM.Snapshot.CurrentSnapshot
+	}
+
+	for _, deviceChange := range resizeBootDiskDeviceChange(vmCtx, createArgs, virtualDisks) {
 		if deviceChange.GetVirtualDeviceConfigSpec().Operation == vimtypes.VirtualDeviceConfigSpecOperationEdit {
 			cloneSpec.Location.DeviceChange = append(cloneSpec.Location.DeviceChange, deviceChange)
 		} else {
@@ -137,6 +169,13 @@ func cloneVMDiskLocators(
 			DiskMoveType: string(vimtypes.VirtualMachineRelocateDiskMoveOptionsMoveChildMostDiskBacking),
 		}
 
+		if createArgs.LinkedClone {
+			// The provisioning of a child disk is that of its parent.
+			locator.DiskMoveType = string(vimtypes.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking)
+			diskLocators = append(diskLocators, locator)
+			continue
+		}
+
 		if backing, ok := disk.(*vimtypes.VirtualDisk).Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo); ok {
 			switch createArgs.StorageProvisioning {
 			case string(vimtypes.OvfCreateImportSpecParamsDiskProvisioningTypeThin):
@@ -155,10 +194,66 @@ func cloneVMDiskLocators(
 	return diskLocators
 }
 
+// cloneVMRemoveSourceDevices removes the source VM's network interfaces and PVC
+// disks from the clone, and returns the remaining disks that are cloned.
+func cloneVMRemoveSourceDevices(
+	cloneSpec *vimtypes.VirtualMachineCloneSpec,
+	virtualDevices object.VirtualDeviceList) object.VirtualDeviceList {
+
+	var virtualDisks object.VirtualDeviceList
+
+	for _, device := range virtualDevices {
+		remove := false
+
+		switch d := device.(type) {
+		case vimtypes.BaseVirtualEthernetCard:
+			remove = true
+		case *vimtypes.VirtualDisk:
+			if d.VDiskId != nil && d.VDiskId.Id != "" {
+				remove = true
+			} else {
+				virtualDisks = append(virtualDisks, d)
+			}
+		}
+
+		if remove {
+			cloneSpec.Config.DeviceChange = append(cloneSpec.Config.DeviceChange, &vimtypes.VirtualDeviceConfigSpec{
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+				Device:    device,
+			})
+		}
+	}
+
+	return virtualDisks
+}
+
+// cloneVMResetBackup clears the source VM's backup data from the clone so the
+// cloned VM is not mistaken for a VM being restored.
+func cloneVMResetBackup(cloneSpec *vimtypes.VirtualMachineCloneSpec) {
+	for _, key := range []string{
+		backupapi.VMResourceYAMLExtraConfigKey,
+		backupapi.AdditionalResourcesYAMLExtraConfigKey,
+		backupapi.PVCDiskDataExtraConfigKey,
+		backupapi.ClassicDiskDataExtraConfigKey,
+		backupapi.BackupVersionExtraConfigKey,
+	} {
+		cloneSpec.Config.ExtraConfig = append(cloneSpec.Config.ExtraConfig, &vimtypes.OptionValue{
+			Key:   key,
+			Value: "",
+		})
+	}
+}
+
 func resizeBootDiskDeviceChange(
 	vmCtx pkgctx.VirtualMachineContext,
+	createArgs *CreateArgs,
 	virtualDisks object.VirtualDeviceList) []vimtypes.BaseVirtualDeviceConfigSpec {
 
+	// The disks of a linked clone are child disks that cannot be resized.
+	if createArgs.LinkedClone {
+		return nil
+	}
+
 	advanced := vmCtx.VM.Spec.Advanced
 	if advanced == nil {
 		return nil
@@ -32,6 +32,7 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vcenter"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
 	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
@@ -52,6 +53,7 @@ var (
 		"guest",
 		"resourcePool",
 		"runtime",
+		"snapshot",
 		"summary",
 	}
 )
@@ -91,6 +93,7 @@ func UpdateStatus(
 	)
 
 	vm.Status.PowerState = convertPowerState(summary.Runtime.PowerState)
+	updatePoweredOnCondition(vm)
 	vm.Status.UniqueID = vcVM.Reference().Value
 	vm.Status.BiosUUID = summary.Config.Uuid
 	vm.Status.InstanceUUID = summary.Config.InstanceUuid
@@ -98,6 +101,7 @@ func UpdateStatus(
 	vm.Status.HardwareVersion = int32(hardwareVersion)
 	updateGuestNetworkStatus(vmCtx.VM, vmCtx.MoVM.Guest)
 	updateStorageStatus(vmCtx.VM, vmCtx.MoVM)
+	updateSnapshotStatus(vmCtx.VM, vmCtx.MoVM)
 
 	if pkgcfg.FromContext(vmCtx).AsyncSignalEnabled {
 		updateProbeStatus(vmCtx, vm, vmCtx.MoVM)
@@ -112,6 +116,7 @@ func UpdateStatus(
 	MarkVMToolsRunningStatusCondition(vmCtx.VM, vmCtx.MoVM.Guest)
 	MarkCustomizationInfoCondition(vmCtx.VM, vmCtx.MoVM.Guest)
 	MarkBootstrapCondition(vmCtx.VM, vmCtx.MoVM.Config)
+	MarkVolumeDiskPlacementSynced(vmCtx.VM, vmCtx.MoVM)
 
 	if f := pkgcfg.FromContext(vmCtx).Features; f.VMResize || f.VMResizeCPUMemory {
 		MarkVMClassConfigurationSynced(vmCtx, vmCtx.VM, k8sClient)
@@ -189,6 +194,53 @@ func guestNicInfoToInterfaceStatus(
 	return status
 }
 
+// isPrimaryIPCandidate returns true if the provided IP address is valid and
+// not a local IP address, i.e. an address that is only valid on the guest OS.
+// Please note this does not exclude private, or RFC 1918 (IPv4) and RFC 4193
+// (IPv6) addresses, ex. 192.168.0.2.
+func isPrimaryIPCandidate(ip string) bool {
+	a := net.ParseIP(ip)
+	return len(a) > 0 &&
+		!a.IsUnspecified() &&
+		!a.IsLinkLocalMulticast() &&
+		!a.IsLinkLocalUnicast() &&
+		!a.IsLoopback()
+}
+
+// guestNicInfoPrimaryIPs returns the first IP4 and IP6 addresses of the
+// provided guest NIC that may be used as the VM's primary IP addresses.
+func guestNicInfoPrimaryIPs(guestNicInfo *vimtypes.GuestNicInfo) (string, string) {
+	var ips []string
+	if guestIPConfig := guestNicInfo.IpConfig; guestIPConfig != nil {
+		for _, ipAddr := range guestIPConfig.IpAddress {
+			// Skip addresses that are not usable, ex. a duplicate address or a
+			// deprecated SLAAC address.
+			if s := ipAddr.State; s != "" && s != string(vimtypes.NetIpConfigInfoIpAddressStatusPreferred) {
+				continue
+			}
+			ips = append(ips, ipAddr.IpAddress)
+		}
+	} else {
+		ips = guestNicInfo.IpAddress
+	}
+
+	var ip4, ip6 string
+	for _, ip := range ips {
+		if !isPrimaryIPCandidate(ip) {
+			continue
+		}
+		if net.ParseIP(ip).To4() != nil {
+			if ip4 == "" {
+				ip4 = ip
+			}
+		} else if ip6 == "" {
+			ip6 = ip
+		}
+	}
+
+	return ip4, ip6
+}
+
 func guestIPStackInfoToIPStackStatus(guestIPStack *vimtypes.GuestStackInfo) vmopv1.VirtualMachineNetworkIPStackStatus {
 	status := vmopv1.VirtualMachineNetworkIPStackStatus{}
 
@@ -209,6 +261,19 @@ func guestIPStackInfoToIPStackStatus(guestIPStack *vimtypes.GuestStackInfo) vmop
 	return status
 }
 
+// updatePoweredOnCondition updates the VM's PoweredOn condition from its
+// observed power state.
+func updatePoweredOnCondition(vm *vmopv1.VirtualMachine) {
+	switch vm.Status.PowerState {
+	case vmopv1.VirtualMachinePowerStateOn:
+		conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionPoweredOn)
+	case "":
+		conditions.MarkUnknown(vm, vmopv1.VirtualMachineConditionPoweredOn, "Unknown", "")
+	default:
+		conditions.MarkFalse(vm, vmopv1.VirtualMachineConditionPoweredOn, string(vm.Status.PowerState), "")
+	}
+}
+
 func convertPowerState(powerState vimtypes.VirtualMachinePowerState) vmopv1.VirtualMachinePowerState {
 	switch powerState {
 	case vimtypes.VirtualMachinePowerStatePoweredOff:
@@ -484,6 +549,35 @@ func MarkVMClassConfigurationSynced(
 	}
 }
 
+// MarkVolumeDiskPlacementSynced sets the condition that reports whether the
+// disks of the VM's attached volumes have the placement requested by their
+// volume. Disks are attached by CNS, which selects the controller and unit
+// number on its own, so a disk attached while the VM is powered on is only
+// moved the next time the VM is powered on.
+func MarkVolumeDiskPlacementSynced(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
+	if !slices.ContainsFunc(vm.Spec.Volumes, virtualmachine.HasVolumeDiskPlacement) {
+		conditions.Delete(vm, vmopv1.VirtualMachineDiskPlacementSynced)
+		return
+	}
+
+	if moVM.Config == nil {
+		return
+	}
+
+	pending := virtualmachine.VolumesWithPendingDiskPlacement(vm, moVM.Config.Hardware.Device)
+	if len(pending) == 0 {
+		conditions.MarkTrue(vm, vmopv1.VirtualMachineDiskPlacementSynced)
+		return
+	}
+
+	conditions.MarkFalse(
+		vm,
+		vmopv1.VirtualMachineDiskPlacementSynced,
+		vmopv1.VirtualMachineDiskPlacementPendingPowerOnReason,
+		"The disks of volumes %s are moved the next time the VM is powered on",
+		strings.Join(pending, ", "))
+}
+
 var (
 	emptyNetConfig   vmopv1.VirtualMachineNetworkConfigStatus
 	emptyIfaceConfig vmopv1.VirtualMachineNetworkConfigInterfaceStatus
@@ -646,24 +740,11 @@ func updateGuestNetworkStatus(vm *vmopv1.VirtualMachine, gi *vimtypes.GuestInfo)
 	)
 
 	if gi != nil {
-		if ip := gi.IpAddress; ip != "" {
-			// Only act on the IP if it is valid.
-			if a := net.ParseIP(ip); len(a) > 0 {
-
-				// Ignore local IP addresses, i.e. addresses that are only valid on
-				// the guest OS. Please note this does not include private, or RFC
-				// 1918 (IPv4) and RFC 4193 (IPv6) addresses, ex. 192.168.0.2.
-				if !a.IsUnspecified() &&
-					!a.IsLinkLocalMulticast() &&
-					!a.IsLinkLocalUnicast() &&
-					!a.IsLoopback() {
-
-					if a.To4() != nil {
-						primaryIP4 = ip
-					} else {
-						primaryIP6 = ip
-					}
-				}
+		if ip := gi.IpAddress; isPrimaryIPCandidate(ip) {
+			if net.ParseIP(ip).To4() != nil {
+				primaryIP4 = ip
+			} else {
+				primaryIP6 = ip
 			}
 		}
 
@@ -701,6 +782,19 @@ func updateGuestNetworkStatus(vm *vmopv1.VirtualMachine, gi *vimtypes.GuestInfo)
 						ifaceName,
 						deviceKey,
 						&gi.Net[i]))
+
+				// The guest's primary IP address is of a single IP family,
+				// so for dual-stack VMs, the primary IP of the other family
+				// is the first one reported by the VM's network interfaces.
+				if primaryIP4 == "" || primaryIP6 == "" {
+					ip4, ip6 := guestNicInfoPrimaryIPs(&gi.Net[i])
+					if primaryIP4 == "" {
+						primaryIP4 = ip4
+					}
+					if primaryIP6 == "" {
+						primaryIP6 = ip6
+					}
+				}
 			}
 		}
 
@@ -746,6 +840,36 @@ func updateStorageStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
 	updateStorageUsage(vm, moVM)
 }
 
+// updateSnapshotStatus updates the status for the VM's snapshot tree.
+func updateSnapshotStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
+	vm.Status.CurrentSnapshot = ""
+	vm.Status.RootSnapshots = nil
+
+	if moVM.Snapshot == nil {
+		return
+	}
+
+	for i := range moVM.Snapshot.RootSnapshotList {
+		vm.Status.RootSnapshots = append(vm.Status.RootSnapshots, moVM.Snapshot.RootSnapshotList[i].Name)
+	}
+
+	if cur := moVM.Snapshot.CurrentSnapshot; cur != nil {
+		var find func(nodes []vimtypes.VirtualMachineSnapshotTree) string
+		find = func(nodes []vimtypes.VirtualMachineSnapshotTree) string {
+			for i := range nodes {
+				if nodes[i].Snapshot.Value == cur.Value {
+					return nodes[i].Name
+				}
+				if name := find(nodes[i].ChildSnapshotList); name != "" {
+					return name
+				}
+			}
+			return ""
+		}
+		vm.Status.CurrentSnapshot = find(moVM.Snapshot.RootSnapshotList)
+	}
+}
+
 func updateChangeBlockTracking(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
 	if moVM.Config != nil {
 		vm.Status.ChangeBlockTracking = moVM.Config.ChangeTrackingEnabled
@@ -884,18 +1008,20 @@ func updateVolumeStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
 					KeyID:      di.CryptoKey.KeyID,
 				}
 			}
+			vm.Status.Volumes[diskIndex].StorageIO = virtualmachine.StorageIOAllocationStatus(vd.StorageIOAllocation)
 		} else if !isFCD {
 			// The disk is a classic, non-FCD that must be added to the list of
 			// volume statuses.
 			di, _ := vmdk.GetVirtualDiskInfoByUUID(ctx, nil, moVM, false, diskUUID)
 			dp := diskPath.Path
 			volStatus := vmopv1.VirtualMachineVolumeStatus{
-				Name:     strings.TrimSuffix(path.Base(dp), path.Ext(dp)),
-				Type:     vmopv1.VirtualMachineStorageDiskTypeClassic,
-				Attached: true,
-				DiskUUID: diskUUID,
-				Limit:    BytesToResourceGiB(di.CapacityInBytes),
-				Used:     BytesToResourceGiB(di.UniqueSize),
+				Name:      strings.TrimSuffix(path.Base(dp), path.Ext(dp)),
+				Type:      vmopv1.VirtualMachineStorageDiskTypeClassic,
+				Attached:  true,
+				DiskUUID:  diskUUID,
+				Limit:     BytesToResourceGiB(di.CapacityInBytes),
+				Used:      BytesToResourceGiB(di.UniqueSize),
+				StorageIO: virtualmachine.StorageIOAllocationStatus(vd.StorageIOAllocation),
 			}
 			if di.CryptoKey.ProviderID != "" || di.CryptoKey.KeyID != "" {
 				volStatus.Crypto = &vmopv1.VirtualMachineVolumeCryptoStatus{
@@ -963,14 +1089,15 @@ func heartbeatValue(value string) int {
 
 // updateProbeStatus updates a VM's status with the results of the configured
 // readiness probes.
-// Please note, this function returns early if the configured probe is TCP.
+// Please note, this function returns early if the configured probe is TCP or
+// HTTPGet, or if the probe requires state that is tracked by the probe manager.
 func updateProbeStatus(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine,
 	moVM mo.VirtualMachine) {
 
 	p := vm.Spec.ReadinessProbe
-	if p == nil || p.TCPSocket != nil {
+	if p == nil || p.TCPSocket != nil || p.HTTPGet != nil || vmopv1util.IsReadinessProbeStateful(p) {
 		return
 	}
 
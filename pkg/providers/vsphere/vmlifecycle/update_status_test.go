@@ -252,6 +252,44 @@ var _ = Describe("UpdateStatus", func() {
 					})
 				})
 			})
+
+			Context("Dual-stack", func() {
+				When("address is ip4 and the interfaces have ip6 addresses", func() {
+					BeforeEach(func() {
+						vmCtx.MoVM.Guest.IpAddress = validIP4
+						vmCtx.MoVM.Guest.Net = []vimtypes.GuestNicInfo{
+							{
+								DeviceConfigId: 4000,
+								IpConfig: &vimtypes.NetIpConfigInfo{
+									IpAddress: []vimtypes.NetIpConfigInfoIpAddress{
+										{
+											IpAddress: validIP4,
+											State:     string(vimtypes.NetIpConfigInfoIpAddressStatusPreferred),
+										},
+										{
+											IpAddress: "fe80::250:56ff:fe9d:2822",
+											State:     string(vimtypes.NetIpConfigInfoIpAddressStatusPreferred),
+										},
+										{
+											IpAddress: "fd1a:6c85:79fe:7c98::a",
+											State:     string(vimtypes.NetIpConfigInfoIpAddressStatusDeprecated),
+										},
+										{
+											IpAddress: validIP6,
+											State:     string(vimtypes.NetIpConfigInfoIpAddressStatusPreferred),
+										},
+									},
+								},
+							},
+						}
+					})
+					Specify("status.network.primaryIP6 should be the first usable ip6 address", func() {
+						Expect(vmCtx.VM.Status.Network).ToNot(BeNil())
+						Expect(vmCtx.VM.Status.Network.PrimaryIP4).To(Equal(validIP4))
+						Expect(vmCtx.VM.Status.Network.PrimaryIP6).To(Equal(validIP6))
+					})
+				})
+			})
 		})
 
 		Context("Interfaces", func() {
@@ -1036,6 +1074,55 @@ var _ = Describe("UpdateStatus", func() {
 				})
 			})
 
+			When("the disks have a storage I/O allocation", func() {
+				BeforeEach(func() {
+					devices := vmCtx.MoVM.Config.Hardware.Device
+					devices[0].(*vimtypes.VirtualDisk).StorageIOAllocation = &vimtypes.StorageIOAllocationInfo{
+						Limit:       ptr.To(int64(-1)),
+						Reservation: ptr.To(int32(0)),
+						Shares:      &vimtypes.SharesInfo{Level: vimtypes.SharesLevelHigh, Shares: 2000},
+					}
+					devices[5].(*vimtypes.VirtualDisk).StorageIOAllocation = &vimtypes.StorageIOAllocationInfo{
+						Limit:       ptr.To(int64(1000)),
+						Reservation: ptr.To(int32(100)),
+						Shares:      &vimtypes.SharesInfo{Level: vimtypes.SharesLevelCustom, Shares: 1500},
+					}
+					vmCtx.VM.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+						{
+							Name:     "my-disk-105",
+							DiskUUID: "105",
+							Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+							Attached: true,
+						},
+					}
+				})
+				Specify("status.volumes includes the observed storage I/O allocations", func() {
+					Expect(vmCtx.VM.Status.Volumes).To(HaveLen(6))
+					for _, vol := range vmCtx.VM.Status.Volumes {
+						switch vol.Name {
+						case "my-disk-100":
+							Expect(vol.StorageIO).To(Equal(&vmopv1.VirtualMachineStorageIOAllocation{
+								Reservation: ptr.To(int32(0)),
+								Shares: &vmopv1.VirtualMachineStorageIOShares{
+									Level: vmopv1.VirtualMachineStorageIOSharesLevelHigh,
+								},
+							}))
+						case "my-disk-105":
+							Expect(vol.StorageIO).To(Equal(&vmopv1.VirtualMachineStorageIOAllocation{
+								Limit:       ptr.To(int64(1000)),
+								Reservation: ptr.To(int32(100)),
+								Shares: &vmopv1.VirtualMachineStorageIOShares{
+									Level:  vmopv1.VirtualMachineStorageIOSharesLevelCustom,
+									Shares: 1500,
+								},
+							}))
+						default:
+							Expect(vol.StorageIO).To(BeNil())
+						}
+					}
+				})
+			})
+
 			When("vm.status.volumes has a stale classic disk", func() {
 				BeforeEach(func() {
 					vmCtx.VM.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
@@ -1188,6 +1275,29 @@ var _ = Describe("UpdateStatus", func() {
 			})
 		})
 
+		When("there is an HTTPGet probe", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+					HTTPGet: &vmopv1.HTTPGetAction{},
+				}
+			})
+			It("should not update status", func() {
+				Expect(conditions.Has(vmCtx.VM, vmopv1.ReadyConditionType)).To(BeFalse())
+			})
+		})
+
+		When("there is a GuestHeartbeat probe with thresholds", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+					GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+					SuccessThreshold: 2,
+				}
+			})
+			It("should not update status", func() {
+				Expect(conditions.Has(vmCtx.VM, vmopv1.ReadyConditionType)).To(BeFalse())
+			})
+		})
+
 		When("there is a GuestHeartbeat probe", func() {
 			BeforeEach(func() {
 				vmCtx.VM.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
@@ -1391,6 +1501,22 @@ var _ = Describe("UpdateStatus", func() {
 			Expect(conditions.IsTrue(vmCtx.VM, vmopv1.VirtualMachineConditionCreated)).To(BeTrue())
 		})
 
+		It("Sets the PoweredOn condition", func() {
+			Expect(conditions.IsTrue(vmCtx.VM, vmopv1.VirtualMachineConditionPoweredOn)).To(BeTrue())
+		})
+
+		When("the VM is powered off", func() {
+			BeforeEach(func() {
+				vmCtx.MoVM.Summary.Runtime.PowerState = vimtypes.VirtualMachinePowerStatePoweredOff
+			})
+			It("Sets the PoweredOn condition to false", func() {
+				c := conditions.Get(vmCtx.VM, vmopv1.VirtualMachineConditionPoweredOn)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(string(vmopv1.VirtualMachinePowerStateOff)))
+			})
+		})
+
 		Context("When FSS_WCP_VMSERVICE_RESIZE is not enabled", func() {
 			BeforeEach(func() {
 				pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
@@ -1991,6 +2117,161 @@ var _ = Describe("VirtualMachineRecocileReady Status to VM Status Condition", fu
 	})
 })
 
+var _ = Describe("VolumeDiskPlacementSynced Status to VM Status Condition", func() {
+	Context("MarkVolumeDiskPlacementSynced", func() {
+		var (
+			vm   *vmopv1.VirtualMachine
+			moVM mo.VirtualMachine
+			disk *vimtypes.VirtualDisk
+		)
+
+		BeforeEach(func() {
+			vm = &vmopv1.VirtualMachine{
+				Spec: vmopv1.VirtualMachineSpec{
+					Volumes: []vmopv1.VirtualMachineVolume{
+						{
+							Name: "my-disk-1",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{},
+							},
+							ControllerType:      vmopv1.VirtualControllerTypeNVME,
+							ControllerBusNumber: ptr.To[int32](0),
+							UnitNumber:          ptr.To[int32](1),
+						},
+					},
+				},
+				Status: vmopv1.VirtualMachineStatus{
+					Volumes: []vmopv1.VirtualMachineVolumeStatus{
+						{
+							Name:     "my-disk-1",
+							Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+							Attached: true,
+							DiskUUID: "disk-uuid-1",
+						},
+					},
+				},
+			}
+
+			disk = &vimtypes.VirtualDisk{
+				VirtualDevice: vimtypes.VirtualDevice{
+					Key:           2000,
+					ControllerKey: 31000,
+					UnitNumber:    ptr.To[int32](1),
+					Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+						Uuid: "disk-uuid-1",
+					},
+				},
+			}
+
+			moVM = mo.VirtualMachine{
+				Config: &vimtypes.VirtualMachineConfigInfo{
+					Hardware: vimtypes.VirtualHardware{
+						Device: []vimtypes.BaseVirtualDevice{
+							&vimtypes.VirtualNVMEController{
+								VirtualController: vimtypes.VirtualController{
+									VirtualDevice: vimtypes.VirtualDevice{
+										Key: 31000,
+									},
+									BusNumber: 0,
+								},
+							},
+							disk,
+						},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			vmlifecycle.MarkVolumeDiskPlacementSynced(vm, moVM)
+		})
+
+		When("the disk has the requested placement", func() {
+			It("sets VirtualMachineDiskPlacementSynced condition to true", func() {
+				expectedConditions := []metav1.Condition{
+					*conditions.TrueCondition(vmopv1.VirtualMachineDiskPlacementSynced),
+				}
+				Expect(vm.Status.Conditions).To(conditions.MatchConditions(expectedConditions))
+			})
+		})
+
+		When("the disk is attached to a different unit number", func() {
+			BeforeEach(func() {
+				disk.UnitNumber = ptr.To[int32](0)
+			})
+			It("sets VirtualMachineDiskPlacementSynced condition to false", func() {
+				expectedConditions := []metav1.Condition{
+					*conditions.FalseCondition(
+						vmopv1.VirtualMachineDiskPlacementSynced,
+						vmopv1.VirtualMachineDiskPlacementPendingPowerOnReason,
+						"The disks of volumes %s are moved the next time the VM is powered on",
+						"my-disk-1"),
+				}
+				Expect(vm.Status.Conditions).To(conditions.MatchConditions(expectedConditions))
+			})
+		})
+
+		When("the disk does not have the requested sharing mode", func() {
+			BeforeEach(func() {
+				vm.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+			})
+			It("sets VirtualMachineDiskPlacementSynced condition to false", func() {
+				c := conditions.Get(vm, vmopv1.VirtualMachineDiskPlacementSynced)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			})
+		})
+
+		When("the controller does not have the requested bus sharing mode", func() {
+			BeforeEach(func() {
+				vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeSCSI
+				vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+				moVM.Config.Hardware.Device[0] = &vimtypes.ParaVirtualSCSIController{
+					VirtualSCSIController: vimtypes.VirtualSCSIController{
+						VirtualController: vimtypes.VirtualController{
+							VirtualDevice: vimtypes.VirtualDevice{
+								Key: 31000,
+							},
+							BusNumber: 0,
+						},
+						SharedBus: vimtypes.VirtualSCSISharingNoSharing,
+					},
+				}
+			})
+			It("sets VirtualMachineDiskPlacementSynced condition to false", func() {
+				c := conditions.Get(vm, vmopv1.VirtualMachineDiskPlacementSynced)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+			})
+		})
+
+		When("the volume is not attached", func() {
+			BeforeEach(func() {
+				vm.Status.Volumes[0].Attached = false
+				disk.UnitNumber = ptr.To[int32](0)
+			})
+			It("sets VirtualMachineDiskPlacementSynced condition to true", func() {
+				expectedConditions := []metav1.Condition{
+					*conditions.TrueCondition(vmopv1.VirtualMachineDiskPlacementSynced),
+				}
+				Expect(vm.Status.Conditions).To(conditions.MatchConditions(expectedConditions))
+			})
+		})
+
+		When("no volume requests a placement", func() {
+			BeforeEach(func() {
+				vm.Spec.Volumes[0].ControllerType = ""
+				vm.Spec.Volumes[0].ControllerBusNumber = nil
+				vm.Spec.Volumes[0].UnitNumber = nil
+				conditions.MarkTrue(vm, vmopv1.VirtualMachineDiskPlacementSynced)
+			})
+			It("removes the VirtualMachineDiskPlacementSynced condition", func() {
+				Expect(conditions.Get(vm, vmopv1.VirtualMachineDiskPlacementSynced)).To(BeNil())
+			})
+		})
+	})
+})
+
 var _ = Describe("UpdateNetworkStatusConfig", func() {
 	var (
 		vm   *vmopv1.VirtualMachine
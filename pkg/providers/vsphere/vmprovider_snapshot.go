@@ -0,0 +1,128 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+)
+
+var vmSnapshotPropertiesSelector = []string{
+	"layoutEx",
+	"snapshot",
+}
+
+// CreateOrUpdateVirtualMachineSnapshot creates the snapshot described by the
+// VirtualMachineSnapshot if it does not already exist, and updates the
+// VirtualMachineSnapshot's status from the VM's snapshot tree.
+func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "snapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), vmSnapshotPropertiesSelector, &vmCtx.MoVM); err != nil {
+		return fmt.Errorf("failed to get VM properties for snapshot: %w", err)
+	}
+
+	var node, parent *vimtypes.VirtualMachineSnapshotTree
+
+	if snapshotID := vmSnapshot.Status.UniqueID; snapshotID != "" {
+		if node, parent = virtualmachine.FindSnapshot(vmCtx.MoVM, snapshotID); node == nil {
+			return fmt.Errorf("snapshot %s no longer exists", snapshotID)
+		}
+	} else {
+		vmCtx.Logger.Info("Creating VM snapshot")
+
+		snapRef, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, vmSnapshot)
+		if err != nil {
+			return err
+		}
+
+		// Record the snapshot as soon as it is created so that it is only
+		// ever looked up by its ID.
+		vmSnapshot.Status.UniqueID = snapRef.Value
+
+		if err := vcVM.Properties(vmCtx, vcVM.Reference(), vmSnapshotPropertiesSelector, &vmCtx.MoVM); err != nil {
+			return fmt.Errorf("failed to get VM properties for snapshot: %w", err)
+		}
+
+		if node, parent = virtualmachine.FindSnapshot(vmCtx.MoVM, snapRef.Value); node == nil {
+			return fmt.Errorf("created snapshot %s not found", snapRef.Value)
+		}
+	}
+
+	virtualmachine.UpdateSnapshotStatus(vmSnapshot, vmCtx.MoVM, node, parent)
+
+	return nil
+}
+
+// DeleteVirtualMachineSnapshot deletes the snapshot described by the
+// VirtualMachineSnapshot. It is not an error if the VM or snapshot does not
+// exist, or if the snapshot was never created.
+func (vs *vSphereVMProvider) DeleteVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "deleteSnapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	if vmSnapshot.Status.UniqueID == "" {
+		// The snapshot was never created.
+		return nil
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, false)
+	if err != nil {
+		return err
+	} else if vcVM == nil {
+		// VM does not exist.
+		return nil
+	}
+
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"snapshot"}, &vmCtx.MoVM); err != nil {
+		return fmt.Errorf("failed to get VM properties for snapshot: %w", err)
+	}
+
+	node, _ := virtualmachine.FindSnapshot(vmCtx.MoVM, vmSnapshot.Status.UniqueID)
+	if node == nil {
+		// Snapshot does not exist.
+		return nil
+	}
+
+	vmCtx.Logger.Info("Deleting VM snapshot")
+
+	return virtualmachine.DeleteSnapshot(vmCtx, vcVM, node.Snapshot)
+}
@@ -26,6 +26,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apierrorsutil "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -461,6 +462,34 @@ func (vs *vSphereVMProvider) GetVirtualMachineHardwareVersion(
 	return vimtypes.ParseHardwareVersion(o.Config.Version)
 }
 
+// UpdateVirtualMachineDiskStorageIOAllocation updates the storage I/O
+// allocation of the VM's virtual disk with the specified UUID. Nothing is done
+// if the disk already has the specified allocation.
+func (vs *vSphereVMProvider) UpdateVirtualMachineDiskStorageIOAllocation(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	diskUUID string,
+	storageIO vmopv1.VirtualMachineStorageIOAllocation) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "updateDiskStorageIO")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.UpdateDiskStorageIOAllocation(vmCtx, vcVM, diskUUID, storageIO)
+}
+
 func (vs *vSphereVMProvider) vmCreatePathName(
 	vmCtx pkgctx.VirtualMachineContext,
 	vcClient *vcclient.Client,
@@ -563,7 +592,7 @@ func (vs *vSphereVMProvider) getCreateArgs(
 		return nil, err
 	}
 
-	if pkgcfg.FromContext(vmCtx).Features.FastDeploy {
+	if pkgcfg.FromContext(vmCtx).Features.FastDeploy && createArgs.CloneSourceMoID == "" {
 		if err := vs.vmCreateGetSourceDiskPaths(vmCtx, vcClient, createArgs); err != nil {
 			return nil, err
 		}
@@ -728,6 +757,7 @@ var VMUpdatePropertiesSelector = []string{
 	"layoutEx",
 	"resourcePool",
 	"runtime",
+	"snapshot",
 	"summary",
 }
 
@@ -774,6 +804,14 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			return fmt.Errorf("VM doesn't have a resourcePool")
 		}
 
+		if pkgcfg.FromContext(vmCtx).Features.VMSnapshots &&
+			vmCtx.VM.Spec.CurrentSnapshot != "" {
+
+			if err := vs.vmUpdateRevertToSnapshot(vmCtx, vcVM); err != nil {
+				return err
+			}
+		}
+
 		clusterMoRef, err := vcenter.GetResourcePoolOwnerMoRef(
 			vmCtx,
 			vcVM.Client(),
@@ -842,6 +880,66 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 	return nil
 }
 
+// vmUpdateRevertToSnapshot reverts the VM to the snapshot described by the
+// VM's spec.currentSnapshot field, and then clears the field. The VM is not
+// reverted again if it was already reverted for the VM's current generation.
+func (vs *vSphereVMProvider) vmUpdateRevertToSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) error {
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	key := ctrlclient.ObjectKey{Namespace: vmCtx.VM.Namespace, Name: vmCtx.VM.Spec.CurrentSnapshot}
+	if err := vs.k8sClient.Get(vmCtx, key, vmSnapshot); err != nil {
+		return fmt.Errorf("failed to get VirtualMachineSnapshot %s: %w", key, err)
+	}
+
+	if vmSnapshot.Spec.VMName != vmCtx.VM.Name {
+		return fmt.Errorf("VirtualMachineSnapshot %s is not a snapshot of this VM", key)
+	}
+
+	node, _ := virtualmachine.FindSnapshot(vmCtx.MoVM, vmSnapshot.Status.UniqueID)
+	if node == nil {
+		return fmt.Errorf("snapshot for VirtualMachineSnapshot %s not found", key)
+	}
+
+	// The revert may have already succeeded without the field being cleared,
+	// in which case the VM must not be reverted again.
+	if virtualmachine.IsRevertedToSnapshot(vmCtx, node.Snapshot) {
+		vmCtx.Logger.Info("VM already reverted to snapshot", "snapshot", vmSnapshot.Name)
+		vmCtx.VM.Spec.CurrentSnapshot = ""
+		return nil
+	}
+
+	vmCtx.Logger.Info("Reverting VM to snapshot", "snapshot", vmSnapshot.Name)
+
+	suppressPowerOn := vmCtx.VM.Spec.PowerState != vmopv1.VirtualMachinePowerStateOn
+	if err := virtualmachine.RevertToSnapshot(vmCtx, vcVM, node.Snapshot, suppressPowerOn); err != nil {
+		return err
+	}
+
+	// Reverting restores the ExtraConfig from when the snapshot was taken, so
+	// the revert is recorded afterwards.
+	if err := virtualmachine.SetRevertedSnapshot(vmCtx, vcVM, node.Snapshot); err != nil {
+		return err
+	}
+
+	// Reverting restores the ExtraConfig from when the snapshot was taken,
+	// including the backup data.
+	if err := virtualmachine.ResetBackupAfterRevert(vmCtx, vcVM); err != nil {
+		return err
+	}
+
+	vmCtx.VM.Spec.CurrentSnapshot = ""
+
+	// Refetch the properties since the VM's config and power state may have
+	// changed as a result of the revert.
+	return vcVM.Properties(
+		vmCtx,
+		vcVM.Reference(),
+		VMUpdatePropertiesSelector,
+		&vmCtx.MoVM)
+}
+
 // vmCreateDoPlacement determines placement of the VM prior to creating the VM on VC.
 func (vs *vSphereVMProvider) vmCreateDoPlacement(
 	vmCtx pkgctx.VirtualMachineContext,
@@ -866,16 +964,37 @@ func (vs *vSphereVMProvider) vmCreateDoPlacement(
 		return err
 	}
 
-	pvcZones, err := kubeutil.GetPVCZoneConstraints(
+	zones, err := kubeutil.GetPVCZoneConstraints(
 		createArgs.Storage.StorageClasses,
 		createArgs.Storage.PVCs)
 	if err != nil {
 		return err
 	}
 
+	// All of the VMs that share a volume must be in the same zone, so the VM
+	// is constrained to the zone of the other VMs that share its volumes.
+	sharedZones, err := vmopv1util.GetSharedVolumeZones(vmCtx, vs.k8sClient, vmCtx.VM)
+	if err != nil {
+		return err
+	}
+	if sharedZones.Len() > 1 {
+		return fmt.Errorf("the VMs that share volumes with this VM are in multiple zones: %s",
+			strings.Join(sets.List(sharedZones), ","))
+	}
+	if sharedZones.Len() > 0 {
+		if zones.Len() > 0 {
+			zones = zones.Intersection(sharedZones)
+			if zones.Len() == 0 {
+				return fmt.Errorf("no allowed zones remaining after applying shared volume zone constraints")
+			}
+		} else {
+			zones = sharedZones
+		}
+	}
+
 	constraints := placement.Constraints{
 		ChildRPName: createArgs.ChildResourcePoolName,
-		Zones:       pvcZones,
+		Zones:       zones,
 	}
 
 	result, err := placement.Placement(
@@ -1309,6 +1428,16 @@ func (vs *vSphereVMProvider) vmCreateGetVirtualMachineImage(
 	createArgs.ImageSpec = imageSpec
 	createArgs.ImageStatus = imageStatus
 
+	if srcVM, ok := imageObj.(*vmopv1.VirtualMachine); ok {
+		// The VM is cloned from another VM.
+		createArgs.UseContentLibrary = false
+		createArgs.CloneSourceMoID = srcVM.Status.UniqueID
+		if c := vmCtx.VM.Spec.Clone; c != nil {
+			createArgs.LinkedClone = c.Mode == vmopv1.VirtualMachineCloneModeLinked
+		}
+		return nil
+	}
+
 	var providerRef common.LocalObjectRef
 	if imageSpec.ProviderRef != nil {
 		providerRef = *imageSpec.ProviderRef
@@ -1510,6 +1639,18 @@ func (vs *vSphereVMProvider) vmCreateGenConfigSpec(
 				ProfileId: createArgs.StorageProfileID,
 			},
 		}
+
+		// The image's controllers are in the ConfigSpec, so add any
+		// controllers requested by the VM's volumes when the VM is created.
+		// Otherwise the controllers are added prior to the VM's first power
+		// on, when the VM's devices are known.
+		if err := virtualmachine.EnsureVolumeDiskControllers(
+			&createArgs.ConfigSpec,
+			vmCtx.VM.Spec.Volumes,
+			nil); err != nil {
+
+			return err
+		}
 	}
 
 	// Get the encryption class details for the VM.
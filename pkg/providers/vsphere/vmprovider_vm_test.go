@@ -377,6 +377,24 @@ func vmTests() {
 						Expect(result).ToNot(HaveLen(0))
 					})
 				})
+				When("getting the quick stats", func() {
+					BeforeEach(func() {
+						propertyPaths = []string{
+							"summary.quickStats",
+							"summary.runtime.maxCpuUsage",
+							"summary.config.memorySizeMB",
+						}
+					})
+					It("should retrieve the properties by value", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(result).To(HaveKeyWithValue(
+							"summary.quickStats", BeAssignableToTypeOf(vimtypes.VirtualMachineQuickStats{})))
+						Expect(result).To(HaveKeyWithValue(
+							"summary.runtime.maxCpuUsage", BeAssignableToTypeOf(int32(0))))
+						Expect(result).To(HaveKeyWithValue(
+							"summary.config.memorySizeMB", BeAssignableToTypeOf(int32(0))))
+					})
+				})
 				DescribeTable("getting "+propExtraConfigKey,
 					func(val any) {
 						t, err := vcVM.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
@@ -2376,6 +2394,71 @@ func vmTests() {
 				})
 			})
 
+			When("VM zone is constrained by VMs that share a volume", func() {
+				BeforeEach(func() {
+					// Need to create the PVC and the other VM before creating the VM.
+					skipCreateOrUpdateVM = true
+
+					vm.Spec.Volumes = []vmopv1.VirtualMachineVolume{
+						{
+							Name: "shared-vol",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+									PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+										ClaimName: "pvc-shared-1",
+									},
+								},
+							},
+							SharingMode: vmopv1.VolumeSharingModeMultiWriter,
+						},
+					}
+				})
+
+				It("creates VM in the zone of the other VMs", func() {
+					Expect(len(ctx.ZoneNames)).To(BeNumerically(">", 1))
+					azName := ctx.ZoneNames[rand.Intn(len(ctx.ZoneNames))]
+
+					// Make sure we do placement.
+					delete(vm.Labels, topology.KubernetesTopologyZoneLabelKey)
+
+					pvc1 := &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "pvc-shared-1",
+							Namespace: vm.Namespace,
+						},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: ptr.To(ctx.StorageClassName),
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+							VolumeMode:       ptr.To(corev1.PersistentVolumeBlock),
+						},
+						Status: corev1.PersistentVolumeClaimStatus{
+							Phase: corev1.ClaimBound,
+						},
+					}
+					Expect(ctx.Client.Create(ctx, pvc1)).To(Succeed())
+					Expect(ctx.Client.Status().Update(ctx, pvc1)).To(Succeed())
+
+					otherVM := &vmopv1.VirtualMachine{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "other-vm",
+							Namespace: vm.Namespace,
+							Labels: map[string]string{
+								topology.KubernetesTopologyZoneLabelKey: azName,
+							},
+						},
+						Spec: vmopv1.VirtualMachineSpec{
+							Volumes: vm.Spec.Volumes,
+						},
+					}
+					Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					_, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Status.Zone).To(Equal(azName))
+				})
+			})
+
 			Context("When Instance Storage FSS is enabled", func() {
 				BeforeEach(func() {
 					testConfig.WithInstanceStorage = true
@@ -125,7 +125,8 @@ func getVirtualMachineClassFromClassName(
 
 // GetVirtualMachineImageSpecAndStatus returns either the VirtualMachineImage
 // or ClusterVirtualMachineImage resource, as well as its spec and status, for
-// the resource used to deploy a VM.
+// the resource used to deploy a VM. If the VM is cloned from another VM, the
+// source VirtualMachine resource is returned with an empty spec and status.
 //
 // Please note, this function is *not* designed to be invoked in the "update"
 // VM workflow. This function assumes it is only ever invoked as part of the
@@ -173,6 +174,22 @@ func GetVirtualMachineImageSpecAndStatus(
 		if objErr = k8sClient.Get(vmCtx, key, &img); objErr == nil {
 			obj, spec, status = &img, img.Spec, img.Status
 		}
+
+	case "VirtualMachine":
+		if !pkgcfg.FromContext(vmCtx).Features.VMClone {
+			reason := "NotSupported"
+			msg := "the VM Clone feature is not enabled"
+			conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady, reason, "%s", msg)
+
+			return nil,
+				vmopv1.VirtualMachineImageSpec{},
+				vmopv1.VirtualMachineImageStatus{},
+				fmt.Errorf("%s: %s", reason, msg)
+		}
+		var srcVM vmopv1.VirtualMachine
+		if objErr = k8sClient.Get(vmCtx, key, &srcVM); objErr == nil {
+			obj = &srcVM
+		}
 	case "":
 		// This is only possible IFF VirtualMachine API resources created at a
 		// schema version prior to spec.image were not yet deployed when VM Op
@@ -242,6 +259,24 @@ func GetVirtualMachineImageSpecAndStatus(
 			err
 	}
 
+	// A VM that is cloned from another VM has no image spec or status, and
+	// its source is ready once the source VM has been created.
+	if srcVM, ok := obj.(*vmopv1.VirtualMachine); ok {
+		if srcVM.Status.UniqueID == "" {
+			msg := "source VirtualMachine has not been created"
+			conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady, "NotCreated", "%s", msg)
+
+			return nil,
+				vmopv1.VirtualMachineImageSpec{},
+				vmopv1.VirtualMachineImageStatus{},
+				errors.New(msg)
+		}
+
+		conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady)
+
+		return obj, spec, status, nil
+	}
+
 	vmiNotReadyMessage := "VirtualMachineImage is not ready"
 
 	// Mirror the image's ReadyConditionType into the VM's
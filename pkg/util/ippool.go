@@ -0,0 +1,102 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// IPRange is an inclusive range of IP addresses.
+type IPRange struct {
+	Start netip.Addr
+	End   netip.Addr
+}
+
+// Contains returns true if the address is in the range.
+func (r IPRange) Contains(addr netip.Addr) bool {
+	return r.Start.Compare(addr) <= 0 && addr.Compare(r.End) <= 0
+}
+
+// ParseIPPoolAddresses parses the addresses of an IPPool, each of which may be
+// a single IP address, a range of IP addresses such as
+// 192.168.1.10-192.168.1.100, or a CIDR such as 192.168.1.0/26. The network
+// and broadcast addresses of an IPv4 CIDR are excluded from its range. An
+// error is returned if an address is invalid, or if the addresses are not all
+// of the same IP family.
+func ParseIPPoolAddresses(addresses []string) ([]IPRange, error) {
+	ranges := make([]IPRange, 0, len(addresses))
+
+	for _, a := range addresses {
+		r, err := parseIPRange(strings.TrimSpace(a))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ranges) > 0 && ranges[0].Start.Is4() != r.Start.Is4() {
+			return nil, fmt.Errorf("address %q is not of the same IP family as %q", a, addresses[0])
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	return ranges, nil
+}
+
+func parseIPRange(s string) (IPRange, error) {
+	if start, end, ok := strings.Cut(s, "-"); ok {
+		startAddr, err := netip.ParseAddr(strings.TrimSpace(start))
+		if err != nil {
+			return IPRange{}, fmt.Errorf("invalid address range %q: %w", s, err)
+		}
+		endAddr, err := netip.ParseAddr(strings.TrimSpace(end))
+		if err != nil {
+			return IPRange{}, fmt.Errorf("invalid address range %q: %w", s, err)
+		}
+		if startAddr.Is4() != endAddr.Is4() {
+			return IPRange{}, fmt.Errorf("invalid address range %q: addresses are not of the same IP family", s)
+		}
+		if startAddr.Compare(endAddr) > 0 {
+			return IPRange{}, fmt.Errorf("invalid address range %q: start is after end", s)
+		}
+		return IPRange{Start: startAddr, End: endAddr}, nil
+	}
+
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return IPRange{}, fmt.Errorf("invalid CIDR %q: %w", s, err)
+		}
+		prefix = prefix.Masked()
+
+		start := prefix.Addr()
+		end := lastAddr(prefix)
+
+		if start.Is4() && prefix.Bits() < 31 {
+			start = start.Next()
+			end = end.Prev()
+		}
+
+		return IPRange{Start: start, End: end}, nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return IPRange{}, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+
+	return IPRange{Start: addr, End: addr}, nil
+}
+
+// lastAddr returns the last address of the masked prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - uint(i%8)) //nolint:gosec // disable G115
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
@@ -0,0 +1,58 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+var _ = DescribeTable("ParseIPPoolAddresses",
+	func(addresses []string, expected []string, expectedErr string) {
+		ranges, err := util.ParseIPPoolAddresses(addresses)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+
+		actual := make([]string, 0, len(ranges))
+		for _, r := range ranges {
+			actual = append(actual, r.Start.String()+"-"+r.End.String())
+		}
+		Expect(actual).To(Equal(expected))
+	},
+	Entry("single address", []string{"192.168.1.10"}, []string{"192.168.1.10-192.168.1.10"}, ""),
+	Entry("range", []string{"192.168.1.10-192.168.1.20"}, []string{"192.168.1.10-192.168.1.20"}, ""),
+	Entry("range with spaces", []string{"192.168.1.10 - 192.168.1.20"}, []string{"192.168.1.10-192.168.1.20"}, ""),
+	Entry("ipv4 cidr", []string{"192.168.1.0/29"}, []string{"192.168.1.1-192.168.1.6"}, ""),
+	Entry("unmasked ipv4 cidr", []string{"192.168.1.5/29"}, []string{"192.168.1.1-192.168.1.6"}, ""),
+	Entry("ipv4 /31 cidr", []string{"192.168.1.0/31"}, []string{"192.168.1.0-192.168.1.1"}, ""),
+	Entry("ipv6 cidr", []string{"2001:db8::/126"}, []string{"2001:db8::-2001:db8::3"}, ""),
+	Entry("multiple", []string{"192.168.1.10", "192.168.2.0/30"}, []string{"192.168.1.10-192.168.1.10", "192.168.2.1-192.168.2.2"}, ""),
+	Entry("invalid address", []string{"192.168.1.300"}, nil, `invalid address "192.168.1.300"`),
+	Entry("invalid cidr", []string{"192.168.1.0/33"}, nil, `invalid CIDR "192.168.1.0/33"`),
+	Entry("invalid range", []string{"192.168.1.20-192.168.1.10"}, nil, "start is after end"),
+	Entry("mixed range", []string{"192.168.1.10-2001:db8::1"}, nil, "addresses are not of the same IP family"),
+	Entry("mixed families", []string{"192.168.1.10", "2001:db8::1"}, nil, `address "2001:db8::1" is not of the same IP family as "192.168.1.10"`),
+)
+
+var _ = Describe("IPRange", func() {
+	It("Contains", func() {
+		r := util.IPRange{
+			Start: netip.MustParseAddr("192.168.1.10"),
+			End:   netip.MustParseAddr("192.168.1.20"),
+		}
+		Expect(r.Contains(netip.MustParseAddr("192.168.1.10"))).To(BeTrue())
+		Expect(r.Contains(netip.MustParseAddr("192.168.1.15"))).To(BeTrue())
+		Expect(r.Contains(netip.MustParseAddr("192.168.1.20"))).To(BeTrue())
+		Expect(r.Contains(netip.MustParseAddr("192.168.1.21"))).To(BeFalse())
+		Expect(r.Contains(netip.MustParseAddr("192.168.1.9"))).To(BeFalse())
+	})
+})
@@ -18,6 +18,22 @@ type Network = schema.NetworkConfig
 
 type Ethernet = schema.EthernetConfig
 
+type Bond = schema.BondConfig
+
+type BondParameters = schema.BondParameters
+
+type BondMode = schema.BondMode
+
+type LACPRate = schema.LACPRate
+
+type TransmitHashPolicy = schema.TransmitHashPolicy
+
+type VLAN = schema.VLANConfig
+
+type Bridge = schema.BridgeConfig
+
+type BridgeParameters = schema.BridgeParameters
+
 type Match = schema.MatchConfig
 
 type Nameserver = schema.NameserverConfig
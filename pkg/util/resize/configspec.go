@@ -48,6 +48,7 @@ func CreateResizeConfigSpec(
 	compareVirtualPMem(ci, cs, &outCS)
 	compareVirtualMachineToolsConfig(ci, cs, &outCS)
 	compareVirtualNuma(ci, cs, &outCS)
+	CompareStorageIOAllocation(ci, cs, &outCS)
 
 	return outCS, nil
 }
@@ -35,6 +35,87 @@ func compareHardwareDevices(
 	outCS.DeviceChange = deviceChanges
 }
 
+// CompareStorageIOAllocation compares the storage I/O allocation of the VM's
+// current virtual disks to the desired virtual disks in the ConfigSpec, which
+// are matched by their device key. An Edit DeviceChange is added to the
+// outCS for each disk whose allocation differs, or the allocation is set on
+// the disk if the outCS already edits it.
+func CompareStorageIOAllocation(
+	ci vimtypes.VirtualMachineConfigInfo,
+	cs vimtypes.VirtualMachineConfigSpec,
+	outCS *vimtypes.VirtualMachineConfigSpec) {
+
+	curDisks := map[int32]*vimtypes.VirtualDisk{}
+	for _, dev := range ci.Hardware.Device {
+		if disk, ok := dev.(*vimtypes.VirtualDisk); ok {
+			curDisks[disk.Key] = disk
+		}
+	}
+
+	for _, dc := range cs.DeviceChange {
+		spec := dc.GetVirtualDeviceConfigSpec()
+		if spec.Device == nil {
+			continue
+		}
+
+		expectedDisk, ok := spec.Device.(*vimtypes.VirtualDisk)
+		if !ok || expectedDisk.StorageIOAllocation == nil {
+			continue
+		}
+
+		curDisk, ok := curDisks[expectedDisk.Key]
+		if !ok || storageIOAllocationEqual(curDisk.StorageIOAllocation, expectedDisk.StorageIOAllocation) {
+			continue
+		}
+
+		if editDisk := findEditedDisk(outCS, curDisk.Key); editDisk != nil {
+			editDisk.StorageIOAllocation = expectedDisk.StorageIOAllocation
+			continue
+		}
+
+		editDisk := *curDisk
+		editDisk.StorageIOAllocation = expectedDisk.StorageIOAllocation
+		outCS.DeviceChange = append(outCS.DeviceChange, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+			Device:    &editDisk,
+		})
+	}
+}
+
+func storageIOAllocationEqual(a, b *vimtypes.StorageIOAllocationInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if !ptrEqual(a.Limit, b.Limit) || !ptrEqual(a.Reservation, b.Reservation) {
+		return false
+	}
+
+	if a.Shares == nil || b.Shares == nil {
+		return a.Shares == b.Shares
+	}
+
+	return a.Shares.Level == b.Shares.Level &&
+		(a.Shares.Level != vimtypes.SharesLevelCustom || a.Shares.Shares == b.Shares.Shares)
+}
+
+func findEditedDisk(
+	cs *vimtypes.VirtualMachineConfigSpec,
+	key int32) *vimtypes.VirtualDisk {
+
+	for _, dc := range cs.DeviceChange {
+		spec := dc.GetVirtualDeviceConfigSpec()
+		if spec.Operation != vimtypes.VirtualDeviceConfigSpecOperationEdit || spec.Device == nil {
+			continue
+		}
+		if disk, ok := spec.Device.(*vimtypes.VirtualDisk); ok && disk.Key == key {
+			return disk
+		}
+	}
+
+	return nil
+}
+
 // compareDevicesByZipping determine what if any DeviceChange entries are needed by zipping
 // the expected and current devices of each supported type together. That is, the devices are
 // compared in their relative order, and Edit, Add, and/or Remove DeviceChanges are created
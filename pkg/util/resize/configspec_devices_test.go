@@ -1261,3 +1261,159 @@ var _ = Describe("Cmp(Ptr)Edit", func() {
 		)
 	})
 })
+
+var _ = Describe("CompareStorageIOAllocation", func() {
+
+	const diskKey = int32(2000)
+
+	var (
+		ci    vimtypes.VirtualMachineConfigInfo
+		cs    vimtypes.VirtualMachineConfigSpec
+		outCS vimtypes.VirtualMachineConfigSpec
+	)
+
+	newDisk := func(alloc *vimtypes.StorageIOAllocationInfo) *vimtypes.VirtualDisk {
+		return &vimtypes.VirtualDisk{
+			VirtualDevice: vimtypes.VirtualDevice{
+				Key:           diskKey,
+				ControllerKey: 1000,
+				UnitNumber:    ptr.To(int32(0)),
+			},
+			CapacityInBytes:     1024 * 1024,
+			StorageIOAllocation: alloc,
+		}
+	}
+
+	desiredAlloc := func() *vimtypes.StorageIOAllocationInfo {
+		return &vimtypes.StorageIOAllocationInfo{
+			Limit:       ptr.To(int64(1000)),
+			Reservation: ptr.To(int32(100)),
+			Shares:      &vimtypes.SharesInfo{Level: vimtypes.SharesLevelCustom, Shares: 1500},
+		}
+	}
+
+	BeforeEach(func() {
+		ci = vimtypes.VirtualMachineConfigInfo{}
+		cs = vimtypes.VirtualMachineConfigSpec{}
+		outCS = vimtypes.VirtualMachineConfigSpec{}
+	})
+
+	JustBeforeEach(func() {
+		resize.CompareStorageIOAllocation(ci, cs, &outCS)
+	})
+
+	When("the disk's allocation differs", func() {
+		BeforeEach(func() {
+			ci.Hardware.Device = []vimtypes.BaseVirtualDevice{newDisk(nil)}
+			cs.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				devChangeEntry(vimtypes.VirtualDeviceConfigSpecOperationEdit, newDisk(desiredAlloc())),
+			}
+		})
+
+		It("edits the disk", func() {
+			Expect(outCS.DeviceChange).To(HaveLen(1))
+			spec := outCS.DeviceChange[0].GetVirtualDeviceConfigSpec()
+			Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationEdit))
+			disk, ok := spec.Device.(*vimtypes.VirtualDisk)
+			Expect(ok).To(BeTrue())
+			Expect(disk.Key).To(Equal(diskKey))
+			Expect(disk.CapacityInBytes).To(Equal(int64(1024 * 1024)))
+			Expect(disk.StorageIOAllocation).To(Equal(desiredAlloc()))
+		})
+
+		It("does not modify the current disk", func() {
+			Expect(ci.Hardware.Device[0].(*vimtypes.VirtualDisk).StorageIOAllocation).To(BeNil())
+		})
+
+		When("the disk is already edited", func() {
+			var editDisk *vimtypes.VirtualDisk
+
+			BeforeEach(func() {
+				editDisk = newDisk(nil)
+				editDisk.CapacityInBytes = 2 * 1024 * 1024
+				outCS.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+					devChangeEntry(vimtypes.VirtualDeviceConfigSpecOperationEdit, editDisk),
+				}
+			})
+
+			It("sets the allocation on the existing edit", func() {
+				Expect(outCS.DeviceChange).To(HaveLen(1))
+				Expect(outCS.DeviceChange[0].GetVirtualDeviceConfigSpec().Device).To(BeIdenticalTo(editDisk))
+				Expect(editDisk.CapacityInBytes).To(Equal(int64(2 * 1024 * 1024)))
+				Expect(editDisk.StorageIOAllocation).To(Equal(desiredAlloc()))
+			})
+		})
+	})
+
+	When("only the number of shares differs for a non-custom level", func() {
+		BeforeEach(func() {
+			cur := desiredAlloc()
+			cur.Shares = &vimtypes.SharesInfo{Level: vimtypes.SharesLevelHigh, Shares: 2000}
+			exp := desiredAlloc()
+			exp.Shares = &vimtypes.SharesInfo{Level: vimtypes.SharesLevelHigh}
+
+			ci.Hardware.Device = []vimtypes.BaseVirtualDevice{newDisk(cur)}
+			cs.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				devChangeEntry(vimtypes.VirtualDeviceConfigSpecOperationEdit, newDisk(exp)),
+			}
+		})
+
+		It("does not edit the disk", func() {
+			Expect(outCS.DeviceChange).To(BeEmpty())
+		})
+	})
+
+	When("the disk's allocation is the same", func() {
+		BeforeEach(func() {
+			ci.Hardware.Device = []vimtypes.BaseVirtualDevice{newDisk(desiredAlloc())}
+			cs.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				devChangeEntry(vimtypes.VirtualDeviceConfigSpecOperationEdit, newDisk(desiredAlloc())),
+			}
+		})
+
+		It("does not edit the disk", func() {
+			Expect(outCS.DeviceChange).To(BeEmpty())
+		})
+	})
+
+	When("the desired disk does not specify an allocation", func() {
+		BeforeEach(func() {
+			ci.Hardware.Device = []vimtypes.BaseVirtualDevice{newDisk(desiredAlloc())}
+			cs.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				devChangeEntry(vimtypes.VirtualDeviceConfigSpecOperationEdit, newDisk(nil)),
+			}
+		})
+
+		It("does not edit the disk", func() {
+			Expect(outCS.DeviceChange).To(BeEmpty())
+		})
+	})
+
+	When("the desired disk does not exist", func() {
+		BeforeEach(func() {
+			cs.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				devChangeEntry(vimtypes.VirtualDeviceConfigSpecOperationAdd, newDisk(desiredAlloc()), -100),
+			}
+		})
+
+		It("does not edit the disk", func() {
+			Expect(outCS.DeviceChange).To(BeEmpty())
+		})
+	})
+
+	When("called by CreateResizeConfigSpec", func() {
+		It("includes the disk edit", func() {
+			ci.Hardware.Device = []vimtypes.BaseVirtualDevice{newDisk(nil)}
+			cs.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				devChangeEntry(vimtypes.VirtualDeviceConfigSpecOperationEdit, newDisk(desiredAlloc())),
+			}
+
+			actualCS, err := resize.CreateResizeConfigSpec(context.Background(), ci, cs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actualCS.DeviceChange).To(HaveLen(1))
+			disk, ok := actualCS.DeviceChange[0].GetVirtualDeviceConfigSpec().Device.(*vimtypes.VirtualDisk)
+			Expect(ok).To(BeTrue())
+			Expect(disk.StorageIOAllocation).To(Equal(desiredAlloc()))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// PVCClaimNameIndex is the name of the field index used to look up the VMs
+// that refer to a PVC.
+const PVCClaimNameIndex = "spec.volumes.persistentVolumeClaim.claimName"
+
+// PVCClaimNames returns the names of the PVCs referred to by the volumes of
+// the provided VM, excluding instance storage volumes. It is the indexer
+// function for PVCClaimNameIndex.
+func PVCClaimNames(obj client.Object) []string {
+	vm, ok := obj.(*vmopv1.VirtualMachine)
+	if !ok {
+		return nil
+	}
+
+	var claimNames []string
+	for _, vol := range vm.Spec.Volumes {
+		if pvc := vol.PersistentVolumeClaim; pvc != nil && pvc.InstanceVolumeClaim == nil {
+			claimNames = append(claimNames, pvc.ClaimName)
+		}
+	}
+	return claimNames
+}
+
+// IsSharedVolume returns true if the volume's disk may be attached to
+// multiple VMs, either because the disk uses the MultiWriter sharing mode or
+// because the disk is attached to a controller whose bus is shared.
+func IsSharedVolume(vol vmopv1.VirtualMachineVolume) bool {
+	switch {
+	case vol.SharingMode == vmopv1.VolumeSharingModeMultiWriter:
+		return true
+	case vol.ControllerSharingMode == vmopv1.VirtualControllerSharingModePhysical,
+		vol.ControllerSharingMode == vmopv1.VirtualControllerSharingModeVirtual:
+		return true
+	}
+	return false
+}
+
+// GetSharedVolumeZones returns the zones of the other VMs in the namespace
+// that refer to the PVCs of the provided VM's shared volumes. The VMs are
+// looked up with the PVCClaimNameIndex, and VMs that are being deleted or
+// have not been placed in a zone are ignored.
+func GetSharedVolumeZones(
+	ctx context.Context,
+	k8sClient client.Client,
+	vm *vmopv1.VirtualMachine) (sets.Set[string], error) {
+
+	zones := sets.New[string]()
+
+	for _, vol := range vm.Spec.Volumes {
+		pvc := vol.PersistentVolumeClaim
+		if pvc == nil || pvc.InstanceVolumeClaim != nil || !IsSharedVolume(vol) {
+			continue
+		}
+
+		var vmList vmopv1.VirtualMachineList
+		if err := k8sClient.List(
+			ctx,
+			&vmList,
+			client.InNamespace(vm.Namespace),
+			client.MatchingFields{PVCClaimNameIndex: pvc.ClaimName}); err != nil {
+
+			return nil, err
+		}
+
+		for i := range vmList.Items {
+			otherVM := &vmList.Items[i]
+			if otherVM.Name == vm.Name || !otherVM.DeletionTimestamp.IsZero() {
+				continue
+			}
+			if zone := otherVM.Labels[topology.KubernetesTopologyZoneLabelKey]; zone != "" {
+				zones.Insert(zone)
+			}
+		}
+	}
+
+	return zones, nil
+}
@@ -0,0 +1,136 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = DescribeTable("IsSharedVolume",
+	func(vol vmopv1.VirtualMachineVolume, expected bool) {
+		Expect(vmopv1util.IsSharedVolume(vol)).To(Equal(expected))
+	},
+	Entry("no sharing mode", vmopv1.VirtualMachineVolume{}, false),
+	Entry("None sharing mode",
+		vmopv1.VirtualMachineVolume{
+			SharingMode:           vmopv1.VolumeSharingModeNone,
+			ControllerSharingMode: vmopv1.VirtualControllerSharingModeNone,
+		},
+		false),
+	Entry("MultiWriter sharing mode",
+		vmopv1.VirtualMachineVolume{SharingMode: vmopv1.VolumeSharingModeMultiWriter},
+		true),
+	Entry("Physical controller sharing mode",
+		vmopv1.VirtualMachineVolume{ControllerSharingMode: vmopv1.VirtualControllerSharingModePhysical},
+		true),
+	Entry("Virtual controller sharing mode",
+		vmopv1.VirtualMachineVolume{ControllerSharingMode: vmopv1.VirtualControllerSharingModeVirtual},
+		true),
+)
+
+var _ = Describe("GetSharedVolumeZones", func() {
+	const (
+		namespace = "my-namespace"
+		claimName = "my-pvc"
+	)
+
+	var (
+		ctx       context.Context
+		k8sClient ctrlclient.Client
+		vm        *vmopv1.VirtualMachine
+		withObjs  []ctrlclient.Object
+	)
+
+	newVM := func(name, zone string, sharingMode vmopv1.VolumeSharingMode) *vmopv1.VirtualMachine {
+		obj := &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{},
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				Volumes: []vmopv1.VirtualMachineVolume{
+					{
+						Name: "vol-1",
+						VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+							PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{},
+						},
+						SharingMode: sharingMode,
+					},
+				},
+			},
+		}
+		obj.Spec.Volumes[0].PersistentVolumeClaim.ClaimName = claimName
+		if zone != "" {
+			obj.Labels[topology.KubernetesTopologyZoneLabelKey] = zone
+		}
+		return obj
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		vm = newVM("my-vm", "", vmopv1.VolumeSharingModeMultiWriter)
+		withObjs = nil
+	})
+
+	JustBeforeEach(func() {
+		k8sClient = builder.NewFakeClient(withObjs...)
+	})
+
+	When("no other VM refers to the PVC", func() {
+		BeforeEach(func() {
+			withObjs = append(withObjs, vm)
+		})
+		It("returns no zones", func() {
+			zones, err := vmopv1util.GetSharedVolumeZones(ctx, k8sClient, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(zones.UnsortedList()).To(BeEmpty())
+		})
+	})
+
+	When("other VMs refer to the PVC", func() {
+		BeforeEach(func() {
+			deletedVM := newVM("deleted-vm", "zone-3", vmopv1.VolumeSharingModeMultiWriter)
+			deletedVM.Finalizers = []string{"my-finalizer"}
+			deletedVM.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+			withObjs = append(withObjs,
+				newVM("vm-1", "zone-1", vmopv1.VolumeSharingModeMultiWriter),
+				newVM("vm-2", "zone-1", vmopv1.VolumeSharingModeMultiWriter),
+				newVM("vm-3", "", vmopv1.VolumeSharingModeMultiWriter),
+				deletedVM,
+			)
+		})
+
+		It("returns the zones of the VMs that are not being deleted", func() {
+			zones, err := vmopv1util.GetSharedVolumeZones(ctx, k8sClient, vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(zones.UnsortedList()).To(ConsistOf("zone-1"))
+		})
+
+		When("the VM's volume is not shared", func() {
+			BeforeEach(func() {
+				vm.Spec.Volumes[0].SharingMode = ""
+			})
+			It("returns no zones", func() {
+				zones, err := vmopv1util.GetSharedVolumeZones(ctx, k8sClient, vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(zones.UnsortedList()).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,76 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+// GetVolumeStorageIOAllocation returns the storage I/O allocation for the
+// volume's disk, which is the volume's own allocation if specified, otherwise
+// the provided allocation from the VM's class. Nil is returned if neither
+// specify an allocation.
+func GetVolumeStorageIOAllocation(
+	volume vmopv1.VirtualMachineVolume,
+	classStorageIO *vmopv1.VirtualMachineStorageIOAllocation) *vmopv1.VirtualMachineStorageIOAllocation {
+
+	if volume.StorageIO != nil {
+		return volume.StorageIO
+	}
+	return classStorageIO
+}
+
+// StorageIOAllocationsEqual returns true if the two storage I/O allocations
+// result in the same allocation for a disk. An omitted limit is the same as
+// an unlimited one, an omitted reservation is the same as a zero reservation,
+// and omitted shares are the same as the Normal level. The number of shares
+// is only compared for the Custom level.
+func StorageIOAllocationsEqual(a, b *vmopv1.VirtualMachineStorageIOAllocation) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if limitOrUnlimited(a.Limit) != limitOrUnlimited(b.Limit) {
+		return false
+	}
+
+	if valueOrZero(a.Reservation) != valueOrZero(b.Reservation) {
+		return false
+	}
+
+	aShares, bShares := sharesOrNormal(a.Shares), sharesOrNormal(b.Shares)
+	if aShares.Level != bShares.Level {
+		return false
+	}
+
+	return aShares.Level != vmopv1.VirtualMachineStorageIOSharesLevelCustom ||
+		aShares.Shares == bShares.Shares
+}
+
+func limitOrUnlimited(limit *int64) int64 {
+	if limit == nil || *limit < 0 {
+		return -1
+	}
+	return *limit
+}
+
+func valueOrZero[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+func sharesOrNormal(
+	shares *vmopv1.VirtualMachineStorageIOShares) vmopv1.VirtualMachineStorageIOShares {
+
+	if shares == nil {
+		return vmopv1.VirtualMachineStorageIOShares{
+			Level: vmopv1.VirtualMachineStorageIOSharesLevelNormal,
+		}
+	}
+	return *shares
+}
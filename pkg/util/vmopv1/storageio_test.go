@@ -0,0 +1,105 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+var _ = Describe("GetVolumeStorageIOAllocation", func() {
+	classStorageIO := &vmopv1.VirtualMachineStorageIOAllocation{
+		Limit: ptr.To(int64(100)),
+	}
+
+	When("the volume specifies an allocation", func() {
+		It("returns the volume's allocation", func() {
+			vol := vmopv1.VirtualMachineVolume{
+				StorageIO: &vmopv1.VirtualMachineStorageIOAllocation{
+					Limit: ptr.To(int64(200)),
+				},
+			}
+			Expect(vmopv1util.GetVolumeStorageIOAllocation(vol, classStorageIO)).To(BeIdenticalTo(vol.StorageIO))
+		})
+	})
+
+	When("the volume does not specify an allocation", func() {
+		It("returns the class's allocation", func() {
+			vol := vmopv1.VirtualMachineVolume{}
+			Expect(vmopv1util.GetVolumeStorageIOAllocation(vol, classStorageIO)).To(BeIdenticalTo(classStorageIO))
+			Expect(vmopv1util.GetVolumeStorageIOAllocation(vol, nil)).To(BeNil())
+		})
+	})
+})
+
+var _ = DescribeTable("StorageIOAllocationsEqual",
+	func(a, b *vmopv1.VirtualMachineStorageIOAllocation, expected bool) {
+		Expect(vmopv1util.StorageIOAllocationsEqual(a, b)).To(Equal(expected))
+		Expect(vmopv1util.StorageIOAllocationsEqual(b, a)).To(Equal(expected))
+	},
+	Entry("both nil", nil, nil, true),
+	Entry("one nil",
+		nil,
+		&vmopv1.VirtualMachineStorageIOAllocation{},
+		false),
+	Entry("both empty",
+		&vmopv1.VirtualMachineStorageIOAllocation{},
+		&vmopv1.VirtualMachineStorageIOAllocation{},
+		true),
+	Entry("omitted limit is unlimited",
+		&vmopv1.VirtualMachineStorageIOAllocation{},
+		&vmopv1.VirtualMachineStorageIOAllocation{Limit: ptr.To(int64(-1))},
+		true),
+	Entry("different limit",
+		&vmopv1.VirtualMachineStorageIOAllocation{Limit: ptr.To(int64(100))},
+		&vmopv1.VirtualMachineStorageIOAllocation{Limit: ptr.To(int64(200))},
+		false),
+	Entry("omitted reservation is zero",
+		&vmopv1.VirtualMachineStorageIOAllocation{},
+		&vmopv1.VirtualMachineStorageIOAllocation{Reservation: ptr.To(int32(0))},
+		true),
+	Entry("different reservation",
+		&vmopv1.VirtualMachineStorageIOAllocation{Reservation: ptr.To(int32(10))},
+		&vmopv1.VirtualMachineStorageIOAllocation{Reservation: ptr.To(int32(20))},
+		false),
+	Entry("omitted shares is normal",
+		&vmopv1.VirtualMachineStorageIOAllocation{},
+		&vmopv1.VirtualMachineStorageIOAllocation{
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level: vmopv1.VirtualMachineStorageIOSharesLevelNormal,
+			},
+		},
+		true),
+	Entry("different shares level",
+		&vmopv1.VirtualMachineStorageIOAllocation{
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level: vmopv1.VirtualMachineStorageIOSharesLevelLow,
+			},
+		},
+		&vmopv1.VirtualMachineStorageIOAllocation{
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level: vmopv1.VirtualMachineStorageIOSharesLevelHigh,
+			},
+		},
+		false),
+	Entry("different custom shares",
+		&vmopv1.VirtualMachineStorageIOAllocation{
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level:  vmopv1.VirtualMachineStorageIOSharesLevelCustom,
+				Shares: 100,
+			},
+		},
+		&vmopv1.VirtualMachineStorageIOAllocation{
+			Shares: &vmopv1.VirtualMachineStorageIOShares{
+				Level:  vmopv1.VirtualMachineStorageIOSharesLevelCustom,
+				Shares: 200,
+			},
+		},
+		false),
+)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
@@ -22,6 +23,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	byokv1 "github.com/vmware-tanzu/vm-operator/external/byok/api/v1alpha1"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
@@ -31,6 +33,7 @@ import (
 const (
 	vmiKind           = "VirtualMachineImage"
 	cvmiKind          = "Cluster" + vmiKind
+	vmKind            = "VirtualMachine"
 	imgNotFoundFormat = "no VM image exists for %q in namespace or cluster scope"
 )
 
@@ -235,6 +238,28 @@ func IsImagelessVM(vm vmopv1.VirtualMachine) bool {
 	return vm.Spec.Image == nil && vm.Spec.ImageName == ""
 }
 
+// IsClonedVM returns true if the provided VM is deployed by cloning another
+// VM.
+func IsClonedVM(vm vmopv1.VirtualMachine) bool {
+	return vm.Spec.Image != nil && vm.Spec.Image.Kind == vmKind
+}
+
+// ClassicDiskInfo returns the observed capacity and size of the VM's classic
+// disks in the same form as a VM image's disks. The first disk is the VM's boot
+// disk.
+func ClassicDiskInfo(vm vmopv1.VirtualMachine) []vmopv1.VirtualMachineImageDiskInfo {
+	var disks []vmopv1.VirtualMachineImageDiskInfo
+	for _, v := range vm.Status.Volumes {
+		if v.Type == vmopv1.VirtualMachineStorageDiskTypeClassic {
+			disks = append(disks, vmopv1.VirtualMachineImageDiskInfo{
+				Capacity: v.Limit,
+				Size:     v.Used,
+			})
+		}
+	}
+	return disks
+}
+
 // ImageRefsEqual returns true if the two image refs match.
 func ImageRefsEqual(ref1, ref2 *vmopv1.VirtualMachineImageRef) bool {
 	if ref1 == nil && ref2 == nil {
@@ -361,6 +386,58 @@ func IsKubernetesNode(vm vmopv1.VirtualMachine) bool {
 	return ok
 }
 
+// IsReady returns true if the provided VM is ready to serve its workload. A
+// VM with a readiness probe is ready when its Ready condition is true, and a
+// VM without a readiness probe is ready when it is powered on. A VM that is
+// being deleted is never ready.
+func IsReady(vm vmopv1.VirtualMachine) bool {
+	if !vm.DeletionTimestamp.IsZero() {
+		return false
+	}
+	if vm.Spec.ReadinessProbe != nil {
+		return pkgcnd.IsTrue(&vm, vmopv1.ReadyConditionType)
+	}
+	return vm.Status.PowerState == vmopv1.VirtualMachinePowerStateOn
+}
+
+// IsAvailable returns true if the provided VM has been ready for at least
+// minReadySeconds as of the provided time. The time at which a VM with a
+// readiness probe became ready is the last transition time of its Ready
+// condition. Since a VM without a readiness probe does not have a Ready
+// condition, the time at which it was powered on is used instead.
+func IsAvailable(vm vmopv1.VirtualMachine, minReadySeconds int32, now time.Time) bool {
+	if !IsReady(vm) {
+		return false
+	}
+	if minReadySeconds <= 0 {
+		return true
+	}
+
+	conditionType := vmopv1.VirtualMachineConditionPoweredOn
+	if vm.Spec.ReadinessProbe != nil {
+		conditionType = vmopv1.ReadyConditionType
+	}
+
+	readyTime := pkgcnd.GetLastTransitionTime(&vm, conditionType)
+	if readyTime == nil || readyTime.IsZero() {
+		return false
+	}
+
+	minReadyDuration := time.Duration(minReadySeconds) * time.Second
+	return !readyTime.Add(minReadyDuration).After(now)
+}
+
+// IsReadinessProbeStateful returns true if the provided readiness probe
+// requires state to be tracked across the runs of the probe, i.e. the probe
+// specifies an initial delay or a success or failure threshold greater than
+// one.
+func IsReadinessProbeStateful(p *vmopv1.VirtualMachineReadinessProbeSpec) bool {
+	if p == nil {
+		return false
+	}
+	return p.InitialDelaySeconds > 0 || p.SuccessThreshold > 1 || p.FailureThreshold > 1
+}
+
 // GetContextWithWorkloadDomainIsolation gets a new context with the
 // WorkloadDomainIsolation capability set to a value based on the provided VM.
 func GetContextWithWorkloadDomainIsolation(
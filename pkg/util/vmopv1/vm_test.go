@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,6 +30,7 @@ import (
 	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
 	spqutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube/spq"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -936,6 +938,158 @@ var _ = DescribeTable("IsKubernetesNode",
 	),
 )
 
+var _ = DescribeTable("IsReady",
+	func(
+		vm vmopv1.VirtualMachine,
+		expected bool,
+	) {
+		Ω(vmopv1util.IsReady(vm)).Should(Equal(expected))
+	},
+	Entry(
+		"no readiness probe and powered off",
+		vmopv1.VirtualMachine{},
+		false,
+	),
+	Entry(
+		"no readiness probe and powered on",
+		vmopv1.VirtualMachine{
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		},
+		true,
+	),
+	Entry(
+		"no readiness probe, powered on, and being deleted",
+		vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				DeletionTimestamp: ptr.To(metav1.Now()),
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		},
+		false,
+	),
+	Entry(
+		"readiness probe and no Ready condition",
+		vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		},
+		false,
+	),
+	Entry(
+		"readiness probe and Ready condition is true",
+		vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+				Conditions: []metav1.Condition{
+					{
+						Type:   vmopv1.ReadyConditionType,
+						Status: metav1.ConditionTrue,
+					},
+				},
+			},
+		},
+		true,
+	),
+)
+
+var _ = Describe("IsAvailable", func() {
+	var (
+		now time.Time
+		vm  vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		vm = vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+				Conditions: []metav1.Condition{
+					{
+						Type:               vmopv1.ReadyConditionType,
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(-30 * time.Second)),
+					},
+				},
+			},
+		}
+	})
+
+	It("should return false if the VM is not ready", func() {
+		vm.Status.Conditions[0].Status = metav1.ConditionFalse
+		Ω(vmopv1util.IsAvailable(vm, 0, now)).Should(BeFalse())
+	})
+
+	It("should return true if the VM is ready and minReadySeconds is zero", func() {
+		Ω(vmopv1util.IsAvailable(vm, 0, now)).Should(BeTrue())
+	})
+
+	It("should return true if the VM has been ready for minReadySeconds", func() {
+		Ω(vmopv1util.IsAvailable(vm, 30, now)).Should(BeTrue())
+	})
+
+	It("should return false if the VM has not been ready for minReadySeconds", func() {
+		Ω(vmopv1util.IsAvailable(vm, 31, now)).Should(BeFalse())
+	})
+
+	When("the VM does not have a readiness probe", func() {
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = nil
+			vm.Status.Conditions = []metav1.Condition{
+				{
+					Type:               vmopv1.VirtualMachineConditionCreated,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-time.Hour)),
+				},
+				{
+					Type:               vmopv1.VirtualMachineConditionPoweredOn,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Second)),
+				},
+			}
+		})
+
+		It("should use the time the VM was powered on", func() {
+			Ω(vmopv1util.IsAvailable(vm, 10, now)).Should(BeTrue())
+			Ω(vmopv1util.IsAvailable(vm, 11, now)).Should(BeFalse())
+		})
+
+		When("the VM was created long before it was powered on", func() {
+			BeforeEach(func() {
+				vm.Status.Conditions[1].LastTransitionTime = metav1.NewTime(now)
+			})
+
+			It("should return false until the VM has been powered on for minReadySeconds", func() {
+				Ω(vmopv1util.IsAvailable(vm, 1, now)).Should(BeFalse())
+				Ω(vmopv1util.IsAvailable(vm, 1, now.Add(time.Second))).Should(BeTrue())
+			})
+		})
+
+		When("the VM does not have a PoweredOn condition", func() {
+			BeforeEach(func() {
+				vm.Status.Conditions = vm.Status.Conditions[:1]
+			})
+
+			It("should return false", func() {
+				Ω(vmopv1util.IsAvailable(vm, 1, now)).Should(BeFalse())
+			})
+		})
+	})
+})
+
 var _ = DescribeTable("GetContextWithWorkloadDomainIsolation",
 	func(
 		ctx context.Context,
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
@@ -422,6 +423,99 @@ func DummyVirtualMachineReplicaSet() *vmopv1.VirtualMachineReplicaSet {
 	}
 }
 
+func DummyVirtualMachineDeployment() *vmopv1.VirtualMachineDeployment {
+	return &vmopv1.VirtualMachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "VirtualMachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-",
+			Labels:       map[string]string{},
+			Annotations:  map[string]string{},
+		},
+		Spec: vmopv1.VirtualMachineDeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: make(map[string]string),
+			},
+			Template: DummyVirtualMachineReplicaSet().Spec.Template,
+		},
+	}
+}
+
+func DummyVirtualMachineSnapshot(namespace, name, vmName string) *vmopv1.VirtualMachineSnapshot {
+	return &vmopv1.VirtualMachineSnapshot{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "VirtualMachineSnapshot",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineSnapshotSpec{
+			VMName:      vmName,
+			Description: "dummy-snapshot",
+		},
+	}
+}
+
+func DummyIPPool(namespace, name, networkName string) *vmopv1.IPPool {
+	return &vmopv1.IPPool{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "IPPool",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.IPPoolSpec{
+			NetworkName: networkName,
+			Addresses:   []string{"192.168.10.10-192.168.10.20"},
+			Prefix:      24,
+			Gateway:     "192.168.10.1",
+			Nameservers: []string{"192.168.10.2"},
+		},
+	}
+}
+
+func DummyVirtualMachineNetworkPolicy(namespace, name string) *vmopv1.VirtualMachineNetworkPolicy {
+	return &vmopv1.VirtualMachineNetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "VirtualMachineNetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineNetworkPolicySpec{
+			VMSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": "db",
+				},
+			},
+			Ingress: []vmopv1.VirtualMachineNetworkPolicyIngressRule{
+				{
+					From: []vmopv1.VirtualMachineNetworkPolicyPeer{
+						{
+							VMSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app": "web",
+								},
+							},
+						},
+					},
+					Ports: []vmopv1.VirtualMachineNetworkPolicyPort{
+						{
+							Protocol: "TCP",
+							Port:     ptr.To(intstr.FromInt32(5432)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func AddDummyInstanceStorageVolume(vm *vmopv1.VirtualMachine) {
 	vm.Spec.Volumes = append(vm.Spec.Volumes, DummyInstanceStorageVirtualMachineVolumes()...)
 }
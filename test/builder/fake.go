@@ -14,6 +14,7 @@ import (
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 	netopv1alpha1 "github.com/vmware-tanzu/net-operator-api/api/v1alpha1"
+	nsxv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/legacy/v1alpha1"
 	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 
 	appv1a1 "github.com/vmware-tanzu/vm-operator/external/appplatform/api/v1alpha1"
@@ -29,6 +30,7 @@ import (
 	vmopv1a1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 func NewFakeClient(objs ...client.Object) client.Client {
@@ -45,6 +47,10 @@ func NewFakeClientWithInterceptors(
 		WithInterceptorFuncs(funcs).
 		WithObjects(objs...).
 		WithStatusSubresource(KnownObjectTypes()...).
+		WithIndex(
+			&vmopv1.VirtualMachine{},
+			vmopv1util.PVCClaimNameIndex,
+			vmopv1util.PVCClaimNames).
 		Build()
 }
 
@@ -61,7 +67,11 @@ func KnownObjectTypes() []client.Object {
 		&vmopv1.ClusterVirtualMachineImage{},
 		&vmopv1.VirtualMachineImage{},
 		&vmopv1.VirtualMachineImageCache{},
+		&vmopv1.VirtualMachineReplicaSet{},
+		&vmopv1.VirtualMachineDeployment{},
+		&vmopv1.VirtualMachineSnapshot{},
 		&vmopv1.VirtualMachineWebConsoleRequest{},
+		&vmopv1.VirtualMachineNetworkPolicy{},
 		&vmopv1a1.WebConsoleRequest{},
 		&cnsv1alpha1.CnsNodeVmAttachment{},
 		&spqv1.StoragePolicyQuota{},
@@ -72,6 +82,8 @@ func KnownObjectTypes() []client.Object {
 		&vpcv1alpha1.Subnet{},
 		&vpcv1alpha1.SubnetSet{},
 		&vpcv1alpha1.SubnetPort{},
+		&vpcv1alpha1.SecurityPolicy{},
+		&nsxv1alpha1.SecurityPolicy{},
 		&byokv1.EncryptionClass{},
 		&capv1.Capabilities{},
 		&appv1a1.SupervisorProperties{},
@@ -98,5 +110,6 @@ func NewScheme() *runtime.Scheme {
 	_ = topologyv1.AddToScheme(scheme)
 	_ = imgregv1a1.AddToScheme(scheme)
 	_ = vpcv1alpha1.AddToScheme(scheme)
+	_ = nsxv1alpha1.AddToScheme(scheme)
 	return scheme
 }
@@ -0,0 +1,195 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-ippool,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=ippools,versions=v1alpha4,name=default.validating.ippool.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create IPPool validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.IPPool{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	pool, err := v.ipPoolFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(ctx, pool)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	pool, err := v.ipPoolFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldPool, err := v.ipPoolFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(ctx, pool, oldPool)...)
+	fieldErrs = append(fieldErrs, v.validateSpec(ctx, pool)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(
+	_ *pkgctx.WebhookRequestContext,
+	pool *vmopv1.IPPool) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if pool.Spec.NetworkName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("networkName"), ""))
+	}
+
+	addressesPath := specPath.Child("addresses")
+	if len(pool.Spec.Addresses) == 0 {
+		allErrs = append(allErrs, field.Required(addressesPath, ""))
+		return allErrs
+	}
+
+	ranges, err := util.ParseIPPoolAddresses(pool.Spec.Addresses)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(addressesPath, pool.Spec.Addresses, err.Error()))
+		return allErrs
+	}
+	isIPv4 := ranges[0].Start.Is4()
+
+	maxPrefix := int32(128)
+	if isIPv4 {
+		maxPrefix = 32
+	}
+	if p := pool.Spec.Prefix; p < 0 || p > maxPrefix {
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("prefix"),
+			p,
+			fmt.Sprintf("must be between 0 and %d", maxPrefix)))
+	}
+
+	if gw := pool.Spec.Gateway; gw != "" {
+		gatewayPath := specPath.Child("gateway")
+		if addr, err := netip.ParseAddr(gw); err != nil {
+			allErrs = append(allErrs, field.Invalid(gatewayPath, gw, "must be a valid IP address"))
+		} else if addr.Is4() != isIPv4 {
+			allErrs = append(allErrs, field.Invalid(gatewayPath, gw, "must be of the same IP family as the addresses"))
+		}
+	}
+
+	for i, ns := range pool.Spec.Nameservers {
+		if _, err := netip.ParseAddr(ns); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("nameservers").Index(i),
+				ns,
+				"must be a valid IP address"))
+		}
+	}
+
+	for i, sd := range pool.Spec.SearchDomains {
+		if !util.IsValidDomainName(sd) {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("searchDomains").Index(i),
+				sd,
+				"must be a valid domain name"))
+		}
+	}
+
+	return allErrs
+}
+
+func (v validator) validateImmutableFields(
+	_ *pkgctx.WebhookRequestContext,
+	pool, oldPool *vmopv1.IPPool) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	// The addresses already allocated from the pool belong to its network.
+	if pool.Spec.NetworkName != oldPool.Spec.NetworkName {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("spec", "networkName"),
+			"field is immutable"))
+	}
+
+	return allErrs
+}
+
+// ipPoolFromUnstructured returns the IPPool from the unstructured object.
+func (v validator) ipPoolFromUnstructured(obj runtime.Unstructured) (*vmopv1.IPPool, error) {
+	pool := &vmopv1.IPPool{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
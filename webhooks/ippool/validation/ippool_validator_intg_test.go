@@ -0,0 +1,104 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateUpdate,
+	)
+}
+
+type intgValidatingWebhookContext struct {
+	builder.IntegrationTestContext
+	pool *vmopv1.IPPool
+}
+
+func newIntgValidatingWebhookContext() *intgValidatingWebhookContext {
+	ctx := &intgValidatingWebhookContext{
+		IntegrationTestContext: *suite.NewIntegrationTestContext(),
+	}
+
+	ctx.pool = builder.DummyIPPool(ctx.Namespace, "dummy-pool", "dummy-network")
+
+	return ctx
+}
+
+func intgTestsValidateCreate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow a valid pool", func() {
+		Expect(ctx.Client.Create(ctx, ctx.pool)).To(Succeed())
+	})
+
+	It("should deny a pool with an invalid address", func() {
+		ctx.pool.Spec.Addresses = []string{"192.168.10.300"}
+		err := ctx.Client.Create(ctx, ctx.pool)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.addresses: Invalid value"))
+	})
+}
+
+func intgTestsValidateUpdate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+		Expect(ctx.Client.Create(ctx, ctx.pool)).To(Succeed())
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow an update to the addresses", func() {
+		ctx.pool.Spec.Addresses = append(ctx.pool.Spec.Addresses, "192.168.10.100")
+		Expect(ctx.Client.Update(ctx, ctx.pool)).To(Succeed())
+	})
+
+	It("should deny an update to the networkName", func() {
+		ctx.pool.Spec.NetworkName = "other-network"
+		err := ctx.Client.Update(ctx, ctx.pool)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("field is immutable"))
+	})
+}
@@ -0,0 +1,301 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+type testParams struct {
+	setup         func(ctx *unitValidatingWebhookContext)
+	validate      func(ctx *unitValidatingWebhookContext, response admission.Response)
+	expectAllowed bool
+}
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	pool, oldPool *vmopv1.IPPool
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	pool := builder.DummyIPPool(
+		"dummy-pool-namespace-for-webhook-validation",
+		"dummy-pool-for-webhook-validation",
+		"dummy-network")
+	obj, err := builder.ToUnstructured(pool)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldPool *vmopv1.IPPool
+		oldObj  *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldPool = pool.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldPool)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj, nil...),
+		pool:                                pool,
+		oldPool:                             oldPool,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	doTest := func(args testParams) {
+		args.setup(ctx)
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.pool)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(args.expectAllowed))
+
+		if args.validate != nil {
+			args.validate(ctx, response)
+		}
+	}
+
+	expectReason := func(substr string) func(*unitValidatingWebhookContext, admission.Response) {
+		return func(_ *unitValidatingWebhookContext, response admission.Response) {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(substr))
+		}
+	}
+
+	DescribeTable("create", doTest,
+		Entry("should allow valid pool",
+			testParams{
+				setup:         func(_ *unitValidatingWebhookContext) {},
+				expectAllowed: true,
+			},
+		),
+		Entry("should allow valid IPv6 pool",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Addresses = []string{"fd00::/120"}
+					ctx.pool.Spec.Prefix = 64
+					ctx.pool.Spec.Gateway = "fd00::1"
+					ctx.pool.Spec.Nameservers = []string{"fd00::2"}
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("should return error on empty networkName",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.NetworkName = ""
+				},
+				validate:      expectReason("spec.networkName: Required value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on empty addresses",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Addresses = nil
+				},
+				validate:      expectReason("spec.addresses: Required value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid address",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Addresses = []string{"192.168.10.20-192.168.10.10"}
+				},
+				validate:      expectReason("spec.addresses: Invalid value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on addresses of mixed IP families",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Addresses = []string{"192.168.10.10", "fd00::10"}
+				},
+				validate:      expectReason("is not of the same IP family"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on IPv4 prefix greater than 32",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Prefix = 33
+				},
+				validate:      expectReason("spec.prefix: Invalid value: 33: must be between 0 and 32"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid gateway",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Gateway = "not-an-ip"
+				},
+				validate:      expectReason("spec.gateway: Invalid value: \"not-an-ip\": must be a valid IP address"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on gateway of different IP family",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Gateway = "fd00::1"
+				},
+				validate:      expectReason("must be of the same IP family as the addresses"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid nameserver",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.Nameservers = []string{"8.8.8.8", "dns"}
+				},
+				validate:      expectReason("spec.nameservers[1]: Invalid value: \"dns\": must be a valid IP address"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid search domain",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.pool.Spec.SearchDomains = []string{"-invalid-"}
+				},
+				validate:      expectReason("spec.searchDomains[0]: Invalid value: \"-invalid-\": must be a valid domain name"),
+				expectAllowed: false,
+			},
+		),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.pool)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the addresses are updated", func() {
+		BeforeEach(func() {
+			ctx.pool.Spec.Addresses = append(ctx.pool.Spec.Addresses, "192.168.10.100")
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the addresses are updated to be invalid", func() {
+		BeforeEach(func() {
+			ctx.pool.Spec.Addresses = []string{"192.168.10.300"}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.addresses: Invalid value"))
+		})
+	})
+
+	When("the networkName is updated", func() {
+		BeforeEach(func() {
+			ctx.pool.Spec.NetworkName = "other-network"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.networkName: Forbidden: field is immutable"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
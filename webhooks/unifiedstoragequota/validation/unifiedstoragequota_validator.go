@@ -29,6 +29,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 const (
@@ -175,6 +176,17 @@ func (h *RequestedCapacityHandler) HandleCreate(ctx *pkgctx.WebhookRequestContex
 			return CapacityResponse{Response: webhook.Errored(http.StatusInternalServerError, err)}
 		}
 		imageStatus = cvmi.Status
+	case "VirtualMachine":
+		// A VM cloned from another VM requests the capacity of the source VM's
+		// boot disk.
+		srcVM := &vmopv1.VirtualMachine{}
+		if err := h.Client.Get(ctx, client.ObjectKey{Namespace: vm.Namespace, Name: vmiName}, srcVM); err != nil {
+			if apierrors.IsNotFound(err) {
+				return CapacityResponse{Response: webhook.Errored(http.StatusNotFound, err)}
+			}
+			return CapacityResponse{Response: webhook.Errored(http.StatusInternalServerError, err)}
+		}
+		imageStatus.Disks = vmopv1util.ClassicDiskInfo(*srcVM)
 	default:
 		return CapacityResponse{Response: webhook.Errored(http.StatusBadRequest, fmt.Errorf("unsupported image kind %s", vm.Spec.Image.Kind))}
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -46,6 +47,9 @@ const (
 	defaultInterfaceName   = "eth0"
 	defaultNamedNetwork    = "VM Network"
 	defaultCdromNamePrefix = "cdrom"
+
+	cloneVolumesWithoutName   = "cannot clone the volumes of the source VM for a VM without a name, generateName is not supported"
+	cloneVolumeClaimExistsFmt = "cannot clone volume %s, PersistentVolumeClaim %s already exists"
 )
 
 // +kubebuilder:webhook:path=/default-mutate-vmoperator-vmware-com-v1alpha4-virtualmachine,mutating=true,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,verbs=create;update,versions=v1alpha4,name=default.mutating.virtualmachine.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -55,6 +59,7 @@ const (
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
@@ -138,6 +143,11 @@ func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
 				return admission.Denied(err.Error())
 			}
 		}
+		if pkgcfg.FromContext(ctx).Features.VMClone {
+			if _, err := SetCloneVolumesOnCreate(ctx, m.client, modified); err != nil {
+				return admission.Denied(err.Error())
+			}
+		}
 	case admissionv1.Update:
 		oldVM, err := m.vmFromUnstructured(ctx.OldObj)
 		if err != nil {
@@ -407,6 +417,7 @@ func SetDefaultBiosUUID(
 const (
 	vmiKind            = "VirtualMachineImage"
 	cvmiKind           = "Cluster" + vmiKind
+	vmKind             = "VirtualMachine"
 	imgNotFoundFormat  = "no VM image exists for %q in namespace or cluster scope"
 	imgNameNotMatchRef = "must refer to the same resource as spec.image"
 )
@@ -613,3 +624,70 @@ func SetDefaultEncryptionClass(
 	return true, nil
 
 }
+
+// SetCloneVolumesOnCreate copies the PersistentVolumeClaim volumes of the
+// source VM to a VM that is cloned from another VM, unless the VM already
+// specifies volumes. Each copied volume refers to a new claim, named after the
+// VM and the volume, that is created by cloning the source VM's claim.
+//
+// An error is returned if the VM does not have a name, ex. when the VM uses
+// generateName, or if a claim with the name of a copied volume's new claim
+// already exists.
+func SetCloneVolumesOnCreate(
+	ctx *pkgctx.WebhookRequestContext,
+	k8sClient ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) (bool, error) {
+
+	if vm.Spec.Image == nil || vm.Spec.Image.Kind != vmKind {
+		return false, nil
+	}
+
+	// Return early if the VM already specifies its own volumes.
+	if len(vm.Spec.Volumes) > 0 {
+		return false, nil
+	}
+
+	srcVM := &vmopv1.VirtualMachine{}
+	key := ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: vm.Spec.Image.Name}
+	if err := k8sClient.Get(ctx, key, srcVM); err != nil {
+		// The validation webhook reports a missing source VM.
+		return false, ctrlclient.IgnoreNotFound(err)
+	}
+
+	var wasMutated bool
+	for _, vol := range srcVM.Spec.Volumes {
+		pvc := vol.PersistentVolumeClaim
+		if pvc == nil || pvc.InstanceVolumeClaim != nil {
+			// Instance storage volumes are specific to the VM's host and are
+			// not cloned.
+			continue
+		}
+
+		if vm.Name == "" {
+			return false, errors.New(cloneVolumesWithoutName)
+		}
+
+		claimName := vm.Name + "-" + vol.Name
+		claimKey := ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: claimName}
+		if err := k8sClient.Get(ctx, claimKey, &corev1.PersistentVolumeClaim{}); err == nil {
+			return false, fmt.Errorf(cloneVolumeClaimExistsFmt, vol.Name, claimName)
+		} else if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+
+		vm.Spec.Volumes = append(vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+			Name: vol.Name,
+			VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+				PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+						ReadOnly:  pvc.ReadOnly,
+					},
+				},
+			},
+		})
+		wasMutated = true
+	}
+
+	return wasMutated, nil
+}
@@ -1286,4 +1286,116 @@ func unitTestsMutating() {
 			Expect(ctx.vm.Spec.ImageName).To(Equal("vmi-new"))
 		})
 	})
+	Describe("SetCloneVolumesOnCreate", func() {
+		var srcVM *vmopv1.VirtualMachine
+
+		BeforeEach(func() {
+			srcVM = builder.DummyBasicVirtualMachine("src-vm", ctx.vm.Namespace)
+			srcVM.Spec.Volumes = []vmopv1.VirtualMachineVolume{
+				{
+					Name: "data",
+					VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+						PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "src-data",
+							},
+						},
+					},
+				},
+			}
+			srcVM.Spec.Volumes = append(srcVM.Spec.Volumes, builder.DummyInstanceStorageVirtualMachineVolumes()...)
+			Expect(ctx.Client.Create(ctx, srcVM)).To(Succeed())
+
+			ctx.vm.Name = "my-vm"
+			ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+				Kind: "VirtualMachine",
+				Name: srcVM.Name,
+			}
+			ctx.vm.Spec.Volumes = nil
+		})
+
+		It("should copy the source VM's PVC volumes", func() {
+			wasMutated, err := mutation.SetCloneVolumesOnCreate(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(wasMutated).To(BeTrue())
+			Expect(ctx.vm.Spec.Volumes).To(HaveLen(1))
+			Expect(ctx.vm.Spec.Volumes[0].Name).To(Equal("data"))
+			Expect(ctx.vm.Spec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil())
+			Expect(ctx.vm.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("my-vm-data"))
+		})
+
+		When("the VM specifies volumes", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Volumes = builder.DummyVirtualMachine().Spec.Volumes
+			})
+
+			It("should not modify the volumes", func() {
+				volumes := ctx.vm.Spec.Volumes
+				wasMutated, err := mutation.SetCloneVolumesOnCreate(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Volumes).To(Equal(volumes))
+			})
+		})
+
+		When("the VM is deployed from an image", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-1",
+				}
+			})
+
+			It("should not add volumes", func() {
+				wasMutated, err := mutation.SetCloneVolumesOnCreate(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Volumes).To(BeEmpty())
+			})
+		})
+
+		When("the VM does not have a name", func() {
+			BeforeEach(func() {
+				ctx.vm.GenerateName = ctx.vm.Name + "-"
+				ctx.vm.Name = ""
+			})
+
+			It("should return an error", func() {
+				wasMutated, err := mutation.SetCloneVolumesOnCreate(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).To(MatchError(ContainSubstring("generateName is not supported")))
+				Expect(wasMutated).To(BeFalse())
+			})
+		})
+
+		When("the claim of a cloned volume already exists", func() {
+			BeforeEach(func() {
+				Expect(ctx.Client.Create(ctx, &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      ctx.vm.Name + "-data",
+						Namespace: ctx.vm.Namespace,
+					},
+				})).To(Succeed())
+			})
+
+			It("should return an error", func() {
+				wasMutated, err := mutation.SetCloneVolumesOnCreate(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).To(MatchError(
+					"cannot clone volume data, PersistentVolumeClaim " + ctx.vm.Name + "-data already exists"))
+				Expect(wasMutated).To(BeFalse())
+			})
+		})
+
+		When("the source VM does not exist", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Image.Name = "missing-vm"
+			})
+
+			It("should not add volumes", func() {
+				wasMutated, err := mutation.SetCloneVolumesOnCreate(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Volumes).To(BeEmpty())
+			})
+		})
+	})
 }
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
@@ -40,6 +42,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	cloudinitvalidate "github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit/validate"
@@ -57,9 +60,14 @@ const (
 
 	vmiKind  = "VirtualMachineImage"
 	cvmiKind = "ClusterVirtualMachineImage"
+	vmKind   = "VirtualMachine"
 
 	readinessProbeOnlyOneAction              = "only one action can be specified"
 	tcpReadinessProbeNotAllowedVPC           = "VPC networking doesn't allow TCP readiness probe to be specified"
+	httpGetReadinessProbeNotAllowedVPC       = "VPC networking doesn't allow HTTPGet readiness probe to be specified"
+	tcpLivenessProbeNotAllowedVPC            = "VPC networking doesn't allow TCP liveness probe to be specified"
+	httpGetLivenessProbeNotAllowedVPC        = "VPC networking doesn't allow HTTPGet liveness probe to be specified"
+	invalidHTTPStatusCodeRange               = "min must be less than or equal to max"
 	updatesNotAllowedWhenPowerOn             = "updates to this field is not allowed when VM power is on"
 	storageClassNotFoundFmt                  = "Storage policy %s does not exist"
 	storageClassNotAssignedFmt               = "Storage policy is not associated with the namespace %s"
@@ -81,6 +89,26 @@ const (
 	invalidZone                              = "cannot use zone that is being deleted"
 	restrictedToPrivUsers                    = "restricted to privileged users"
 	invalidPVCBYOKFmt                        = "cannot attach volume to vm with spec.crypto.encryptionClassName=%q"
+	invalidCloneSourceSelf                   = "cannot clone a VM from itself"
+	invalidCloneSourceNotCreated             = "source VM has not been created"
+	invalidCloneWithoutVMImage               = "may only be set when spec.image.kind is " + vmKind
+	invalidBootDiskCapacityLinkedClone       = "cannot resize the boot disk of a linked clone"
+	invalidBootDiskCapacityShrink            = "cannot be decreased"
+	invalidBootDiskCapacitySnapshots         = "cannot resize the boot disk of a VM with snapshots"
+	invalidVolumeUnitNumberRangeFmt          = "must be less than %d for a %s controller"
+	invalidVolumeUnitNumberSCSIController    = "unit number 7 is reserved for the SCSI controller"
+	invalidVolumeMultiWriterSATA             = "MultiWriter sharing mode is not supported for a SATA controller"
+	invalidVolumeMultiWriterBusSharing       = "MultiWriter sharing mode is not supported for a controller whose bus is shared"
+	invalidVolumeDuplicateUnitNumber         = "controller type, bus number, and unit number must be unique"
+	invalidVolumeDiskPlacementPowerState     = "cannot change the controller, unit number, or sharing mode of a volume unless the VM is powered off, since CNS selects the controller and unit number of a disk attached to a powered on VM and the disk cannot be moved until the VM is powered off"
+	invalidVolumeControllerSharingMode       = "may only be specified for a SCSI controller"
+	invalidVolumeSharedPVCAccessModeFmt      = "PVC %s must have the ReadWriteMany access mode and the Block volume mode to be shared"
+	invalidVolumeSharedPVCInstanceStorage    = "instance storage volumes cannot be shared"
+	invalidVolumeSharedSharingModeFmt        = "PVC %s is used by VM %s with a different sharing mode"
+	invalidVolumeSharedZoneFmt               = "PVC %s is used by VM %s in zone %s"
+	invalidVolumeSharedControllerFmt         = "PVC %s is used by VM %s with a different controller type or controller sharing mode"
+	invalidStorageIOReservationExceedsLimit  = "must not exceed the limit"
+	invalidStorageIOSharesNotCustom          = "may only be specified when level is Custom"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha4,name=default.validating.virtualmachine.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -131,6 +159,7 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 
 	fieldErrs = append(fieldErrs, v.validateAvailabilityZone(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateImageOnCreate(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateCloneOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateClassOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateStorageClass(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm)...)
@@ -139,6 +168,7 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validatePowerStateOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnCreate(ctx, vm)...)
@@ -147,6 +177,7 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateNetworkHostAndDomainName(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateMinHardwareVersion(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateCdrom(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateCurrentSnapshot(ctx, vm, nil)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -196,6 +227,7 @@ func (v validator) validateImageOnUpdate(ctx *pkgctx.WebhookRequestContext, vm,
 // Changes to following fields are not allowed:
 //   - Image
 //   - ImageName
+//   - Clone
 //   - StorageClass
 //   - ResourcePolicyName
 //   - Minimum VM Hardware Version
@@ -236,15 +268,19 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateBootstrap(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNetwork(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateVolumeDiskPlacementOnUpdate(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateBootDiskCapacityOnUpdate(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnUpdate(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateMinHardwareVersion(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateLabel(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateNetworkHostAndDomainName(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateCdrom(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateCurrentSnapshot(ctx, vm, oldVM)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -437,6 +473,8 @@ func (v validator) validateImageOnCreate(ctx *pkgctx.WebhookRequestContext, vm *
 		allErrs = append(allErrs, field.Required(f, ""))
 	case vm.Spec.Image.Kind == "":
 		allErrs = append(allErrs, field.Required(f.Child("kind"), invalidImageKind))
+	case vm.Spec.Image.Kind == vmKind && pkgcfg.FromContext(ctx).Features.VMClone:
+		allErrs = append(allErrs, v.validateCloneSource(ctx, vm)...)
 	case vm.Spec.Image.Kind != vmiKind && vm.Spec.Image.Kind != cvmiKind:
 		allErrs = append(allErrs, field.Invalid(f.Child("kind"), vm.Spec.Image.Kind, invalidImageKind))
 	}
@@ -444,6 +482,59 @@ func (v validator) validateImageOnCreate(ctx *pkgctx.WebhookRequestContext, vm *
 	return allErrs
 }
 
+// validateCloneSource validates the source VM of a VM that is cloned from
+// another VM.
+func (v validator) validateCloneSource(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	f := field.NewPath("spec", "image", "name")
+	srcName := vm.Spec.Image.Name
+
+	if srcName == vm.Name {
+		return field.ErrorList{field.Invalid(f, srcName, invalidCloneSourceSelf)}
+	}
+
+	srcVM := &vmopv1.VirtualMachine{}
+	key := ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: srcName}
+	if err := v.client.Get(ctx, key, srcVM); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(f, srcName)}
+		}
+		return field.ErrorList{field.InternalError(f, err)}
+	}
+
+	if srcVM.Status.UniqueID == "" {
+		return field.ErrorList{field.Invalid(f, srcName, invalidCloneSourceNotCreated)}
+	}
+
+	return nil
+}
+
+func (v validator) validateCloneOnCreate(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	clone := vm.Spec.Clone
+	if clone == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	f := field.NewPath("spec", "clone")
+
+	if !pkgcfg.FromContext(ctx).Features.VMClone {
+		return append(allErrs, field.Forbidden(f, fmt.Sprintf(featureNotEnabled, "VM Clone")))
+	}
+
+	if vm.Spec.Image == nil || vm.Spec.Image.Kind != vmKind {
+		allErrs = append(allErrs, field.Forbidden(f, invalidCloneWithoutVMImage))
+	}
+
+	if clone.Mode == vmopv1.VirtualMachineCloneModeLinked {
+		if adv := vm.Spec.Advanced; adv != nil && adv.BootDiskCapacity != nil && !adv.BootDiskCapacity.IsZero() {
+			allErrs = append(allErrs, field.Forbidden(
+				field.NewPath("spec", "advanced", "bootDiskCapacity"), invalidBootDiskCapacityLinkedClone))
+		}
+	}
+
+	return allErrs
+}
+
 func (v validator) validateClassOnCreate(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -629,9 +720,183 @@ func (v validator) validateNetwork(ctx *pkgctx.WebhookRequestContext, vm *vmopv1
 		}
 	}
 
+	allErrs = append(allErrs, v.validateNetworkDevices(networkPath, vm)...)
+
+	return allErrs
+}
+
+// validateNetworkDevices validates the bonds, VLANs, and bridges that are
+// configured in the guest on top of the VM's network interfaces.
+func (v validator) validateNetworkDevices(
+	networkPath *field.Path,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	networkSpec := vm.Spec.Network
+	if len(networkSpec.Bonds) == 0 && len(networkSpec.VLANs) == 0 && len(networkSpec.Bridges) == 0 {
+		return allErrs
+	}
+
+	if vm.Spec.Bootstrap == nil || vm.Spec.Bootstrap.CloudInit == nil {
+		for _, d := range []struct {
+			child string
+			count int
+		}{
+			{"bonds", len(networkSpec.Bonds)},
+			{"vlans", len(networkSpec.VLANs)},
+			{"bridges", len(networkSpec.Bridges)},
+		} {
+			if d.count > 0 {
+				allErrs = append(allErrs, field.Invalid(
+					networkPath.Child(d.child),
+					// Not exposing the devices here in error message
+					d.child,
+					d.child+" is available only with the following bootstrap providers: CloudInit",
+				))
+			}
+		}
+	}
+
+	var (
+		interfaceNames = map[string]struct{}{}
+		bondNames      = map[string]struct{}{}
+		vlanNames      = map[string]struct{}{}
+		deviceNames    = map[string]struct{}{}
+		// memberNames is the set of interfaces, bonds, and VLANs that are
+		// members of a bond or ports of a bridge.
+		memberNames = map[string]struct{}{}
+	)
+
+	for _, interfaceSpec := range networkSpec.Interfaces {
+		interfaceNames[interfaceSpec.Name] = struct{}{}
+		deviceNames[interfaceSpec.Name] = struct{}{}
+	}
+
+	validateDeviceName := func(p *field.Path, name string) field.ErrorList {
+		if _, ok := deviceNames[name]; ok {
+			return field.ErrorList{field.Duplicate(p.Child("name"), name)}
+		}
+		deviceNames[name] = struct{}{}
+		return nil
+	}
+
+	validateMember := func(p *field.Path, name string) field.ErrorList {
+		if _, ok := memberNames[name]; ok {
+			return field.ErrorList{field.Invalid(p, name, "is already a member of another bond or bridge")}
+		}
+		memberNames[name] = struct{}{}
+		return nil
+	}
+
+	for i, bond := range networkSpec.Bonds {
+		p := networkPath.Child("bonds").Index(i)
+
+		allErrs = append(allErrs, validateDeviceName(p, bond.Name)...)
+		bondNames[bond.Name] = struct{}{}
+
+		for j, name := range bond.Interfaces {
+			pj := p.Child("interfaces").Index(j)
+			if _, ok := interfaceNames[name]; !ok {
+				allErrs = append(allErrs, field.Invalid(pj, name, "must be the name of a network interface"))
+				continue
+			}
+			allErrs = append(allErrs, validateMember(pj, name)...)
+		}
+
+		if bond.Primary != "" {
+			if bond.Mode != "active-backup" {
+				allErrs = append(allErrs, field.Invalid(p.Child("primary"), bond.Primary,
+					"primary is only valid when mode is active-backup"))
+			} else if !slices.Contains(bond.Interfaces, bond.Primary) {
+				allErrs = append(allErrs, field.Invalid(p.Child("primary"), bond.Primary,
+					"must be the name of a network interface in the bond"))
+			}
+		}
+
+		if bond.LACPRate != "" && bond.Mode != "802.3ad" {
+			allErrs = append(allErrs, field.Invalid(p.Child("lacpRate"), bond.LACPRate,
+				"lacpRate is only valid when mode is 802.3ad"))
+		}
+
+		if bond.TransmitHashPolicy != "" && bond.Mode != "balance-xor" && bond.Mode != "802.3ad" {
+			allErrs = append(allErrs, field.Invalid(p.Child("transmitHashPolicy"), bond.TransmitHashPolicy,
+				"transmitHashPolicy is only valid when mode is balance-xor or 802.3ad"))
+		}
+
+		allErrs = append(allErrs, validateNetworkDeviceIPConfig(p, bond.VirtualMachineNetworkDeviceIPSpec)...)
+	}
+
+	type vlanLinkID struct {
+		link string
+		id   int32
+	}
+	vlanLinkIDs := map[vlanLinkID]struct{}{}
+
+	for i, vlan := range networkSpec.VLANs {
+		p := networkPath.Child("vlans").Index(i)
+
+		allErrs = append(allErrs, validateDeviceName(p, vlan.Name)...)
+		vlanNames[vlan.Name] = struct{}{}
+
+		_, isInterface := interfaceNames[vlan.Link]
+		_, isBond := bondNames[vlan.Link]
+		if !isInterface && !isBond {
+			allErrs = append(allErrs, field.Invalid(p.Child("link"), vlan.Link,
+				"must be the name of a network interface or bond"))
+		} else if _, ok := memberNames[vlan.Link]; ok {
+			allErrs = append(allErrs, field.Invalid(p.Child("link"), vlan.Link,
+				"cannot be a member of a bond"))
+		}
+
+		key := vlanLinkID{link: vlan.Link, id: vlan.ID}
+		if _, ok := vlanLinkIDs[key]; ok {
+			allErrs = append(allErrs, field.Duplicate(p.Child("id"), vlan.ID))
+		}
+		vlanLinkIDs[key] = struct{}{}
+
+		allErrs = append(allErrs, validateNetworkDeviceIPConfig(p, vlan.VirtualMachineNetworkDeviceIPSpec)...)
+	}
+
+	for i, bridge := range networkSpec.Bridges {
+		p := networkPath.Child("bridges").Index(i)
+
+		allErrs = append(allErrs, validateDeviceName(p, bridge.Name)...)
+
+		for j, name := range bridge.Interfaces {
+			pj := p.Child("interfaces").Index(j)
+			_, isInterface := interfaceNames[name]
+			_, isBond := bondNames[name]
+			_, isVLAN := vlanNames[name]
+			if !isInterface && !isBond && !isVLAN {
+				allErrs = append(allErrs, field.Invalid(pj, name,
+					"must be the name of a network interface, bond, or VLAN"))
+				continue
+			}
+			allErrs = append(allErrs, validateMember(pj, name)...)
+		}
+
+		allErrs = append(allErrs, validateNetworkDeviceIPConfig(p, bridge.VirtualMachineNetworkDeviceIPSpec)...)
+	}
+
 	return allErrs
 }
 
+func validateNetworkDeviceIPConfig(
+	path *field.Path,
+	ipSpec vmopv1.VirtualMachineNetworkDeviceIPSpec) field.ErrorList {
+
+	return validateNetworkIPConfig(
+		path,
+		ipSpec.Addresses,
+		ipSpec.DHCP4,
+		ipSpec.DHCP6,
+		ipSpec.Gateway4,
+		ipSpec.Gateway6,
+		ipSpec.Nameservers,
+		ipSpec.Routes)
+}
+
 func (v validator) validateNetworkInterfaceSpec(
 	interfacePath *field.Path,
 	interfaceSpec vmopv1.VirtualMachineNetworkInterfaceSpec,
@@ -656,15 +921,46 @@ func (v validator) validateNetworkInterfaceSpec(
 		allErrs = append(allErrs, field.Invalid(interfacePath.Child("name"), networkIfCRName, "is the resulting network interface name: "+msg))
 	}
 
+	allErrs = append(allErrs, validateNetworkIPConfig(
+		interfacePath,
+		interfaceSpec.Addresses,
+		interfaceSpec.DHCP4,
+		interfaceSpec.DHCP6,
+		interfaceSpec.Gateway4,
+		interfaceSpec.Gateway6,
+		interfaceSpec.Nameservers,
+		interfaceSpec.Routes)...)
+
+	return allErrs
+}
+
+// validateNetworkIPConfig validates the IP configuration of a network interface,
+// bond, VLAN, or bridge.
+func validateNetworkIPConfig(
+	path *field.Path,
+	addresses []string,
+	dhcp4, dhcp6 bool,
+	gateway4, gateway6 string,
+	nameservers []string,
+	routes []vmopv1.VirtualMachineNetworkRouteSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
 	var ipv4Addrs, ipv6Addrs []string
-	for i, ipCIDR := range interfaceSpec.Addresses {
+	for i, ipCIDR := range addresses {
 		ip, _, err := net.ParseCIDR(ipCIDR)
 		if err != nil {
-			p := interfacePath.Child("addresses").Index(i)
+			p := path.Child("addresses").Index(i)
 			allErrs = append(allErrs, field.Invalid(p, ipCIDR, err.Error()))
 			continue
 		}
 
+		if ip.To4() != nil && strings.Contains(ipCIDR, ":") {
+			p := path.Child("addresses").Index(i)
+			allErrs = append(allErrs, field.Invalid(p, ipCIDR, "IPv4-mapped IPv6 addresses are not supported"))
+			continue
+		}
+
 		if ip.To4() != nil {
 			ipv4Addrs = append(ipv4Addrs, ipCIDR)
 		} else {
@@ -672,67 +968,67 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
-	if ipv4 := interfaceSpec.Gateway4; ipv4 != "" {
-		p := interfacePath.Child("gateway4")
+	if gateway4 != "" {
+		p := path.Child("gateway4")
 
 		if len(ipv4Addrs) == 0 {
-			allErrs = append(allErrs, field.Invalid(p, ipv4, "gateway4 must have an IPv4 address in the addresses field"))
+			allErrs = append(allErrs, field.Invalid(p, gateway4, "gateway4 must have an IPv4 address in the addresses field"))
 		}
 
-		if ip := net.ParseIP(ipv4); ip == nil || ip.To4() == nil {
-			allErrs = append(allErrs, field.Invalid(p, ipv4, "must be a valid IPv4 address"))
+		if ip := net.ParseIP(gateway4); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(p, gateway4, "must be a valid IPv4 address"))
 		}
 	}
 
-	if ipv6 := interfaceSpec.Gateway6; ipv6 != "" {
-		p := interfacePath.Child("gateway6")
+	if gateway6 != "" {
+		p := path.Child("gateway6")
 
 		if len(ipv6Addrs) == 0 {
-			allErrs = append(allErrs, field.Invalid(p, ipv6, "gateway6 must have an IPv6 address in the addresses field"))
+			allErrs = append(allErrs, field.Invalid(p, gateway6, "gateway6 must have an IPv6 address in the addresses field"))
 		}
 
-		if ip := net.ParseIP(ipv6); ip == nil || ip.To16() == nil || ip.To4() != nil {
-			allErrs = append(allErrs, field.Invalid(p, ipv6, "must be a valid IPv6 address"))
+		if ip := net.ParseIP(gateway6); ip == nil || ip.To16() == nil || ip.To4() != nil {
+			allErrs = append(allErrs, field.Invalid(p, gateway6, "must be a valid IPv6 address"))
 		}
 	}
 
-	if interfaceSpec.DHCP4 {
+	if dhcp4 {
 		if len(ipv4Addrs) > 0 {
-			p := interfacePath.Child("dhcp4")
+			p := path.Child("dhcp4")
 			allErrs = append(allErrs, field.Invalid(p, strings.Join(ipv4Addrs, ","),
 				"dhcp4 cannot be used with IPv4 addresses in addresses field"))
 		}
 
-		if gw := interfaceSpec.Gateway4; gw != "" {
-			p := interfacePath.Child("gateway4")
-			allErrs = append(allErrs, field.Invalid(p, gw, "gateway4 is mutually exclusive with dhcp4"))
+		if gateway4 != "" {
+			p := path.Child("gateway4")
+			allErrs = append(allErrs, field.Invalid(p, gateway4, "gateway4 is mutually exclusive with dhcp4"))
 		}
 	}
 
-	if interfaceSpec.DHCP6 {
+	if dhcp6 {
 		if len(ipv6Addrs) > 0 {
-			p := interfacePath.Child("dhcp6")
+			p := path.Child("dhcp6")
 			allErrs = append(allErrs, field.Invalid(p, strings.Join(ipv6Addrs, ","),
 				"dhcp6 cannot be used with IPv6 addresses in addresses field"))
 		}
 
-		if gw := interfaceSpec.Gateway6; gw != "" {
-			p := interfacePath.Child("gateway6")
-			allErrs = append(allErrs, field.Invalid(p, gw, "gateway6 is mutually exclusive with dhcp6"))
+		if gateway6 != "" {
+			p := path.Child("gateway6")
+			allErrs = append(allErrs, field.Invalid(p, gateway6, "gateway6 is mutually exclusive with dhcp6"))
 		}
 	}
 
-	for i, n := range interfaceSpec.Nameservers {
+	for i, n := range nameservers {
 		if net.ParseIP(n) == nil {
 			allErrs = append(allErrs,
-				field.Invalid(interfacePath.Child("nameservers").Index(i), n, "must be an IPv4 or IPv6 address"))
+				field.Invalid(path.Child("nameservers").Index(i), n, "must be an IPv4 or IPv6 address"))
 		}
 	}
 
-	if len(interfaceSpec.Routes) > 0 {
-		p := interfacePath.Child("routes")
+	if len(routes) > 0 {
+		p := path.Child("routes")
 
-		for i, r := range interfaceSpec.Routes {
+		for i, r := range routes {
 			ip, _, err := net.ParseCIDR(r.To)
 			if err != nil {
 				allErrs = append(allErrs, field.Invalid(p.Index(i).Child("to"), r.To, err.Error()))
@@ -828,11 +1124,13 @@ func (v validator) validateNetworkInterfaceSpecWithBootstrap(
 
 	var (
 		cloudInit *vmopv1.VirtualMachineBootstrapCloudInitSpec
+		linuxPrep *vmopv1.VirtualMachineBootstrapLinuxPrepSpec
 		sysPrep   *vmopv1.VirtualMachineBootstrapSysprepSpec
 	)
 
 	if vm.Spec.Bootstrap != nil {
 		cloudInit = vm.Spec.Bootstrap.CloudInit
+		linuxPrep = vm.Spec.Bootstrap.LinuxPrep
 		sysPrep = vm.Spec.Bootstrap.Sysprep
 	}
 
@@ -867,6 +1165,16 @@ func (v validator) validateNetworkInterfaceSpecWithBootstrap(
 		}
 	}
 
+	if interfaceSpec.SLAAC {
+		if cloudInit == nil && linuxPrep == nil && sysPrep == nil {
+			allErrs = append(allErrs, field.Invalid(
+				interfacePath.Child("slaac"),
+				interfaceSpec.SLAAC,
+				"slaac is available only with the following bootstrap providers: CloudInit, LinuxPrep, and Sysprep",
+			))
+		}
+	}
+
 	if nameservers := interfaceSpec.Nameservers; len(nameservers) > 0 {
 		if cloudInit == nil && sysPrep == nil {
 			allErrs = append(allErrs, field.Invalid(
@@ -894,6 +1202,7 @@ func (v validator) validateVolumes(ctx *pkgctx.WebhookRequestContext, vm *vmopv1
 	var allErrs field.ErrorList
 	volumesPath := field.NewPath("spec", "volumes")
 	volumeNames := map[string]bool{}
+	unitNumbers := map[string]bool{}
 
 	for i, vol := range vm.Spec.Volumes {
 		volPath := volumesPath.Index(i)
@@ -920,11 +1229,275 @@ func (v validator) validateVolumes(ctx *pkgctx.WebhookRequestContext, vm *vmopv1
 		} else {
 			allErrs = append(allErrs, v.validateVolumeWithPVC(ctx, vm, vol, volPath)...)
 		}
+
+		allErrs = append(allErrs, v.validateVolumeDiskPlacement(vol, volPath)...)
+		allErrs = append(allErrs, v.validateVolumeStorageIO(vol, volPath)...)
+		allErrs = append(allErrs, v.validateSharedVolume(ctx, vm, vol, volPath)...)
+
+		if vol.UnitNumber != nil && vol.ControllerBusNumber != nil {
+			key := fmt.Sprintf("%s:%d:%d", vol.ControllerType, *vol.ControllerBusNumber, *vol.UnitNumber)
+			if unitNumbers[key] {
+				allErrs = append(allErrs, field.Invalid(volPath.Child("unitNumber"), *vol.UnitNumber, invalidVolumeDuplicateUnitNumber))
+			} else {
+				unitNumbers[key] = true
+			}
+		}
 	}
 
 	return allErrs
 }
 
+// validateVolumeDiskPlacementOnUpdate disallows changes to the placement of a
+// volume's disk unless the VM is powered off or will be powered off. Disks
+// are attached by CNS, which selects the controller and unit number on its
+// own, so the disks are only moved to their requested placement when the VM
+// is powered on.
+func (v validator) validateVolumeDiskPlacementOnUpdate(
+	_ *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	if oldVM.Spec.PowerState == vmopv1.VirtualMachinePowerStateOff ||
+		vm.Spec.PowerState == vmopv1.VirtualMachinePowerStateOff {
+
+		return nil
+	}
+
+	oldVolumes := make(map[string]vmopv1.VirtualMachineVolume, len(oldVM.Spec.Volumes))
+	for _, vol := range oldVM.Spec.Volumes {
+		oldVolumes[vol.Name] = vol
+	}
+
+	var allErrs field.ErrorList
+	volumesPath := field.NewPath("spec", "volumes")
+
+	for i, vol := range vm.Spec.Volumes {
+		if !virtualmachine.HasVolumeDiskPlacement(vol) {
+			continue
+		}
+		if oldVol, ok := oldVolumes[vol.Name]; ok && equalVolumeDiskPlacement(vol, oldVol) {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(volumesPath.Index(i), invalidVolumeDiskPlacementPowerState))
+	}
+
+	return allErrs
+}
+
+func equalVolumeDiskPlacement(a, b vmopv1.VirtualMachineVolume) bool {
+	return a.ControllerType == b.ControllerType &&
+		ptr.Equal(a.ControllerBusNumber, b.ControllerBusNumber) &&
+		ptr.Equal(a.UnitNumber, b.UnitNumber) &&
+		a.SharingMode == b.SharingMode &&
+		a.ControllerSharingMode == b.ControllerSharingMode
+}
+
+func (v validator) validateVolumeDiskPlacement(
+	vol vmopv1.VirtualMachineVolume,
+	volPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if vol.ControllerType == "" && vol.ControllerBusNumber != nil {
+		allErrs = append(allErrs, field.Required(volPath.Child("controllerType"),
+			"when controllerBusNumber is specified"))
+	}
+
+	if vol.UnitNumber != nil {
+		unitNumberPath := volPath.Child("unitNumber")
+
+		if vol.ControllerType == "" {
+			allErrs = append(allErrs, field.Required(volPath.Child("controllerType"),
+				"when unitNumber is specified"))
+		}
+		if vol.ControllerBusNumber == nil {
+			allErrs = append(allErrs, field.Required(volPath.Child("controllerBusNumber"),
+				"when unitNumber is specified"))
+		}
+
+		if vol.ControllerType != "" {
+			if maxUnitNumber := vol.ControllerType.MaxUnitNumber(); *vol.UnitNumber >= maxUnitNumber {
+				allErrs = append(allErrs, field.Invalid(unitNumberPath, *vol.UnitNumber,
+					fmt.Sprintf(invalidVolumeUnitNumberRangeFmt, maxUnitNumber, vol.ControllerType)))
+			}
+			if vol.ControllerType == vmopv1.VirtualControllerTypeSCSI && *vol.UnitNumber == 7 {
+				allErrs = append(allErrs, field.Invalid(unitNumberPath, *vol.UnitNumber,
+					invalidVolumeUnitNumberSCSIController))
+			}
+		}
+	}
+
+	if vol.SharingMode == vmopv1.VolumeSharingModeMultiWriter &&
+		vol.ControllerType == vmopv1.VirtualControllerTypeSATA {
+
+		allErrs = append(allErrs, field.Invalid(volPath.Child("sharingMode"), vol.SharingMode,
+			invalidVolumeMultiWriterSATA))
+	}
+
+	if vol.SharingMode == vmopv1.VolumeSharingModeMultiWriter &&
+		(vol.ControllerSharingMode == vmopv1.VirtualControllerSharingModePhysical ||
+			vol.ControllerSharingMode == vmopv1.VirtualControllerSharingModeVirtual) {
+
+		allErrs = append(allErrs, field.Invalid(volPath.Child("sharingMode"), vol.SharingMode,
+			invalidVolumeMultiWriterBusSharing))
+	}
+
+	if vol.ControllerSharingMode != "" {
+		if vol.ControllerType != vmopv1.VirtualControllerTypeSCSI {
+			allErrs = append(allErrs, field.Invalid(volPath.Child("controllerSharingMode"),
+				vol.ControllerSharingMode, invalidVolumeControllerSharingMode))
+		}
+		if vol.ControllerBusNumber == nil {
+			allErrs = append(allErrs, field.Required(volPath.Child("controllerBusNumber"),
+				"when controllerSharingMode is specified"))
+		}
+	}
+
+	return allErrs
+}
+
+func (v validator) validateVolumeStorageIO(
+	vol vmopv1.VirtualMachineVolume,
+	volPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if vol.StorageIO == nil {
+		return allErrs
+	}
+
+	storageIOPath := volPath.Child("storageIO")
+
+	if l, r := vol.StorageIO.Limit, vol.StorageIO.Reservation; l != nil && r != nil && int64(*r) > *l {
+		allErrs = append(allErrs, field.Invalid(storageIOPath.Child("reservation"), *r,
+			invalidStorageIOReservationExceedsLimit))
+	}
+
+	if s := vol.StorageIO.Shares; s != nil &&
+		s.Level != vmopv1.VirtualMachineStorageIOSharesLevelCustom && s.Shares != 0 {
+
+		allErrs = append(allErrs, field.Forbidden(storageIOPath.Child("shares", "shares"),
+			invalidStorageIOSharesNotCustom))
+	}
+
+	return allErrs
+}
+
+// validateSharedVolume validates a volume that may be attached to multiple
+// VMs, i.e. a volume with the MultiWriter sharing mode or a controller whose
+// bus is shared. The volume's PVC must support being attached to multiple VMs,
+// and all of the VMs that use the PVC must share it the same way and attach
+// it to compatible controllers. The VMs must also be in the same zone, which
+// is only validated once the VM's zone is known. Until then, the VM is placed
+// in the zone of the other VMs.
+func (v validator) validateSharedVolume(
+	ctx *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine,
+	vol vmopv1.VirtualMachineVolume,
+	volPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	pvcSource := vol.PersistentVolumeClaim
+	if pvcSource == nil || pvcSource.ClaimName == "" {
+		return allErrs
+	}
+
+	if !vmopv1util.IsSharedVolume(vol) {
+		return allErrs
+	}
+
+	sharingPath, sharingValue := volPath.Child("sharingMode"), any(vol.SharingMode)
+	if vol.SharingMode != vmopv1.VolumeSharingModeMultiWriter {
+		sharingPath, sharingValue = volPath.Child("controllerSharingMode"), vol.ControllerSharingMode
+	}
+
+	if pvcSource.InstanceVolumeClaim != nil {
+		return append(allErrs, field.Invalid(sharingPath, sharingValue,
+			invalidVolumeSharedPVCInstanceStorage))
+	}
+
+	// The PVC may be created after the VM, in which case the volume
+	// controller waits for the PVC to be bound before attaching it.
+	pvc := &corev1.PersistentVolumeClaim{}
+	key := ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: pvcSource.ClaimName}
+	if err := v.client.Get(ctx, key, pvc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.InternalError(volPath, err))
+		}
+	} else if !slices.Contains(pvc.Spec.AccessModes, corev1.ReadWriteMany) ||
+		pvc.Spec.VolumeMode == nil || *pvc.Spec.VolumeMode != corev1.PersistentVolumeBlock {
+
+		allErrs = append(allErrs, field.Invalid(sharingPath, sharingValue,
+			fmt.Sprintf(invalidVolumeSharedPVCAccessModeFmt, pvcSource.ClaimName)))
+	}
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := v.client.List(
+		ctx,
+		vmList,
+		ctrlclient.InNamespace(vm.Namespace),
+		ctrlclient.MatchingFields{vmopv1util.PVCClaimNameIndex: pvcSource.ClaimName}); err != nil {
+
+		return append(allErrs, field.InternalError(volPath, err))
+	}
+
+	zone := vm.Labels[topology.KubernetesTopologyZoneLabelKey]
+
+	for i := range vmList.Items {
+		otherVM := &vmList.Items[i]
+		if otherVM.Name == vm.Name || !otherVM.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for _, otherVol := range otherVM.Spec.Volumes {
+			if otherVol.PersistentVolumeClaim == nil ||
+				otherVol.PersistentVolumeClaim.ClaimName != pvcSource.ClaimName {
+
+				continue
+			}
+
+			if !vmopv1util.IsSharedVolume(otherVol) ||
+				volumeSharingMode(otherVol) != volumeSharingMode(vol) {
+
+				allErrs = append(allErrs, field.Invalid(sharingPath, sharingValue,
+					fmt.Sprintf(invalidVolumeSharedSharingModeFmt, pvcSource.ClaimName, otherVM.Name)))
+				continue
+			}
+
+			if otherZone := otherVM.Labels[topology.KubernetesTopologyZoneLabelKey]; zone != "" &&
+				otherZone != "" && otherZone != zone {
+
+				allErrs = append(allErrs, field.Invalid(volPath.Child("persistentVolumeClaim", "claimName"),
+					pvcSource.ClaimName,
+					fmt.Sprintf(invalidVolumeSharedZoneFmt, pvcSource.ClaimName, otherVM.Name, otherZone)))
+			}
+
+			if otherVol.ControllerType != vol.ControllerType ||
+				controllerSharingMode(otherVol) != controllerSharingMode(vol) {
+
+				allErrs = append(allErrs, field.Invalid(volPath.Child("controllerType"), vol.ControllerType,
+					fmt.Sprintf(invalidVolumeSharedControllerFmt, pvcSource.ClaimName, otherVM.Name)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func volumeSharingMode(vol vmopv1.VirtualMachineVolume) vmopv1.VolumeSharingMode {
+	if vol.SharingMode == "" {
+		return vmopv1.VolumeSharingModeNone
+	}
+	return vol.SharingMode
+}
+
+func controllerSharingMode(vol vmopv1.VirtualMachineVolume) vmopv1.VirtualControllerSharingMode {
+	if vol.ControllerSharingMode == "" {
+		return vmopv1.VirtualControllerSharingModeNone
+	}
+	return vol.ControllerSharingMode
+}
+
 func (v validator) validateVolumeWithPVC(
 	_ *pkgctx.WebhookRequestContext,
 	_ *vmopv1.VirtualMachine,
@@ -983,6 +1556,9 @@ func (v validator) validateReadinessProbe(ctx *pkgctx.WebhookRequestContext, vm
 	if probe.TCPSocket != nil {
 		actionsCnt++
 	}
+	if probe.HTTPGet != nil {
+		actionsCnt++
+	}
 	if probe.GuestHeartbeat != nil {
 		actionsCnt++
 	}
@@ -994,21 +1570,97 @@ func (v validator) validateReadinessProbe(ctx *pkgctx.WebhookRequestContext, vm
 	}
 
 	if probe.TCPSocket != nil {
-		tcpSocketPath := readinessProbePath.Child("tcpSocket")
-
-		// TCP readiness probe is not allowed under VPC Networking
-		if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
-			allErrs = append(allErrs, field.Forbidden(tcpSocketPath, tcpReadinessProbeNotAllowedVPC))
-		} else if probe.TCPSocket.Port.IntValue() != allowedRestrictedNetworkTCPProbePort {
-			// Validate port if environment is a restricted network environment between SV CP VMs and Workload VMs e.g. VMC.
-			isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
-			if err != nil {
-				allErrs = append(allErrs, field.Forbidden(tcpSocketPath, err.Error()))
-			} else if isRestrictedEnv {
-				allErrs = append(allErrs,
-					field.NotSupported(tcpSocketPath.Child("port"), probe.TCPSocket.Port.IntValue(),
-						[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
-			}
+		allErrs = append(allErrs, v.validateNetworkProbePort(
+			ctx, readinessProbePath.Child("tcpSocket"), probe.TCPSocket.Port, tcpReadinessProbeNotAllowedVPC)...)
+	}
+
+	if probe.HTTPGet != nil {
+		allErrs = append(allErrs, v.validateHTTPGetProbe(
+			ctx, readinessProbePath.Child("httpGet"), probe.HTTPGet, httpGetReadinessProbeNotAllowedVPC)...)
+	}
+
+	return allErrs
+}
+
+func (v validator) validateLivenessProbe(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	probe := vm.Spec.LivenessProbe
+	if probe == nil {
+		return allErrs
+	}
+
+	livenessProbePath := field.NewPath("spec", "livenessProbe")
+
+	actionsCnt := 0
+	if probe.TCPSocket != nil {
+		actionsCnt++
+	}
+	if probe.HTTPGet != nil {
+		actionsCnt++
+	}
+	if probe.GuestHeartbeat != nil {
+		actionsCnt++
+	}
+	if len(probe.GuestInfo) != 0 {
+		actionsCnt++
+	}
+	if actionsCnt > 1 {
+		allErrs = append(allErrs, field.Forbidden(livenessProbePath, readinessProbeOnlyOneAction))
+	}
+
+	if probe.TCPSocket != nil {
+		allErrs = append(allErrs, v.validateNetworkProbePort(
+			ctx, livenessProbePath.Child("tcpSocket"), probe.TCPSocket.Port, tcpLivenessProbeNotAllowedVPC)...)
+	}
+
+	if probe.HTTPGet != nil {
+		allErrs = append(allErrs, v.validateHTTPGetProbe(
+			ctx, livenessProbePath.Child("httpGet"), probe.HTTPGet, httpGetLivenessProbeNotAllowedVPC)...)
+	}
+
+	return allErrs
+}
+
+// validateHTTPGetProbe validates the HTTPGet action of a probe.
+func (v validator) validateHTTPGetProbe(
+	ctx *pkgctx.WebhookRequestContext,
+	httpGetPath *field.Path,
+	action *vmopv1.HTTPGetAction,
+	vpcNotAllowedMsg string) field.ErrorList {
+
+	allErrs := v.validateNetworkProbePort(ctx, httpGetPath, action.Port, vpcNotAllowedMsg)
+
+	if r := action.SuccessStatusCodes; r != nil && r.Min > r.Max {
+		allErrs = append(allErrs, field.Invalid(httpGetPath.Child("successStatusCodes"),
+			fmt.Sprintf("%d-%d", r.Min, r.Max), invalidHTTPStatusCodeRange))
+	}
+
+	return allErrs
+}
+
+// validateNetworkProbePort validates a probe action that requires network
+// connectivity to the VM.
+func (v validator) validateNetworkProbePort(
+	ctx *pkgctx.WebhookRequestContext,
+	actionPath *field.Path,
+	port intstr.IntOrString,
+	vpcNotAllowedMsg string) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	// Network readiness probes are not allowed under VPC Networking
+	if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
+		allErrs = append(allErrs, field.Forbidden(actionPath, vpcNotAllowedMsg))
+	} else if port.IntValue() != allowedRestrictedNetworkTCPProbePort {
+		// Validate port if environment is a restricted network environment between SV CP VMs and Workload VMs e.g. VMC.
+		isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
+		if err != nil {
+			allErrs = append(allErrs, field.Forbidden(actionPath, err.Error()))
+		} else if isRestrictedEnv {
+			allErrs = append(allErrs,
+				field.NotSupported(actionPath.Child("port"), port.IntValue(),
+					[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
 		}
 	}
 
@@ -1037,6 +1689,47 @@ func (v validator) validateAdvanced(ctx *pkgctx.WebhookRequestContext, vm *vmopv
 	return allErrs
 }
 
+// validateBootDiskCapacityOnUpdate validates that the boot disk capacity is
+// only ever increased, and only for VMs whose boot disk may be extended.
+func (v validator) validateBootDiskCapacityOnUpdate(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	if !pkgcfg.FromContext(ctx).Features.VMBootDiskResize {
+		return nil
+	}
+
+	var capacity, oldCapacity *resource.Quantity
+	if adv := vm.Spec.Advanced; adv != nil && adv.BootDiskCapacity != nil && !adv.BootDiskCapacity.IsZero() {
+		capacity = adv.BootDiskCapacity
+	}
+	if adv := oldVM.Spec.Advanced; adv != nil && adv.BootDiskCapacity != nil && !adv.BootDiskCapacity.IsZero() {
+		oldCapacity = adv.BootDiskCapacity
+	}
+
+	// There is nothing to validate if the capacity is unset or unchanged.
+	if capacity == nil || (oldCapacity != nil && capacity.Cmp(*oldCapacity) == 0) {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	p := field.NewPath("spec", "advanced", "bootDiskCapacity")
+
+	if oldCapacity != nil && capacity.Cmp(*oldCapacity) < 0 {
+		return append(allErrs, field.Invalid(p, capacity.String(), invalidBootDiskCapacityShrink))
+	}
+
+	if clone := vm.Spec.Clone; clone != nil && clone.Mode == vmopv1.VirtualMachineCloneModeLinked {
+		allErrs = append(allErrs, field.Forbidden(p, invalidBootDiskCapacityLinkedClone))
+	}
+
+	if oldVM.Status.CurrentSnapshot != "" || len(oldVM.Status.RootSnapshots) > 0 {
+		allErrs = append(allErrs, field.Forbidden(p, invalidBootDiskCapacitySnapshots))
+	}
+
+	return allErrs
+}
+
 func (v validator) validateNextRestartTimeOnCreate(
 	ctx *pkgctx.WebhookRequestContext,
 	vm *vmopv1.VirtualMachine) field.ErrorList {
@@ -1199,6 +1892,7 @@ func (v validator) validateImmutableFields(ctx *pkgctx.WebhookRequestContext, vm
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, v.validateImageOnUpdate(ctx, vm, oldVM)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.Clone, oldVM.Spec.Clone, specPath.Child("clone"))...)
 	allErrs = append(allErrs, v.validateClassOnUpdate(ctx, vm, oldVM)...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.StorageClass, oldVM.Spec.StorageClass, specPath.Child("storageClass"))...)
 	// New VMs always have non-empty biosUUID. Existing VMs being upgraded may have an empty biosUUID.
@@ -1250,7 +1944,9 @@ func (v validator) validateImmutableNetwork(ctx *pkgctx.WebhookRequestContext, v
 		newInterfaces = newNetwork.Interfaces
 	}
 
-	if len(oldInterfaces) != len(newInterfaces) {
+	mutableNetworks := pkgcfg.FromContext(ctx).Features.MutableNetworks
+
+	if !mutableNetworks && len(oldInterfaces) != len(newInterfaces) {
 		return append(allErrs, field.Forbidden(p.Child("interfaces"), "network interfaces cannot be added or removed"))
 	}
 
@@ -1260,6 +1956,51 @@ func (v validator) validateImmutableNetwork(ctx *pkgctx.WebhookRequestContext, v
 		return allErrs
 	}
 
+	if mutableNetworks {
+		// Interfaces may be added and removed, but the network of an existing
+		// interface, identified by its name, cannot be changed.
+		oldInterfacesByName := make(map[string]*vmopv1.VirtualMachineNetworkInterfaceSpec, len(oldInterfaces))
+		for i := range oldInterfaces {
+			oldInterfacesByName[oldInterfaces[i].Name] = &oldInterfaces[i]
+		}
+
+		interfacesChanged := len(oldInterfaces) != len(newInterfaces)
+		for i := range newInterfaces {
+			newInterface := &newInterfaces[i]
+			oldInterface, ok := oldInterfacesByName[newInterface.Name]
+			if !ok {
+				interfacesChanged = true
+				continue
+			}
+			if !reflect.DeepEqual(newInterface.Network, oldInterface.Network) {
+				allErrs = append(allErrs, field.Forbidden(
+					p.Child("interfaces").Index(i).Child("network"),
+					validation.FieldImmutableErrorMsg))
+			}
+		}
+
+		// The network adapters of the vSphere VM are matched with the
+		// interfaces by their network, so an interface cannot be added or
+		// removed if that would be ambiguous.
+		if interfacesChanged {
+			networks := make(map[string]struct{}, len(newInterfaces))
+			for i := range newInterfaces {
+				var network string
+				if n := newInterfaces[i].Network; n != nil {
+					network = n.Name
+				}
+				if _, ok := networks[network]; ok {
+					allErrs = append(allErrs, field.Forbidden(
+						p.Child("interfaces").Index(i).Child("network"),
+						"network interfaces cannot be added or removed when more than one interface is connected to the same network"))
+				}
+				networks[network] = struct{}{}
+			}
+		}
+
+		return allErrs
+	}
+
 	for i := range newInterfaces {
 		newInterface := &newInterfaces[i]
 		oldInterface := &oldInterfaces[i]
@@ -1509,6 +2250,38 @@ func (v validator) validateCdrom(
 	return allErrs
 }
 
+func (v validator) validateCurrentSnapshot(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	snapshotName := vm.Spec.CurrentSnapshot
+	if snapshotName == "" || (oldVM != nil && oldVM.Spec.CurrentSnapshot == snapshotName) {
+		return nil
+	}
+
+	f := field.NewPath("spec", "currentSnapshot")
+
+	if !pkgcfg.FromContext(ctx).Features.VMSnapshots {
+		return field.ErrorList{field.Forbidden(f, fmt.Sprintf(featureNotEnabled, "VM Snapshots"))}
+	}
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	key := ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: snapshotName}
+	if err := v.client.Get(ctx, key, vmSnapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(f, snapshotName)}
+		}
+		return field.ErrorList{field.InternalError(f, err)}
+	}
+
+	if vmSnapshot.Spec.VMName != vm.Name {
+		return field.ErrorList{field.Invalid(f, snapshotName,
+			fmt.Sprintf("VirtualMachineSnapshot is a snapshot of VirtualMachine %q", vmSnapshot.Spec.VMName))}
+	}
+
+	return nil
+}
+
 func validateCdromWhenPoweredOn(
 	cdrom, oldCdrom []vmopv1.VirtualMachineCdromSpec) field.ErrorList {
 
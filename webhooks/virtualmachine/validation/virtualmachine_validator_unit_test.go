@@ -14,6 +14,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -1125,6 +1126,109 @@ func unitTestsValidateCreate() {
 						`spec.readinessProbe.tcpSocket: Forbidden: VPC networking doesn't allow TCP readiness probe to be specified`),
 				},
 			),
+			Entry("should fail when Readiness probe has TCPSocket and HTTPGet actions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: make(map[string]string),
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{},
+							HTTPGet:   &vmopv1.HTTPGetAction{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe: Forbidden: only one action can be specified`),
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe is specified under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet: Forbidden: VPC networking doesn't allow HTTPGet readiness probe to be specified`),
+				},
+			),
+			Entry("should deny when restricted network and HTTPGet port in readiness probe is not 6443",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: map[string]string{"IsRestrictedNetwork": "true"},
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(8080)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.port: Unsupported value: 8080: supported values: "6443"`),
+				},
+			),
+			Entry("should deny when HTTPGet readiness probe has an invalid success status code range",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: make(map[string]string),
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Port: intstr.FromInt(8080),
+								SuccessStatusCodes: &vmopv1.HTTPStatusCodeRange{
+									Min: 400,
+									Max: 200,
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.successStatusCodes: Invalid value: "400-200": min must be less than or equal to max`),
+				},
+			),
+			Entry("should allow HTTPGet readiness probe",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: make(map[string]string),
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Path: "/healthz",
+								Port: intstr.FromInt(8080),
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
 			Entry("should allow when non-TCP readiness probe is specified under VPC networking",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
@@ -1210,6 +1314,76 @@ func unitTestsValidateCreate() {
 		)
 	})
 
+	Context("Liveness Probe", func() {
+
+		DescribeTable("create", doTest,
+			Entry("should fail when Liveness probe has multiple actions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							GuestInfo: []vmopv1.GuestInfoAction{
+								{
+									Key: "my-key",
+								},
+							},
+							GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe: Forbidden: only one action can be specified`),
+				},
+			),
+			Entry("should deny when TCP liveness probe is specified under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe.tcpSocket: Forbidden: VPC networking doesn't allow TCP liveness probe to be specified`),
+				},
+			),
+			Entry("should deny when restricted network and HTTPGet port in liveness probe is not 6443",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: map[string]string{"IsRestrictedNetwork": "true"},
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(8080)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe.httpGet.port: Unsupported value: 8080: supported values: "6443"`),
+				},
+			),
+			Entry("should allow GuestHeartbeat liveness probe under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+							FailureThreshold: 5,
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					expectAllowed: true,
+				},
+			),
+		)
+	})
+
 	Context("StorageClass", func() {
 
 		DescribeTable("StorageClass create", doTest,
@@ -1927,41 +2101,43 @@ func unitTestsValidateCreate() {
 				},
 			),
 
-			// Please note mtu is available only with the following bootstrap providers: CloudInit
-			Entry("validate mtu when bootstrap doesn't support mtu",
+			Entry("allow link-local gateway6",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
-							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
-						}
-						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
-							HostName: "my-vm",
-							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
-								{
-									Name: "eth0",
-									MTU:  ptr.To[int64](9000),
-								},
-							},
-						}
+						ctx.vm.Spec.Network.Interfaces[0].Addresses = []string{"2605:a601:a0ba:720:2ce6:776d:8be4:2496/48"}
+						ctx.vm.Spec.Network.Interfaces[0].Gateway6 = "fe80::1"
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("validate IPv4-mapped IPv6 addresses",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].Addresses = []string{"::ffff:192.168.1.10/120"}
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].mtu: Invalid value: 9000: mtu is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.interfaces[0].addresses[0]: Invalid value: "::ffff:192.168.1.10/120": IPv4-mapped IPv6 addresses are not supported`,
 					),
 				},
 			),
 
-			Entry("validate mtu when bootstrap supports mtu",
+			Entry("allow dual-stack with dhcp6 and slaac when bootstrap is Sysprep",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
-							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+							Sysprep: &vmopv1.VirtualMachineBootstrapSysprepSpec{
+								RawSysprep: &common.SecretKeySelector{},
+							},
 						}
 						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
-							HostName: "my-vm",
 							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
 								{
-									Name: "eth0",
-									MTU:  ptr.To[int64](9000),
+									Name:      "eth0",
+									Addresses: []string{"192.168.1.100/24"},
+									Gateway4:  "192.168.1.1",
+									DHCP6:     true,
+									SLAAC:     true,
 								},
 							},
 						}
@@ -1970,103 +2146,356 @@ func unitTestsValidateCreate() {
 				},
 			),
 
-			// Please note nameservers is available only with the following bootstrap
-			// providers: CloudInit and Sysprep.
-			Entry("validate nameservers when bootstrap doesn't support nameservers",
-				testParams{
-					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
-							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
-						}
-						ctx.vm.Spec.Network.Interfaces[0].Nameservers = []string{
-							"not-an-ip",
-							"192.168.1.1/24",
-						}
-					},
-					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].nameservers[0]: Invalid value: "not-an-ip": must be an IPv4 or IPv6 address`,
-						`spec.network.interfaces[0].nameservers[1]: Invalid value: "192.168.1.1/24": must be an IPv4 or IPv6 address`,
-						`spec.network.interfaces[0].nameservers: Invalid value: "not-an-ip,192.168.1.1/24": nameservers is available only with the following bootstrap providers: CloudInit and Sysprep`,
-					),
-				},
-			),
-
-			Entry("disallows nameservers vAppConfig",
+			Entry("validate slaac when bootstrap doesn't support slaac",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
 							VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{},
 						}
-						ctx.vm.Spec.Network.Interfaces[0].Nameservers = []string{
-							"192.168.1.1",
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:  "eth0",
+									SLAAC: true,
+								},
+							},
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].nameservers: Invalid value: "192.168.1.1": nameservers is available only with the following bootstrap providers: CloudInit and Sysprep`,
+						`spec.network.interfaces[0].slaac: Invalid value: true: slaac is available only with the following bootstrap providers: CloudInit, LinuxPrep, and Sysprep`,
 					),
 				},
 			),
 
-			Entry("allows nameservers with Sysprep",
+			Entry("allow bonds, vlans, and bridges when bootstrap is CloudInit",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
-							Sysprep: &vmopv1.VirtualMachineBootstrapSysprepSpec{
-								RawSysprep: &common.SecretKeySelector{},
-							},
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
 						}
-						ctx.vm.Spec.Network.Interfaces[0].Nameservers = []string{
-							"8.8.8.8",
-							"2001:4860:4860::8888",
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{Name: "eth0"},
+								{Name: "eth1"},
+								{Name: "eth2"},
+							},
+							Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+								{
+									Name:       "bond0",
+									Interfaces: []string{"eth0", "eth1"},
+									Mode:       "active-backup",
+									Primary:    "eth0",
+									VirtualMachineNetworkDeviceIPSpec: vmopv1.VirtualMachineNetworkDeviceIPSpec{
+										Addresses: []string{"192.168.1.100/24"},
+										Gateway4:  "192.168.1.1",
+									},
+								},
+							},
+							VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+								{
+									Name: "bond0.100",
+									ID:   100,
+									Link: "bond0",
+								},
+								{
+									Name: "eth2.100",
+									ID:   100,
+									Link: "eth2",
+								},
+							},
+							Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+								{
+									Name:       "br0",
+									Interfaces: []string{"bond0.100", "eth2.100"},
+									VirtualMachineNetworkDeviceIPSpec: vmopv1.VirtualMachineNetworkDeviceIPSpec{
+										DHCP4: true,
+									},
+								},
+							},
 						}
 					},
 					expectAllowed: true,
 				},
 			),
 
-			// Please note routes is available only with the following bootstrap providers: CloudInit
-			Entry("validate routes when bootstrap doesn't support routes",
+			Entry("validate bonds, vlans, and bridges when bootstrap is not CloudInit",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
-							Sysprep: &vmopv1.VirtualMachineBootstrapSysprepSpec{
-								RawSysprep: &common.SecretKeySelector{},
-							},
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
 						}
-						ctx.vm.Spec.Network.Interfaces[0].Routes = []vmopv1.VirtualMachineNetworkRouteSpec{
-							{
-								To:  "10.100.10.1",
-								Via: "192.168.1",
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{Name: "eth0"},
 							},
-							{
-								To:  "2605:a601:a0ba:720:2ce6::/48",
-								Via: "2463:foobar",
+							Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+								{
+									Name:       "bond0",
+									Interfaces: []string{"eth0"},
+								},
 							},
-							{
-								To:  "192.168.1.1/24",
-								Via: "ef71:6ce2:3b91:8349:b2b2:f76c:86ae:915b",
+							VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+								{
+									Name: "bond0.100",
+									ID:   100,
+									Link: "bond0",
+								},
+							},
+							Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+								{
+									Name:       "br0",
+									Interfaces: []string{"bond0.100"},
+								},
 							},
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].routes[0].to: Invalid value: "10.100.10.1": invalid CIDR address: 10.100.10.1`,
-						`spec.network.interfaces[0].routes[0].via: Invalid value: "192.168.1": must be an IPv4 or IPv6 address`,
-						`spec.network.interfaces[0].routes[1].via: Invalid value: "2463:foobar": must be an IPv4 or IPv6 address`,
-						`spec.network.interfaces[0].routes[2]: Invalid value: "": cannot mix IP address families`,
-						`spec.network.interfaces[0].routes: Invalid value: "routes": routes is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.bonds: Invalid value: "bonds": bonds is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.vlans: Invalid value: "vlans": vlans is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.bridges: Invalid value: "bridges": bridges is available only with the following bootstrap providers: CloudInit`,
 					),
 				},
 			),
 
-			Entry("validate routes when bootstrap supports routes",
+			Entry("validate bond members and parameters",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
 							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
 						}
-						ctx.vm.Spec.Network.Interfaces[0].Routes = []vmopv1.VirtualMachineNetworkRouteSpec{
-							{
-								To:     "10.100.10.1/24",
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{Name: "eth0"},
+								{Name: "eth1"},
+							},
+							Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+								{
+									Name:               "bond0",
+									Interfaces:         []string{"eth0", "eth9"},
+									Mode:               "balance-rr",
+									Primary:            "eth0",
+									LACPRate:           "fast",
+									TransmitHashPolicy: "layer2",
+									VirtualMachineNetworkDeviceIPSpec: vmopv1.VirtualMachineNetworkDeviceIPSpec{
+										Gateway4: "192.168.1.1",
+									},
+								},
+								{
+									Name:       "eth1",
+									Interfaces: []string{"eth0"},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.bonds[0].interfaces[1]: Invalid value: "eth9": must be the name of a network interface`,
+						`spec.network.bonds[0].primary: Invalid value: "eth0": primary is only valid when mode is active-backup`,
+						`spec.network.bonds[0].lacpRate: Invalid value: "fast": lacpRate is only valid when mode is 802.3ad`,
+						`spec.network.bonds[0].transmitHashPolicy: Invalid value: "layer2": transmitHashPolicy is only valid when mode is balance-xor or 802.3ad`,
+						`spec.network.bonds[0].gateway4: Invalid value: "192.168.1.1": gateway4 must have an IPv4 address in the addresses field`,
+						`spec.network.bonds[1].name: Duplicate value: "eth1"`,
+						`spec.network.bonds[1].interfaces[0]: Invalid value: "eth0": is already a member of another bond or bridge`,
+					),
+				},
+			),
+
+			Entry("validate vlan links and bridge ports",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{Name: "eth0"},
+								{Name: "eth1"},
+							},
+							Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+								{
+									Name:       "bond0",
+									Interfaces: []string{"eth0"},
+								},
+							},
+							VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+								{
+									Name: "vlan100",
+									ID:   100,
+									Link: "eth0",
+								},
+								{
+									Name: "vlan200",
+									ID:   200,
+									Link: "eth9",
+								},
+								{
+									Name: "vlan300",
+									ID:   300,
+									Link: "eth1",
+								},
+								{
+									Name: "vlan301",
+									ID:   300,
+									Link: "eth1",
+								},
+							},
+							Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+								{
+									Name:       "br0",
+									Interfaces: []string{"vlan300", "br9", "eth0"},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.vlans[0].link: Invalid value: "eth0": cannot be a member of a bond`,
+						`spec.network.vlans[1].link: Invalid value: "eth9": must be the name of a network interface or bond`,
+						`spec.network.vlans[3].id: Duplicate value: 300`,
+						`spec.network.bridges[0].interfaces[1]: Invalid value: "br9": must be the name of a network interface, bond, or VLAN`,
+						`spec.network.bridges[0].interfaces[2]: Invalid value: "eth0": is already a member of another bond or bridge`,
+					),
+				},
+			),
+
+			// Please note mtu is available only with the following bootstrap providers: CloudInit
+			Entry("validate mtu when bootstrap doesn't support mtu",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							HostName: "my-vm",
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+									MTU:  ptr.To[int64](9000),
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].mtu: Invalid value: 9000: mtu is available only with the following bootstrap providers: CloudInit`,
+					),
+				},
+			),
+
+			Entry("validate mtu when bootstrap supports mtu",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							HostName: "my-vm",
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+									MTU:  ptr.To[int64](9000),
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			// Please note nameservers is available only with the following bootstrap
+			// providers: CloudInit and Sysprep.
+			Entry("validate nameservers when bootstrap doesn't support nameservers",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+						ctx.vm.Spec.Network.Interfaces[0].Nameservers = []string{
+							"not-an-ip",
+							"192.168.1.1/24",
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].nameservers[0]: Invalid value: "not-an-ip": must be an IPv4 or IPv6 address`,
+						`spec.network.interfaces[0].nameservers[1]: Invalid value: "192.168.1.1/24": must be an IPv4 or IPv6 address`,
+						`spec.network.interfaces[0].nameservers: Invalid value: "not-an-ip,192.168.1.1/24": nameservers is available only with the following bootstrap providers: CloudInit and Sysprep`,
+					),
+				},
+			),
+
+			Entry("disallows nameservers vAppConfig",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{},
+						}
+						ctx.vm.Spec.Network.Interfaces[0].Nameservers = []string{
+							"192.168.1.1",
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].nameservers: Invalid value: "192.168.1.1": nameservers is available only with the following bootstrap providers: CloudInit and Sysprep`,
+					),
+				},
+			),
+
+			Entry("allows nameservers with Sysprep",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Sysprep: &vmopv1.VirtualMachineBootstrapSysprepSpec{
+								RawSysprep: &common.SecretKeySelector{},
+							},
+						}
+						ctx.vm.Spec.Network.Interfaces[0].Nameservers = []string{
+							"8.8.8.8",
+							"2001:4860:4860::8888",
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			// Please note routes is available only with the following bootstrap providers: CloudInit
+			Entry("validate routes when bootstrap doesn't support routes",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Sysprep: &vmopv1.VirtualMachineBootstrapSysprepSpec{
+								RawSysprep: &common.SecretKeySelector{},
+							},
+						}
+						ctx.vm.Spec.Network.Interfaces[0].Routes = []vmopv1.VirtualMachineNetworkRouteSpec{
+							{
+								To:  "10.100.10.1",
+								Via: "192.168.1",
+							},
+							{
+								To:  "2605:a601:a0ba:720:2ce6::/48",
+								Via: "2463:foobar",
+							},
+							{
+								To:  "192.168.1.1/24",
+								Via: "ef71:6ce2:3b91:8349:b2b2:f76c:86ae:915b",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].routes[0].to: Invalid value: "10.100.10.1": invalid CIDR address: 10.100.10.1`,
+						`spec.network.interfaces[0].routes[0].via: Invalid value: "192.168.1": must be an IPv4 or IPv6 address`,
+						`spec.network.interfaces[0].routes[1].via: Invalid value: "2463:foobar": must be an IPv4 or IPv6 address`,
+						`spec.network.interfaces[0].routes[2]: Invalid value: "": cannot mix IP address families`,
+						`spec.network.interfaces[0].routes: Invalid value: "routes": routes is available only with the following bootstrap providers: CloudInit`,
+					),
+				},
+			),
+
+			Entry("validate routes when bootstrap supports routes",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+						ctx.vm.Spec.Network.Interfaces[0].Routes = []vmopv1.VirtualMachineNetworkRouteSpec{
+							{
+								To:     "10.100.10.1/24",
 								Via:    "10.10.1.1",
 								Metric: 42,
 							},
@@ -2561,82 +2990,557 @@ func unitTestsValidateCreate() {
 			),
 		)
 	})
-}
-
-func unitTestsValidateUpdate() {
-	var (
-		ctx *unitValidatingWebhookContext
-	)
 
-	type updateArgs struct {
-		isServiceUser               bool
-		changeInstanceUUID          bool
-		changeBiosUUID              bool
-		changeImageRef              bool
-		changeImageName             bool
-		changeStorageClass          bool
-		changeResourcePolicy        bool
-		assignZoneName              bool
-		changeZoneName              bool
-		isSysprepTransportUsed      bool
-		withInstanceStorageVolumes  bool
-		changeInstanceStorageVolume bool
-		oldInstanceUUID             string
-		oldBiosUUID                 string
-		oldPowerState               vmopv1.VirtualMachinePowerState
-		newPowerState               vmopv1.VirtualMachinePowerState
-		newPowerStateEmptyAllowed   bool
-		nextRestartTime             string
-		lastRestartTime             string
-	}
+	Context("Clone", func() {
+		const srcVMName = "dummy-src-vm"
 
-	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
-		// Init immutable fields that aren't set in the dummy VM.
-		if ctx.oldVM.Spec.Reserved == nil {
-			ctx.oldVM.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{}
-		}
-		ctx.oldVM.Spec.Reserved.ResourcePolicyName = "policy"
-		ctx.oldVM.Spec.InstanceUUID = args.oldInstanceUUID
-		ctx.oldVM.Spec.BiosUUID = args.oldBiosUUID
+		var srcVM *vmopv1.VirtualMachine
 
-		if args.isServiceUser {
-			ctx.IsPrivilegedAccount = true
+		createSrcVM := func(ctx *unitValidatingWebhookContext, uniqueID string) {
+			srcVM = builder.DummyBasicVirtualMachine(srcVMName, ctx.vm.Namespace)
+			Expect(ctx.Client.Create(ctx, srcVM)).To(Succeed())
+			srcVM.Status.UniqueID = uniqueID
+			Expect(ctx.Client.Status().Update(ctx, srcVM)).To(Succeed())
 		}
 
-		if args.changeImageRef {
-			if ctx.vm.Spec.Image == nil {
-				ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{}
-			}
-			ctx.vm.Spec.Image.Name += updateSuffix
-		}
-		if args.changeImageName {
-			ctx.vm.Spec.ImageName += updateSuffix
-		}
-		if args.changeInstanceUUID {
-			ctx.vm.Spec.InstanceUUID += updateSuffix
-		}
-		if args.changeBiosUUID {
-			ctx.vm.Spec.BiosUUID += updateSuffix
-		}
-		if args.changeStorageClass {
-			ctx.vm.Spec.StorageClass += updateSuffix
-		}
-		if ctx.vm.Spec.Reserved == nil {
-			ctx.vm.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{}
-		}
-		ctx.vm.Spec.Reserved.ResourcePolicyName = "policy"
-		if args.changeResourcePolicy {
-			ctx.vm.Spec.Reserved.ResourcePolicyName = "policy" + updateSuffix
-		}
-		if args.assignZoneName {
-			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName
-		}
-		if args.changeZoneName {
-			ctx.oldVM.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName
-			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName + updateSuffix
+		enableVMClone := func(ctx *unitValidatingWebhookContext) {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMClone = true
+			})
 		}
 
-		if args.withInstanceStorageVolumes {
+		DescribeTable("create", doTest,
+			Entry("should disallow image kind VirtualMachine when the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{Kind: "VirtualMachine", Name: srcVMName}
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(field.NewPath("spec", "image", "kind"), "VirtualMachine", invalidImageKindMsg).Error(),
+					),
+				},
+			),
+			Entry("should allow cloning a VM that has been created",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableVMClone(ctx)
+						createSrcVM(ctx, "vm-42")
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{Kind: "VirtualMachine", Name: srcVMName}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeLinked}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow cloning a VM that does not exist",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableVMClone(ctx)
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{Kind: "VirtualMachine", Name: srcVMName}
+					},
+					validate: doValidateWithMsg(
+						field.NotFound(field.NewPath("spec", "image", "name"), srcVMName).Error(),
+					),
+				},
+			),
+			Entry("should disallow cloning a VM that has not been created",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableVMClone(ctx)
+						createSrcVM(ctx, "")
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{Kind: "VirtualMachine", Name: srcVMName}
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(field.NewPath("spec", "image", "name"), srcVMName, "source VM has not been created").Error(),
+					),
+				},
+			),
+			Entry("should disallow cloning a VM from itself",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableVMClone(ctx)
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{Kind: "VirtualMachine", Name: ctx.vm.Name}
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(field.NewPath("spec", "image", "name"), "dummy-vm", "cannot clone a VM from itself").Error(),
+					),
+				},
+			),
+			Entry("should disallow spec.clone when the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeFull}
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(field.NewPath("spec", "clone"), "the VM Clone feature is not enabled").Error(),
+					),
+				},
+			),
+			Entry("should disallow spec.clone when the VM is deployed from an image",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableVMClone(ctx)
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeFull}
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(field.NewPath("spec", "clone"), "may only be set when spec.image.kind is VirtualMachine").Error(),
+					),
+				},
+			),
+			Entry("should disallow resizing the boot disk of a linked clone",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableVMClone(ctx)
+						createSrcVM(ctx, "vm-42")
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{Kind: "VirtualMachine", Name: srcVMName}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeLinked}
+						ctx.vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							BootDiskCapacity: ptr.To(resource.MustParse("20Gi")),
+						}
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(field.NewPath("spec", "advanced", "bootDiskCapacity"), "cannot resize the boot disk of a linked clone").Error(),
+					),
+				},
+			),
+		)
+	})
+
+	Context("Volume disk placement", func() {
+		volPath := field.NewPath("spec", "volumes").Index(0)
+
+		setPlacement := func(
+			ctx *unitValidatingWebhookContext,
+			controllerType vmopv1.VirtualControllerType,
+			busNumber, unitNumber *int32) {
+
+			ctx.vm.Spec.Volumes[0].ControllerType = controllerType
+			ctx.vm.Spec.Volumes[0].ControllerBusNumber = busNumber
+			ctx.vm.Spec.Volumes[0].UnitNumber = unitNumber
+		}
+
+		DescribeTable("create", doTest,
+			Entry("should allow a controller type, bus number, and unit number",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeNVME, ptr.To[int32](1), ptr.To[int32](14))
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow only a controller type",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSCSI, nil, nil)
+						ctx.vm.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow a bus number without a controller type",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, "", ptr.To[int32](0), nil)
+					},
+					validate: doValidateWithMsg(
+						field.Required(volPath.Child("controllerType"), "when controllerBusNumber is specified").Error(),
+					),
+				},
+			),
+			Entry("should disallow a unit number without a bus number",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSCSI, nil, ptr.To[int32](1))
+					},
+					validate: doValidateWithMsg(
+						field.Required(volPath.Child("controllerBusNumber"), "when unitNumber is specified").Error(),
+					),
+				},
+			),
+			Entry("should disallow a unit number that is out of range",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSATA, ptr.To[int32](0), ptr.To[int32](30))
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("unitNumber"), 30, "must be less than 30 for a SATA controller").Error(),
+					),
+				},
+			),
+			Entry("should disallow the unit number reserved for the SCSI controller",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSCSI, ptr.To[int32](0), ptr.To[int32](7))
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("unitNumber"), 7, "unit number 7 is reserved for the SCSI controller").Error(),
+					),
+				},
+			),
+			Entry("should disallow the MultiWriter sharing mode with a SATA controller",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSATA, nil, nil)
+						ctx.vm.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("sharingMode"), vmopv1.VolumeSharingModeMultiWriter,
+							"MultiWriter sharing mode is not supported for a SATA controller").Error(),
+					),
+				},
+			),
+			Entry("should disallow two volumes with the same controller and unit number",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSCSI, ptr.To[int32](0), ptr.To[int32](1))
+						vol := *ctx.vm.Spec.Volumes[0].DeepCopy()
+						vol.Name = "other-volume"
+						vol.PersistentVolumeClaim.ClaimName = "other-claim"
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vol)
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(field.NewPath("spec", "volumes").Index(1).Child("unitNumber"), 1,
+							"controller type, bus number, and unit number must be unique").Error(),
+					),
+				},
+			),
+			Entry("should allow a SCSI controller sharing mode",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSCSI, ptr.To[int32](1), nil)
+						ctx.vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow a controller sharing mode with a NVME controller",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeNVME, ptr.To[int32](1), nil)
+						ctx.vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("controllerSharingMode"), vmopv1.VirtualControllerSharingModePhysical,
+							"may only be specified for a SCSI controller").Error(),
+					),
+				},
+			),
+			Entry("should disallow the MultiWriter sharing mode with a controller whose bus is shared",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSCSI, ptr.To[int32](1), nil)
+						ctx.vm.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+						ctx.vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("sharingMode"), vmopv1.VolumeSharingModeMultiWriter,
+							"MultiWriter sharing mode is not supported for a controller whose bus is shared").Error(),
+					),
+				},
+			),
+			Entry("should disallow a controller sharing mode without a bus number",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setPlacement(ctx, vmopv1.VirtualControllerTypeSCSI, nil, nil)
+						ctx.vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModeVirtual
+					},
+					validate: doValidateWithMsg(
+						field.Required(volPath.Child("controllerBusNumber"), "when controllerSharingMode is specified").Error(),
+					),
+				},
+			),
+		)
+	})
+
+	Context("Volume storage I/O allocation", func() {
+		storageIOPath := field.NewPath("spec", "volumes").Index(0).Child("storageIO")
+
+		DescribeTable("create", doTest,
+			Entry("should allow a limit, reservation, and custom shares",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes[0].StorageIO = &vmopv1.VirtualMachineStorageIOAllocation{
+							Limit:       ptr.To[int64](1000),
+							Reservation: ptr.To[int32](1000),
+							Shares: &vmopv1.VirtualMachineStorageIOShares{
+								Level:  vmopv1.VirtualMachineStorageIOSharesLevelCustom,
+								Shares: 2000,
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow a reservation that exceeds the limit",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes[0].StorageIO = &vmopv1.VirtualMachineStorageIOAllocation{
+							Limit:       ptr.To[int64](100),
+							Reservation: ptr.To[int32](200),
+						}
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(storageIOPath.Child("reservation"), 200, "must not exceed the limit").Error(),
+					),
+				},
+			),
+			Entry("should disallow a number of shares when the level is not Custom",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes[0].StorageIO = &vmopv1.VirtualMachineStorageIOAllocation{
+							Shares: &vmopv1.VirtualMachineStorageIOShares{
+								Level:  vmopv1.VirtualMachineStorageIOSharesLevelHigh,
+								Shares: 2000,
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(storageIOPath.Child("shares", "shares"), "may only be specified when level is Custom").Error(),
+					),
+				},
+			),
+		)
+	})
+
+	Context("Shared volumes", func() {
+		volPath := field.NewPath("spec", "volumes").Index(0)
+
+		newPVC := func(
+			ctx *unitValidatingWebhookContext,
+			accessMode corev1.PersistentVolumeAccessMode,
+			volumeMode corev1.PersistentVolumeMode) *corev1.PersistentVolumeClaim {
+
+			return &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ctx.vm.Spec.Volumes[0].PersistentVolumeClaim.ClaimName,
+					Namespace: ctx.vm.Namespace,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+					VolumeMode:  ptr.To(volumeMode),
+				},
+			}
+		}
+
+		newOtherVM := func(ctx *unitValidatingWebhookContext) *vmopv1.VirtualMachine {
+			otherVM := ctx.vm.DeepCopy()
+			otherVM.Name = "other-vm"
+			return otherVM
+		}
+
+		// Disks shared by the VMs of a Windows Server Failover Cluster are
+		// attached to a controller whose bus is shared.
+		setWSFC := func(ctx *unitValidatingWebhookContext) {
+			ctx.vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeSCSI
+			ctx.vm.Spec.Volumes[0].ControllerBusNumber = ptr.To[int32](1)
+			ctx.vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName
+		}
+
+		setMultiWriter := func(ctx *unitValidatingWebhookContext) {
+			ctx.vm.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+			ctx.vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeSCSI
+			ctx.vm.Spec.Volumes[0].ControllerBusNumber = ptr.To[int32](1)
+			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName
+		}
+
+		DescribeTable("create", doTest,
+			Entry("should allow a ReadWriteMany block PVC shared by VMs in the same zone with bus sharing",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setWSFC(ctx)
+						Expect(ctx.Client.Create(ctx, newPVC(ctx, corev1.ReadWriteMany, corev1.PersistentVolumeBlock))).To(Succeed())
+						Expect(ctx.Client.Create(ctx, newOtherVM(ctx))).To(Succeed())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow a ReadWriteMany block PVC shared by VMs in the same zone with the MultiWriter sharing mode",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setMultiWriter(ctx)
+						Expect(ctx.Client.Create(ctx, newPVC(ctx, corev1.ReadWriteMany, corev1.PersistentVolumeBlock))).To(Succeed())
+						Expect(ctx.Client.Create(ctx, newOtherVM(ctx))).To(Succeed())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow a PVC used by a VM in another zone before the VM is placed",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setWSFC(ctx)
+						otherVM := newOtherVM(ctx)
+						otherVM.Labels[topology.KubernetesTopologyZoneLabelKey] = "other-zone"
+						Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+						delete(ctx.vm.Labels, topology.KubernetesTopologyZoneLabelKey)
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow a ReadWriteOnce PVC with bus sharing",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setWSFC(ctx)
+						Expect(ctx.Client.Create(ctx, newPVC(ctx, corev1.ReadWriteOnce, corev1.PersistentVolumeBlock))).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("controllerSharingMode"), vmopv1.VirtualControllerSharingModePhysical,
+							"PVC "+builder.DummyPVCName+" must have the ReadWriteMany access mode and the Block volume mode to be shared").Error(),
+					),
+				},
+			),
+			Entry("should disallow a ReadWriteOnce PVC with the MultiWriter sharing mode",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setMultiWriter(ctx)
+						Expect(ctx.Client.Create(ctx, newPVC(ctx, corev1.ReadWriteOnce, corev1.PersistentVolumeBlock))).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("sharingMode"), vmopv1.VolumeSharingModeMultiWriter,
+							"PVC "+builder.DummyPVCName+" must have the ReadWriteMany access mode and the Block volume mode to be shared").Error(),
+					),
+				},
+			),
+			Entry("should disallow a PVC used by a VM that does not share it",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setMultiWriter(ctx)
+						otherVM := newOtherVM(ctx)
+						otherVM.Spec.Volumes[0].SharingMode = ""
+						Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("sharingMode"), vmopv1.VolumeSharingModeMultiWriter,
+							"PVC "+builder.DummyPVCName+" is used by VM other-vm with a different sharing mode").Error(),
+					),
+				},
+			),
+			Entry("should disallow a PVC used by a VM with a different sharing mode",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setWSFC(ctx)
+						otherVM := newOtherVM(ctx)
+						otherVM.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+						otherVM.Spec.Volumes[0].ControllerSharingMode = ""
+						Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("controllerSharingMode"), vmopv1.VirtualControllerSharingModePhysical,
+							"PVC "+builder.DummyPVCName+" is used by VM other-vm with a different sharing mode").Error(),
+					),
+				},
+			),
+			Entry("should disallow a PVC used by a VM in a different zone",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setWSFC(ctx)
+						otherVM := newOtherVM(ctx)
+						otherVM.Labels[topology.KubernetesTopologyZoneLabelKey] = "other-zone"
+						Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("persistentVolumeClaim", "claimName"), builder.DummyPVCName,
+							"PVC "+builder.DummyPVCName+" is used by VM other-vm in zone other-zone").Error(),
+					),
+				},
+			),
+			Entry("should disallow a PVC used by a VM with a different controller",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setMultiWriter(ctx)
+						otherVM := newOtherVM(ctx)
+						otherVM.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeNVME
+						Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+					},
+					validate: doValidateWithMsg(
+						field.Invalid(volPath.Child("controllerType"), vmopv1.VirtualControllerTypeSCSI,
+							"PVC "+builder.DummyPVCName+" is used by VM other-vm with a different controller type or controller sharing mode").Error(),
+					),
+				},
+			),
+		)
+	})
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		isServiceUser               bool
+		changeInstanceUUID          bool
+		changeBiosUUID              bool
+		changeImageRef              bool
+		changeImageName             bool
+		changeStorageClass          bool
+		changeResourcePolicy        bool
+		assignZoneName              bool
+		changeZoneName              bool
+		isSysprepTransportUsed      bool
+		withInstanceStorageVolumes  bool
+		changeInstanceStorageVolume bool
+		oldInstanceUUID             string
+		oldBiosUUID                 string
+		oldPowerState               vmopv1.VirtualMachinePowerState
+		newPowerState               vmopv1.VirtualMachinePowerState
+		newPowerStateEmptyAllowed   bool
+		nextRestartTime             string
+		lastRestartTime             string
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		// Init immutable fields that aren't set in the dummy VM.
+		if ctx.oldVM.Spec.Reserved == nil {
+			ctx.oldVM.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{}
+		}
+		ctx.oldVM.Spec.Reserved.ResourcePolicyName = "policy"
+		ctx.oldVM.Spec.InstanceUUID = args.oldInstanceUUID
+		ctx.oldVM.Spec.BiosUUID = args.oldBiosUUID
+
+		if args.isServiceUser {
+			ctx.IsPrivilegedAccount = true
+		}
+
+		if args.changeImageRef {
+			if ctx.vm.Spec.Image == nil {
+				ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{}
+			}
+			ctx.vm.Spec.Image.Name += updateSuffix
+		}
+		if args.changeImageName {
+			ctx.vm.Spec.ImageName += updateSuffix
+		}
+		if args.changeInstanceUUID {
+			ctx.vm.Spec.InstanceUUID += updateSuffix
+		}
+		if args.changeBiosUUID {
+			ctx.vm.Spec.BiosUUID += updateSuffix
+		}
+		if args.changeStorageClass {
+			ctx.vm.Spec.StorageClass += updateSuffix
+		}
+		if ctx.vm.Spec.Reserved == nil {
+			ctx.vm.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{}
+		}
+		ctx.vm.Spec.Reserved.ResourcePolicyName = "policy"
+		if args.changeResourcePolicy {
+			ctx.vm.Spec.Reserved.ResourcePolicyName = "policy" + updateSuffix
+		}
+		if args.assignZoneName {
+			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName
+		}
+		if args.changeZoneName {
+			ctx.oldVM.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName
+			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyZoneName + updateSuffix
+		}
+
+		if args.withInstanceStorageVolumes {
 			instanceStorageVolumes := builder.DummyInstanceStorageVirtualMachineVolumes()
 			ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, instanceStorageVolumes...)
 		}
@@ -2883,29 +3787,98 @@ func unitTestsValidateUpdate() {
 						ctx.vm.Annotations[vmopv1.ImportedVMAnnotation] = dummyImportedAnnVal + updateSuffix
 						ctx.vm.Annotations[vmopv1.FailedOverVMAnnotation] = dummyFailedOverAnnVal + updateSuffix
 					},
-					expectAllowed: true,
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow removing admin-only annotations by privileged user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						privilegedUsersEnvList := "  , foo ,bar , test,  "
+						privilegedUser := "bar"
+
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.PrivilegedUsers = privilegedUsersEnvList
+						})
+
+						ctx.UserInfo.Username = privilegedUser
+						ctx.IsPrivilegedAccount = pkgbuilder.IsPrivilegedAccount(ctx.WebhookContext, ctx.UserInfo)
+
+						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
+						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
+						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
+						ctx.oldVM.Annotations[vmopv1.RestoredVMAnnotation] = dummyRegisteredAnnVal
+						ctx.oldVM.Annotations[vmopv1.ImportedVMAnnotation] = dummyImportedAnnVal
+						ctx.oldVM.Annotations[vmopv1.FailedOverVMAnnotation] = dummyFailedOverAnnVal
+					},
+					expectAllowed: true,
+				},
+			),
+		)
+	})
+
+	Context("Label", func() {
+		labelPath := field.NewPath("metadata", "labels")
+
+		DescribeTable("update", doTest,
+			Entry("should disallow updating VM with admin-only labels set by SSO user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
+						ctx.vm.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal + updateSuffix
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(labelPath.Child(vmopv1.PausedVMLabelKey), "modifying this label is not allowed for non-admin users").Error()),
+				},
+			),
+			Entry("should disallow removing admin-only labels set by SSO user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(labelPath.Child(vmopv1.PausedVMLabelKey), "modifying this label is not allowed for non-admin users").Error()),
+				},
+			),
+			Entry("should allow updating VM with admin-only label by service user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.IsPrivilegedAccount = true
+						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
+						ctx.vm.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal + updateSuffix
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow removing admin-only label by service user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.IsPrivilegedAccount = true
+						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
+					},
+					expectAllowed: true,
+				},
+			),
+		)
+	})
+
+	Context("Clone", func() {
+		DescribeTable("update", doTest,
+			Entry("forbid changing the clone mode",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeFull}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeLinked}
+					},
+					validate: doValidateWithMsg(
+						"spec.clone: Invalid value", "field is immutable"),
 				},
 			),
-			Entry("should allow removing admin-only annotations by privileged user",
+			Entry("allow an unchanged clone mode",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						privilegedUsersEnvList := "  , foo ,bar , test,  "
-						privilegedUser := "bar"
-
-						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
-							config.PrivilegedUsers = privilegedUsersEnvList
-						})
-
-						ctx.UserInfo.Username = privilegedUser
-						ctx.IsPrivilegedAccount = pkgbuilder.IsPrivilegedAccount(ctx.WebhookContext, ctx.UserInfo)
-
-						ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
-						ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
-						ctx.oldVM.Annotations[constants.CreatedAtBuildVersionAnnotationKey] = dummyCreatedAtBuildVersionVal
-						ctx.oldVM.Annotations[constants.CreatedAtSchemaVersionAnnotationKey] = dummyCreatedAtSchemaVersionVal
-						ctx.oldVM.Annotations[vmopv1.RestoredVMAnnotation] = dummyRegisteredAnnVal
-						ctx.oldVM.Annotations[vmopv1.ImportedVMAnnotation] = dummyImportedAnnVal
-						ctx.oldVM.Annotations[vmopv1.FailedOverVMAnnotation] = dummyFailedOverAnnVal
+						ctx.oldVM.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeLinked}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeLinked}
 					},
 					expectAllowed: true,
 				},
@@ -2913,44 +3886,95 @@ func unitTestsValidateUpdate() {
 		)
 	})
 
-	Context("Label", func() {
-		labelPath := field.NewPath("metadata", "labels")
+	Context("BootDiskCapacity", func() {
+		bootDiskCapacityPath := field.NewPath("spec", "advanced", "bootDiskCapacity")
+
+		setCapacities := func(ctx *unitValidatingWebhookContext, oldCapacity, capacity string) {
+			ctx.oldVM.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+				BootDiskCapacity: ptr.To(resource.MustParse(oldCapacity)),
+			}
+			ctx.vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+				BootDiskCapacity: ptr.To(resource.MustParse(capacity)),
+			}
+		}
+
+		enableBootDiskResize := func(ctx *unitValidatingWebhookContext) {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.Features.VMBootDiskResize = true
+			})
+		}
 
 		DescribeTable("update", doTest,
-			Entry("should disallow updating VM with admin-only labels set by SSO user",
+			Entry("should allow increasing the capacity",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
-						ctx.vm.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal + updateSuffix
+						enableBootDiskResize(ctx)
+						setCapacities(ctx, "10Gi", "20Gi")
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow setting the capacity",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableBootDiskResize(ctx)
+						ctx.vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							BootDiskCapacity: ptr.To(resource.MustParse("20Gi")),
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow decreasing the capacity when the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						setCapacities(ctx, "20Gi", "10Gi")
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow decreasing the capacity",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						enableBootDiskResize(ctx)
+						setCapacities(ctx, "20Gi", "10Gi")
 					},
 					validate: doValidateWithMsg(
-						field.Forbidden(labelPath.Child(vmopv1.PausedVMLabelKey), "modifying this label is not allowed for non-admin users").Error()),
+						field.Invalid(bootDiskCapacityPath, "10Gi", "cannot be decreased").Error(),
+					),
 				},
 			),
-			Entry("should disallow removing admin-only labels set by SSO user",
+			Entry("should disallow increasing the capacity of a linked clone",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
+						enableBootDiskResize(ctx)
+						setCapacities(ctx, "10Gi", "20Gi")
+						ctx.oldVM.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeLinked}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{Mode: vmopv1.VirtualMachineCloneModeLinked}
 					},
 					validate: doValidateWithMsg(
-						field.Forbidden(labelPath.Child(vmopv1.PausedVMLabelKey), "modifying this label is not allowed for non-admin users").Error()),
+						field.Forbidden(bootDiskCapacityPath, "cannot resize the boot disk of a linked clone").Error(),
+					),
 				},
 			),
-			Entry("should allow updating VM with admin-only label by service user",
+			Entry("should disallow increasing the capacity of a VM with snapshots",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.IsPrivilegedAccount = true
-						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
-						ctx.vm.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal + updateSuffix
+						enableBootDiskResize(ctx)
+						setCapacities(ctx, "10Gi", "20Gi")
+						ctx.oldVM.Status.RootSnapshots = []string{"snap-1"}
 					},
-					expectAllowed: true,
+					validate: doValidateWithMsg(
+						field.Forbidden(bootDiskCapacityPath, "cannot resize the boot disk of a VM with snapshots").Error(),
+					),
 				},
 			),
-			Entry("should allow removing admin-only label by service user",
+			Entry("should allow an unchanged capacity of a VM with snapshots",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.IsPrivilegedAccount = true
-						ctx.oldVM.Labels[vmopv1.PausedVMLabelKey] = dummyPausedVMLabelVal
+						enableBootDiskResize(ctx)
+						setCapacities(ctx, "20Gi", "20Gi")
+						ctx.oldVM.Status.RootSnapshots = []string{"snap-1"}
 					},
 					expectAllowed: true,
 				},
@@ -3969,6 +4993,149 @@ func unitTestsValidateUpdate() {
 		),
 	)
 
+	Context("Volume disk placement", func() {
+		const invalidVolumeDiskPlacementPowerStateMsg = "cannot change the controller, unit number, or sharing mode of a volume " +
+			"unless the VM is powered off, since CNS selects the controller and unit number of a disk attached to a powered on VM " +
+			"and the disk cannot be moved until the VM is powered off"
+
+		volPath := field.NewPath("spec", "volumes").Index(0)
+
+		DescribeTable("update", doTest,
+			Entry("should allow an unchanged placement when the VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeNVME
+						ctx.vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeNVME
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow changing the placement when the VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeNVME
+						ctx.vm.Spec.Volumes[0].ControllerBusNumber = ptr.To[int32](0)
+						ctx.vm.Spec.Volumes[0].UnitNumber = ptr.To[int32](1)
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(volPath, invalidVolumeDiskPlacementPowerStateMsg).Error(),
+					),
+				},
+			),
+			Entry("should disallow adding a volume with a placement when the VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes[0].Name = "new-volume"
+						ctx.vm.Spec.Volumes[0].SharingMode = vmopv1.VolumeSharingModeMultiWriter
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(volPath, invalidVolumeDiskPlacementPowerStateMsg).Error(),
+					),
+				},
+			),
+			Entry("should disallow changing the controller sharing mode when the VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						for _, vm := range []*vmopv1.VirtualMachine{ctx.oldVM, ctx.vm} {
+							vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeSCSI
+							vm.Spec.Volumes[0].ControllerBusNumber = ptr.To[int32](1)
+						}
+						ctx.vm.Spec.Volumes[0].ControllerSharingMode = vmopv1.VirtualControllerSharingModePhysical
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(volPath, invalidVolumeDiskPlacementPowerStateMsg).Error(),
+					),
+				},
+			),
+			Entry("should allow changing the placement when the VM is being powered off",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeNVME
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow changing the placement when the VM is powered off",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.Volumes[0].ControllerType = vmopv1.VirtualControllerTypeNVME
+					},
+					expectAllowed: true,
+				},
+			),
+		)
+	})
+
+	Context("CurrentSnapshot", func() {
+		currentSnapshotPath := field.NewPath("spec", "currentSnapshot")
+
+		DescribeTable("update", doTest,
+			Entry("should allow when currentSnapshot is unchanged and the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.CurrentSnapshot = "snap-1"
+						ctx.vm.Spec.CurrentSnapshot = "snap-1"
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow setting currentSnapshot when the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.CurrentSnapshot = "snap-1"
+					},
+					validate: doValidateWithMsg(
+						field.Forbidden(currentSnapshotPath, "the VM Snapshots feature is not enabled").Error(),
+					),
+				},
+			),
+			Entry("should disallow setting currentSnapshot to a snapshot that does not exist",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMSnapshots = true
+						})
+						ctx.vm.Spec.CurrentSnapshot = "snap-1"
+					},
+					validate: doValidateWithMsg(
+						field.NotFound(currentSnapshotPath, "snap-1").Error(),
+					),
+				},
+			),
+			Entry("should disallow setting currentSnapshot to a snapshot of another VM",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMSnapshots = true
+						})
+						vmSnapshot := builder.DummyVirtualMachineSnapshot(ctx.vm.Namespace, "snap-1", "other-vm")
+						Expect(ctx.Client.Create(ctx, vmSnapshot)).To(Succeed())
+						ctx.vm.Spec.CurrentSnapshot = vmSnapshot.Name
+					},
+					validate: doValidateWithMsg(
+						`spec.currentSnapshot: Invalid value: "snap-1": VirtualMachineSnapshot is a snapshot of VirtualMachine "other-vm"`,
+					),
+				},
+			),
+			Entry("should allow setting currentSnapshot to a snapshot of the VM",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMSnapshots = true
+						})
+						vmSnapshot := builder.DummyVirtualMachineSnapshot(ctx.vm.Namespace, "snap-1", ctx.vm.Name)
+						Expect(ctx.Client.Create(ctx, vmSnapshot)).To(Succeed())
+						ctx.vm.Spec.CurrentSnapshot = vmSnapshot.Name
+					},
+					expectAllowed: true,
+				},
+			),
+		)
+	})
+
 	Context("Network", func() {
 
 		DescribeTable("network update", doTest,
@@ -4058,6 +5225,123 @@ func unitTestsValidateUpdate() {
 					validate: doValidateWithMsg(`spec.network.interfaces[0].network: Forbidden: field is immutable`),
 				},
 			),
+			Entry("allow adding and removing Network Interfaces when MutableNetworks is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.MutableNetworks = true
+						})
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "net1"},
+								},
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "net2"},
+								},
+							},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "net2"},
+								},
+								{
+									Name:    "eth2",
+									Network: &common.PartialObjectRef{Name: "net3"},
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("disallow adding Network Interfaces on the same network when MutableNetworks is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.MutableNetworks = true
+						})
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "net1"},
+								},
+							},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "net1"},
+								},
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "net1"},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(`spec.network.interfaces[1].network: Forbidden: network interfaces cannot be added or removed when more than one interface is connected to the same network`),
+				},
+			),
+			Entry("allow Network Interfaces on the same network when MutableNetworks is enabled and interfaces are unchanged",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.MutableNetworks = true
+						})
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "net1"},
+								},
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "net1"},
+								},
+							},
+						}
+						ctx.vm.Spec.Network = ctx.oldVM.Spec.Network.DeepCopy()
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("disallow Network Interface Network ref change when MutableNetworks is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.MutableNetworks = true
+						})
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "net1"},
+								},
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "net2"},
+								},
+							},
+						}
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "net99"},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(`spec.network.interfaces[0].network: Forbidden: field is immutable`),
+				},
+			),
 		)
 	})
 }
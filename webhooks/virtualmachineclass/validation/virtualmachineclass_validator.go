@@ -31,6 +31,9 @@ const (
 
 	invalidCPUReqMsg    = "CPU request must not be larger than the CPU limit"
 	invalidMemoryReqMsg = "memory request must not be larger than the memory limit"
+
+	invalidStorageIOReservationMsg = "must not exceed the limit"
+	invalidStorageIOSharesMsg      = "may only be specified when level is Custom"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachineclass,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineclasses,versions=v1alpha4,name=default.validating.virtualmachineclass.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -71,6 +74,7 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 
 	var fieldErrs field.ErrorList
 
+	fieldErrs = append(fieldErrs, v.validateHardware(ctx, vmClass, field.NewPath("spec", "hardware"))...)
 	fieldErrs = append(fieldErrs, v.validatePolicies(ctx, vmClass, field.NewPath("spec", "policies"))...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
@@ -86,7 +90,15 @@ func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Respo
 }
 
 func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmClass, err := v.vmClassFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
 	var fieldErrs field.ErrorList
+
+	fieldErrs = append(fieldErrs, v.validateHardware(ctx, vmClass, field.NewPath("spec", "hardware"))...)
+
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
 		validationErrs = append(validationErrs, fieldErr.Error())
@@ -95,6 +107,31 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
 }
 
+func (v validator) validateHardware(_ *pkgctx.WebhookRequestContext, vmClass *vmopv1.VirtualMachineClass,
+	hwPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	storageIO := vmClass.Spec.Hardware.StorageIO
+	if storageIO == nil {
+		return allErrs
+	}
+	storageIOPath := hwPath.Child("storageIO")
+
+	// Validate the storage I/O reservation.
+	if storageIO.Limit != nil && storageIO.Reservation != nil && int64(*storageIO.Reservation) > *storageIO.Limit {
+		allErrs = append(allErrs, field.Invalid(storageIOPath.Child("reservation"), *storageIO.Reservation,
+			invalidStorageIOReservationMsg))
+	}
+
+	// Validate the storage I/O shares.
+	if shares := storageIO.Shares; shares != nil &&
+		shares.Level != vmopv1.VirtualMachineStorageIOSharesLevelCustom && shares.Shares != 0 {
+		allErrs = append(allErrs, field.Forbidden(storageIOPath.Child("shares", "shares"), invalidStorageIOSharesMsg))
+	}
+
+	return allErrs
+}
+
 func (v validator) validatePolicies(ctx *pkgctx.WebhookRequestContext, vmClass *vmopv1.VirtualMachineClass,
 	polPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
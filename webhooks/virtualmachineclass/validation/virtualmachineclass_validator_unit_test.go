@@ -16,6 +16,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -90,6 +91,7 @@ func unitTestsValidateCreate() {
 		invalidMemoryRequest bool
 		noCPULimit           bool
 		noMemoryLimit        bool
+		storageIO            *vmopv1.VirtualMachineStorageIOAllocation
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -109,6 +111,7 @@ func unitTestsValidateCreate() {
 		if args.noMemoryLimit {
 			ctx.vmClass.Spec.Policies.Resources.Limits.Memory = resource.MustParse("0")
 		}
+		ctx.vmClass.Spec.Hardware.StorageIO = args.storageIO
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmClass)
 		Expect(err).ToNot(HaveOccurred())
@@ -133,12 +136,36 @@ func unitTestsValidateCreate() {
 	reqPath := field.NewPath("spec", "policies", "resources", "requests")
 	invalidCPUField := field.Invalid(reqPath.Child("cpu"), "2Gi", "CPU request must not be larger than the CPU limit")
 	invalidMemField := field.Invalid(reqPath.Child("memory"), "2Gi", "memory request must not be larger than the memory limit")
+	storageIOPath := field.NewPath("spec", "hardware", "storageIO")
+	invalidStorageIOReservationField := field.Invalid(storageIOPath.Child("reservation"), 200, "must not exceed the limit")
+	invalidStorageIOSharesField := field.Forbidden(storageIOPath.Child("shares", "shares"), "may only be specified when level is Custom")
 	DescribeTable("create table", validateCreate,
 		Entry("should allow valid", createArgs{}, true, nil, nil),
 		Entry("should allow no cpu limit", createArgs{noCPULimit: true}, true, nil, nil),
 		Entry("should allow no memory limit", createArgs{noMemoryLimit: true}, true, nil, nil),
 		Entry("should deny invalid cpu request", createArgs{invalidCPURequest: true}, false, invalidCPUField.Error(), nil),
 		Entry("should deny invalid memory request", createArgs{invalidMemoryRequest: true}, false, invalidMemField.Error(), nil),
+		Entry("should allow valid storage I/O allocation",
+			createArgs{storageIO: &vmopv1.VirtualMachineStorageIOAllocation{
+				Limit:       ptr.To[int64](200),
+				Reservation: ptr.To[int32](100),
+				Shares: &vmopv1.VirtualMachineStorageIOShares{
+					Level:  vmopv1.VirtualMachineStorageIOSharesLevelCustom,
+					Shares: 2000,
+				},
+			}}, true, nil, nil),
+		Entry("should deny storage I/O reservation larger than the limit",
+			createArgs{storageIO: &vmopv1.VirtualMachineStorageIOAllocation{
+				Limit:       ptr.To[int64](100),
+				Reservation: ptr.To[int32](200),
+			}}, false, invalidStorageIOReservationField.Error(), nil),
+		Entry("should deny storage I/O shares when level is not Custom",
+			createArgs{storageIO: &vmopv1.VirtualMachineStorageIOAllocation{
+				Shares: &vmopv1.VirtualMachineStorageIOShares{
+					Level:  vmopv1.VirtualMachineStorageIOSharesLevelLow,
+					Shares: 2000,
+				},
+			}}, false, invalidStorageIOSharesField.Error(), nil),
 	)
 }
 
@@ -211,6 +238,25 @@ func unitTestsValidateUpdate() {
 		Entry("should deny policy memory change", updateArgs{changeMemory: true}, true, nil, nil),
 	)
 
+	When("the storage I/O reservation is larger than the limit", func() {
+		JustBeforeEach(func() {
+			var err error
+			ctx.vmClass.Spec.Hardware.StorageIO = &vmopv1.VirtualMachineStorageIOAllocation{
+				Limit:       ptr.To[int64](100),
+				Reservation: ptr.To[int32](200),
+			}
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmClass)
+			Expect(err).ToNot(HaveOccurred())
+			response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(field.Invalid(
+				field.NewPath("spec", "hardware", "storageIO", "reservation"), 200, "must not exceed the limit").Error()))
+		})
+	})
+
 	When("the update is performed while object deletion", func() {
 		JustBeforeEach(func() {
 			t := metav1.Now()
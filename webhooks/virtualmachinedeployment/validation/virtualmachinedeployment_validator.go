@@ -0,0 +1,239 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	bothFencepostsZero = "may not be 0 when maxSurge is 0"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachinedeployment,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinedeployments,versions=v1alpha4,name=default.validating.virtualmachinedeployment.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineDeployment validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineDeployment{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	d, err := v.deploymentFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(ctx, d)...)
+	fieldErrs = append(fieldErrs, v.validateStrategy(ctx, d)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	d, err := v.deploymentFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldD, err := v.deploymentFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(ctx, d)...)
+	fieldErrs = append(fieldErrs, v.validateStrategy(ctx, d)...)
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(ctx, d, oldD)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateLabelSelectorLabelMatch(
+	_ *pkgctx.WebhookRequestContext,
+	d *vmopv1.VirtualMachineDeployment) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("selector"),
+				d.Spec.Selector,
+				err.Error(),
+			),
+		)
+	} else if !selector.Matches(labels.Set(d.Spec.Template.ObjectMeta.Labels)) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("template", "metadata", "labels"),
+				d.Spec.Template.ObjectMeta.Labels,
+				fmt.Sprintf("must match spec.selector %q", selector.String()),
+			),
+		)
+	}
+
+	allErrs = append(allErrs, d.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
+
+	return allErrs
+}
+
+func (v validator) validateStrategy(
+	_ *pkgctx.WebhookRequestContext,
+	d *vmopv1.VirtualMachineDeployment) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	strategyPath := field.NewPath("spec", "strategy")
+	ru := d.Spec.Strategy.RollingUpdate
+
+	if ru == nil {
+		return nil
+	}
+
+	if d.Spec.Strategy.Type == vmopv1.VirtualMachineDeploymentStrategyTypeRecreate {
+		return append(allErrs, field.Forbidden(
+			strategyPath.Child("rollingUpdate"),
+			"may not be specified when strategy type is Recreate"))
+	}
+
+	ruPath := strategyPath.Child("rollingUpdate")
+	allErrs = append(allErrs, validateIntOrPercent(ru.MaxSurge, ruPath.Child("maxSurge"))...)
+	allErrs = append(allErrs, validateIntOrPercent(ru.MaxUnavailable, ruPath.Child("maxUnavailable"))...)
+
+	if len(allErrs) == 0 && isZero(ru.MaxSurge) && isZero(ru.MaxUnavailable) {
+		allErrs = append(allErrs, field.Invalid(
+			ruPath.Child("maxUnavailable"),
+			ru.MaxUnavailable,
+			bothFencepostsZero))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateImmutableFields(
+	_ *pkgctx.WebhookRequestContext,
+	d, oldD *vmopv1.VirtualMachineDeployment) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	// Changing the selector would orphan the replica sets of the previous
+	// revisions.
+	if !apiequality.Semantic.DeepEqual(d.Spec.Selector, oldD.Spec.Selector) {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("spec", "selector"),
+			"field is immutable"))
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent validates the provided value is either a non-negative
+// integer or a percentage between 0% and 100%.
+func validateIntOrPercent(val *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	if val == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	switch val.Type {
+	case intstr.Int:
+		if val.IntValue() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath, val.String(), "must be greater than or equal to 0"))
+		}
+	case intstr.String:
+		v, err := intstr.GetScaledValueFromIntOrPercent(val, 100, false)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, val.String(), "must be an integer or percentage (e.g '5%')"))
+		} else if v < 0 || v > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath, val.String(), "must be a percentage between 0% and 100%"))
+		}
+	}
+
+	return allErrs
+}
+
+// isZero returns true if the provided value is set and resolves to zero.
+func isZero(val *intstr.IntOrString) bool {
+	if val == nil {
+		return false
+	}
+	v, err := intstr.GetScaledValueFromIntOrPercent(val, 100, false)
+	return err == nil && v == 0
+}
+
+// deploymentFromUnstructured returns the VirtualMachineDeployment from the
+// unstructured object.
+func (v validator) deploymentFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineDeployment, error) {
+	d := &vmopv1.VirtualMachineDeployment{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
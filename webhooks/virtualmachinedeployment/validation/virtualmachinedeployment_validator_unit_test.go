@@ -0,0 +1,269 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+type testParams struct {
+	setup         func(ctx *unitValidatingWebhookContext)
+	validate      func(ctx *unitValidatingWebhookContext, response admission.Response)
+	expectAllowed bool
+}
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	d, oldD *vmopv1.VirtualMachineDeployment
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	d := builder.DummyVirtualMachineDeployment()
+	d.Name = "dummy-deployment-for-webhook-validation"
+	d.Namespace = "dummy-deployment-namespace-for-webhook-validation"
+	obj, err := builder.ToUnstructured(d)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldD   *vmopv1.VirtualMachineDeployment
+		oldObj *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldD = d.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldD)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj, nil...),
+		d:                                   d,
+		oldD:                                oldD,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	doTest := func(args testParams) {
+		args.setup(ctx)
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.d)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(args.expectAllowed))
+
+		if args.validate != nil {
+			args.validate(ctx, response)
+		}
+	}
+
+	expectReason := func(substr string) func(*unitValidatingWebhookContext, admission.Response) {
+		return func(_ *unitValidatingWebhookContext, response admission.Response) {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(substr))
+		}
+	}
+
+	DescribeTable("create", doTest,
+		Entry("should allow valid deployment",
+			testParams{
+				setup:         func(_ *unitValidatingWebhookContext) {},
+				expectAllowed: true,
+			},
+		),
+		Entry("should return error on selector mismatch",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.d.Spec.Selector = &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					}
+					ctx.d.Spec.Template.Labels = map[string]string{"foo": "baz"}
+				},
+				validate:      expectReason(`spec.template.metadata.labels: Invalid value`),
+				expectAllowed: false,
+			},
+		),
+		Entry("should allow rolling update with valid fenceposts",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.d.Spec.Strategy.RollingUpdate = &vmopv1.VirtualMachineDeploymentRollingUpdate{
+						MaxSurge:       &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"},
+					}
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("should return error when maxSurge and maxUnavailable are both zero",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.d.Spec.Strategy.RollingUpdate = &vmopv1.VirtualMachineDeploymentRollingUpdate{
+						MaxSurge:       &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "0%"},
+					}
+				},
+				validate:      expectReason("spec.strategy.rollingUpdate.maxUnavailable: Invalid value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on negative maxSurge",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.d.Spec.Strategy.RollingUpdate = &vmopv1.VirtualMachineDeploymentRollingUpdate{
+						MaxSurge: &intstr.IntOrString{Type: intstr.Int, IntVal: -1},
+					}
+				},
+				validate:      expectReason("spec.strategy.rollingUpdate.maxSurge: Invalid value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid maxUnavailable percentage",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.d.Spec.Strategy.RollingUpdate = &vmopv1.VirtualMachineDeploymentRollingUpdate{
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "150%"},
+					}
+				},
+				validate:      expectReason("spec.strategy.rollingUpdate.maxUnavailable: Invalid value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on rollingUpdate with Recreate strategy",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.d.Spec.Strategy.Type = vmopv1.VirtualMachineDeploymentStrategyTypeRecreate
+					ctx.d.Spec.Strategy.RollingUpdate = &vmopv1.VirtualMachineDeploymentRollingUpdate{}
+				},
+				validate:      expectReason("spec.strategy.rollingUpdate: Forbidden"),
+				expectAllowed: false,
+			},
+		),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.d)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the template is updated", func() {
+		BeforeEach(func() {
+			ctx.d.Spec.Template.Spec.ImageName = "new-image"
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the selector is updated", func() {
+		BeforeEach(func() {
+			ctx.d.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"foo": "bar"},
+			}
+			ctx.d.Spec.Template.Labels = map[string]string{"foo": "bar"}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.selector: Forbidden: field is immutable"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,289 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinenetworkpolicy"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+)
+
+// maxNameLength is the maximum length of the name of a
+// VirtualMachineNetworkPolicy. The names of the security policies created for
+// the policy are derived from its name and must also be valid.
+var maxNameLength = validation.DNS1123SubdomainMaxLength -
+	(len(virtualmachinenetworkpolicy.IsolationSecurityPolicyName("")))
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachinenetworkpolicy,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinenetworkpolicies,versions=v1alpha4,name=default.validating.virtualmachinenetworkpolicy.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineNetworkPolicy validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineNetworkPolicy{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	np, err := v.networkPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateMetadata(ctx, np)...)
+	fieldErrs = append(fieldErrs, v.validateSpec(ctx, np)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	np, err := v.networkPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(ctx, np)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateMetadata(
+	_ *pkgctx.WebhookRequestContext,
+	np *vmopv1.VirtualMachineNetworkPolicy) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if len(np.Name) > maxNameLength {
+		allErrs = append(allErrs, field.TooLong(
+			field.NewPath("metadata", "name"),
+			np.Name,
+			maxNameLength))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateSpec(
+	_ *pkgctx.WebhookRequestContext,
+	np *vmopv1.VirtualMachineNetworkPolicy) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	allErrs = append(allErrs, validateLabelSelector(
+		&np.Spec.VMSelector, specPath.Child("vmSelector"))...)
+
+	policyTypes := map[vmopv1.VirtualMachineNetworkPolicyType]struct{}{}
+	for i, t := range np.Spec.PolicyTypes {
+		p := specPath.Child("policyTypes").Index(i)
+		switch t {
+		case vmopv1.VirtualMachineNetworkPolicyTypeIngress,
+			vmopv1.VirtualMachineNetworkPolicyTypeEgress:
+		default:
+			allErrs = append(allErrs, field.NotSupported(p, t, []string{
+				string(vmopv1.VirtualMachineNetworkPolicyTypeIngress),
+				string(vmopv1.VirtualMachineNetworkPolicyTypeEgress),
+			}))
+			continue
+		}
+		if _, ok := policyTypes[t]; ok {
+			allErrs = append(allErrs, field.Duplicate(p, t))
+		}
+		policyTypes[t] = struct{}{}
+	}
+
+	for i, r := range np.Spec.Ingress {
+		rulePath := specPath.Child("ingress").Index(i)
+		for j := range r.From {
+			allErrs = append(allErrs, validatePeer(r.From[j], rulePath.Child("from").Index(j))...)
+		}
+		for j := range r.Ports {
+			allErrs = append(allErrs, validatePort(r.Ports[j], rulePath.Child("ports").Index(j))...)
+		}
+	}
+
+	for i, r := range np.Spec.Egress {
+		rulePath := specPath.Child("egress").Index(i)
+		for j := range r.To {
+			allErrs = append(allErrs, validatePeer(r.To[j], rulePath.Child("to").Index(j))...)
+		}
+		for j := range r.Ports {
+			allErrs = append(allErrs, validatePort(r.Ports[j], rulePath.Child("ports").Index(j))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateLabelSelector(
+	selector *metav1.LabelSelector,
+	fieldPath *field.Path) field.ErrorList {
+
+	return metav1validation.ValidateLabelSelector(
+		selector,
+		metav1validation.LabelSelectorValidationOptions{},
+		fieldPath)
+}
+
+func validatePeer(
+	peer vmopv1.VirtualMachineNetworkPolicyPeer,
+	peerPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if peer.IPBlock != nil {
+		if peer.VMSelector != nil || peer.NamespaceSelector != nil {
+			allErrs = append(allErrs, field.Forbidden(
+				peerPath.Child("ipBlock"),
+				"may not be specified with vmSelector or namespaceSelector"))
+		}
+		if _, err := netip.ParsePrefix(peer.IPBlock.CIDR); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				peerPath.Child("ipBlock", "cidr"),
+				peer.IPBlock.CIDR,
+				"must be a valid CIDR"))
+		}
+		return allErrs
+	}
+
+	if peer.VMSelector == nil && peer.NamespaceSelector == nil {
+		allErrs = append(allErrs, field.Required(
+			peerPath,
+			"must specify one of vmSelector, namespaceSelector, or ipBlock"))
+		return allErrs
+	}
+
+	if peer.VMSelector != nil {
+		allErrs = append(allErrs, validateLabelSelector(
+			peer.VMSelector, peerPath.Child("vmSelector"))...)
+	}
+	if peer.NamespaceSelector != nil {
+		allErrs = append(allErrs, validateLabelSelector(
+			peer.NamespaceSelector, peerPath.Child("namespaceSelector"))...)
+	}
+
+	return allErrs
+}
+
+func validatePort(
+	port vmopv1.VirtualMachineNetworkPolicyPort,
+	portPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	switch port.Protocol {
+	case "", "TCP", "UDP":
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			portPath.Child("protocol"),
+			port.Protocol,
+			[]string{"TCP", "UDP"}))
+	}
+
+	if port.Port != nil {
+		if port.Port.Type == intstr.Int {
+			for _, msg := range validation.IsValidPortNum(port.Port.IntValue()) {
+				allErrs = append(allErrs, field.Invalid(portPath.Child("port"), port.Port.IntValue(), msg))
+			}
+		} else {
+			for _, msg := range validation.IsValidPortName(port.Port.StrVal) {
+				allErrs = append(allErrs, field.Invalid(portPath.Child("port"), port.Port.StrVal, msg))
+			}
+		}
+	}
+
+	if port.EndPort != nil {
+		endPortPath := portPath.Child("endPort")
+		switch {
+		case port.Port == nil:
+			allErrs = append(allErrs, field.Required(
+				portPath.Child("port"),
+				"must be specified when endPort is specified"))
+		case port.Port.Type != intstr.Int:
+			allErrs = append(allErrs, field.Forbidden(
+				endPortPath,
+				"may not be specified when port is a named port"))
+		case int(*port.EndPort) < port.Port.IntValue():
+			allErrs = append(allErrs, field.Invalid(
+				endPortPath,
+				*port.EndPort,
+				"must be greater than or equal to port"))
+		}
+	}
+
+	return allErrs
+}
+
+// networkPolicyFromUnstructured returns the VirtualMachineNetworkPolicy from
+// the unstructured object.
+func (v validator) networkPolicyFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineNetworkPolicy, error) {
+
+	np := &vmopv1.VirtualMachineNetworkPolicy{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), np); err != nil {
+		return nil, err
+	}
+	return np, nil
+}
@@ -0,0 +1,110 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateUpdate,
+	)
+}
+
+type intgValidatingWebhookContext struct {
+	builder.IntegrationTestContext
+	np *vmopv1.VirtualMachineNetworkPolicy
+}
+
+func newIntgValidatingWebhookContext() *intgValidatingWebhookContext {
+	ctx := &intgValidatingWebhookContext{
+		IntegrationTestContext: *suite.NewIntegrationTestContext(),
+	}
+
+	ctx.np = builder.DummyVirtualMachineNetworkPolicy(ctx.Namespace, "dummy-np")
+
+	return ctx
+}
+
+func intgTestsValidateCreate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow a valid policy", func() {
+		Expect(ctx.Client.Create(ctx, ctx.np)).To(Succeed())
+	})
+
+	It("should deny a policy with an invalid CIDR", func() {
+		ctx.np.Spec.Ingress[0].From[0] = vmopv1.VirtualMachineNetworkPolicyPeer{
+			IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.300/8"},
+		}
+		err := ctx.Client.Create(ctx, ctx.np)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.ingress[0].from[0].ipBlock.cidr: Invalid value"))
+	})
+}
+
+func intgTestsValidateUpdate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+		Expect(ctx.Client.Create(ctx, ctx.np)).To(Succeed())
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow an update to the ports", func() {
+		ctx.np.Spec.Ingress[0].Ports[0].EndPort = ptr.To[int32](5433)
+		Expect(ctx.Client.Update(ctx, ctx.np)).To(Succeed())
+	})
+
+	It("should deny an update to a named port with an endPort", func() {
+		ctx.np.Spec.Ingress[0].Ports[0].Port = ptr.To(intstr.FromString("postgres"))
+		ctx.np.Spec.Ingress[0].Ports[0].EndPort = ptr.To[int32](5433)
+		err := ctx.Client.Update(ctx, ctx.np)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.ingress[0].ports[0].endPort: Forbidden"))
+	})
+}
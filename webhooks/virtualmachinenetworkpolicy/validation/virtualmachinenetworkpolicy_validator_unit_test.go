@@ -0,0 +1,341 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+type testParams struct {
+	setup         func(ctx *unitValidatingWebhookContext)
+	validate      func(ctx *unitValidatingWebhookContext, response admission.Response)
+	expectAllowed bool
+}
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	np, oldNP *vmopv1.VirtualMachineNetworkPolicy
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	np := builder.DummyVirtualMachineNetworkPolicy(
+		"dummy-np-namespace-for-webhook-validation",
+		"dummy-np-for-webhook-validation")
+	obj, err := builder.ToUnstructured(np)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldNP  *vmopv1.VirtualMachineNetworkPolicy
+		oldObj *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldNP = np.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldNP)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj, nil...),
+		np:                                  np,
+		oldNP:                               oldNP,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	doTest := func(args testParams) {
+		args.setup(ctx)
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.np)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(args.expectAllowed))
+
+		if args.validate != nil {
+			args.validate(ctx, response)
+		}
+	}
+
+	expectReason := func(substr string) func(*unitValidatingWebhookContext, admission.Response) {
+		return func(_ *unitValidatingWebhookContext, response admission.Response) {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(substr))
+		}
+	}
+
+	DescribeTable("create", doTest,
+		Entry("should allow valid policy",
+			testParams{
+				setup:         func(_ *unitValidatingWebhookContext) {},
+				expectAllowed: true,
+			},
+		),
+		Entry("should allow valid policy with egress rules",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.PolicyTypes = []vmopv1.VirtualMachineNetworkPolicyType{
+						vmopv1.VirtualMachineNetworkPolicyTypeIngress,
+						vmopv1.VirtualMachineNetworkPolicyTypeEgress,
+					}
+					ctx.np.Spec.Egress = []vmopv1.VirtualMachineNetworkPolicyEgressRule{
+						{
+							To: []vmopv1.VirtualMachineNetworkPolicyPeer{
+								{
+									IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "fd00::/64"},
+								},
+								{
+									NamespaceSelector: &metav1.LabelSelector{},
+								},
+							},
+							Ports: []vmopv1.VirtualMachineNetworkPolicyPort{
+								{
+									Protocol: "UDP",
+									Port:     ptr.To(intstr.FromInt32(5000)),
+									EndPort:  ptr.To[int32](5010),
+								},
+								{
+									Port: ptr.To(intstr.FromString("http")),
+								},
+							},
+						},
+					}
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("should return error on name that is too long",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Name = strings.Repeat("a", 250)
+				},
+				validate:      expectReason("metadata.name: Too long"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid vmSelector",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.VMSelector.MatchLabels = map[string]string{"-invalid-": "db"}
+				},
+				validate:      expectReason("spec.vmSelector.matchLabels: Invalid value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on duplicate policy type",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.PolicyTypes = []vmopv1.VirtualMachineNetworkPolicyType{
+						vmopv1.VirtualMachineNetworkPolicyTypeIngress,
+						vmopv1.VirtualMachineNetworkPolicyTypeIngress,
+					}
+				},
+				validate:      expectReason("spec.policyTypes[1]: Duplicate value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on empty peer",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].From = append(ctx.np.Spec.Ingress[0].From, vmopv1.VirtualMachineNetworkPolicyPeer{})
+				},
+				validate:      expectReason("spec.ingress[0].from[1]: Required value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on ipBlock with a selector",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].From[0].IPBlock = &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.0/8"}
+				},
+				validate:      expectReason("spec.ingress[0].from[0].ipBlock: Forbidden"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid CIDR",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].From[0] = vmopv1.VirtualMachineNetworkPolicyPeer{
+						IPBlock: &vmopv1.VirtualMachineNetworkPolicyIPBlock{CIDR: "10.0.0.0"},
+					}
+				},
+				validate:      expectReason("spec.ingress[0].from[0].ipBlock.cidr: Invalid value: \"10.0.0.0\": must be a valid CIDR"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid port number",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].Ports[0].Port = ptr.To(intstr.FromInt32(70000))
+				},
+				validate:      expectReason("spec.ingress[0].ports[0].port: Invalid value: 70000"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on invalid protocol",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].Ports[0].Protocol = "SCTP"
+				},
+				validate:      expectReason("spec.ingress[0].ports[0].protocol: Unsupported value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on endPort without port",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].Ports[0].Port = nil
+					ctx.np.Spec.Ingress[0].Ports[0].EndPort = ptr.To[int32](6000)
+				},
+				validate:      expectReason("spec.ingress[0].ports[0].port: Required value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on endPort with named port",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].Ports[0].Port = ptr.To(intstr.FromString("postgres"))
+					ctx.np.Spec.Ingress[0].Ports[0].EndPort = ptr.To[int32](6000)
+				},
+				validate:      expectReason("spec.ingress[0].ports[0].endPort: Forbidden"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on endPort less than port",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.np.Spec.Ingress[0].Ports[0].EndPort = ptr.To[int32](5000)
+				},
+				validate:      expectReason("spec.ingress[0].ports[0].endPort: Invalid value: 5000: must be greater than or equal to port"),
+				expectAllowed: false,
+			},
+		),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.np)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the vmSelector is updated", func() {
+		BeforeEach(func() {
+			ctx.np.Spec.VMSelector.MatchLabels["tier"] = "backend"
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("a rule is updated to be invalid", func() {
+		BeforeEach(func() {
+			ctx.np.Spec.Ingress[0].From[0] = vmopv1.VirtualMachineNetworkPolicyPeer{}
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.ingress[0].from[0]: Required value"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
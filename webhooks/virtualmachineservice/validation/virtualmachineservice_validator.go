@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -25,6 +26,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/providers"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
@@ -32,15 +35,41 @@ import (
 
 const (
 	webHookName = "default"
+
+	// maxClientIPServiceAffinitySeconds is the max timeout of ClientIP
+	// session affinity that k8s allows (1 day).
+	maxClientIPServiceAffinitySeconds = 86400
 )
 
 var (
 	supportedServiceType = sets.NewString(
 		string(vmopv1.VirtualMachineServiceTypeLoadBalancer),
 		string(vmopv1.VirtualMachineServiceTypeClusterIP),
+		string(vmopv1.VirtualMachineServiceTypeNodePort),
 		string(vmopv1.VirtualMachineServiceTypeExternalName),
 	)
 
+	supportedSessionAffinity = sets.NewString(
+		string(vmopv1.VirtualMachineServiceAffinityClientIP),
+		string(vmopv1.VirtualMachineServiceAffinityNone),
+	)
+
+	supportedExternalTrafficPolicy = sets.NewString(
+		string(vmopv1.VirtualMachineServiceExternalTrafficPolicyCluster),
+		string(vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal),
+	)
+
+	supportedIPFamilies = sets.NewString(
+		string(vmopv1.VirtualMachineServiceIPv4Protocol),
+		string(vmopv1.VirtualMachineServiceIPv6Protocol),
+	)
+
+	supportedIPFamilyPolicy = sets.NewString(
+		string(vmopv1.VirtualMachineServiceIPFamilyPolicySingleStack),
+		string(vmopv1.VirtualMachineServiceIPFamilyPolicyPreferDualStack),
+		string(vmopv1.VirtualMachineServiceIPFamilyPolicyRequireDualStack),
+	)
+
 	supportedPortProtocols = sets.NewString(
 		string(corev1.ProtocolTCP),
 		string(corev1.ProtocolUDP),
@@ -132,6 +161,23 @@ func (v validator) validateMetadata(ctx *pkgctx.WebhookRequestContext, vmService
 
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, ValidateDNS1035Label(vmService.Name, mdPath.Child("name"))...)
+	allErrs = append(allErrs, validateLoadBalancerProvider(vmService, mdPath)...)
+
+	return allErrs
+}
+
+// validateLoadBalancerProvider returns an error if the load balancer provider
+// annotation is not a registered provider type, since the controller would
+// otherwise silently fall back to the default provider.
+func validateLoadBalancerProvider(vmService *vmopv1.VirtualMachineService, mdPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if t, ok := vmService.Annotations[utils.AnnotationLoadBalancerProviderKey]; ok {
+		if types := providers.RegisteredLoadbalancerProviderTypes(); !slices.Contains(types, t) {
+			allErrs = append(allErrs, field.NotSupported(
+				mdPath.Child("annotations").Key(utils.AnnotationLoadBalancerProviderKey), t, types))
+		}
+	}
 
 	return allErrs
 }
@@ -141,10 +187,10 @@ func (v validator) validateSpec(ctx *pkgctx.WebhookRequestContext, vmService *vm
 	specPath := field.NewPath("spec")
 
 	switch vmService.Spec.Type {
-	case vmopv1.VirtualMachineServiceTypeLoadBalancer:
+	case vmopv1.VirtualMachineServiceTypeLoadBalancer, vmopv1.VirtualMachineServiceTypeNodePort:
 		if isHeadlessVMService(vmService) {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("clusterIP"), vmService.Spec.ClusterIP,
-				"may not be set to 'None' for LoadBalancer services"))
+				fmt.Sprintf("may not be set to 'None' for %s services", vmService.Spec.Type)))
 		}
 
 	case vmopv1.VirtualMachineServiceTypeExternalName:
@@ -207,6 +253,112 @@ func (v validator) validateSpec(ctx *pkgctx.WebhookRequestContext, vmService *vm
 		}
 	}
 
+	if lbClass := vmService.Spec.LoadBalancerClass; lbClass != nil {
+		fldPath := specPath.Child("loadBalancerClass")
+
+		if vmService.Spec.Type != vmopv1.VirtualMachineServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "may only be used when `type` is 'LoadBalancer'"))
+		}
+
+		allErrs = append(allErrs, unversionedvalidation.ValidateLabelName(*lbClass, fldPath)...)
+	}
+
+	allErrs = append(allErrs, validateSessionAffinity(vmService, specPath)...)
+	allErrs = append(allErrs, validateExternalTrafficPolicy(vmService, specPath)...)
+	allErrs = append(allErrs, validateIPFamilies(vmService, specPath)...)
+
+	return allErrs
+}
+
+func validateSessionAffinity(vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	affinity := vmService.Spec.SessionAffinity
+
+	if affinity != "" && !supportedSessionAffinity.Has(string(affinity)) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("sessionAffinity"), affinity, supportedSessionAffinity.List()))
+	}
+
+	if cfg := vmService.Spec.SessionAffinityConfig; cfg != nil {
+		fldPath := specPath.Child("sessionAffinityConfig")
+
+		if affinity != vmopv1.VirtualMachineServiceAffinityClientIP {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "may only be used when `sessionAffinity` is 'ClientIP'"))
+		} else if cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+			timeoutSeconds := *cfg.ClientIP.TimeoutSeconds
+			if timeoutSeconds <= 0 || timeoutSeconds > maxClientIPServiceAffinitySeconds {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("clientIP", "timeoutSeconds"), timeoutSeconds,
+					fmt.Sprintf("must be greater than 0 and less than or equal to %d", maxClientIPServiceAffinitySeconds)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateExternalTrafficPolicy(vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	policy := vmService.Spec.ExternalTrafficPolicy
+
+	if policy == "" {
+		return nil
+	}
+
+	fldPath := specPath.Child("externalTrafficPolicy")
+
+	if vmService.Spec.Type != vmopv1.VirtualMachineServiceTypeLoadBalancer && vmService.Spec.Type != vmopv1.VirtualMachineServiceTypeNodePort {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "may only be used when `type` is 'NodePort' or 'LoadBalancer'"))
+	}
+
+	if !supportedExternalTrafficPolicy.Has(string(policy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, policy, supportedExternalTrafficPolicy.List()))
+	}
+
+	return allErrs
+}
+
+func validateIPFamilies(vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	ipFamiliesPath := specPath.Child("ipFamilies")
+	ipFamilyPolicyPath := specPath.Child("ipFamilyPolicy")
+
+	if vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeExternalName {
+		if len(vmService.Spec.IPFamilies) > 0 {
+			allErrs = append(allErrs, field.Forbidden(ipFamiliesPath, "may not be set for ExternalName services"))
+		}
+		if vmService.Spec.IPFamilyPolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(ipFamilyPolicyPath, "may not be set for ExternalName services"))
+		}
+		return allErrs
+	}
+
+	if len(vmService.Spec.IPFamilies) > 2 {
+		allErrs = append(allErrs, field.TooMany(ipFamiliesPath, len(vmService.Spec.IPFamilies), 2))
+	}
+
+	seen := sets.Set[vmopv1.VirtualMachineServiceIPFamily]{}
+	for i, f := range vmService.Spec.IPFamilies {
+		if !supportedIPFamilies.Has(string(f)) {
+			allErrs = append(allErrs, field.NotSupported(ipFamiliesPath.Index(i), f, supportedIPFamilies.List()))
+		} else if seen.Has(f) {
+			allErrs = append(allErrs, field.Duplicate(ipFamiliesPath.Index(i), f))
+		}
+		seen.Insert(f)
+	}
+
+	// A Service with multiple IP families is only valid with a dual-stack
+	// policy, and the policy is not defaulted from the families.
+	if p := vmService.Spec.IPFamilyPolicy; p != nil {
+		if !supportedIPFamilyPolicy.Has(string(*p)) {
+			allErrs = append(allErrs, field.NotSupported(ipFamilyPolicyPath, *p, supportedIPFamilyPolicy.List()))
+		} else if *p == vmopv1.VirtualMachineServiceIPFamilyPolicySingleStack && len(vmService.Spec.IPFamilies) > 1 {
+			allErrs = append(allErrs, field.Invalid(ipFamilyPolicyPath, *p,
+				"must be 'PreferDualStack' or 'RequireDualStack' when multiple `ipFamilies` are specified"))
+		}
+	} else if len(vmService.Spec.IPFamilies) > 1 {
+		allErrs = append(allErrs, field.Required(ipFamilyPolicyPath,
+			"must be 'PreferDualStack' or 'RequireDualStack' when multiple `ipFamilies` are specified"))
+	}
+
 	return allErrs
 }
 
@@ -222,6 +374,12 @@ func validatePorts(vmService *vmopv1.VirtualMachineService, specPath *field.Path
 	for i := range vmService.Spec.Ports {
 		portPath := portsPath.Index(i)
 		allErrs = append(allErrs, validateServicePort(&vmService.Spec.Ports[i], len(vmService.Spec.Ports) > 1, &allPortNames, portPath)...)
+
+		if vmService.Spec.Ports[i].NodePort != 0 &&
+			vmService.Spec.Type != vmopv1.VirtualMachineServiceTypeNodePort &&
+			vmService.Spec.Type != vmopv1.VirtualMachineServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.Forbidden(portPath.Child("nodePort"), "may only be used when `type` is 'NodePort' or 'LoadBalancer'"))
+		}
 	}
 
 	// Check for duplicate Ports, considering (protocol,port) pairs.
@@ -263,8 +421,24 @@ func validateServicePort(sp *vmopv1.VirtualMachineServicePort, requireName bool,
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), sp.Protocol, supportedPortProtocols.List()))
 	}
 
-	for _, msg := range validation.IsValidPortNum(int(sp.TargetPort)) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPort"), sp.TargetPort, msg))
+	// The TargetPort may be omitted when the port is resolved by name from
+	// each VM.
+	if len(sp.TargetPortName) == 0 || sp.TargetPort != 0 {
+		for _, msg := range validation.IsValidPortNum(int(sp.TargetPort)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPort"), sp.TargetPort, msg))
+		}
+	}
+
+	if len(sp.TargetPortName) != 0 {
+		for _, msg := range validation.IsValidPortName(sp.TargetPortName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPortName"), sp.TargetPortName, msg))
+		}
+	}
+
+	if sp.NodePort != 0 {
+		for _, msg := range validation.IsValidPortNum(int(sp.NodePort)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodePort"), sp.NodePort, msg))
+		}
 	}
 
 	return allErrs
@@ -285,6 +459,17 @@ func (v validator) validateAllowedChanges(ctx *pkgctx.WebhookRequestContext, vmS
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("clusterIP"), "field is immutable"))
 	}
 
+	// Service's LoadBalancerClass cannot be changed through updates so neither should ours.
+	if !reflect.DeepEqual(vmService.Spec.LoadBalancerClass, oldVMService.Spec.LoadBalancerClass) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("loadBalancerClass"), "field is immutable"))
+	}
+
+	// Like a Service, the primary IP family cannot be changed once set.
+	if len(oldVMService.Spec.IPFamilies) > 0 && len(vmService.Spec.IPFamilies) > 0 &&
+		vmService.Spec.IPFamilies[0] != oldVMService.Spec.IPFamilies[0] {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("ipFamilies").Index(0), "field is immutable"))
+	}
+
 	return allErrs
 }
 
@@ -13,7 +13,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/providers"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice/utils"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -83,14 +86,32 @@ func unitTestsValidateCreate() {
 	)
 
 	type createArgs struct {
-		invalidDNSName        bool
-		emptyType             bool
-		invalidType           bool
-		invalidPorts          bool
-		invalidSelector       bool
-		invalidClusterIP      bool
-		invalidLBSourceRanges bool
-		invalidExternalName   bool
+		invalidDNSName                 bool
+		emptyType                      bool
+		invalidType                    bool
+		invalidPorts                   bool
+		invalidSelector                bool
+		invalidClusterIP               bool
+		invalidLBSourceRanges          bool
+		invalidExternalName            bool
+		nodePortType                   bool
+		invalidAffinity                bool
+		invalidAffinityConfig          bool
+		invalidAffinityTimeout         bool
+		invalidTrafficPolicy           bool
+		trafficPolicyType              bool
+		selectorlessLocalTrafficPolicy bool
+		invalidIPFamily                bool
+		duplicateIPFamilies            bool
+		singleStackDual                bool
+		dualStackNoPolicy              bool
+		externalNameIPFamily           bool
+		dualStack                      bool
+		lbClass                        bool
+		invalidLBClass                 bool
+		lbClassType                    bool
+		lbProvider                     bool
+		invalidLBProvider              bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -123,6 +144,94 @@ func unitTestsValidateCreate() {
 			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeExternalName
 			ctx.vmService.Spec.ExternalName = "InValid!"
 		}
+		if args.nodePortType {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeNodePort
+			ctx.vmService.Spec.Ports[0].NodePort = 30080
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+		}
+		if args.invalidAffinity {
+			ctx.vmService.Spec.SessionAffinity = "Invalid"
+		}
+		if args.invalidAffinityConfig {
+			ctx.vmService.Spec.SessionAffinityConfig = &vmopv1.VirtualMachineServiceSessionAffinityConfig{}
+		}
+		if args.invalidAffinityTimeout {
+			ctx.vmService.Spec.SessionAffinity = vmopv1.VirtualMachineServiceAffinityClientIP
+			ctx.vmService.Spec.SessionAffinityConfig = &vmopv1.VirtualMachineServiceSessionAffinityConfig{
+				ClientIP: &vmopv1.VirtualMachineServiceClientIPConfig{
+					TimeoutSeconds: ptr.To[int32](86401),
+				},
+			}
+		}
+		if args.invalidTrafficPolicy {
+			ctx.vmService.Spec.ExternalTrafficPolicy = "Invalid"
+		}
+		if args.trafficPolicyType {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeClusterIP
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+		}
+		if args.selectorlessLocalTrafficPolicy {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			ctx.vmService.Spec.Selector = nil
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+		}
+		if args.invalidIPFamily {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{"IPv5"}
+		}
+		if args.duplicateIPFamilies {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{
+				vmopv1.VirtualMachineServiceIPv4Protocol,
+				vmopv1.VirtualMachineServiceIPv4Protocol,
+			}
+			ctx.vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.VirtualMachineServiceIPFamilyPolicyPreferDualStack)
+		}
+		if args.singleStackDual {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{
+				vmopv1.VirtualMachineServiceIPv4Protocol,
+				vmopv1.VirtualMachineServiceIPv6Protocol,
+			}
+			ctx.vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.VirtualMachineServiceIPFamilyPolicySingleStack)
+		}
+		if args.dualStackNoPolicy {
+			ctx.vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{
+				vmopv1.VirtualMachineServiceIPv4Protocol,
+				vmopv1.VirtualMachineServiceIPv6Protocol,
+			}
+		}
+		if args.externalNameIPFamily {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeExternalName
+			ctx.vmService.Spec.ExternalName = "example.com"
+			ctx.vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{vmopv1.VirtualMachineServiceIPv4Protocol}
+		}
+		if args.dualStack {
+			ctx.vmService.Spec.SessionAffinity = vmopv1.VirtualMachineServiceAffinityClientIP
+			ctx.vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{
+				vmopv1.VirtualMachineServiceIPv6Protocol,
+				vmopv1.VirtualMachineServiceIPv4Protocol,
+			}
+			ctx.vmService.Spec.IPFamilyPolicy = ptr.To(vmopv1.VirtualMachineServiceIPFamilyPolicyRequireDualStack)
+		}
+
+		if args.lbClass {
+			ctx.vmService.Spec.LoadBalancerClass = ptr.To("example.com/my-lb")
+		}
+		if args.invalidLBClass {
+			ctx.vmService.Spec.LoadBalancerClass = ptr.To("Invalid Class!")
+		}
+		if args.lbClassType {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeClusterIP
+			ctx.vmService.Spec.LoadBalancerClass = ptr.To("example.com/my-lb")
+		}
+		if args.lbProvider {
+			ctx.vmService.Annotations = map[string]string{
+				utils.AnnotationLoadBalancerProviderKey: providers.GenericLoadBalancer,
+			}
+		}
+		if args.invalidLBProvider {
+			ctx.vmService.Annotations = map[string]string{
+				utils.AnnotationLoadBalancerProviderKey: "generic",
+			}
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmService)
 		Expect(err).ToNot(HaveOccurred())
@@ -154,6 +263,24 @@ func unitTestsValidateCreate() {
 		Entry("should deny invalid ClusterIP", createArgs{invalidClusterIP: true}, false, "spec.clusterIP: Invalid value: \"100.1000.1.1\": must be a valid IP address", nil),
 		Entry("should deny invalid LoadBalancerSourceRanges", createArgs{invalidLBSourceRanges: true}, false, `spec.loadBalancerSourceRanges[0]: Invalid value: "10.1.1.1/42": must be compatible with https://pkg.go.dev/net#ParseCIDR`, nil),
 		Entry("should deny invalid ExternalName", createArgs{invalidExternalName: true}, false, "spec.externalName: Invalid value: \"InValid!\": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters", nil),
+		Entry("should allow LoadBalancerClass", createArgs{lbClass: true}, true, nil, nil),
+		Entry("should deny invalid LoadBalancerClass", createArgs{invalidLBClass: true}, false, `spec.loadBalancerClass: Invalid value: "Invalid Class!"`, nil),
+		Entry("should deny LoadBalancerClass for ClusterIP", createArgs{lbClassType: true}, false, "spec.loadBalancerClass: Forbidden: may only be used when `type` is 'LoadBalancer'", nil),
+		Entry("should allow registered load balancer provider", createArgs{lbProvider: true}, true, nil, nil),
+		Entry("should deny unregistered load balancer provider", createArgs{invalidLBProvider: true}, false, `metadata.annotations[virtualmachineservice.vmoperator.vmware.com/loadBalancerProvider]: Unsupported value: "generic"`, nil),
+		Entry("should allow NodePort", createArgs{nodePortType: true}, true, nil, nil),
+		Entry("should allow dual-stack with ClientIP session affinity", createArgs{dualStack: true}, true, nil, nil),
+		Entry("should deny invalid SessionAffinity", createArgs{invalidAffinity: true}, false, `spec.sessionAffinity: Unsupported value: "Invalid"`, nil),
+		Entry("should deny SessionAffinityConfig without ClientIP affinity", createArgs{invalidAffinityConfig: true}, false, "spec.sessionAffinityConfig: Forbidden: may only be used when `sessionAffinity` is 'ClientIP'", nil),
+		Entry("should deny invalid SessionAffinityConfig timeout", createArgs{invalidAffinityTimeout: true}, false, "spec.sessionAffinityConfig.clientIP.timeoutSeconds: Invalid value: 86401: must be greater than 0 and less than or equal to 86400", nil),
+		Entry("should deny invalid ExternalTrafficPolicy", createArgs{invalidTrafficPolicy: true}, false, `spec.externalTrafficPolicy: Unsupported value: "Invalid"`, nil),
+		Entry("should deny ExternalTrafficPolicy for ClusterIP", createArgs{trafficPolicyType: true}, false, "spec.externalTrafficPolicy: Forbidden: may only be used when `type` is 'NodePort' or 'LoadBalancer'", nil),
+		Entry("should allow Local ExternalTrafficPolicy without a selector", createArgs{selectorlessLocalTrafficPolicy: true}, true, nil, nil),
+		Entry("should deny invalid IPFamilies", createArgs{invalidIPFamily: true}, false, `spec.ipFamilies[0]: Unsupported value: "IPv5"`, nil),
+		Entry("should deny duplicate IPFamilies", createArgs{duplicateIPFamilies: true}, false, `spec.ipFamilies[1]: Duplicate value: "IPv4"`, nil),
+		Entry("should deny SingleStack with two IPFamilies", createArgs{singleStackDual: true}, false, `spec.ipFamilyPolicy: Invalid value: "SingleStack": must be 'PreferDualStack' or 'RequireDualStack' when multiple `+"`ipFamilies`"+` are specified`, nil),
+		Entry("should deny two IPFamilies without an IPFamilyPolicy", createArgs{dualStackNoPolicy: true}, false, `spec.ipFamilyPolicy: Required value: must be 'PreferDualStack' or 'RequireDualStack' when multiple `+"`ipFamilies`"+` are specified`, nil),
+		Entry("should deny IPFamilies for ExternalName", createArgs{externalNameIPFamily: true}, false, "spec.ipFamilies: Forbidden: may not be set for ExternalName services", nil),
 	)
 
 	validatePortCreate := func(expectedReason string, ports []vmopv1.VirtualMachineServicePort) {
@@ -212,6 +339,57 @@ func unitTestsValidateCreate() {
 				},
 			},
 		),
+		Entry("should allow valid target port name", "",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:           "http",
+					Protocol:       "TCP",
+					Port:           80,
+					TargetPortName: "http",
+				},
+			},
+		),
+		Entry("should allow valid target port name with target port", "",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:           "http",
+					Protocol:       "TCP",
+					Port:           80,
+					TargetPort:     8080,
+					TargetPortName: "http",
+				},
+			},
+		),
+		Entry("should deny invalid target port name", "spec.ports[0].targetPortName: Invalid value: \"INVALID!\"",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:           "http",
+					Protocol:       "TCP",
+					Port:           80,
+					TargetPortName: "INVALID!",
+				},
+			},
+		),
+		Entry("should deny invalid target port with target port name", "spec.ports[0].targetPort: Invalid value: 200000:",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:           "http",
+					Protocol:       "TCP",
+					Port:           80,
+					TargetPort:     200000,
+					TargetPortName: "http",
+				},
+			},
+		),
+		Entry("should deny missing target port and target port name", "spec.ports[0].targetPort: Invalid value: 0:",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:     "http",
+					Protocol: "TCP",
+					Port:     80,
+				},
+			},
+		),
 		Entry("should deny duplicate names", "spec.ports[1].name: Duplicate value: \"port1\"",
 			[]vmopv1.VirtualMachineServicePort{
 				{
@@ -228,6 +406,17 @@ func unitTestsValidateCreate() {
 				},
 			},
 		),
+		Entry("should deny invalid node port", "spec.ports[0].nodePort: Invalid value: 100000:",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:       "port1",
+					Protocol:   "TCP",
+					Port:       80,
+					TargetPort: 8080,
+					NodePort:   100000,
+				},
+			},
+		),
 		Entry("should deny duplicate protocol/port", "spec.ports[1]: Duplicate value: v1alpha4.VirtualMachineServicePort",
 			[]vmopv1.VirtualMachineServicePort{
 				{
@@ -254,8 +443,10 @@ func unitTestsValidateUpdate() {
 	)
 
 	type updateArgs struct {
-		updateType      bool
-		updateClusterIP bool
+		updateType       bool
+		updateClusterIP  bool
+		updateIPFamilies bool
+		updateLBClass    bool
 	}
 
 	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -267,6 +458,15 @@ func unitTestsValidateUpdate() {
 		if args.updateClusterIP {
 			ctx.vmService.Spec.ClusterIP = "9.9.9.9"
 		}
+		if args.updateLBClass {
+			ctx.vmService.Spec.LoadBalancerClass = ptr.To("example.com/my-lb")
+		}
+		if args.updateIPFamilies {
+			ctx.oldVMService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{vmopv1.VirtualMachineServiceIPv4Protocol}
+			ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldVMService)
+			Expect(err).ToNot(HaveOccurred())
+			ctx.vmService.Spec.IPFamilies = []vmopv1.VirtualMachineServiceIPFamily{vmopv1.VirtualMachineServiceIPv6Protocol}
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmService)
 		Expect(err).ToNot(HaveOccurred())
@@ -292,6 +492,8 @@ func unitTestsValidateUpdate() {
 		Entry("should allow", updateArgs{}, true, nil, nil),
 		Entry("should deny Type change", updateArgs{updateType: true}, false, "spec.type: Forbidden: field is immutable", nil),
 		Entry("should deny ClusterIP change", updateArgs{updateClusterIP: true}, false, "spec.clusterIP: Forbidden: field is immutable", nil),
+		Entry("should deny LoadBalancerClass change", updateArgs{updateLBClass: true}, false, "spec.loadBalancerClass: Forbidden: field is immutable", nil),
+		Entry("should deny primary IPFamily change", updateArgs{updateIPFamilies: true}, false, "spec.ipFamilies[0]: Forbidden: field is immutable", nil),
 	)
 
 	When("the update is performed while object deletion", func() {
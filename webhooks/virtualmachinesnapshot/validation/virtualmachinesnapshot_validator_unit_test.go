@@ -0,0 +1,261 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+type testParams struct {
+	setup         func(ctx *unitValidatingWebhookContext)
+	validate      func(ctx *unitValidatingWebhookContext, response admission.Response)
+	expectAllowed bool
+}
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmSnapshot, oldVMSnapshot *vmopv1.VirtualMachineSnapshot
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmSnapshot := builder.DummyVirtualMachineSnapshot(
+		"dummy-snapshot-namespace-for-webhook-validation",
+		"dummy-snapshot-for-webhook-validation",
+		"dummy-vm")
+	obj, err := builder.ToUnstructured(vmSnapshot)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldVMSnapshot *vmopv1.VirtualMachineSnapshot
+		oldObj        *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldVMSnapshot = vmSnapshot.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj, nil...),
+		vmSnapshot:                          vmSnapshot,
+		oldVMSnapshot:                       oldVMSnapshot,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	doTest := func(args testParams) {
+		args.setup(ctx)
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(args.expectAllowed))
+
+		if args.validate != nil {
+			args.validate(ctx, response)
+		}
+	}
+
+	expectReason := func(substr string) func(*unitValidatingWebhookContext, admission.Response) {
+		return func(_ *unitValidatingWebhookContext, response admission.Response) {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(substr))
+		}
+	}
+
+	DescribeTable("create", doTest,
+		Entry("should allow valid snapshot",
+			testParams{
+				setup:         func(_ *unitValidatingWebhookContext) {},
+				expectAllowed: true,
+			},
+		),
+		Entry("should return error on empty vmName",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.vmSnapshot.Spec.VMName = ""
+				},
+				validate:      expectReason("spec.vmName: Required value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should allow quiesce without a timeout",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.vmSnapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{}
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("should allow quiesce with a valid timeout",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.vmSnapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					}
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("should return error on quiesce timeout less than 5 minutes",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.vmSnapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{
+						Timeout: &metav1.Duration{Duration: time.Minute},
+					}
+				},
+				validate:      expectReason("spec.quiesce.timeout: Invalid value"),
+				expectAllowed: false,
+			},
+		),
+		Entry("should return error on quiesce timeout greater than 240 minutes",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					ctx.vmSnapshot.Spec.Quiesce = &vmopv1.QuiesceSpec{
+						Timeout: &metav1.Duration{Duration: 5 * time.Hour},
+					}
+				},
+				validate:      expectReason("spec.quiesce.timeout: Invalid value"),
+				expectAllowed: false,
+			},
+		),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+	})
+
+	When("the metadata is updated", func() {
+		BeforeEach(func() {
+			ctx.vmSnapshot.Labels = map[string]string{"foo": "bar"}
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	When("the vmName is updated", func() {
+		BeforeEach(func() {
+			ctx.vmSnapshot.Spec.VMName = "other-vm"
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec: Forbidden: field is immutable"))
+		})
+	})
+
+	When("memory is updated", func() {
+		BeforeEach(func() {
+			ctx.vmSnapshot.Spec.Memory = true
+		})
+
+		It("should deny the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec: Forbidden: field is immutable"))
+		})
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}